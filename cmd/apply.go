@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mayens/ingress-to-gateway/pkg/converter"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// applyResources server-side applies the generated resources to the
+// cluster. The plan of what they create and update, diffed against the live
+// objects, is printed first, and change sets larger than
+// --confirm-threshold need --yes or an interactive confirmation.
+func applyResources(ctx context.Context, resources []interface{}) error {
+	objs, err := converter.ToUnstructured(resources)
+	if err != nil {
+		return err
+	}
+	client, err := newClient()
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	live, err := client.LiveObjects(ctx, objs)
+	if err != nil {
+		return fmt.Errorf("failed to read the current cluster state: %w", err)
+	}
+
+	plan, err := converter.NewPlan(unstructuredList(objs), unstructuredList(live))
+	if err != nil {
+		return err
+	}
+	if err := confirmPlan(plan, os.Stdin); err != nil {
+		return err
+	}
+
+	// Every object has a kind, so the plan has an entry for each
+	var changed []*unstructured.Unstructured
+	for i, entry := range plan.Entries {
+		if entry.Action != converter.PlanUnchanged {
+			changed = append(changed, objs[i])
+		}
+	}
+	if len(changed) == 0 {
+		fmt.Fprintf(os.Stderr, "Nothing to apply, %s is up to date\n", client.Server())
+		return nil
+	}
+	if err := client.Apply(ctx, changed); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Applied %d resource(s) to %s\n", len(changed), client.Server())
+	return nil
+}
+
+// unstructuredList returns objs as the resources plans take
+func unstructuredList(objs []*unstructured.Unstructured) []interface{} {
+	resources := make([]interface{}, 0, len(objs))
+	for _, obj := range objs {
+		resources = append(resources, obj)
+	}
+	return resources
+}
+
+// confirmPlan prints the plan to stderr and, when it creates or updates
+// more than --confirm-threshold resources without --yes, asks for
+// confirmation when in is a terminal and refuses otherwise
+func confirmPlan(plan converter.Plan, in *os.File) error {
+	fmt.Fprintln(os.Stderr, "Plan:")
+	if err := plan.WriteTable(os.Stderr); err != nil {
+		return fmt.Errorf("failed to write plan: %w", err)
+	}
+	changes := plan.Changes()
+	if applyYes || changes <= confirmThreshold {
+		return nil
+	}
+
+	if info, err := in.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return fmt.Errorf("plan changes %d resources, more than --confirm-threshold=%d; re-run with --yes to apply", changes, confirmThreshold)
+	}
+	fmt.Fprintf(os.Stderr, "Apply %d changes? [y/N]: ", changes)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return fmt.Errorf("apply cancelled")
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/mayens/ingress-to-gateway/pkg/converter"
+)
+
+func TestConfirmPlan(t *testing.T) {
+	// Converted routes of a namespace, some of them already applied
+	var plan converter.Plan
+	for i := 0; i < 12; i++ {
+		action := converter.PlanCreate
+		switch {
+		case i%4 == 1:
+			action = converter.PlanUpdate
+		case i%4 == 2:
+			action = converter.PlanUnchanged
+		}
+		plan.Entries = append(plan.Entries, converter.PlanEntry{Namespace: "web", Kind: "HTTPRoute", Name: fmt.Sprintf("route-%d", i), Action: action})
+	}
+
+	tests := []struct {
+		name      string
+		threshold int
+		yes       bool
+		wantErr   string
+	}{
+		{name: "below the threshold", threshold: 10},
+		{name: "at the threshold", threshold: 9},
+		{
+			name:      "crosses the threshold",
+			threshold: 8,
+			wantErr:   "plan changes 9 resources, more than --confirm-threshold=8; re-run with --yes to apply",
+		},
+		{name: "crosses the threshold with --yes", threshold: 8, yes: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(threshold int, yes bool) {
+				confirmThreshold, applyYes = threshold, yes
+			}(confirmThreshold, applyYes)
+			confirmThreshold, applyYes = tt.threshold, tt.yes
+
+			// Input piped in rather than typed cannot confirm
+			r, w, err := os.Pipe()
+			if err != nil {
+				t.Fatalf("Pipe() error = %v", err)
+			}
+			defer r.Close()
+			fmt.Fprintln(w, "y")
+			w.Close()
+
+			err = confirmPlan(plan, r)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("confirmPlan() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("confirmPlan() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
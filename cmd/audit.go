@@ -20,9 +20,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/mayens/ingress-to-gateway/pkg/analyzer"
+	"github.com/mayens/ingress-to-gateway/pkg/converter"
 	"github.com/mayens/ingress-to-gateway/pkg/k8s"
 	"github.com/mayens/ingress-to-gateway/pkg/reporter"
 )
@@ -31,6 +33,11 @@ var (
 	allNamespaces bool
 	outputFormat  string
 	detailed      bool
+	checkHosts    bool
+	includeTrans  bool
+	checkSecrets  bool
+	checkRotation bool
+	controllerCfg string
 )
 
 // auditCmd represents the audit command
@@ -55,7 +62,24 @@ Example usage:
   ingress-to-gateway audit --all-namespaces
 
   # Generate detailed report with JSON output
-  ingress-to-gateway audit --detailed --output=json`,
+  ingress-to-gateway audit --detailed --output=json
+
+  # Audit a large production cluster gently
+  ingress-to-gateway audit --all-namespaces --max-api-qps=2
+
+  # Warn about hostnames served by other load balancers before cutover
+  ingress-to-gateway audit --all-namespaces --check-host-collisions
+
+  # Verify TLS secrets exist, are not expired and cover their hosts
+  ingress-to-gateway audit --all-namespaces --check-tls-secrets
+
+  # Tell cert-manager issued certificates from static secrets, with notes on
+  # how Envoy Gateway picks up renewed certificates
+  ingress-to-gateway audit --all-namespaces --check-cert-rotation --target=envoy-gateway
+
+  # Report how the global settings of a custom controller ConfigMap carry over
+  # to Envoy Gateway policies
+  ingress-to-gateway audit -A --controller-config=nginx/nginx-config --target=envoy-gateway`,
 	RunE: runAudit,
 }
 
@@ -65,13 +89,23 @@ func init() {
 	auditCmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "audit across all namespaces")
 	auditCmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "output format: table, json, yaml")
 	auditCmd.Flags().BoolVarP(&detailed, "detailed", "d", false, "generate detailed report with recommendations")
+	auditCmd.Flags().BoolVar(&includeTrans, "include-transient", false, "include ephemeral cert-manager solver and Knative route Ingresses in the report")
+	auditCmd.Flags().BoolVar(&checkSecrets, "check-tls-secrets", false, "read the referenced TLS secrets and verify their certificates cover the hosts and are not expired")
+	auditCmd.Flags().BoolVar(&checkRotation, "check-cert-rotation", false, "report whether each TLS secret is renewed by cert-manager for the Ingress, by a standalone Certificate or by hand, and what the migration changes")
+	auditCmd.Flags().StringVar(&target, "target", "", "gateway implementation whose certificate reload behavior --check-cert-rotation and controller setting policies describe: nginx-gateway-fabric, envoy-gateway, istio, cilium, kong, traefik, gke")
+	auditCmd.Flags().StringVar(&controllerCfg, "controller-config", converter.DefaultControllerConfig, "namespace/name of the ingress-nginx controller ConfigMap whose global settings to report, empty to skip")
+	auditCmd.Flags().BoolVar(&checkHosts, "check-host-collisions", false, "warn about hostnames already served by other load balancers (Ingresses or Gateways) in the cluster")
 }
 
 func runAudit(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	if err := validateTarget(target); err != nil {
+		return err
+	}
+
 	// Create Kubernetes client
-	client, err := k8s.NewClient(kubeconfig)
+	client, err := newClient()
 	if err != nil {
 		return fmt.Errorf("failed to create kubernetes client: %w", err)
 	}
@@ -95,6 +129,14 @@ func runAudit(cmd *cobra.Command, args []string) error {
 		namespaces = []string{ns}
 	}
 
+	var clusterChecks []k8s.AccessCheck
+	if checkHosts {
+		clusterChecks = hostCollisionPermissions
+	}
+	if err := preflight(ctx, client, "audit", namespaces, clusterChecks); err != nil {
+		return err
+	}
+
 	// Create analyzer
 	a := analyzer.NewAnalyzer(client)
 
@@ -106,13 +148,58 @@ func runAudit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to analyze ingresses: %w", err)
 	}
 
+	if !includeTrans {
+		var skipped int
+		results, skipped = analyzer.ExcludeTransient(results)
+		if skipped > 0 {
+			fmt.Fprintf(os.Stderr, "Excluded %d transient Ingress(es) (cert-manager solvers, Knative routes); use --include-transient to report them\n", skipped)
+		}
+	}
+
 	if len(results) == 0 {
 		fmt.Println("No Ingress resources found.")
 		return nil
 	}
 
+	if checkHosts {
+		if err := a.CheckHostCollisions(ctx, results); err != nil {
+			return fmt.Errorf("failed to check host collisions: %w", err)
+		}
+	}
+
+	if checkSecrets {
+		if err := a.CheckTLSSecrets(ctx, results); err != nil {
+			return fmt.Errorf("failed to check TLS secrets: %w", err)
+		}
+	}
+
+	if checkRotation {
+		if err := a.CheckCertRotation(ctx, results, target); err != nil {
+			return fmt.Errorf("failed to check certificate rotation: %w", err)
+		}
+	}
+
+	var controller *analyzer.ControllerConfig
+	if controllerCfg != "" {
+		ns, name, ok := strings.Cut(controllerCfg, "/")
+		if !ok || ns == "" || name == "" {
+			return fmt.Errorf("invalid --controller-config %q, want namespace/name", controllerCfg)
+		}
+		controller, err = a.AnalyzeControllerConfig(ctx, ns, name, target)
+		if err != nil {
+			return fmt.Errorf("failed to analyze the controller ConfigMap: %w", err)
+		}
+		if controller == nil && cmd.Flags().Changed("controller-config") {
+			fmt.Fprintf(os.Stderr, "Controller ConfigMap %s not found or not readable; global settings are not reported\n", controllerCfg)
+		}
+	}
+
+	printAPIStats(client)
+
 	// Generate report
 	r := reporter.NewReporter(outputFormat, detailed)
+	r.SetMetadata(runMetadata(cmd, client, namespaces))
+	r.SetControllerConfig(controller)
 	if err := r.GenerateAuditReport(results, os.Stdout); err != nil {
 		return fmt.Errorf("failed to generate report: %w", err)
 	}
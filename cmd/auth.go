@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mayens/ingress-to-gateway/pkg/k8s"
+	"github.com/spf13/cobra"
+)
+
+var (
+	authAllNamespaces bool
+	authCommand       string
+)
+
+// authCmd groups the credential helpers
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Inspect cluster credentials and permissions",
+}
+
+// authCheckCmd represents the auth check command
+var authCheckCmd = &cobra.Command{
+	Use:   "check [flags]",
+	Short: "Verify connectivity and permissions before a migration run",
+	Long: `Check connects to the API server with the same kubeconfig, proxy and
+identity settings as the other commands, then asks the server whether the
+current identity may perform the operations each command needs
+(SelfSubjectAccessReview).
+
+Credentials from exec plugins (e.g. gke-gcloud-auth-plugin, kubelogin,
+aws eks get-token) and proxies from HTTPS_PROXY/NO_PROXY or the kubeconfig
+proxy-url are honored.
+
+Example usage:
+  # Check access in the current namespace
+  ingress-to-gateway auth check
+
+  # Check cluster-wide access before a batch run
+  ingress-to-gateway auth check -A --command=batch
+
+  # Check what a service account may do
+  ingress-to-gateway auth check -A --as=system:serviceaccount:migration:auditor`,
+	RunE: runAuthCheck,
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authCheckCmd)
+
+	authCheckCmd.Flags().BoolVarP(&authAllNamespaces, "all-namespaces", "A", false, "check access across all namespaces")
+	authCheckCmd.Flags().StringVar(&authCommand, "command", "", "only check the permissions of this command: audit, batch, bootstrap, convert, lint-ingress")
+}
+
+// commandAccessChecks lists the permissions each command needs in namespace
+func commandAccessChecks(ns string, allNamespaces bool) []k8s.AccessCheck {
+	var checks []k8s.AccessCheck
+	for _, command := range permissionCommands() {
+		if allNamespaces && command != "convert" && command != "bootstrap" {
+			checks = append(checks, k8s.AccessCheck{Command: command, Verb: "list", Resource: "namespaces"})
+		}
+		for _, perm := range commandPermissions[command] {
+			perm.Command = command
+			perm.Namespace = ns
+			checks = append(checks, perm)
+		}
+	}
+	for _, perm := range hostCollisionPermissions {
+		perm.Command = "audit"
+		checks = append(checks, perm)
+	}
+	for _, perm := range gatewayClassPermissions {
+		perm.Command = "bootstrap"
+		checks = append(checks, perm)
+	}
+	return checks
+}
+
+func runAuthCheck(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	client, err := newClient()
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	info, err := client.ConnectionInfo(ctx)
+	fmt.Printf("Server:      %s\n", info.Server)
+	if info.Proxy != "" {
+		fmt.Printf("Proxy:       %s\n", info.Proxy)
+	} else {
+		fmt.Printf("Proxy:       none (direct)\n")
+	}
+	fmt.Printf("Credentials: %s\n", info.AuthMethod)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Version:     %s\n", info.ServerVersion)
+	if info.User != "" {
+		fmt.Printf("User:        %s\n", info.User)
+		if len(info.Groups) > 0 {
+			fmt.Printf("Groups:      %s\n", strings.Join(info.Groups, ", "))
+		}
+	}
+	fmt.Println()
+
+	ns := ""
+	if !authAllNamespaces {
+		ns = namespace
+		if ns == "" {
+			ns, err = client.CurrentNamespace()
+			if err != nil {
+				return fmt.Errorf("failed to get current namespace: %w", err)
+			}
+		}
+	}
+
+	var checks []k8s.AccessCheck
+	for _, check := range commandAccessChecks(ns, authAllNamespaces) {
+		if authCommand == "" || check.Command == authCommand {
+			checks = append(checks, check)
+		}
+	}
+	if len(checks) == 0 {
+		return fmt.Errorf("unknown command %q: must be one of %s", authCommand, strings.Join(permissionCommands(), ", "))
+	}
+
+	results, err := client.CheckAccess(ctx, checks)
+	if err != nil {
+		return err
+	}
+
+	denied := 0
+	for _, r := range results {
+		scope := r.Namespace
+		if scope == "" {
+			scope = "*"
+		}
+		status := "✅ allowed"
+		if !r.Allowed {
+			status = "❌ denied"
+			denied++
+		}
+		fmt.Printf("  %-13s %-45s %-20s %s\n", r.Command, r.AccessCheck.String(), scope, status)
+		if !r.Allowed && r.Reason != "" {
+			fmt.Printf("  %-13s %s\n", "", r.Reason)
+		}
+	}
+
+	if denied > 0 {
+		return fmt.Errorf("%d permission check(s) denied", denied)
+	}
+	fmt.Println("\n✅ All permission checks passed")
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCommandAccessChecks(t *testing.T) {
+	tests := []struct {
+		name          string
+		namespace     string
+		allNamespaces bool
+		want          []string // command: check @ namespace
+	}{
+		{
+			name:      "namespace",
+			namespace: "team-a",
+			want: []string{
+				"audit: list ingresses.networking.k8s.io @ team-a",
+				"batch: list ingresses.networking.k8s.io @ team-a",
+				"bootstrap: create gateways.gateway.networking.k8s.io @ team-a",
+				"convert: get ingresses.networking.k8s.io @ team-a",
+				"lint-ingress: list ingresses.networking.k8s.io @ team-a",
+				"audit: list ingresses.networking.k8s.io @ *",
+				"audit: list gateways.gateway.networking.k8s.io @ *",
+				"audit: list httproutes.gateway.networking.k8s.io @ *",
+				"bootstrap: create gatewayclasses.gateway.networking.k8s.io @ *",
+			},
+		},
+		{
+			name:          "all namespaces",
+			allNamespaces: true,
+			want: []string{
+				"audit: list namespaces @ *",
+				"audit: list ingresses.networking.k8s.io @ *",
+				"batch: list namespaces @ *",
+				"batch: list ingresses.networking.k8s.io @ *",
+				"bootstrap: create gateways.gateway.networking.k8s.io @ *",
+				"convert: get ingresses.networking.k8s.io @ *",
+				"lint-ingress: list namespaces @ *",
+				"lint-ingress: list ingresses.networking.k8s.io @ *",
+				"audit: list ingresses.networking.k8s.io @ *",
+				"audit: list gateways.gateway.networking.k8s.io @ *",
+				"audit: list httproutes.gateway.networking.k8s.io @ *",
+				"bootstrap: create gatewayclasses.gateway.networking.k8s.io @ *",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, check := range commandAccessChecks(tt.namespace, tt.allNamespaces) {
+				scope := check.Namespace
+				if scope == "" {
+					scope = "*"
+				}
+				got = append(got, check.Command+": "+check.String()+" @ "+scope)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("commandAccessChecks() =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+
+	// The table must not leak the namespace into the shared permissions
+	commandAccessChecks("team-b", false)
+	for command, perms := range commandPermissions {
+		for _, perm := range perms {
+			if perm.Namespace != "" || perm.Command != "" {
+				t.Errorf("commandPermissions[%s] modified: %+v", command, perm)
+			}
+		}
+	}
+}
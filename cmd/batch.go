@@ -18,21 +18,38 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 
 	"github.com/spf13/cobra"
+	"github.com/mayens/ingress-to-gateway/pkg/analyzer"
 	"github.com/mayens/ingress-to-gateway/pkg/converter"
 	"github.com/mayens/ingress-to-gateway/pkg/k8s"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sigs.k8s.io/yaml"
 )
 
 var (
 	batchOutputDir string
 	batchNamespace string
 	batchAll       bool
+	batchTransient bool
+	batchInput     string
+	batchKustomize bool
+	batchRetry     bool
+	batchApply     bool
 )
 
+// batchStateFile records the failures of a batch run in the output directory
+const batchStateFile = "batch-state.json"
+
 // batchCmd represents the batch command
 var batchCmd = &cobra.Command{
 	Use:   "batch [flags]",
@@ -54,7 +71,29 @@ Example usage:
   ingress-to-gateway batch --all-namespaces -o ./output
 
   # Batch convert with per-host splitting
-  ingress-to-gateway batch --split-mode=per-host -o ./output`,
+  ingress-to-gateway batch --split-mode=per-host -o ./output
+
+  # Merge the Ingresses of each namespace into one HTTPRoute per hostname
+  ingress-to-gateway batch -A --merge-hosts -o ./output
+
+  # Also generate the Gateways the routes reference
+  ingress-to-gateway batch -A --emit-gateway -o ./output
+
+  # Attach every route to a shared Gateway in the infra namespace
+  ingress-to-gateway batch -A --gateway=shared --gateway-namespace=infra -o ./output
+
+  # Convert the manifests of a GitOps repository without cluster access
+  ingress-to-gateway batch -f ./clusters/prod -o ./output
+
+  # Write one file per namespace with kustomization.yaml files for kubectl apply -k
+  ingress-to-gateway batch -A --emit-gateway --kustomize -o ./output
+  kubectl apply -k ./output
+
+  # Also apply the resources, confirming when more than 50 are created or updated
+  ingress-to-gateway batch -A -o ./output --apply --confirm-threshold=50
+
+  # Reprocess only the namespaces and Ingresses that failed in the last run
+  ingress-to-gateway batch -A --retry-failed -o ./output`,
 	RunE: runBatch,
 }
 
@@ -63,36 +102,217 @@ func init() {
 
 	batchCmd.Flags().StringVarP(&batchOutputDir, "output-dir", "o", "./httproutes", "output directory for HTTPRoutes")
 	batchCmd.Flags().BoolVarP(&batchAll, "all-namespaces", "A", false, "convert across all namespaces")
+	batchCmd.Flags().StringVarP(&batchInput, "file", "f", "", "convert manifests from a file, directory (searched recursively) or glob instead of the cluster")
+	batchCmd.Flags().BoolVar(&batchTransient, "include-transient", false, "count and process ephemeral cert-manager solver and Knative route Ingresses (owned ones also need --include-managed)")
 	batchCmd.Flags().StringVar(&splitMode, "split-mode", "single", "split mode: single, per-host, per-pattern")
-	batchCmd.Flags().StringVar(&gatewayClass, "gateway-class", "nginx", "gateway class name")
+	batchCmd.Flags().BoolVar(&mergeHosts, "merge-hosts", false, "merge the Ingresses of a namespace that share a hostname into one HTTPRoute per hostname, in place of --split-mode")
+	batchCmd.Flags().StringVar(&gatewayName, "gateway", "", "gateway name to reference (default: derive from ingress class)")
+	batchCmd.Flags().StringVar(&gatewayNS, "gateway-namespace", "", "namespace of the gateway to reference (default: the Ingress namespace)")
+	batchCmd.Flags().StringVar(&sectionName, "section-name", "", "gateway listener name routes attach to (default: all listeners)")
+	batchCmd.Flags().Int32Var(&gatewayPort, "gateway-port", 0, "gateway listener port routes attach to (default: all ports)")
+	batchCmd.Flags().StringVar(&gatewayClass, "gateway-class", "", "gateway class name (default: the --target implementation's, else nginx)")
+	batchCmd.Flags().StringSliceVar(&copyAnnots, "copy-annotations", nil, "Ingress annotation keys or globs (e.g. argocd.argoproj.io/*) to copy to routes; nginx.ingress.kubernetes.io keys are never copied")
+	batchCmd.Flags().StringSliceVar(&dropAnnots, "drop-annotations", nil, "annotation keys or globs matching --copy-annotations not to copy to routes")
+	batchCmd.Flags().StringSliceVar(&copyLabels, "copy-labels", nil, "Ingress label keys or globs to copy to routes (default all)")
+	batchCmd.Flags().StringSliceVar(&stripLabels, "strip-labels", nil, "Ingress label keys or globs not to copy to routes")
+	batchCmd.Flags().StringSliceVar(&denyMeta, "deny-metadata", nil, "label and annotation keys or globs never copied to routes, in addition to the built-in credential and tracking keys")
+	batchCmd.Flags().StringSliceVar(&redactMeta, "redact-metadata", nil, "label and annotation keys or globs copied to routes with the value "+converter.RedactedValue)
+	batchCmd.Flags().StringVar(&progressive, "progressive-delivery", converter.ProgressiveSkip, "Ingresses owned by Flagger or Argo Rollouts: skip or generate")
+	batchCmd.Flags().BoolVar(&includeManaged, "include-managed", false, "convert Ingresses owned by other controllers (operators, Knative, cert-manager solvers)")
+	batchCmd.Flags().BoolVar(&batchRetry, "retry-failed", false, "reprocess only the namespaces and Ingresses recorded as failed in the output directory's "+batchStateFile+" by the previous run")
+	batchCmd.Flags().BoolVar(&batchApply, "apply", false, "server-side apply the generated resources of every namespace to the cluster once written, after printing a plan of the resources they create, update and leave unchanged")
+	batchCmd.Flags().BoolVarP(&applyYes, "yes", "y", false, "apply without asking for confirmation")
+	batchCmd.Flags().IntVar(&confirmThreshold, "confirm-threshold", 10, "number of resources to create or update above which --apply asks for confirmation")
+	batchCmd.Flags().BoolVar(&batchKustomize, "kustomize", false, "write each namespace's resources to one file with a kustomization.yaml, and a kustomization.yaml listing the namespaces, for kubectl apply -k")
+	batchCmd.Flags().StringVar(&maxFileSize, "max-file-size", "", "combine each namespace's resources into files of at most this size, e.g. 512Ki")
+	batchCmd.Flags().IntVar(&maxDocsPerFile, "max-docs-per-file", 0, "combine each namespace's resources into files of at most this many documents")
+	batchCmd.Flags().StringVar(&defaultBackend, "default-backend-mode", converter.DefaultBackendCatchAll, "default-backend annotation handling: catch-all, listener or none")
+	batchCmd.Flags().StringVar(&specDefault, "spec-default-backend-mode", converter.SpecDefaultBackendRule, "spec.defaultBackend handling: rule (\"/\" rule in the Ingress routes), catch-all (separate lowest-precedence HTTPRoute) or none")
+	batchCmd.Flags().BoolVar(&emitGateway, "emit-gateway", false, "also generate the referenced Gateways, in their namespace's directory, with HTTP/HTTPS listeners derived from spec.tls")
+	batchCmd.Flags().StringVar(&certManager, "cert-manager", converter.CertManagerGateway, "cert-manager.io Ingress annotations: gateway (carried to --emit-gateway Gateways), certificate (a Certificate per TLS secret) or none")
+	batchCmd.Flags().BoolVar(&prefixCompat, "prefix-compat", false, "also match ImplementationSpecific paths with a regex so /foo keeps matching /foobar as in nginx")
+	batchCmd.Flags().StringVar(&timeoutPrec, "timeout-precedence", converter.TimeoutPrecedenceMax, "route timeout when proxy-read and proxy-send timeouts differ: max or min")
+	batchCmd.Flags().StringVar(&apiVersion, "api-version", converter.APIVersionV1, "Gateway API version of generated Gateways and HTTPRoutes: v1 or v1beta1")
+	batchCmd.Flags().StringVar(&channel, "channel", converter.ChannelExperimental, "Gateway API release channel of the installed CRDs: experimental or standard (leaves out experimental resources and fields)")
+	batchCmd.Flags().BoolVar(&experimentalOn, "experimental", true, "emit experimental Gateway API resources and fields (see the version command); override per feature with INGRESS_TO_GATEWAY_EXPERIMENTAL_<FEATURE>=true|false")
+	batchCmd.Flags().StringVar(&nameTemplate, "name-template", converter.DefaultNameTemplate, "Go template for HTTPRoute names, with .Ingress, .Namespace and .Class; split modes append a suffix")
+	batchCmd.Flags().BoolVar(&ruleNames, "rule-names", false, "name each HTTPRoute rule after its path and backend (needs --api-version=v1 and the Gateway API v1.2+ experimental CRDs)")
+	batchCmd.Flags().BoolVar(&strictAnnot, "strict-annotations", false, "fail on malformed annotation values, such as a non-numeric timeout, instead of warning and leaving the annotation out")
+	batchCmd.Flags().StringVar(&mappingsFile, "annotation-mappings", "", "YAML file mapping custom annotations to HTTPRoute filter, timeouts and policy templates")
+	batchCmd.Flags().StringVar(&hookPath, "annotation-hook", "", "executable converting the annotations no translator handles, given as JSON on stdin")
+	batchCmd.Flags().BoolVar(&showAnnots, "show-annotations", false, "print what each nginx annotation was converted to, or why it was not (always written to annotations.json)")
+	batchCmd.Flags().StringVar(&target, "target", "", "gateway implementation profile selecting the GatewayClass, policies and features: nginx-gateway-fabric, envoy-gateway, istio, cilium, kong, traefik, gke")
 }
 
 func runBatch(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
-	// Create Kubernetes client
-	client, err := k8s.NewClient(kubeconfig)
+	if err := validateTarget(target); err != nil {
+		return err
+	}
+	if err := validateProgressive(progressive); err != nil {
+		return err
+	}
+	if err := validateDefaultBackendMode(defaultBackend); err != nil {
+		return err
+	}
+	if err := validateSpecDefaultBackendMode(specDefault); err != nil {
+		return err
+	}
+	if err := validateCertManagerMode(certManager); err != nil {
+		return err
+	}
+	if _, err := converter.ParseNameTemplate(nameTemplate); err != nil {
+		return err
+	}
+	if err := validateTimeoutPrecedence(timeoutPrec); err != nil {
+		return err
+	}
+	if err := validateAPIVersion(apiVersion); err != nil {
+		return err
+	}
+	if err := validateChannel(channel); err != nil {
+		return err
+	}
+	gate, err := experimentalGate()
+	if err != nil {
+		return err
+	}
+	translators, err := translatorRegistry()
+	if err != nil {
+		return err
+	}
+	classRefs, err := ingressClassParentRefs()
+	if err != nil {
+		return err
+	}
+	limits, err := chunkLimits()
 	if err != nil {
-		return fmt.Errorf("failed to create kubernetes client: %w", err)
+		return err
+	}
+	// Each namespace's resources go to one file, or chunks of it
+	combined := limits.Enabled() || batchKustomize
+
+	// The previous run's failures, and the files its kustomizations list
+	var retry *batchRetrySet
+	written := make(map[string][]string)
+	if batchRetry {
+		if emitGateway {
+			return fmt.Errorf("--retry-failed cannot be combined with --emit-gateway, since Gateways collect listeners from every namespace; rerun the full batch")
+		}
+		previous, err := readBatchState(filepath.Join(batchOutputDir, batchStateFile))
+		if err != nil {
+			return err
+		}
+		if len(previous.Failures) == 0 {
+			fmt.Fprintf(os.Stderr, "No failures recorded in %s, nothing to retry\n", filepath.Join(batchOutputDir, batchStateFile))
+			return nil
+		}
+		// Routes written per namespace or merged across Ingresses are
+		// regenerated for the whole namespace
+		retry = previous.retrySet(combined || mergeHosts)
+		written = previous.files()
+	}
+	state := &batchState{}
+
+	// Create converter
+	opts := converter.Options{
+		RoutingOptions: converter.RoutingOptions{
+			SplitMode:              splitMode,
+			DefaultBackendMode:     defaultBackend,
+			PrefixCompat:           prefixCompat,
+			TimeoutPrecedence:      timeoutPrec,
+			SpecDefaultBackendMode: specDefault,
+			MergeHosts:             mergeHosts,
+			Translators:            translators,
+		},
+		NamingOptions: converter.NamingOptions{
+			NameTemplate: nameTemplate,
+			RuleNames:    ruleNames,
+		},
+		GatewayOptions: converter.GatewayOptions{
+			GatewayName:      gatewayName,
+			GatewayClass:     gatewayClass,
+			GatewayNamespace: gatewayNS,
+			SectionName:      sectionName,
+			Port:             gatewayPort,
+			IngressClasses:   classRefs,
+			CertManager:      certManager,
+		},
+		MetadataOptions: converter.MetadataOptions{
+			CopyAnnotations: copyAnnots,
+			DropAnnotations: dropAnnots,
+			CopyLabels:      copyLabels,
+			StripLabels:     stripLabels,
+			DenyKeys:        denyMeta,
+			RedactKeys:      redactMeta,
+		},
+		OwnershipOptions: converter.OwnershipOptions{
+			ProgressiveDelivery: progressive,
+			IncludeManaged:      includeManaged,
+		},
+		TargetOptions: converter.TargetOptions{
+			Target:       target,
+			APIVersion:   apiVersion,
+			Channel:      channel,
+			Experimental: gate,
+		},
+		ValidationOptions: converter.ValidationOptions{
+			StrictAnnotations: strictAnnot,
+		},
+		OutputOptions: converter.OutputOptions{
+			OutputFormat: "yaml",
+		},
 	}
+	c := converter.NewConverter(opts)
 
-	// Determine namespaces
+	// Ingresses come from the cluster, or from manifests when --file is set
+	var client *k8s.Client
 	var namespaces []string
-	if batchAll {
-		nsList, err := client.ListNamespaces(ctx)
+	var fileIngresses map[string][]interface{}
+
+	if batchInput != "" {
+		fileIngresses, namespaces, err = loadBatchFiles(c, batchInput)
 		if err != nil {
-			return fmt.Errorf("failed to list namespaces: %w", err)
+			return err
+		}
+		if retry != nil {
+			namespaces = retry.filter(namespaces)
 		}
-		namespaces = nsList
 	} else {
-		ns := namespace
-		if ns == "" {
-			ns, err = client.CurrentNamespace()
+		client, err = newClient()
+		if err != nil {
+			return fmt.Errorf("failed to create kubernetes client: %w", err)
+		}
+		// Backend Service appProtocols select GRPCRoute and BackendTLSPolicy
+		c.SetServiceLookup(client)
+		if emitGateway {
+			addIngressClasses(ctx, c, client)
+		}
+
+		// Determine namespaces
+		if retry != nil {
+			namespaces = retry.namespaces()
+		} else if batchAll {
+			nsList, err := client.ListNamespaces(ctx)
 			if err != nil {
-				return fmt.Errorf("failed to get current namespace: %w", err)
+				return fmt.Errorf("failed to list namespaces: %w", err)
 			}
+			namespaces = nsList
+		} else {
+			ns := namespace
+			if ns == "" {
+				ns, err = client.CurrentNamespace()
+				if err != nil {
+					return fmt.Errorf("failed to get current namespace: %w", err)
+				}
+			}
+			namespaces = []string{ns}
+		}
+
+		if err := preflight(ctx, client, "batch", namespaces, nil); err != nil {
+			return err
 		}
-		namespaces = []string{ns}
 	}
 
 	// Create output directory
@@ -100,25 +320,33 @@ func runBatch(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	// Create converter
-	opts := converter.Options{
-		SplitMode:    splitMode,
-		GatewayClass: gatewayClass,
-		OutputFormat: "yaml",
-	}
-	c := converter.NewConverter(opts)
-
 	totalConverted := 0
 	totalFailed := 0
+	totalTransient := 0
+	var diagnostics []converter.Diagnostic
+	var fidelity []converter.Fidelity
+	// Ingresses the Gateways are generated for, and all generated resources
+	var converted, generated []interface{}
+	// ReferenceGrants of every conversion, merged and written once all
+	// Ingresses are converted, since Ingresses of any namespace share them
+	var grants []interface{}
 
 	// Process each namespace
 	for _, ns := range namespaces {
 		fmt.Fprintf(os.Stderr, "Processing namespace: %s\n", ns)
 
-		ingresses, err := client.ListIngresses(ctx, ns)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to list ingresses in %s: %v\n", ns, err)
-			continue
+		ingresses := fileIngresses[ns]
+		if client != nil {
+			listed, err := client.ListIngresses(ctx, ns)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to list ingresses in %s: %v\n", ns, err)
+				state.fail(ns, "", err)
+				continue
+			}
+			ingresses = make([]interface{}, 0, len(listed))
+			for _, ing := range listed {
+				ingresses = append(ingresses, ing)
+			}
 		}
 
 		if len(ingresses) == 0 {
@@ -130,20 +358,50 @@ func runBatch(cmd *cobra.Command, args []string) error {
 		nsDir := filepath.Join(batchOutputDir, ns)
 		if err := os.MkdirAll(nsDir, 0755); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to create directory %s: %v\n", nsDir, err)
+			state.fail(ns, "", err)
 			continue
 		}
 
+		// Resources collected for chunked output
+		var nsResources []interface{}
+		// Ingresses converted together, since merged routes span Ingresses
+		var merged []interface{}
+
 		// Convert each ingress
 		for _, ingress := range ingresses {
-			name := ingress.GetName()
+			name := ingress.(metav1.Object).GetName()
+			if retry != nil && !retry.includes(ns, name) {
+				continue
+			}
+			if reason := transientReason(ingress); reason != "" && !batchTransient {
+				fmt.Fprintf(os.Stderr, "  Skipping: %s (%s)\n", name, reason)
+				totalTransient++
+				continue
+			}
 			fmt.Fprintf(os.Stderr, "  Converting: %s\n", name)
+			if mergeHosts {
+				merged = append(merged, ingress)
+				continue
+			}
 
 			httpRoutes, err := c.Convert(ctx, []interface{}{ingress})
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "    Error: %v\n", err)
+				state.fail(ns, name, err)
 				totalFailed++
 				continue
 			}
+			printDiagnostics(c.Diagnostics())
+			diagnostics = append(diagnostics, c.Diagnostics()...)
+			fidelity = append(fidelity, c.Fidelity()...)
+			converted = append(converted, ingress)
+			httpRoutes, grants = splitReferenceGrants(httpRoutes, grants)
+			generated = append(generated, httpRoutes...)
+
+			if combined {
+				nsResources = append(nsResources, httpRoutes...)
+				continue
+			}
 
 			// Write HTTPRoutes
 			for i, hr := range httpRoutes {
@@ -157,6 +415,7 @@ func runBatch(cmd *cobra.Command, args []string) error {
 				f, err := os.Create(outputPath)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "    Error creating %s: %v\n", filename, err)
+					state.fail(ns, name, err)
 					totalFailed++
 					continue
 				}
@@ -164,6 +423,7 @@ func runBatch(cmd *cobra.Command, args []string) error {
 				if err := c.WriteOutput([]interface{}{hr}, f); err != nil {
 					f.Close()
 					fmt.Fprintf(os.Stderr, "    Error writing %s: %v\n", filename, err)
+					state.fail(ns, name, err)
 					totalFailed++
 					continue
 				}
@@ -172,6 +432,118 @@ func runBatch(cmd *cobra.Command, args []string) error {
 				totalConverted++
 			}
 		}
+
+		if len(merged) > 0 {
+			resources, err := c.Convert(ctx, merged)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "  Error: %v\n", err)
+				state.fail(ns, "", err)
+				totalFailed += len(merged)
+				continue
+			}
+			printDiagnostics(c.Diagnostics())
+			diagnostics = append(diagnostics, c.Diagnostics()...)
+			fidelity = append(fidelity, c.Fidelity()...)
+			converted = append(converted, merged...)
+			resources, grants = splitReferenceGrants(resources, grants)
+			generated = append(generated, resources...)
+
+			if combined {
+				nsResources = append(nsResources, resources...)
+			} else {
+				for _, res := range resources {
+					filename := res.(metav1.Object).GetName() + ".yaml"
+					if err := writeResourceFile(c, filepath.Join(nsDir, filename), res); err != nil {
+						fmt.Fprintf(os.Stderr, "    Error: %v\n", err)
+						state.fail(ns, "", err)
+						totalFailed++
+						continue
+					}
+					fmt.Fprintf(os.Stderr, "    Created: %s\n", filename)
+					totalConverted++
+				}
+			}
+		}
+
+		if len(nsResources) > 0 {
+			files, err := c.WriteCombined(nsResources, filepath.Join(nsDir, "httproutes.yaml"), limits)
+			for _, f := range files {
+				fmt.Fprintf(os.Stderr, "  Created: %s\n", filepath.Base(f))
+				written[ns] = appendUnique(written[ns], filepath.Base(f))
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "  Error: %v\n", err)
+				state.fail(ns, "", err)
+				totalFailed++
+				continue
+			}
+			totalConverted += len(nsResources)
+		}
+	}
+
+	// Gateways are generated once all Ingresses are converted, since a
+	// Gateway in a shared namespace collects listeners from every namespace
+	if emitGateway && len(converted) > 0 {
+		gateways, err := c.GenerateGateways(converted)
+		if err != nil {
+			return err
+		}
+		printDiagnostics(c.Diagnostics())
+		diagnostics = append(diagnostics, c.Diagnostics()...)
+		gateways, grants = splitReferenceGrants(gateways, grants)
+		generated = append(generated, gateways...)
+		count, failed := writeByNamespace(c, gateways, "gateways.yaml", limits, written)
+		totalConverted += count
+		totalFailed += failed
+	}
+
+	if len(grants) > 0 {
+		// A retry regenerates the grants of the reprocessed Ingresses only,
+		// so the grants written by the previous run are merged in
+		if retry != nil {
+			previous, err := readReferenceGrants()
+			if err != nil {
+				return err
+			}
+			grants = append(previous, grants...)
+		}
+		grants = converter.MergeReferenceGrants(grants)
+		generated = append(generated, grants...)
+		count, failed := writeByNamespace(c, grants, "referencegrants.yaml", limits, written)
+		totalConverted += count
+		totalFailed += failed
+	}
+
+	if batchKustomize {
+		if err := writeKustomizations(written); err != nil {
+			return err
+		}
+	}
+
+	// Conversion report for items needing manual follow-up
+	if len(diagnostics) > 0 {
+		if err := writeDiagnosticsFile(filepath.Join(batchOutputDir, "diagnostics.json"), diagnostics); err != nil {
+			return err
+		}
+	}
+	annotations := converter.AnnotationResults(fidelity)
+	if len(annotations) > 0 {
+		if err := writeAnnotationsFile(filepath.Join(batchOutputDir, "annotations.json"), annotations); err != nil {
+			return err
+		}
+	}
+	if showAnnots {
+		printAnnotationResults(annotations)
+	}
+	state.Files = written
+	if err := writeBatchState(filepath.Join(batchOutputDir, batchStateFile), state); err != nil {
+		return err
+	}
+
+	if batchApply && len(generated) > 0 {
+		if err := applyResources(ctx, generated); err != nil {
+			return err
+		}
 	}
 
 	// Summary
@@ -180,7 +552,366 @@ func runBatch(cmd *cobra.Command, args []string) error {
 	if totalFailed > 0 {
 		fmt.Fprintf(os.Stderr, "  Failed: %d\n", totalFailed)
 	}
+	if len(state.Failures) > 0 {
+		fmt.Fprintf(os.Stderr, "  Failures recorded: %d (see %s, reprocess them with --retry-failed)\n", len(state.Failures), batchStateFile)
+	}
+	if totalTransient > 0 {
+		fmt.Fprintf(os.Stderr, "  Skipped transient: %d (use --include-transient to convert them)\n", totalTransient)
+	}
+	if len(fidelity) > 0 {
+		fmt.Fprintf(os.Stderr, "  Annotation fidelity: %.1f%%\n", converter.AggregateFidelity(fidelity))
+		fmt.Fprintf(os.Stderr, "  Annotations: %s (see annotations.json)\n", annotationSummary(annotations))
+	}
+	if len(diagnostics) > 0 {
+		fmt.Fprintf(os.Stderr, "  Diagnostics: %d (see diagnostics.json)\n", len(diagnostics))
+	}
 	fmt.Fprintf(os.Stderr, "  Output directory: %s\n", batchOutputDir)
+	if client != nil {
+		printAPIStats(client)
+	}
+	printNextSteps(converter.NextSteps(converted, generated, diagnostics, batchRunOutput(generated)))
+
+	return nil
+}
+
+// clusterScopedDir is the output directory of cluster-scoped resources,
+// which no namespace can be named
+const clusterScopedDir = "_cluster"
+
+// writeByNamespace writes Gateways and ReferenceGrants to the directory of
+// their namespace and GatewayClasses to clusterScopedDir, one file each or
+// combined in combinedFile, recording the combined files in files
+func writeByNamespace(c *converter.Converter, resources []interface{}, combinedFile string, limits converter.ChunkLimits, files map[string][]string) (written, failed int) {
+	byNamespace := make(map[string][]interface{})
+	var namespaces []string
+	for _, res := range resources {
+		ns := res.(metav1.Object).GetNamespace()
+		// GatewayClasses are cluster-scoped
+		if ns == "" {
+			ns = clusterScopedDir
+		}
+		if _, exists := byNamespace[ns]; !exists {
+			namespaces = append(namespaces, ns)
+		}
+		byNamespace[ns] = append(byNamespace[ns], res)
+	}
+	sort.Strings(namespaces)
 
+	for _, ns := range namespaces {
+		nsDir := filepath.Join(batchOutputDir, ns)
+		if err := os.MkdirAll(nsDir, 0755); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to create directory %s: %v\n", nsDir, err)
+			failed += len(byNamespace[ns])
+			continue
+		}
+
+		if limits.Enabled() || batchKustomize {
+			created, err := c.WriteCombined(byNamespace[ns], filepath.Join(nsDir, combinedFile), limits)
+			for _, f := range created {
+				fmt.Fprintf(os.Stderr, "  Created: %s/%s\n", ns, filepath.Base(f))
+				files[ns] = appendUnique(files[ns], filepath.Base(f))
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "  Error: %v\n", err)
+				failed++
+				continue
+			}
+			written += len(byNamespace[ns])
+			continue
+		}
+
+		for _, res := range byNamespace[ns] {
+			filename := res.(metav1.Object).GetName() + ".yaml"
+			if _, ok := res.(*gatewayv1beta1.ReferenceGrant); ok {
+				filename = res.(metav1.Object).GetName() + "-referencegrant.yaml"
+			}
+			if err := writeResourceFile(c, filepath.Join(nsDir, filename), res); err != nil {
+				fmt.Fprintf(os.Stderr, "  Error: %v\n", err)
+				failed++
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "  Created: %s/%s\n", ns, filename)
+			written++
+		}
+	}
+	return written, failed
+}
+
+// splitReferenceGrants returns resources without their ReferenceGrants, and
+// grants with them appended
+func splitReferenceGrants(resources, grants []interface{}) ([]interface{}, []interface{}) {
+	kept := resources[:0:0]
+	for _, res := range resources {
+		if _, ok := res.(*gatewayv1beta1.ReferenceGrant); ok {
+			grants = append(grants, res)
+			continue
+		}
+		kept = append(kept, res)
+	}
+	return kept, grants
+}
+
+// readReferenceGrants reads the ReferenceGrants a previous run wrote to the
+// namespace directories, one per file or combined in referencegrants.yaml
+func readReferenceGrants() ([]interface{}, error) {
+	paths, err := filepath.Glob(filepath.Join(batchOutputDir, "*", "*referencegrant*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	var grants []interface{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		for _, doc := range regexp.MustCompile(`(?m)^---\s*$`).Split(string(data), -1) {
+			var grant gatewayv1beta1.ReferenceGrant
+			if err := yaml.Unmarshal([]byte(doc), &grant); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+			}
+			if grant.Kind == "ReferenceGrant" {
+				grants = append(grants, &grant)
+			}
+		}
+	}
+	return grants, nil
+}
+
+// batchRunOutput returns where the resources were written: the output
+// directory with --kustomize, else the directory of each namespace, leaving
+// out diagnostics.json and annotations.json
+func batchRunOutput(resources []interface{}) converter.RunOutput {
+	if batchKustomize {
+		return converter.RunOutput{Paths: []string{batchOutputDir}, Kustomize: true}
+	}
+
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, res := range resources {
+		ns := res.(metav1.Object).GetNamespace()
+		if !seen[ns] {
+			seen[ns] = true
+			dirs = append(dirs, filepath.Join(batchOutputDir, ns))
+		}
+	}
+	sort.Strings(dirs)
+	return converter.RunOutput{Paths: dirs, Recursive: true}
+}
+
+// writeKustomizations writes a kustomization.yaml to each namespace
+// directory listing its files, and one to the output directory listing the
+// namespace directories
+func writeKustomizations(files map[string][]string) error {
+	namespaces := make([]string, 0, len(files))
+	for ns, nsFiles := range files {
+		if err := converter.WriteKustomization(filepath.Join(batchOutputDir, ns), nsFiles); err != nil {
+			return fmt.Errorf("failed to write kustomization for namespace %s: %w", ns, err)
+		}
+		fmt.Fprintf(os.Stderr, "  Created: %s/%s\n", ns, converter.KustomizationFile)
+		namespaces = append(namespaces, ns)
+	}
+	if err := converter.WriteKustomization(batchOutputDir, namespaces); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "  Created: %s\n", converter.KustomizationFile)
 	return nil
 }
+
+// writeResourceFile writes a single resource to path
+func writeResourceFile(c *converter.Converter, path string, res interface{}) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Base(path), err)
+	}
+	if err := c.WriteOutput([]interface{}{res}, f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return f.Close()
+}
+
+// transientReason returns why an Ingress is transient, "" for other resources
+func transientReason(obj interface{}) string {
+	if ing, ok := obj.(*networkingv1.Ingress); ok {
+		return analyzer.TransientReason(ing)
+	}
+	return ""
+}
+
+// loadBatchFiles loads Ingress, Traefik IngressRoute, Istio VirtualService
+// and NGINX VirtualServer manifests from a file, directory or glob and
+// groups them by namespace. Manifests without a namespace belong to --namespace, or
+// default. Without --all-namespaces, an explicit --namespace limits the run
+// to that namespace. Referenced resources such as Traefik Middlewares and
+// Istio DestinationRules are indexed for the resources of every namespace.
+func loadBatchFiles(c *converter.Converter, path string) (map[string][]interface{}, []string, error) {
+	loaded, err := c.LoadFromPath(path)
+	printSkippedFiles(c.SkippedFiles())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load ingress from file: %w", err)
+	}
+
+	defaultNamespace := namespace
+	if defaultNamespace == "" {
+		defaultNamespace = "default"
+	}
+
+	byNamespace := make(map[string][]interface{})
+	for _, obj := range loaded {
+		meta := obj.(metav1.Object)
+		if meta.GetNamespace() == "" {
+			meta.SetNamespace(defaultNamespace)
+		}
+	}
+	c.AddReferenced(loaded)
+
+	for _, obj := range loaded {
+		if converter.IsReferenced(obj) {
+			continue
+		}
+		ns := obj.(metav1.Object).GetNamespace()
+		if namespace != "" && !batchAll && ns != namespace {
+			continue
+		}
+		byNamespace[ns] = append(byNamespace[ns], obj)
+	}
+
+	namespaces := make([]string, 0, len(byNamespace))
+	for ns := range byNamespace {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return byNamespace, namespaces, nil
+}
+
+// batchState is what a batch run leaves in batch-state.json: the namespaces
+// and Ingresses that failed, and the files each namespace's kustomization
+// lists so a retry can keep them
+type batchState struct {
+	Failures []batchFailure      `json:"failures"`
+	Files    map[string][]string `json:"files,omitempty"`
+}
+
+// batchFailure is a namespace that could not be listed or written, or an
+// Ingress that could not be converted or written
+type batchFailure struct {
+	Namespace string `json:"namespace"`
+	Ingress   string `json:"ingress,omitempty"` // empty when the whole namespace failed
+	Error     string `json:"error"`
+}
+
+// fail records a failure, once per namespace and Ingress
+func (s *batchState) fail(namespace, ingress string, err error) {
+	for _, f := range s.Failures {
+		if f.Namespace == namespace && f.Ingress == ingress {
+			return
+		}
+	}
+	s.Failures = append(s.Failures, batchFailure{Namespace: namespace, Ingress: ingress, Error: err.Error()})
+}
+
+// files returns a copy of the files each namespace's kustomization lists,
+// which a retry extends rather than replaces
+func (s *batchState) files() map[string][]string {
+	files := make(map[string][]string, len(s.Files))
+	for ns, names := range s.Files {
+		files[ns] = append([]string(nil), names...)
+	}
+	return files
+}
+
+// batchRetrySet selects what --retry-failed reprocesses: whole namespaces,
+// or single Ingresses of a namespace
+type batchRetrySet struct {
+	all       map[string]bool
+	ingresses map[string]map[string]bool
+}
+
+// retrySet returns the failures to reprocess, widened to whole namespaces
+// when wholeNamespaces is set
+func (s *batchState) retrySet(wholeNamespaces bool) *batchRetrySet {
+	set := &batchRetrySet{all: make(map[string]bool), ingresses: make(map[string]map[string]bool)}
+	for _, f := range s.Failures {
+		if f.Ingress == "" || wholeNamespaces {
+			set.all[f.Namespace] = true
+			continue
+		}
+		if set.ingresses[f.Namespace] == nil {
+			set.ingresses[f.Namespace] = make(map[string]bool)
+		}
+		set.ingresses[f.Namespace][f.Ingress] = true
+	}
+	return set
+}
+
+// namespaces returns the namespaces to reprocess, sorted
+func (r *batchRetrySet) namespaces() []string {
+	var namespaces []string
+	for ns := range r.all {
+		namespaces = append(namespaces, ns)
+	}
+	for ns := range r.ingresses {
+		if !r.all[ns] {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// filter keeps the namespaces with failures to reprocess
+func (r *batchRetrySet) filter(namespaces []string) []string {
+	var kept []string
+	for _, ns := range namespaces {
+		if r.all[ns] || r.ingresses[ns] != nil {
+			kept = append(kept, ns)
+		}
+	}
+	return kept
+}
+
+// includes reports whether the Ingress is reprocessed
+func (r *batchRetrySet) includes(namespace, ingress string) bool {
+	return r.all[namespace] || r.ingresses[namespace][ingress]
+}
+
+// readBatchState reads the state a previous batch run left in path
+func readBatchState(path string) (*batchState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no batch state in %s; run batch without --retry-failed first", filepath.Dir(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch state: %w", err)
+	}
+	var state batchState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse batch state %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// writeBatchState writes the state of this run to path
+func writeBatchState(path string, state *batchState) error {
+	if state.Failures == nil {
+		state.Failures = []batchFailure{}
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch state: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write batch state: %w", err)
+	}
+	return nil
+}
+
+// appendUnique appends name unless files already lists it
+func appendUnique(files []string, name string) []string {
+	for _, f := range files {
+		if f == name {
+			return files
+		}
+	}
+	return append(files, name)
+}
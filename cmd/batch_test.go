@@ -0,0 +1,304 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBatchStateFail(t *testing.T) {
+	state := &batchState{}
+	state.fail("team-a", "web", errors.New("conversion failed"))
+	state.fail("team-a", "web", errors.New("write failed"))
+	state.fail("team-a", "", errors.New("list failed"))
+	state.fail("team-b", "web", errors.New("conversion failed"))
+
+	want := []batchFailure{
+		{Namespace: "team-a", Ingress: "web", Error: "conversion failed"},
+		{Namespace: "team-a", Error: "list failed"},
+		{Namespace: "team-b", Ingress: "web", Error: "conversion failed"},
+	}
+	if !reflect.DeepEqual(state.Failures, want) {
+		t.Errorf("Failures = %+v, want %+v", state.Failures, want)
+	}
+}
+
+func TestBatchRetrySet(t *testing.T) {
+	failures := []batchFailure{
+		{Namespace: "team-a", Ingress: "web", Error: "conversion failed"},
+		{Namespace: "team-a", Ingress: "api", Error: "conversion failed"},
+		{Namespace: "team-b", Error: "list failed"},
+		{Namespace: "team-c", Ingress: "shop", Error: "write failed"},
+	}
+
+	type include struct {
+		namespace, ingress string
+		want               bool
+	}
+	tests := []struct {
+		name            string
+		wholeNamespaces bool
+		wantNamespaces  []string
+		wantFiltered    []string
+		wantIncludes    []include
+	}{
+		{
+			name:           "failed ingresses",
+			wantNamespaces: []string{"team-a", "team-b", "team-c"},
+			wantFiltered:   []string{"team-a", "team-b", "team-c"},
+			wantIncludes: []include{
+				{"team-a", "web", true},
+				{"team-a", "api", true},
+				{"team-a", "admin", false},
+				{"team-b", "anything", true},
+				{"team-c", "shop", true},
+				{"team-c", "cart", false},
+				{"team-d", "web", false},
+			},
+		},
+		{
+			name:            "widened to whole namespaces",
+			wholeNamespaces: true,
+			wantNamespaces:  []string{"team-a", "team-b", "team-c"},
+			wantFiltered:    []string{"team-a", "team-b", "team-c"},
+			wantIncludes: []include{
+				{"team-a", "admin", true},
+				{"team-b", "anything", true},
+				{"team-c", "cart", true},
+				{"team-d", "web", false},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := (&batchState{Failures: failures}).retrySet(tt.wholeNamespaces)
+
+			if got := set.namespaces(); !reflect.DeepEqual(got, tt.wantNamespaces) {
+				t.Errorf("namespaces() = %v, want %v", got, tt.wantNamespaces)
+			}
+			if got := set.filter([]string{"default", "team-a", "team-b", "team-c", "team-d"}); !reflect.DeepEqual(got, tt.wantFiltered) {
+				t.Errorf("filter() = %v, want %v", got, tt.wantFiltered)
+			}
+			for _, inc := range tt.wantIncludes {
+				if got := set.includes(inc.namespace, inc.ingress); got != inc.want {
+					t.Errorf("includes(%s, %s) = %v, want %v", inc.namespace, inc.ingress, got, inc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBatchStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), batchStateFile)
+
+	if _, err := readBatchState(path); err == nil || !strings.Contains(err.Error(), "run batch without --retry-failed first") {
+		t.Errorf("readBatchState() of a missing file error = %v, want a hint to run batch first", err)
+	}
+
+	// A run without failures writes an empty list, which a retry reads as
+	// nothing to do
+	if err := writeBatchState(path, &batchState{}); err != nil {
+		t.Fatalf("writeBatchState() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read state: %v", err)
+	}
+	if !strings.Contains(string(data), `"failures": []`) {
+		t.Errorf("state = %s, want an empty failures list", data)
+	}
+
+	previous := &batchState{
+		Failures: []batchFailure{{Namespace: "team-a", Ingress: "web", Error: "conversion failed"}},
+		Files: map[string][]string{
+			"team-a": {"api-httproute.yaml", "web-httproute.yaml"},
+			"team-b": {"shop-httproute.yaml"},
+		},
+	}
+	if err := writeBatchState(path, previous); err != nil {
+		t.Fatalf("writeBatchState() error = %v", err)
+	}
+	got, err := readBatchState(path)
+	if err != nil {
+		t.Fatalf("readBatchState() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, previous) {
+		t.Errorf("readBatchState() = %+v, want %+v", got, previous)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+		t.Fatalf("failed to write state: %v", err)
+	}
+	if _, err := readBatchState(path); err == nil {
+		t.Errorf("readBatchState() of a corrupt file succeeded")
+	}
+}
+
+func TestBatchStateFilesKeptOnRetry(t *testing.T) {
+	previous := &batchState{
+		Failures: []batchFailure{{Namespace: "team-a", Ingress: "web", Error: "conversion failed"}},
+		Files: map[string][]string{
+			"team-a": {"api-httproute.yaml"},
+			"team-b": {"shop-httproute.yaml"},
+		},
+	}
+
+	// The retry writes the failed Ingress again and its namespace
+	// kustomization keeps the files of the previous run
+	written := previous.files()
+	written["team-a"] = appendUnique(written["team-a"], "web-httproute.yaml")
+	written["team-a"] = appendUnique(written["team-a"], "api-httproute.yaml")
+
+	want := map[string][]string{
+		"team-a": {"api-httproute.yaml", "web-httproute.yaml"},
+		"team-b": {"shop-httproute.yaml"},
+	}
+	if !reflect.DeepEqual(written, want) {
+		t.Errorf("files after retry = %v, want %v", written, want)
+	}
+	if !reflect.DeepEqual(previous.Files["team-a"], []string{"api-httproute.yaml"}) {
+		t.Errorf("previous files = %v, want them unchanged", previous.Files["team-a"])
+	}
+
+	path := filepath.Join(t.TempDir(), batchStateFile)
+	if err := writeBatchState(path, &batchState{Files: written}); err != nil {
+		t.Fatalf("writeBatchState() error = %v", err)
+	}
+	next, err := readBatchState(path)
+	if err != nil {
+		t.Fatalf("readBatchState() error = %v", err)
+	}
+	if !reflect.DeepEqual(next.Files, want) {
+		t.Errorf("files of the next run = %v, want %v", next.Files, want)
+	}
+}
+
+func TestBatchReferenceGrantsMerged(t *testing.T) {
+	manifests := `apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+  namespace: app
+  annotations:
+    nginx.ingress.kubernetes.io/auth-url: http://oauth2-proxy.auth.svc.cluster.local:4180/oauth2/auth
+spec:
+  rules:
+  - host: web.example.com
+    http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service:
+            name: web
+            port:
+              number: 80
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: api
+  namespace: app
+  annotations:
+    nginx.ingress.kubernetes.io/auth-url: http://basic-auth.auth.svc.cluster.local/check
+spec:
+  rules:
+  - host: api.example.com
+    http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service:
+            name: api
+            port:
+              number: 80
+`
+	input := filepath.Join(t.TempDir(), "ingresses.yaml")
+	if err := os.WriteFile(input, []byte(manifests), 0644); err != nil {
+		t.Fatalf("failed to write manifests: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		kustomize bool
+		wantFile  string
+	}{
+		{name: "file per resource", wantFile: "from-app-securitypolicy-referencegrant.yaml"},
+		{name: "kustomize", kustomize: true, wantFile: "referencegrants.yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := t.TempDir()
+			defer func(input, output, profile string, kustomize bool) {
+				batchInput, batchOutputDir, target, batchKustomize = input, output, profile, kustomize
+			}(batchInput, batchOutputDir, target, batchKustomize)
+			batchInput, batchOutputDir, target, batchKustomize = input, output, "envoy-gateway", tt.kustomize
+
+			if err := runBatch(batchCmd, nil); err != nil {
+				t.Fatalf("runBatch() error = %v", err)
+			}
+
+			// The grant is written once, to the namespace of the services
+			paths, err := filepath.Glob(filepath.Join(output, "*", "*referencegrant*.yaml"))
+			if err != nil {
+				t.Fatalf("Glob() error = %v", err)
+			}
+			want := []string{filepath.Join(output, "auth", tt.wantFile)}
+			if !reflect.DeepEqual(paths, want) {
+				t.Fatalf("ReferenceGrant files = %v, want %v", paths, want)
+			}
+			data, err := os.ReadFile(paths[0])
+			if err != nil {
+				t.Fatalf("failed to read grant: %v", err)
+			}
+			if n := strings.Count(string(data), "kind: ReferenceGrant"); n != 1 {
+				t.Errorf("%s has %d ReferenceGrants, want 1:\n%s", tt.wantFile, n, data)
+			}
+			for _, svc := range []string{"name: oauth2-proxy", "name: basic-auth"} {
+				if !strings.Contains(string(data), svc) {
+					t.Errorf("ReferenceGrant does not list %s:\n%s", svc, data)
+				}
+			}
+
+			if !tt.kustomize {
+				return
+			}
+			kustomization, err := os.ReadFile(filepath.Join(output, "auth", "kustomization.yaml"))
+			if err != nil {
+				t.Fatalf("failed to read kustomization: %v", err)
+			}
+			if !strings.Contains(string(kustomization), "referencegrants.yaml") {
+				t.Errorf("auth kustomization does not list referencegrants.yaml:\n%s", kustomization)
+			}
+			routes, err := os.ReadFile(filepath.Join(output, "app", "httproutes.yaml"))
+			if err != nil {
+				t.Fatalf("failed to read routes: %v", err)
+			}
+			if strings.Contains(string(routes), "kind: ReferenceGrant") {
+				t.Errorf("app/httproutes.yaml contains a ReferenceGrant of the auth namespace")
+			}
+		})
+	}
+}
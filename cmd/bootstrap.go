@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mayens/ingress-to-gateway/pkg/converter"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+var (
+	implementation   string
+	bootstrapGateway string
+	bootstrapClass   string
+	bootstrapOutput  string
+	bootstrapFormat  string
+	bootstrapAPI     string
+	bootstrapApply   bool
+	applyYes         bool
+	confirmThreshold int
+)
+
+// bootstrapCmd represents the bootstrap command
+var bootstrapCmd = &cobra.Command{
+	Use:   "bootstrap",
+	Short: "Generate a GatewayClass and Gateway for a Gateway implementation",
+	Long: `Bootstrap generates the GatewayClass of a Gateway API implementation and a
+minimal Gateway of that class to attach converted routes to, for clusters
+without a Gateway yet.
+
+The Gateway has one HTTP listener on port 80 admitting routes from all
+namespaces, and is named gateway-nginx by default: the Gateway converted
+routes of nginx-class Ingresses reference. The implementation's controller
+and the Gateway API CRDs must be installed for the Gateway to be programmed.
+
+With --apply a plan listing, per namespace, the resources to create and
+those that already exist is printed first. Existing resources are never
+updated: one whose spec differs from the generated one is reported and
+skipped. Creating more than --confirm-threshold resources needs --yes or an
+interactive confirmation. Only the GatewayClass and Gateway are applied;
+converted routes are not.
+
+Supported implementations: ` + strings.Join(converter.BootstrapImplementations(), ", ") + `
+
+Example usage:
+  # Print the GatewayClass and Gateway for Envoy Gateway
+  ingress-to-gateway bootstrap --implementation=envoy-gateway
+
+  # Create them in the cluster; existing resources are left unchanged
+  ingress-to-gateway bootstrap --implementation=istio -n infra --apply
+
+  # Apply without the confirmation asked for larger change sets
+  ingress-to-gateway bootstrap --implementation=istio -n infra --apply --yes
+
+  # Name the Gateway after the one routes are converted with
+  ingress-to-gateway bootstrap --implementation=nginx-gateway-fabric --gateway=shared -o gateway.yaml
+  ingress-to-gateway convert -f ingresses.yaml --gateway=shared --gateway-namespace=default`,
+	RunE: runBootstrap,
+}
+
+func init() {
+	rootCmd.AddCommand(bootstrapCmd)
+
+	bootstrapCmd.Flags().StringVar(&implementation, "implementation", "", "Gateway implementation: "+strings.Join(converter.BootstrapImplementations(), ", "))
+	bootstrapCmd.Flags().StringVar(&bootstrapGateway, "gateway", "gateway-nginx", "name of the generated Gateway")
+	bootstrapCmd.Flags().StringVar(&bootstrapClass, "gateway-class", "", "name of the generated GatewayClass (default: the implementation's)")
+	bootstrapCmd.Flags().StringVarP(&bootstrapOutput, "output-file", "o", "", "output file (default: stdout)")
+	bootstrapCmd.Flags().StringVar(&bootstrapFormat, "format", "yaml", "output format: yaml, json, ndjson or list")
+	bootstrapCmd.Flags().StringVar(&bootstrapAPI, "api-version", converter.APIVersionV1, "Gateway API version of the generated resources: v1 or v1beta1")
+	bootstrapCmd.Flags().BoolVar(&bootstrapApply, "apply", false, "create the resources in the cluster instead of printing them")
+	bootstrapCmd.Flags().BoolVarP(&applyYes, "yes", "y", false, "apply without asking for confirmation")
+	bootstrapCmd.Flags().IntVar(&confirmThreshold, "confirm-threshold", 10, "number of resources to create or update above which --apply asks for confirmation")
+	bootstrapCmd.MarkFlagRequired("implementation")
+}
+
+func runBootstrap(cmd *cobra.Command, args []string) error {
+	if bootstrapFormat == converter.FormatHelm || bootstrapFormat == converter.FormatHelmChart {
+		return fmt.Errorf("invalid output format: %s (valid: yaml, json, ndjson, list)", bootstrapFormat)
+	}
+	if err := validateOutputFormat(bootstrapFormat); err != nil {
+		return err
+	}
+	if err := validateAPIVersion(bootstrapAPI); err != nil {
+		return err
+	}
+
+	ns := namespace
+	if ns == "" {
+		ns = "default"
+	}
+
+	c := converter.NewConverter(converter.Options{},
+		converter.WithGatewayClass(bootstrapClass),
+		converter.WithAPIVersion(bootstrapAPI, converter.ChannelStandard),
+		converter.WithOutputFormat(bootstrapFormat))
+	resources, err := c.Bootstrap(implementation, ns, bootstrapGateway)
+	if err != nil {
+		return err
+	}
+
+	if bootstrapApply {
+		return applyBootstrap(resources, ns)
+	}
+
+	output := os.Stdout
+	if bootstrapOutput != "" {
+		f, err := os.Create(bootstrapOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		output = f
+	}
+	if err := c.WriteOutput(resources, output); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	if bootstrapOutput != "" {
+		fmt.Fprintf(os.Stderr, "GatewayClass and Gateway written to %s\n", bootstrapOutput)
+	}
+	return nil
+}
+
+// applyBootstrap creates the bootstrap resources in namespace ns, leaving
+// existing ones as they are
+func applyBootstrap(resources []interface{}, ns string) error {
+	ctx := context.Background()
+	client, err := newClient()
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	if err := preflight(ctx, client, "bootstrap", []string{ns}, gatewayClassPermissions); err != nil {
+		return err
+	}
+
+	var existing []interface{}
+	for _, res := range resources {
+		var old interface{}
+		var err error
+		switch r := res.(type) {
+		case *gatewayv1.GatewayClass:
+			old, err = client.GetGatewayClass(ctx, r.Name)
+		case *gatewayv1.Gateway:
+			old, err = client.GetGateway(ctx, r.Namespace, r.Name)
+		default:
+			continue
+		}
+		switch {
+		case apierrors.IsNotFound(err):
+		case err != nil:
+			return fmt.Errorf("failed to read the current cluster state: %w", err)
+		default:
+			existing = append(existing, old)
+		}
+	}
+	plan, err := converter.NewCreatePlan(resources, existing)
+	if err != nil {
+		return err
+	}
+	if err := confirmPlan(plan, os.Stdin); err != nil {
+		return err
+	}
+
+	for _, res := range resources {
+		var kind, name string
+		var created bool
+		switch r := res.(type) {
+		case *gatewayv1.GatewayClass:
+			kind, name = "GatewayClass", r.Name
+			created, err = client.CreateGatewayClass(ctx, r)
+		case *gatewayv1.Gateway:
+			kind, name = "Gateway", r.Namespace+"/"+r.Name
+			created, err = client.CreateGateway(ctx, r)
+		default:
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create %s %s: %w", kind, name, err)
+		}
+		if created {
+			fmt.Fprintf(os.Stderr, "%s %s created\n", kind, name)
+		} else {
+			fmt.Fprintf(os.Stderr, "%s %s exists, skipped\n", kind, name)
+		}
+	}
+	return nil
+}
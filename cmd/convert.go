@@ -18,21 +18,63 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/mayens/ingress-to-gateway/pkg/converter"
+	"github.com/mayens/ingress-to-gateway/pkg/experimental"
 	"github.com/mayens/ingress-to-gateway/pkg/k8s"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 var (
-	inputFile     string
-	outputFile    string
-	splitMode     string
-	gatewayName   string
-	gatewayClass  string
-	convertOutput string
+	inputFile      string
+	outputFile     string
+	splitMode      string
+	gatewayName    string
+	gatewayClass   string
+	convertOutput  string
+	helmChart      string
+	helmValues     []string
+	target         string
+	diagFile       string
+	annotFile      string
+	showAnnots     bool
+	copyAnnots     []string
+	dropAnnots     []string
+	copyLabels     []string
+	stripLabels    []string
+	denyMeta       []string
+	redactMeta     []string
+	progressive    string
+	defaultBackend string
+	specDefault    string
+	includeManaged bool
+	maxFileSize    string
+	maxDocsPerFile int
+	prefixCompat   bool
+	emitGateway    bool
+	timeoutPrec    string
+	gatewayNS      string
+	sectionName    string
+	gatewayPort    int32
+	apiVersion     string
+	channel        string
+	experimentalOn bool
+	ruleNames      bool
+	nameTemplate   string
+	strictAnnot    bool
+	mergeHosts     bool
+	mappingsFile   string
+	hookPath       string
+	certManager    string
+	serverDryRun   bool
+	convertApply   bool
 )
 
 // convertCmd represents the convert command
@@ -57,6 +99,9 @@ Split Modes:
   • per-host:    Separate HTTPRoute per hostname (maximum flexibility)
   • per-pattern: Grouped by hostname patterns (intelligent)
 
+With --merge-hosts, all Ingresses of a namespace for the same hostname are
+merged into one HTTPRoute named after the hostname.
+
 Example usage:
   # Convert from cluster
   ingress-to-gateway convert my-ingress -n default
@@ -64,23 +109,137 @@ Example usage:
   # Convert from file with per-host splitting
   ingress-to-gateway convert -f ingress.yaml --split-mode=per-host
 
+  # Convert from stdin in a pipeline
+  kubectl get ingress my-ingress -o yaml | ingress-to-gateway convert -f -
+
+  # Convert every Ingress in a GitOps repository without cluster access
+  ingress-to-gateway convert -f ./clusters/prod -o httproutes.yaml
+  ingress-to-gateway convert -f 'apps/**/ingress*.yaml'
+
+  # Merge Ingresses that split one hostname into one HTTPRoute per hostname
+  ingress-to-gateway convert -f ./clusters/prod --merge-hosts
+
+  # Convert every Ingress in the cluster from a kubectl dump
+  kubectl get ingress -A -o yaml > ingresses.yaml
+  ingress-to-gateway convert -f ingresses.yaml -o httproutes.yaml
+
   # Convert and save to file
   ingress-to-gateway convert my-ingress -o httproute.yaml
 
+  # Stream one JSON object per line to jq
+  ingress-to-gateway convert -f ingresses.yaml --format=ndjson | jq -c .metadata.name
+
   # Convert with custom gateway reference
-  ingress-to-gateway convert my-ingress --gateway=my-gateway`,
+  ingress-to-gateway convert my-ingress --gateway=my-gateway
+
+  # Attach to the HTTPS listener of a shared Gateway in another namespace
+  ingress-to-gateway convert my-ingress --gateway=shared --gateway-namespace=infra --section-name=https
+
+  # Also generate the Gateway, with HTTPS listeners from spec.tls
+  ingress-to-gateway convert my-ingress --emit-gateway --gateway-class=eg
+
+  # Match a cluster with the standard-channel v1beta1 CRDs installed
+  ingress-to-gateway convert my-ingress --api-version=v1beta1 --channel=standard
+
+  # Leave out experimental features except HTTPRoute timeouts
+  INGRESS_TO_GATEWAY_EXPERIMENTAL_HTTPROUTE_TIMEOUTS=true ingress-to-gateway convert my-ingress --experimental=false
+
+  # Prefix route names with the namespace
+  ingress-to-gateway convert my-ingress --name-template='{{.Namespace}}-{{.Ingress}}'
+
+  # Name each rule after its path and backend (Gateway API v1.2+ experimental CRDs)
+  ingress-to-gateway convert my-ingress --rule-names
+
+  # Check the output against the CRD schemas and admission webhooks of the cluster
+  ingress-to-gateway convert -f ingresses.yaml --server-dry-run -o httproutes.yaml
+
+  # Apply the routes after printing what they create and update in the cluster
+  ingress-to-gateway convert -f ingresses.yaml --apply -o httproutes.yaml
+
+  # Fail instead of warning when an annotation value is malformed
+  ingress-to-gateway convert my-ingress --strict-annotations
+
+  # Convert the wrapper annotations of an in-house controller
+  ingress-to-gateway convert -f ingresses.yaml --annotation-mappings=mappings.yaml
+
+  # Pass annotations nothing converts to a platform script
+  ingress-to-gateway convert -f ingresses.yaml --annotation-hook=./convert-annotations
+
+  # Show what each annotation became and save the report for CI gating
+  ingress-to-gateway convert -f ingresses.yaml --show-annotations --annotations-file=annotations.json
+
+  # Keep Argo CD tracking annotations but drop cost-allocation labels
+  ingress-to-gateway convert my-ingress --copy-annotations=argocd.argoproj.io/ --strip-labels=cost-center
+
+  # Copy only team ownership labels and every example.com annotation but the notes
+  ingress-to-gateway convert my-ingress --copy-labels='team.example.com/*' \
+    --copy-annotations='*.example.com/*' --drop-annotations='notes.example.com/*'
+
+  # Never copy internal tracking labels, and mask the value of on-call contacts
+  ingress-to-gateway convert my-ingress --copy-annotations='*.example.com/*' \
+    --deny-metadata='internal.example.com/*' --redact-metadata='*/on-call'
+
+  # Use the shorter of proxy-read-timeout and proxy-send-timeout when they differ
+  ingress-to-gateway convert my-ingress --timeout-precedence=min
+
+  # Keep nginx string-prefix matching (/foo also matches /foobar) for ImplementationSpecific paths
+  ingress-to-gateway convert my-ingress --prefix-compat
+
+  # Scaffold a Helm chart with the Gateway and hostnames in values.yaml
+  ingress-to-gateway convert -f ingresses.yaml --format=helm-chart -o ./charts/routes
+
+  # Convert the Ingress templates of a Helm chart
+  ingress-to-gateway convert --helm-chart ./chart --helm-values values.yaml`,
 	RunE: runConvert,
 }
 
 func init() {
 	rootCmd.AddCommand(convertCmd)
 
-	convertCmd.Flags().StringVarP(&inputFile, "file", "f", "", "input file containing Ingress resource")
+	convertCmd.Flags().StringVarP(&inputFile, "file", "f", "", "input file, directory (searched recursively) or glob of Ingress manifests, - for stdin")
 	convertCmd.Flags().StringVarP(&outputFile, "output-file", "o", "", "output file for HTTPRoute (default: stdout)")
 	convertCmd.Flags().StringVar(&splitMode, "split-mode", "single", "split mode: single, per-host, per-pattern")
+	convertCmd.Flags().BoolVar(&mergeHosts, "merge-hosts", false, "merge the Ingresses of a namespace that share a hostname into one HTTPRoute per hostname, in place of --split-mode")
 	convertCmd.Flags().StringVar(&gatewayName, "gateway", "", "gateway name to reference (default: derive from ingress class)")
-	convertCmd.Flags().StringVar(&gatewayClass, "gateway-class", "nginx", "gateway class name")
-	convertCmd.Flags().StringVar(&convertOutput, "format", "yaml", "output format: yaml or json")
+	convertCmd.Flags().StringVar(&gatewayNS, "gateway-namespace", "", "namespace of the gateway to reference (default: the Ingress namespace)")
+	convertCmd.Flags().StringVar(&sectionName, "section-name", "", "gateway listener name routes attach to (default: all listeners)")
+	convertCmd.Flags().Int32Var(&gatewayPort, "gateway-port", 0, "gateway listener port routes attach to (default: all ports)")
+	convertCmd.Flags().StringVar(&gatewayClass, "gateway-class", "", "gateway class name (default: the --target implementation's, else nginx)")
+	convertCmd.Flags().StringVar(&convertOutput, "format", "yaml", "output format: yaml, json, ndjson, list (a single v1 List document), helm (templates guarded by .Values.httpRoute.enabled) or helm-chart (a chart directory at --output-file)")
+	convertCmd.Flags().StringVar(&target, "target", "", "gateway implementation profile selecting the GatewayClass, policies and features: nginx-gateway-fabric, envoy-gateway, istio, cilium, kong, traefik, gke")
+	convertCmd.Flags().StringVar(&diagFile, "diagnostics-file", "", "write conversion diagnostics as JSON to this file")
+	convertCmd.Flags().StringVar(&annotFile, "annotations-file", "", "write the per-annotation conversion report (converted, partial or skipped) as JSON to this file")
+	convertCmd.Flags().BoolVar(&showAnnots, "show-annotations", false, "print what each nginx annotation was converted to, or why it was not")
+	convertCmd.Flags().StringVar(&helmChart, "helm-chart", "", "render a local Helm chart and convert its Ingress templates")
+	convertCmd.Flags().StringSliceVar(&helmValues, "helm-values", nil, "values files used when rendering --helm-chart")
+	convertCmd.Flags().StringSliceVar(&copyAnnots, "copy-annotations", nil, "Ingress annotation keys or globs (e.g. argocd.argoproj.io/*) to copy to routes; nginx.ingress.kubernetes.io keys are never copied")
+	convertCmd.Flags().StringSliceVar(&dropAnnots, "drop-annotations", nil, "annotation keys or globs matching --copy-annotations not to copy to routes")
+	convertCmd.Flags().StringSliceVar(&copyLabels, "copy-labels", nil, "Ingress label keys or globs to copy to routes (default all)")
+	convertCmd.Flags().StringSliceVar(&stripLabels, "strip-labels", nil, "Ingress label keys or globs not to copy to routes")
+	convertCmd.Flags().StringSliceVar(&denyMeta, "deny-metadata", nil, "label and annotation keys or globs never copied to routes, in addition to the built-in credential and tracking keys")
+	convertCmd.Flags().StringSliceVar(&redactMeta, "redact-metadata", nil, "label and annotation keys or globs copied to routes with the value "+converter.RedactedValue)
+	convertCmd.Flags().StringVar(&progressive, "progressive-delivery", converter.ProgressiveSkip, "Ingresses owned by Flagger or Argo Rollouts: skip or generate")
+	convertCmd.Flags().BoolVar(&includeManaged, "include-managed", false, "convert Ingresses owned by other controllers (operators, Knative, cert-manager solvers)")
+	convertCmd.Flags().StringVar(&maxFileSize, "max-file-size", "", "split output into numbered files of at most this size, e.g. 512Ki (requires --output-file)")
+	convertCmd.Flags().IntVar(&maxDocsPerFile, "max-docs-per-file", 0, "split output into numbered files of at most this many documents (requires --output-file)")
+	convertCmd.Flags().StringVar(&defaultBackend, "default-backend-mode", converter.DefaultBackendCatchAll, "default-backend annotation handling: catch-all, listener or none")
+	convertCmd.Flags().StringVar(&specDefault, "spec-default-backend-mode", converter.SpecDefaultBackendRule, "spec.defaultBackend handling: rule (\"/\" rule in the Ingress routes), catch-all (separate lowest-precedence HTTPRoute) or none")
+	convertCmd.Flags().BoolVar(&emitGateway, "emit-gateway", false, "also generate the referenced Gateways with HTTP/HTTPS listeners derived from spec.tls")
+	convertCmd.Flags().StringVar(&certManager, "cert-manager", converter.CertManagerGateway, "cert-manager.io Ingress annotations: gateway (carried to --emit-gateway Gateways), certificate (a Certificate per TLS secret) or none")
+	convertCmd.Flags().BoolVar(&prefixCompat, "prefix-compat", false, "also match ImplementationSpecific paths with a regex so /foo keeps matching /foobar as in nginx")
+	convertCmd.Flags().StringVar(&timeoutPrec, "timeout-precedence", converter.TimeoutPrecedenceMax, "route timeout when proxy-read and proxy-send timeouts differ: max or min")
+	convertCmd.Flags().StringVar(&apiVersion, "api-version", converter.APIVersionV1, "Gateway API version of generated Gateways and HTTPRoutes: v1 or v1beta1")
+	convertCmd.Flags().StringVar(&channel, "channel", converter.ChannelExperimental, "Gateway API release channel of the installed CRDs: experimental or standard (leaves out experimental resources and fields)")
+	convertCmd.Flags().BoolVar(&experimentalOn, "experimental", true, "emit experimental Gateway API resources and fields (see the version command); override per feature with INGRESS_TO_GATEWAY_EXPERIMENTAL_<FEATURE>=true|false")
+	convertCmd.Flags().StringVar(&nameTemplate, "name-template", converter.DefaultNameTemplate, "Go template for HTTPRoute names, with .Ingress, .Namespace and .Class; split modes append a suffix")
+	convertCmd.Flags().BoolVar(&ruleNames, "rule-names", false, "name each HTTPRoute rule after its path and backend (needs --api-version=v1 and the Gateway API v1.2+ experimental CRDs)")
+	convertCmd.Flags().BoolVar(&strictAnnot, "strict-annotations", false, "fail on malformed annotation values, such as a non-numeric timeout, instead of warning and leaving the annotation out")
+	convertCmd.Flags().StringVar(&mappingsFile, "annotation-mappings", "", "YAML file mapping custom annotations to HTTPRoute filter, timeouts and policy templates")
+	convertCmd.Flags().BoolVar(&serverDryRun, "server-dry-run", false, "submit the generated resources to the API server with dry-run=All and fail, writing nothing, if it rejects any")
+	convertCmd.Flags().BoolVar(&convertApply, "apply", false, "server-side apply the generated resources to the cluster, after printing a plan of the resources they create, update and leave unchanged")
+	convertCmd.Flags().BoolVarP(&applyYes, "yes", "y", false, "apply without asking for confirmation")
+	convertCmd.Flags().IntVar(&confirmThreshold, "confirm-threshold", 10, "number of resources to create or update above which --apply asks for confirmation")
+	convertCmd.Flags().StringVar(&hookPath, "annotation-hook", "", "executable converting the annotations no translator handles, given as JSON on stdin")
 }
 
 func runConvert(cmd *cobra.Command, args []string) error {
@@ -92,35 +251,155 @@ func runConvert(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid split mode: %s (valid: single, per-host, per-pattern)", splitMode)
 	}
 
+	if err := validateOutputFormat(convertOutput); err != nil {
+		return err
+	}
+	if err := validateTarget(target); err != nil {
+		return err
+	}
+	if err := validateProgressive(progressive); err != nil {
+		return err
+	}
+	if err := validateDefaultBackendMode(defaultBackend); err != nil {
+		return err
+	}
+	if err := validateSpecDefaultBackendMode(specDefault); err != nil {
+		return err
+	}
+	if err := validateCertManagerMode(certManager); err != nil {
+		return err
+	}
+	if _, err := converter.ParseNameTemplate(nameTemplate); err != nil {
+		return err
+	}
+	if err := validateTimeoutPrecedence(timeoutPrec); err != nil {
+		return err
+	}
+	if err := validateAPIVersion(apiVersion); err != nil {
+		return err
+	}
+	if err := validateChannel(channel); err != nil {
+		return err
+	}
+	gate, err := experimentalGate()
+	if err != nil {
+		return err
+	}
+	translators, err := translatorRegistry()
+	if err != nil {
+		return err
+	}
+	classRefs, err := ingressClassParentRefs()
+	if err != nil {
+		return err
+	}
+	limits, err := chunkLimits()
+	if err != nil {
+		return err
+	}
+	if limits.Enabled() && outputFile == "" {
+		return fmt.Errorf("--max-file-size and --max-docs-per-file require --output-file")
+	}
+	if convertOutput == converter.FormatHelmChart && (outputFile == "" || limits.Enabled()) {
+		return fmt.Errorf("--format=helm-chart requires --output-file naming the chart directory, without file size limits")
+	}
+
 	// Create converter
 	opts := converter.Options{
-		SplitMode:    splitMode,
-		GatewayName:  gatewayName,
-		GatewayClass: gatewayClass,
-		OutputFormat: convertOutput,
+		RoutingOptions: converter.RoutingOptions{
+			SplitMode:              splitMode,
+			DefaultBackendMode:     defaultBackend,
+			PrefixCompat:           prefixCompat,
+			TimeoutPrecedence:      timeoutPrec,
+			SpecDefaultBackendMode: specDefault,
+			MergeHosts:             mergeHosts,
+			Translators:            translators,
+		},
+		NamingOptions: converter.NamingOptions{
+			NameTemplate: nameTemplate,
+			RuleNames:    ruleNames,
+		},
+		GatewayOptions: converter.GatewayOptions{
+			GatewayName:      gatewayName,
+			GatewayClass:     gatewayClass,
+			EmitGateway:      emitGateway,
+			GatewayNamespace: gatewayNS,
+			SectionName:      sectionName,
+			Port:             gatewayPort,
+			IngressClasses:   classRefs,
+			CertManager:      certManager,
+		},
+		MetadataOptions: converter.MetadataOptions{
+			CopyAnnotations: copyAnnots,
+			DropAnnotations: dropAnnots,
+			CopyLabels:      copyLabels,
+			StripLabels:     stripLabels,
+			DenyKeys:        denyMeta,
+			RedactKeys:      redactMeta,
+		},
+		OwnershipOptions: converter.OwnershipOptions{
+			ProgressiveDelivery: progressive,
+			IncludeManaged:      includeManaged,
+		},
+		TargetOptions: converter.TargetOptions{
+			Target:       target,
+			APIVersion:   apiVersion,
+			Channel:      channel,
+			Experimental: gate,
+		},
+		ValidationOptions: converter.ValidationOptions{
+			StrictAnnotations: strictAnnot,
+		},
+		OutputOptions: converter.OutputOptions{
+			OutputFormat: convertOutput,
+		},
 	}
 	c := converter.NewConverter(opts)
 
 	var ingresses []interface{}
-	var err error
 
-	if inputFile != "" {
-		// Read from file
-		ingresses, err = c.LoadFromFile(inputFile)
+	if helmChart != "" {
+		// Render Helm chart
+		ingresses, err = c.LoadFromHelmChart(ctx, converter.HelmOptions{
+			ChartPath:   helmChart,
+			ValuesFiles: helmValues,
+			Namespace:   namespace,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to load ingress from helm chart: %w", err)
+		}
+		if len(ingresses) == 0 {
+			return fmt.Errorf("no Ingress resources rendered by chart %s", helmChart)
+		}
+	} else if inputFile == "-" {
+		// Read from stdin
+		ingresses, err = c.LoadFromReader(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to load ingress from stdin: %w", err)
+		}
+	} else if inputFile != "" {
+		// Read from a file, directory or glob
+		ingresses, err = c.LoadFromPath(inputFile)
+		printSkippedFiles(c.SkippedFiles())
 		if err != nil {
 			return fmt.Errorf("failed to load ingress from file: %w", err)
 		}
 	} else {
 		// Read from cluster
 		if len(args) == 0 {
-			return fmt.Errorf("ingress name required when not using --file")
+			return fmt.Errorf("ingress name required when not using --file or --helm-chart")
 		}
 		ingressName := args[0]
 
-		client, err := k8s.NewClient(kubeconfig)
+		client, err := newClient()
 		if err != nil {
 			return fmt.Errorf("failed to create kubernetes client: %w", err)
 		}
+		// Backend Service appProtocols select GRPCRoute and BackendTLSPolicy
+		c.SetServiceLookup(client)
+		if emitGateway {
+			addIngressClasses(ctx, c, client)
+		}
 
 		ns := namespace
 		if ns == "" {
@@ -142,8 +421,53 @@ func runConvert(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("conversion failed: %w", err)
 	}
+	printDiagnostics(c.Diagnostics())
+	if diagFile != "" {
+		if err := writeDiagnosticsFile(diagFile, c.Diagnostics()); err != nil {
+			return err
+		}
+	}
+	annotations := converter.AnnotationResults(c.Fidelity())
+	if showAnnots {
+		printAnnotationResults(annotations)
+	}
+	if annotFile != "" {
+		if err := writeAnnotationsFile(annotFile, annotations); err != nil {
+			return err
+		}
+	}
+
+	if serverDryRun {
+		if err := runServerDryRun(ctx, httpRoutes); err != nil {
+			return err
+		}
+	}
+	if convertApply {
+		if err := applyResources(ctx, httpRoutes); err != nil {
+			return err
+		}
+	}
 
 	// Output results
+	if convertOutput == converter.FormatHelmChart {
+		if _, err := c.WriteHelmChart(httpRoutes, outputFile); err != nil {
+			return fmt.Errorf("failed to write helm chart: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Helm chart written to %s\n", outputFile)
+		printNextSteps(converter.NextSteps(ingresses, httpRoutes, c.Diagnostics(), converter.RunOutput{Paths: []string{outputFile}, HelmChart: true}))
+		return nil
+	}
+
+	if limits.Enabled() {
+		files, err := c.WriteChunks(httpRoutes, outputFile, limits)
+		if err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "HTTPRoute(s) written to %s\n", strings.Join(files, ", "))
+		printNextSteps(converter.NextSteps(ingresses, httpRoutes, c.Diagnostics(), converter.RunOutput{Paths: files}))
+		return nil
+	}
+
 	output := os.Stdout
 	if outputFile != "" {
 		f, err := os.Create(outputFile)
@@ -158,9 +482,316 @@ func runConvert(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to write output: %w", err)
 	}
 
+	var out converter.RunOutput
 	if outputFile != "" {
 		fmt.Fprintf(os.Stderr, "HTTPRoute(s) written to %s\n", outputFile)
+		out.Paths = []string{outputFile}
 	}
+	printNextSteps(converter.NextSteps(ingresses, httpRoutes, c.Diagnostics(), out))
 
 	return nil
 }
+
+// printDiagnostics prints conversion diagnostics to stderr
+func printDiagnostics(diags []converter.Diagnostic) {
+	for _, d := range diags {
+		switch d.Severity {
+		case converter.SeverityError:
+			fmt.Fprintf(os.Stderr, "Error: %s\n", d)
+		case converter.SeverityWarning:
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", d)
+		default:
+			fmt.Fprintf(os.Stderr, "Note: %s\n", d)
+		}
+	}
+}
+
+// printAnnotationResults prints the per-annotation conversion report to stderr
+func printAnnotationResults(results []converter.AnnotationResult) {
+	if len(results) == 0 {
+		return
+	}
+	fmt.Fprintln(os.Stderr, "\nAnnotations:")
+	for _, r := range results {
+		name := strings.TrimPrefix(r.Annotation, "nginx.ingress.kubernetes.io/")
+		switch r.Status {
+		case converter.AnnotationConverted:
+			fmt.Fprintf(os.Stderr, "  converted  %s %s", r.Ingress, name)
+		case converter.AnnotationPartial:
+			fmt.Fprintf(os.Stderr, "  partial    %s %s", r.Ingress, name)
+		default:
+			fmt.Fprintf(os.Stderr, "  skipped    %s %s: %s\n", r.Ingress, name, r.Reason)
+			continue
+		}
+		if r.ConvertedTo != "" {
+			fmt.Fprintf(os.Stderr, " -> %s", r.ConvertedTo)
+		}
+		fmt.Fprintln(os.Stderr)
+	}
+	fmt.Fprintf(os.Stderr, "  %s\n", annotationSummary(results))
+}
+
+// annotationSummary counts annotations by conversion status
+func annotationSummary(results []converter.AnnotationResult) string {
+	counts := make(map[string]int)
+	for _, r := range results {
+		counts[r.Status]++
+	}
+	return fmt.Sprintf("%d converted, %d partial, %d skipped",
+		counts[converter.AnnotationConverted], counts[converter.AnnotationPartial], counts[converter.AnnotationSkipped])
+}
+
+// writeAnnotationsFile writes the per-annotation conversion report as JSON
+// for automation
+func writeAnnotationsFile(path string, results []converter.AnnotationResult) error {
+	if results == nil {
+		results = []converter.AnnotationResult{}
+	}
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal annotation report: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write annotation report: %w", err)
+	}
+	return nil
+}
+
+// printNextSteps prints the checklist for taking the output live to stderr
+func printNextSteps(steps []string) {
+	if len(steps) == 0 {
+		return
+	}
+	fmt.Fprintln(os.Stderr, "\nNext steps:")
+	for i, step := range steps {
+		fmt.Fprintf(os.Stderr, "  %d. %s\n", i+1, step)
+	}
+}
+
+// printSkippedFiles reports discovered files that could not be parsed
+func printSkippedFiles(skipped []string) {
+	for _, s := range skipped {
+		fmt.Fprintf(os.Stderr, "Warning: skipped %s\n", s)
+	}
+}
+
+// writeDiagnosticsFile writes conversion diagnostics as JSON for automation
+func writeDiagnosticsFile(path string, diags []converter.Diagnostic) error {
+	if diags == nil {
+		diags = []converter.Diagnostic{}
+	}
+	data, err := json.MarshalIndent(diags, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal diagnostics: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write diagnostics file: %w", err)
+	}
+	return nil
+}
+
+// runServerDryRun submits resources to the API server with dry-run=All,
+// which checks them against the CRD schemas and admission webhooks the local
+// validation cannot see, and fails listing the rejected ones
+func runServerDryRun(ctx context.Context, resources []interface{}) error {
+	objs, err := converter.ToUnstructured(resources)
+	if err != nil {
+		return err
+	}
+	client, err := newClient()
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	rejections, err := client.ServerDryRun(ctx, objs)
+	if err != nil {
+		return fmt.Errorf("server dry run failed: %w", err)
+	}
+	if len(rejections) == 0 {
+		fmt.Fprintf(os.Stderr, "Server dry run: %d resource(s) accepted by %s\n", len(objs), client.Server())
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "Server dry run rejected by %s:\n", client.Server())
+	for _, r := range rejections {
+		fmt.Fprintf(os.Stderr, "  - %s\n", r)
+	}
+	return fmt.Errorf("server dry run rejected %d of %d resource(s)", len(rejections), len(objs))
+}
+
+// addIngressClasses reads the IngressClasses of the cluster, from which the
+// GatewayClasses of generated Gateways are derived. Without them Gateways
+// use the default GatewayClass.
+func addIngressClasses(ctx context.Context, c *converter.Converter, client *k8s.Client) {
+	classes, err := client.ListIngressClasses(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to list IngressClasses, GatewayClasses are not generated: %v\n", err)
+		return
+	}
+	resources, err := converter.IngressClassResources(classes)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	c.AddReferenced(resources)
+}
+
+// validateOutputFormat checks the --format flag
+func validateOutputFormat(format string) error {
+	for _, f := range converter.OutputFormats {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format: %s (valid: %s)", format, strings.Join(converter.OutputFormats, ", "))
+}
+
+// validateTarget checks the --target flag against the supported implementations
+func validateTarget(target string) error {
+	if target == "" {
+		return nil
+	}
+	for _, t := range converter.Targets {
+		if t == target {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid target: %s (valid: %s)", target, strings.Join(converter.Targets, ", "))
+}
+
+// validateProgressive checks the --progressive-delivery mode
+func validateProgressive(mode string) error {
+	for _, m := range converter.ProgressiveModes {
+		if m == mode {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid progressive delivery mode: %s (valid: %s)", mode, strings.Join(converter.ProgressiveModes, ", "))
+}
+
+// validateDefaultBackendMode checks the --default-backend-mode flag
+func validateDefaultBackendMode(mode string) error {
+	for _, m := range converter.DefaultBackendModes {
+		if m == mode {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid default backend mode: %s (valid: %s)", mode, strings.Join(converter.DefaultBackendModes, ", "))
+}
+
+// validateSpecDefaultBackendMode checks the --spec-default-backend-mode flag
+func validateSpecDefaultBackendMode(mode string) error {
+	for _, m := range converter.SpecDefaultBackendModes {
+		if m == mode {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid spec default backend mode: %s (valid: %s)", mode, strings.Join(converter.SpecDefaultBackendModes, ", "))
+}
+
+// validateCertManagerMode checks the --cert-manager flag
+func validateCertManagerMode(mode string) error {
+	for _, m := range converter.CertManagerModes {
+		if m == mode {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid cert-manager mode: %s (valid: %s)", mode, strings.Join(converter.CertManagerModes, ", "))
+}
+
+// validateTimeoutPrecedence checks the --timeout-precedence flag
+func validateTimeoutPrecedence(precedence string) error {
+	for _, p := range converter.TimeoutPrecedences {
+		if p == precedence {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid timeout precedence: %s (valid: %s)", precedence, strings.Join(converter.TimeoutPrecedences, ", "))
+}
+
+// validateAPIVersion checks the --api-version flag
+func validateAPIVersion(version string) error {
+	for _, v := range converter.APIVersions {
+		if v == version {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid API version: %s (valid: %s)", version, strings.Join(converter.APIVersions, ", "))
+}
+
+// validateChannel checks the --channel flag
+func validateChannel(ch string) error {
+	for _, c := range converter.Channels {
+		if c == ch {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid channel: %s (valid: %s)", ch, strings.Join(converter.Channels, ", "))
+}
+
+// experimentalGate builds the gate of experimental features from the
+// --experimental flag and the per-feature environment overrides
+func experimentalGate() (*experimental.Gate, error) {
+	gate, err := experimental.NewGate(experimentalOn, os.Environ())
+	if err != nil {
+		return nil, fmt.Errorf("invalid experimental feature override: %w", err)
+	}
+	return gate, nil
+}
+
+// translatorRegistry returns the annotation translators of the run: the
+// built-in ones, those of --annotation-mappings and the --annotation-hook
+func translatorRegistry() (*converter.Registry, error) {
+	registry := converter.DefaultRegistry()
+	if hookPath != "" {
+		path, err := exec.LookPath(hookPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid annotation hook: %w", err)
+		}
+		registry.SetHook(converter.NewExecHook(path))
+	}
+	if mappingsFile == "" {
+		return registry, nil
+	}
+	mappings, err := converter.LoadMappings(mappingsFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := registry.RegisterMappings(mappings); err != nil {
+		return nil, fmt.Errorf("invalid annotation mappings %s: %w", mappingsFile, err)
+	}
+	return registry, nil
+}
+
+// ingressClassParentRefs reads the per-ingress-class parentRefs from the
+// ingressClasses key of the config file and checks the --gateway-port flag
+func ingressClassParentRefs() (map[string]converter.ParentRef, error) {
+	if gatewayPort < 0 || gatewayPort > 65535 {
+		return nil, fmt.Errorf("invalid gateway port: %d (valid: 1-65535)", gatewayPort)
+	}
+
+	var refs map[string]converter.ParentRef
+	if err := viper.UnmarshalKey("ingressClasses", &refs); err != nil {
+		return nil, fmt.Errorf("failed to read ingressClasses from config file: %w", err)
+	}
+	for class, ref := range refs {
+		if ref.Port < 0 || ref.Port > 65535 {
+			return nil, fmt.Errorf("invalid gateway port for ingress class %s: %d (valid: 1-65535)", class, ref.Port)
+		}
+	}
+	return refs, nil
+}
+
+// chunkLimits parses the --max-file-size and --max-docs-per-file flags
+func chunkLimits() (converter.ChunkLimits, error) {
+	limits := converter.ChunkLimits{MaxDocs: maxDocsPerFile}
+	if maxDocsPerFile < 0 {
+		return limits, fmt.Errorf("invalid --max-docs-per-file: %d", maxDocsPerFile)
+	}
+	if maxFileSize != "" {
+		size, err := resource.ParseQuantity(maxFileSize)
+		if err != nil || size.Value() <= 0 {
+			return limits, fmt.Errorf("invalid --max-file-size: %s", maxFileSize)
+		}
+		limits.MaxBytes = int(size.Value())
+	}
+	return limits, nil
+}
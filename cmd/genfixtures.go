@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mayens/ingress-to-gateway/pkg/converter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fixtureCount       int
+	fixtureAnnotations int
+	fixtureNamespaces  int
+	fixtureSeed        int64
+	fixtureOutput      string
+)
+
+// genfixturesCmd represents the hidden genfixtures command
+var genfixturesCmd = &cobra.Command{
+	Use:   "genfixtures",
+	Short: "Generate synthetic Ingress manifests for load testing",
+	Long: `Generate synthetic Ingress manifests with varied hosts, paths, TLS and
+annotations, to measure conversion time and memory on a realistic volume
+before a production run. The same flags and seed always produce the same
+manifests.
+
+Example usage:
+  # 5000 Ingresses with 5 annotations each
+  ingress-to-gateway genfixtures --count 5000 --annotations-per-ingress 5 -o fixtures.yaml
+
+  # Time a batch conversion of them
+  time ingress-to-gateway convert -f fixtures.yaml -o /dev/null`,
+	Hidden: true,
+	RunE:   runGenfixtures,
+}
+
+func init() {
+	rootCmd.AddCommand(genfixturesCmd)
+
+	genfixturesCmd.Flags().IntVar(&fixtureCount, "count", 100, "number of Ingresses to generate")
+	genfixturesCmd.Flags().IntVar(&fixtureAnnotations, "annotations-per-ingress", 3, "nginx annotations per Ingress")
+	genfixturesCmd.Flags().IntVar(&fixtureNamespaces, "namespaces", 1, "spread the Ingresses over this many namespaces")
+	genfixturesCmd.Flags().Int64Var(&fixtureSeed, "seed", 1, "random seed")
+	genfixturesCmd.Flags().StringVarP(&fixtureOutput, "output-file", "o", "", "output file (default: stdout)")
+}
+
+func runGenfixtures(cmd *cobra.Command, args []string) error {
+	if fixtureCount < 0 || fixtureAnnotations < 0 || fixtureNamespaces < 1 {
+		return fmt.Errorf("--count and --annotations-per-ingress must not be negative, --namespaces must be at least 1")
+	}
+
+	ingresses := converter.GenerateFixtures(converter.FixtureOptions{
+		Count:                 fixtureCount,
+		AnnotationsPerIngress: fixtureAnnotations,
+		Namespaces:            fixtureNamespaces,
+		Seed:                  fixtureSeed,
+	})
+	resources := make([]interface{}, len(ingresses))
+	for i, ing := range ingresses {
+		resources[i] = ing
+	}
+
+	output := os.Stdout
+	if fixtureOutput != "" {
+		f, err := os.Create(fixtureOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		output = f
+	}
+
+	c := converter.NewConverter(converter.Options{}, converter.WithOutputFormat(converter.FormatYAML))
+	if err := c.WriteOutput(resources, output); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	if fixtureOutput != "" {
+		fmt.Fprintf(os.Stderr, "%d Ingresses written to %s\n", len(ingresses), fixtureOutput)
+	}
+	return nil
+}
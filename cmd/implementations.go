@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mayens/ingress-to-gateway/pkg/converter"
+	"github.com/mayens/ingress-to-gateway/pkg/reporter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	implementationsOutput   string
+	implementationsDetailed bool
+)
+
+// implementationsCmd represents the implementations command
+var implementationsCmd = &cobra.Command{
+	Use:   "implementations [implementation]",
+	Short: "List target implementations and their capabilities",
+	Long: `List the Gateway implementations accepted by --target and what the
+converter generates for each.
+
+For every implementation the listing shows:
+  • The default GatewayClass and its controllerName
+  • Supported and unsupported features (GRPCRoute, TLSRoute, ...)
+  • The policy kinds generated and the annotations converted to them
+  • The CRDs the converted output needs (with --detailed)
+
+No cluster access is required.
+
+Example usage:
+  # List all implementations
+  ingress-to-gateway implementations
+
+  # Show the CRDs Envoy Gateway output needs
+  ingress-to-gateway implementations envoy-gateway --detailed
+
+  # Machine-readable capability data
+  ingress-to-gateway implementations --output=json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runImplementations,
+}
+
+func init() {
+	rootCmd.AddCommand(implementationsCmd)
+
+	implementationsCmd.Flags().StringVarP(&implementationsOutput, "output", "o", "table", "output format: table, json, yaml")
+	implementationsCmd.Flags().BoolVarP(&implementationsDetailed, "detailed", "d", false, "also list the CRDs each implementation needs")
+}
+
+func runImplementations(cmd *cobra.Command, args []string) error {
+	switch implementationsOutput {
+	case "table", "json", "yaml":
+	default:
+		return fmt.Errorf("invalid output format: %s (valid: table, json, yaml)", implementationsOutput)
+	}
+
+	caps := converter.Implementations()
+	if len(args) == 1 {
+		if err := validateTarget(args[0]); err != nil {
+			return err
+		}
+		for _, c := range caps {
+			if c.Name == args[0] {
+				caps = []converter.Capabilities{c}
+				break
+			}
+		}
+	}
+
+	r := reporter.NewReporter(implementationsOutput, implementationsDetailed)
+	if err := r.GenerateImplementationsReport(caps, os.Stdout); err != nil {
+		return fmt.Errorf("failed to generate report: %w", err)
+	}
+	return nil
+}
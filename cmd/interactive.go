@@ -20,7 +20,6 @@ import (
 	"context"
 
 	"github.com/mayens/ingress-to-gateway/pkg/interactive"
-	"github.com/mayens/ingress-to-gateway/pkg/k8s"
 	"github.com/spf13/cobra"
 )
 
@@ -61,7 +60,7 @@ func runInteractive(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
 	// Create Kubernetes client
-	client, err := k8s.NewClient(kubeconfig)
+	client, err := newClient()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/mayens/ingress-to-gateway/pkg/analyzer"
+	"github.com/mayens/ingress-to-gateway/pkg/reporter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintAllNamespaces bool
+	lintOutput        string
+	lintVerbose       bool
+)
+
+// lintCmd represents the lint-ingress command
+var lintCmd = &cobra.Command{
+	Use:   "lint-ingress [flags]",
+	Short: "Lint Ingress annotations before migration",
+	Long: `Lint-ingress checks the nginx annotations on live Ingress resources for
+mistakes that ingress-nginx silently ignores and that would reduce conversion
+fidelity.
+
+The linter flags:
+  • Misspelled annotation prefixes (e.g. nginx.ingress.kubernetes/)
+  • Conflicting annotations (e.g. rewrite-target with app-root)
+  • Invalid integer, duration and boolean values
+
+Example usage:
+  # Lint Ingress in current namespace
+  ingress-to-gateway lint-ingress
+
+  # Lint across all namespaces with JSON output
+  ingress-to-gateway lint-ingress --all-namespaces --output=json`,
+	RunE: runLint,
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+
+	lintCmd.Flags().BoolVarP(&lintAllNamespaces, "all-namespaces", "A", false, "lint across all namespaces")
+	lintCmd.Flags().StringVarP(&lintOutput, "output", "o", "table", "output format: table, json, yaml")
+	lintCmd.Flags().BoolVarP(&lintVerbose, "verbose", "v", false, "also list Ingress resources without findings")
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	// Create Kubernetes client
+	client, err := newClient()
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	// Determine namespaces to lint
+	var namespaces []string
+	if lintAllNamespaces {
+		nsList, err := client.ListNamespaces(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list namespaces: %w", err)
+		}
+		namespaces = nsList
+	} else {
+		ns := namespace
+		if ns == "" {
+			ns, err = client.CurrentNamespace()
+			if err != nil {
+				return fmt.Errorf("failed to get current namespace: %w", err)
+			}
+		}
+		namespaces = []string{ns}
+	}
+
+	a := analyzer.NewAnalyzer(client)
+	results, err := a.LintIngresses(ctx, namespaces)
+	if err != nil {
+		return fmt.Errorf("failed to lint ingresses: %w", err)
+	}
+
+	if len(results) == 0 {
+		fmt.Println("No Ingress resources found.")
+		return nil
+	}
+
+	r := reporter.NewReporter(lintOutput, lintVerbose)
+	if err := r.GenerateLintReport(results, os.Stdout); err != nil {
+		return fmt.Errorf("failed to generate report: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/mayens/ingress-to-gateway/internal/version"
+	"github.com/mayens/ingress-to-gateway/pkg/k8s"
+	"github.com/mayens/ingress-to-gateway/pkg/reporter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// redactedFlags hold credentials and are never written to reports
+var redactedFlags = map[string]bool{
+	"token": true,
+}
+
+// runMetadata describes the current run for report headers: the cluster
+// and context, the namespaces scanned and the flags set on the command line
+func runMetadata(cmd *cobra.Command, client *k8s.Client, namespaces []string) *reporter.RunMetadata {
+	options := make(map[string]string)
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		value := f.Value.String()
+		if redactedFlags[f.Name] {
+			value = "REDACTED"
+		}
+		options[f.Name] = value
+	})
+
+	return &reporter.RunMetadata{
+		Tool:       "ingress-to-gateway",
+		Version:    version.Version,
+		GitCommit:  version.GitCommit,
+		Timestamp:  time.Now().UTC(),
+		Cluster:    client.Server(),
+		Context:    client.Context(),
+		Namespaces: namespaces,
+		Options:    options,
+	}
+}
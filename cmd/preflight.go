@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mayens/ingress-to-gateway/pkg/k8s"
+)
+
+// commandPermissions lists the namespaced permissions each command needs
+var commandPermissions = map[string][]k8s.AccessCheck{
+	"audit":        {{Verb: "list", Group: "networking.k8s.io", Resource: "ingresses"}},
+	"batch":        {{Verb: "list", Group: "networking.k8s.io", Resource: "ingresses"}},
+	"bootstrap":    {{Verb: "create", Group: "gateway.networking.k8s.io", Resource: "gateways"}},
+	"convert":      {{Verb: "get", Group: "networking.k8s.io", Resource: "ingresses"}},
+	"lint-ingress": {{Verb: "list", Group: "networking.k8s.io", Resource: "ingresses"}},
+}
+
+// hostCollisionPermissions are the cluster-wide permissions of audit --check-host-collisions
+var hostCollisionPermissions = []k8s.AccessCheck{
+	{Verb: "list", Group: "networking.k8s.io", Resource: "ingresses"},
+	{Verb: "list", Group: "gateway.networking.k8s.io", Resource: "gateways"},
+	{Verb: "list", Group: "gateway.networking.k8s.io", Resource: "httproutes"},
+}
+
+// gatewayClassPermissions are the cluster-wide permissions of bootstrap --apply
+var gatewayClassPermissions = []k8s.AccessCheck{
+	{Verb: "create", Group: "gateway.networking.k8s.io", Resource: "gatewayclasses"},
+}
+
+// permissionCommands returns the commands with known permissions, sorted
+func permissionCommands() []string {
+	commands := make([]string, 0, len(commandPermissions))
+	for command := range commandPermissions {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+	return commands
+}
+
+// preflight verifies the permissions of command in every namespace before a
+// run starts, so a multi-namespace run does not fail halfway through.
+// clusterChecks are verified at cluster scope only.
+func preflight(ctx context.Context, client *k8s.Client, command string, namespaces []string, clusterChecks []k8s.AccessCheck) error {
+	if skipPreflight {
+		return nil
+	}
+
+	var missing []string
+	check := func(checks []k8s.AccessCheck) ([]k8s.AccessResult, error) {
+		for i := range checks {
+			checks[i].Command = command
+		}
+		return client.CheckAccess(ctx, checks)
+	}
+
+	for _, perm := range commandPermissions[command] {
+		// A cluster-wide grant covers every namespace with one review
+		if len(namespaces) > 1 {
+			perm.Namespace = ""
+			results, err := check([]k8s.AccessCheck{perm})
+			if err != nil {
+				return fmt.Errorf("permission preflight failed: %w", err)
+			}
+			if results[0].Allowed {
+				continue
+			}
+		}
+
+		checks := make([]k8s.AccessCheck, 0, len(namespaces))
+		for _, ns := range namespaces {
+			perm.Namespace = ns
+			checks = append(checks, perm)
+		}
+		results, err := check(checks)
+		if err != nil {
+			return fmt.Errorf("permission preflight failed: %w", err)
+		}
+		for _, r := range results {
+			if !r.Allowed {
+				missing = append(missing, fmt.Sprintf("%s in namespace %s", r.AccessCheck, r.Namespace))
+			}
+		}
+	}
+
+	if len(clusterChecks) > 0 {
+		results, err := check(append([]k8s.AccessCheck(nil), clusterChecks...))
+		if err != nil {
+			return fmt.Errorf("permission preflight failed: %w", err)
+		}
+		for _, r := range results {
+			if !r.Allowed {
+				missing = append(missing, fmt.Sprintf("%s cluster-wide", r.AccessCheck))
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "Missing permissions for %s:\n  %s\n", command, strings.Join(missing, "\n  "))
+		return fmt.Errorf("%d missing permission(s) for %s; grant them or rerun with --skip-preflight", len(missing), command)
+	}
+	return nil
+}
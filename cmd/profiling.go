@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	_ "net/http/pprof" // registers /debug/pprof/ on http.DefaultServeMux
+	"os"
+	"runtime/trace"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	// pprofAddr and traceFile are hidden flags for performance bug reports
+	pprofAddr string
+	traceFile string
+
+	pprofListener net.Listener
+	traceOutput   *os.File
+)
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&pprofAddr, "pprof-addr", "", "serve net/http/pprof on this address, e.g. localhost:6060, while the command runs")
+	rootCmd.PersistentFlags().StringVar(&traceFile, "trace-file", "", "write a runtime execution trace to this file, for go tool trace")
+	rootCmd.PersistentFlags().MarkHidden("pprof-addr")
+	rootCmd.PersistentFlags().MarkHidden("trace-file")
+}
+
+// startProfiling starts the pprof server and execution trace requested on
+// the command line; stopProfiling ends them once the command returns
+func startProfiling(cmd *cobra.Command, args []string) error {
+	if pprofAddr != "" {
+		listener, err := net.Listen("tcp", pprofAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on pprof address: %w", err)
+		}
+		pprofListener = listener
+		go http.Serve(listener, http.DefaultServeMux)
+		fmt.Fprintf(os.Stderr, "Serving pprof on http://%s/debug/pprof/\n", listener.Addr())
+	}
+
+	if traceFile != "" {
+		f, err := os.Create(traceFile)
+		if err != nil {
+			return fmt.Errorf("failed to create trace file: %w", err)
+		}
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to start trace: %w", err)
+		}
+		traceOutput = f
+	}
+	return nil
+}
+
+// stopProfiling flushes the execution trace and stops the pprof server
+func stopProfiling() error {
+	if pprofListener != nil {
+		pprofListener.Close()
+		pprofListener = nil
+	}
+	if traceOutput == nil {
+		return nil
+	}
+
+	trace.Stop()
+	err := traceOutput.Close()
+	traceOutput = nil
+	if err != nil {
+		return fmt.Errorf("failed to write trace file: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Wrote execution trace to %s (view with go tool trace)\n", traceFile)
+	return nil
+}
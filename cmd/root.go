@@ -22,12 +22,24 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/mayens/ingress-to-gateway/pkg/k8s"
 )
 
 var (
 	cfgFile    string
 	kubeconfig string
 	namespace  string
+	maxAPIQPS  float32
+
+	// kubectl-style identity flags
+	asUser      string
+	asGroups    []string
+	bearerToken string
+	clientCert  string
+	clientKey   string
+
+	// skipPreflight disables the permission checks before a run
+	skipPreflight bool
 )
 
 // rootCmd represents the base command
@@ -57,12 +69,20 @@ Example usage:
   ingress-to-gateway batch --split-mode=per-host
 
   # Validate HTTPRoute
-  ingress-to-gateway validate httproute.yaml`,
+  ingress-to-gateway validate httproute.yaml
+
+  # Audit under a scoped identity
+  ingress-to-gateway audit -A --as=system:serviceaccount:migration:auditor`,
+	PersistentPreRunE: startProfiling,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() error {
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+	if stopErr := stopProfiling(); err == nil {
+		err = stopErr
+	}
+	return err
 }
 
 func init() {
@@ -72,6 +92,13 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.ingress-to-gateway.yaml)")
 	rootCmd.PersistentFlags().StringVar(&kubeconfig, "kubeconfig", "", "path to kubeconfig file")
 	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "", "kubernetes namespace")
+	rootCmd.PersistentFlags().StringVar(&asUser, "as", "", "username to impersonate for the operation")
+	rootCmd.PersistentFlags().StringSliceVar(&asGroups, "as-group", nil, "group to impersonate for the operation, can be repeated")
+	rootCmd.PersistentFlags().StringVar(&bearerToken, "token", "", "bearer token for authentication to the API server")
+	rootCmd.PersistentFlags().StringVar(&clientCert, "client-certificate", "", "path to a client certificate file for TLS")
+	rootCmd.PersistentFlags().StringVar(&clientKey, "client-key", "", "path to a client key file for TLS")
+	rootCmd.PersistentFlags().BoolVar(&skipPreflight, "skip-preflight", false, "skip the permission checks performed before audit, batch and bootstrap --apply runs")
+	rootCmd.PersistentFlags().Float32Var(&maxAPIQPS, "max-api-qps", 0, "maximum Kubernetes API requests per second (default: client-go default of 5)")
 
 	// Bind flags to viper
 	viper.BindPFlag("kubeconfig", rootCmd.PersistentFlags().Lookup("kubeconfig"))
@@ -100,3 +127,24 @@ func initConfig() {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
 }
+
+// newClient creates a Kubernetes client from the global flags
+func newClient() (*k8s.Client, error) {
+	return k8s.NewClientWithOptions(kubeconfig, k8s.ClientOptions{
+		MaxQPS:            maxAPIQPS,
+		Impersonate:       asUser,
+		ImpersonateGroups: asGroups,
+		Token:             bearerToken,
+		ClientCertificate: clientCert,
+		ClientKey:         clientKey,
+	})
+}
+
+// printAPIStats reports the API server load of a run to stderr
+func printAPIStats(client *k8s.Client) {
+	stats := client.Stats()
+	fmt.Fprintln(os.Stderr, stats)
+	if stats.Throttled > 0 || stats.ServerThrottled > 0 {
+		fmt.Fprintln(os.Stderr, "Requests were throttled; adjust --max-api-qps to trade run time against API server load")
+	}
+}
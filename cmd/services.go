@@ -0,0 +1,270 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mayens/ingress-to-gateway/pkg/converter"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// tcpServicesCmd represents the tcp-services command
+var tcpServicesCmd = &cobra.Command{
+	Use:   "tcp-services [namespace/name]",
+	Short: "Convert the ingress-nginx tcp-services ConfigMap to TCPRoutes",
+	Long: `Convert the tcp-services ConfigMap of ingress-nginx, which exposes Services
+on raw TCP ports of the controller, to TCPRoutes. Ingresses do not reference
+these Services, so the convert command leaves them out of the migration.
+
+Each entry "<port>": "<namespace>/<service>:<port>" becomes a TCPRoute in the
+Service namespace, attached to a listener tcp-<port> of the Gateway. The
+Gateway is gateway-nginx in the ConfigMap namespace unless --gateway and
+--gateway-namespace name another; with --emit-gateway it is generated with
+the TCP listeners, otherwise the listeners to add to it are printed.
+
+The ConfigMap is read from the cluster, by default ` + converter.DefaultTCPServices + `,
+or from --file.
+
+Example usage:
+  # Convert the ConfigMap of the default ingress-nginx installation
+  ingress-to-gateway tcp-services --target=envoy-gateway
+
+  # Convert a ConfigMap manifest and generate the Gateway listeners
+  ingress-to-gateway tcp-services -f tcp-services.yaml --emit-gateway --gateway=tcp-gateway`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigMap(args, converter.DefaultTCPServices, (*converter.Converter).ConvertTCPServices)
+	},
+}
+
+// udpServicesCmd represents the udp-services command
+var udpServicesCmd = &cobra.Command{
+	Use:   "udp-services [namespace/name]",
+	Short: "Convert the ingress-nginx udp-services ConfigMap to UDPRoutes",
+	Long: `Convert the udp-services ConfigMap of ingress-nginx, which exposes Services
+on UDP ports of the controller, to UDPRoutes, as tcp-services does for TCP.
+
+Each entry "<port>": "<namespace>/<service>:<port>" becomes a UDPRoute in the
+Service namespace, attached to a listener udp-<port> of the Gateway. With
+--emit-gateway the Gateway is generated with the UDP listeners, otherwise the
+listeners to add to it are printed.
+
+The ConfigMap is read from the cluster, by default ` + converter.DefaultUDPServices + `,
+or from --file.
+
+Example usage:
+  # Convert the ConfigMap of the default ingress-nginx installation
+  ingress-to-gateway udp-services --target=envoy-gateway
+
+  # Convert a ConfigMap manifest and generate the Gateway listeners
+  ingress-to-gateway udp-services -f udp-services.yaml --emit-gateway --gateway=udp-gateway`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigMap(args, converter.DefaultUDPServices, (*converter.Converter).ConvertUDPServices)
+	},
+}
+
+// controllerConfigCmd represents the controller-config command
+var controllerConfigCmd = &cobra.Command{
+	Use:   "controller-config [namespace/name]",
+	Short: "Convert the global settings of the ingress-nginx controller ConfigMap",
+	Long: `Convert the global settings of the ingress-nginx controller ConfigMap, which
+apply to every Ingress without showing in their annotations, to policies of
+the --target implementation attached to the Gateway.
+
+nginx-gateway-fabric gets a ClientSettingsPolicy (proxy-body-size,
+client-body-timeout, keep-alive, keep-alive-requests); envoy-gateway a
+ClientTrafficPolicy (ssl-protocols, ssl-ciphers, ssl-ecdh-curve,
+use-forwarded-headers, use-proxy-protocol, keep-alive, client-header-timeout)
+and a BackendTrafficPolicy (proxy-connect-timeout, proxy-read-timeout).
+Every other setting is reported with how to carry it over. The audit command
+reports the same settings.
+
+The ConfigMap is read from the cluster, by default ` + converter.DefaultControllerConfig + `,
+or from --file.
+
+Example usage:
+  # Convert the settings of the default ingress-nginx installation
+  ingress-to-gateway controller-config --target=envoy-gateway --gateway-namespace=infra
+
+  # Convert a ConfigMap manifest
+  ingress-to-gateway controller-config -f nginx-config.yaml --target=nginx-gateway-fabric`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigMap(args, converter.DefaultControllerConfig, (*converter.Converter).ConvertControllerConfig)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tcpServicesCmd)
+	rootCmd.AddCommand(udpServicesCmd)
+	rootCmd.AddCommand(controllerConfigCmd)
+	addConfigMapFlags(tcpServicesCmd)
+	addConfigMapFlags(udpServicesCmd)
+	addConfigMapFlags(controllerConfigCmd)
+}
+
+// addConfigMapFlags registers the flags of the commands converting
+// ingress-nginx ConfigMaps, shared with the convert command
+func addConfigMapFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&inputFile, "file", "f", "", "ConfigMap manifest, - for stdin (default: read from the cluster)")
+	cmd.Flags().StringVarP(&outputFile, "output-file", "o", "", "output file (default: stdout)")
+	cmd.Flags().StringVar(&convertOutput, "format", "yaml", "output format: yaml, json, ndjson or list")
+	cmd.Flags().StringVar(&gatewayName, "gateway", "", "gateway name to reference (default: gateway-nginx)")
+	cmd.Flags().StringVar(&gatewayNS, "gateway-namespace", "", "namespace of the gateway to reference (default: the ConfigMap namespace)")
+	cmd.Flags().StringVar(&gatewayClass, "gateway-class", "", "gateway class name (default: the --target implementation's, else nginx)")
+	cmd.Flags().BoolVar(&emitGateway, "emit-gateway", false, "also generate the Gateway with the listeners the routes attach to")
+	cmd.Flags().StringVar(&target, "target", "", "gateway implementation profile: nginx-gateway-fabric, envoy-gateway, istio, cilium, kong, traefik, gke")
+	cmd.Flags().StringVar(&apiVersion, "api-version", converter.APIVersionV1, "Gateway API version of generated Gateways: v1 or v1beta1")
+	cmd.Flags().StringVar(&channel, "channel", converter.ChannelExperimental, "Gateway API release channel of the installed CRDs: experimental or standard")
+	cmd.Flags().BoolVar(&experimentalOn, "experimental", true, "emit experimental Gateway API resources; override per feature with INGRESS_TO_GATEWAY_EXPERIMENTAL_<FEATURE>=true|false")
+	cmd.Flags().StringVar(&diagFile, "diagnostics-file", "", "write conversion diagnostics as JSON to this file")
+	cmd.Flags().BoolVar(&serverDryRun, "server-dry-run", false, "submit the generated resources to the API server with dry-run=All and fail, writing nothing, if it rejects any")
+}
+
+// configMapConverter converts an ingress-nginx ConfigMap
+type configMapConverter func(c *converter.Converter, ctx context.Context, cm *corev1.ConfigMap) ([]interface{}, error)
+
+// runConfigMap reads the ConfigMap named by args, defaultName or --file and
+// writes what convert generates from it
+func runConfigMap(args []string, defaultName string, convert configMapConverter) error {
+	ctx := context.Background()
+
+	if convertOutput == converter.FormatHelm || convertOutput == converter.FormatHelmChart {
+		return fmt.Errorf("invalid output format: %s (valid: yaml, json, ndjson, list)", convertOutput)
+	}
+	if err := validateOutputFormat(convertOutput); err != nil {
+		return err
+	}
+	if err := validateTarget(target); err != nil {
+		return err
+	}
+	if err := validateAPIVersion(apiVersion); err != nil {
+		return err
+	}
+	if err := validateChannel(channel); err != nil {
+		return err
+	}
+	gate, err := experimentalGate()
+	if err != nil {
+		return err
+	}
+	if inputFile != "" && len(args) > 0 {
+		return fmt.Errorf("give either a ConfigMap name or --file")
+	}
+
+	opts := converter.Options{
+		GatewayOptions: converter.GatewayOptions{
+			GatewayName:      gatewayName,
+			GatewayNamespace: gatewayNS,
+			GatewayClass:     gatewayClass,
+			EmitGateway:      emitGateway,
+		},
+		TargetOptions: converter.TargetOptions{
+			Target:       target,
+			APIVersion:   apiVersion,
+			Channel:      channel,
+			Experimental: gate,
+		},
+		OutputOptions: converter.OutputOptions{
+			OutputFormat: convertOutput,
+		},
+	}
+	c := converter.NewConverter(opts)
+
+	var cm *corev1.ConfigMap
+	switch inputFile {
+	case "-":
+		cm, err = converter.LoadConfigMap(os.Stdin)
+	case "":
+		cm, err = clusterConfigMap(ctx, c, args, defaultName)
+	default:
+		var f *os.File
+		f, err = os.Open(inputFile)
+		if err != nil {
+			return fmt.Errorf("failed to open file: %w", err)
+		}
+		defer f.Close()
+		cm, err = converter.LoadConfigMap(f)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load ConfigMap: %w", err)
+	}
+
+	resources, err := convert(c, ctx, cm)
+	if err != nil {
+		return fmt.Errorf("conversion failed: %w", err)
+	}
+	printDiagnostics(c.Diagnostics())
+	if diagFile != "" {
+		if err := writeDiagnosticsFile(diagFile, c.Diagnostics()); err != nil {
+			return err
+		}
+	}
+	if len(resources) == 0 {
+		fmt.Fprintf(os.Stderr, "Nothing generated from ConfigMap %s/%s\n", cm.Namespace, cm.Name)
+		return nil
+	}
+	if serverDryRun {
+		if err := runServerDryRun(ctx, resources); err != nil {
+			return err
+		}
+	}
+
+	output := os.Stdout
+	if outputFile != "" {
+		f, err := os.Create(outputFile)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		output = f
+	}
+	if err := c.WriteOutput(resources, output); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	if outputFile != "" {
+		fmt.Fprintf(os.Stderr, "Resources written to %s\n", outputFile)
+	}
+	return nil
+}
+
+// clusterConfigMap reads the ConfigMap named namespace/name in args, else
+// defaultName, from the cluster. Named Service ports are resolved from the
+// cluster too.
+func clusterConfigMap(ctx context.Context, c *converter.Converter, args []string, defaultName string) (*corev1.ConfigMap, error) {
+	ref := defaultName
+	if len(args) > 0 {
+		ref = args[0]
+	}
+	ns, name, ok := strings.Cut(ref, "/")
+	if !ok || ns == "" || name == "" {
+		return nil, fmt.Errorf("invalid ConfigMap %q, want namespace/name", ref)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+	c.SetServiceLookup(client)
+	return client.GetConfigMap(ctx, ns, name)
+}
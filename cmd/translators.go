@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// translatorsCmd represents the translators command
+var translatorsCmd = &cobra.Command{
+	Use:   "translators",
+	Short: "List the annotation translators",
+	Long: `List the translators converting Ingress annotations to HTTPRoute
+filters, timeouts and policies, in the order they run, with the
+annotations each one converts.
+
+Library users extend the converter by registering translators for the
+annotations of other controllers; see converter.Registry. The CLI adds a
+translator for each mapping of --annotation-mappings, and runs the
+--annotation-hook executable last for the annotations none handles.
+
+Example usage:
+  # List the translators of convert and batch
+  ingress-to-gateway translators
+
+  # Check an annotation mapping file
+  ingress-to-gateway translators --annotation-mappings=mappings.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runTranslators,
+}
+
+func init() {
+	rootCmd.AddCommand(translatorsCmd)
+	translatorsCmd.Flags().StringVar(&mappingsFile, "annotation-mappings", "", "YAML file mapping custom annotations to HTTPRoute filter, timeouts and policy templates")
+	translatorsCmd.Flags().StringVar(&hookPath, "annotation-hook", "", "executable converting the annotations no translator handles, given as JSON on stdin")
+}
+
+func runTranslators(cmd *cobra.Command, args []string) error {
+	registry, err := translatorRegistry()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TRANSLATOR\tANNOTATIONS")
+	for _, t := range registry.Translators() {
+		fmt.Fprintf(w, "%s\t%s\n", t.Name(), strings.Join(t.Annotations(), ", "))
+	}
+	if hook := registry.Hook(); hook != nil {
+		fmt.Fprintf(w, "%s\t(unhandled annotations)\n", hook.Name())
+	}
+	return w.Flush()
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/mayens/ingress-to-gateway/internal/version"
+	"github.com/spf13/cobra"
+)
+
+var (
+	updateCheckOnly  bool
+	updateForce      bool
+	requireSignature bool
+)
+
+// updateTimeout bounds the release query and downloads
+const updateTimeout = 5 * time.Minute
+
+// updateCmd represents the update command
+var updateCmd = &cobra.Command{
+	Use:   "update [flags]",
+	Short: "Update ingress-to-gateway to the latest release",
+	Long: `Update downloads the latest GitHub release binary for the current OS and
+architecture and replaces the running binary.
+
+The download is verified against the release checksums.txt (SHA-256)
+before anything is replaced. When the release is signed and the cosign CLI
+is installed, the signature of checksums.txt is verified as well; use
+--require-signature to refuse unsigned updates.
+
+Builds managed by a package manager (e.g. Homebrew) should be updated with
+that package manager instead.
+
+Example usage:
+  # Install the latest release
+  ingress-to-gateway update
+
+  # Only report whether an update is available
+  ingress-to-gateway update --check
+
+  # Refuse to install a release without a verified signature
+  ingress-to-gateway update --require-signature`,
+	RunE: runUpdate,
+}
+
+func init() {
+	rootCmd.AddCommand(updateCmd)
+
+	updateCmd.Flags().BoolVar(&updateCheckOnly, "check", false, "only report whether a newer release is available")
+	updateCmd.Flags().BoolVar(&updateForce, "force", false, "reinstall the latest release even if it is not newer")
+	updateCmd.Flags().BoolVar(&requireSignature, "require-signature", false, "fail unless the release signature is verified with cosign")
+}
+
+func runUpdate(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), updateTimeout)
+	defer cancel()
+	client := http.DefaultClient
+
+	release, err := version.LatestRelease(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	latest := release.Version()
+	if !version.IsNewer(latest, version.Version) && !updateForce {
+		fmt.Fprintf(os.Stderr, "ingress-to-gateway %s is up to date\n", version.Version)
+		return nil
+	}
+	if updateCheckOnly {
+		fmt.Fprintf(os.Stderr, "A newer version is available: %s (current %s)\n  %s\n", latest, version.Version, release.HTMLURL)
+		return nil
+	}
+
+	name := version.BinaryAsset(runtime.GOOS, runtime.GOARCH)
+	binaryAsset := release.Asset(name)
+	if binaryAsset == nil {
+		return fmt.Errorf("release %s has no binary for %s/%s", release.TagName, runtime.GOOS, runtime.GOARCH)
+	}
+	checksumsAsset := release.Asset(version.ChecksumsAsset)
+	if checksumsAsset == nil {
+		return fmt.Errorf("release %s publishes no %s; refusing to install an unverified binary", release.TagName, version.ChecksumsAsset)
+	}
+
+	fmt.Fprintf(os.Stderr, "Downloading %s %s...\n", name, release.TagName)
+	checksums, err := version.Download(ctx, client, checksumsAsset)
+	if err != nil {
+		return err
+	}
+	if err := verifyReleaseSignature(ctx, client, release, checksums); err != nil {
+		return err
+	}
+
+	binary, err := version.Download(ctx, client, binaryAsset)
+	if err != nil {
+		return err
+	}
+	if err := version.VerifyChecksum(binary, checksums, name); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Checksum verified\n")
+
+	path, err := version.ReplaceExecutable(binary)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Updated %s from %s to %s\n", path, version.Version, latest)
+	return nil
+}
+
+// verifyReleaseSignature verifies the cosign signature of the checksums
+// file. Unsigned releases and a missing cosign CLI only warn unless
+// --require-signature is set; an invalid signature always fails.
+func verifyReleaseSignature(ctx context.Context, client *http.Client, release *version.Release, checksums []byte) error {
+	sigAsset := release.Asset(version.SignatureAsset)
+	certAsset := release.Asset(version.CertificateAsset)
+	if sigAsset == nil || certAsset == nil {
+		if requireSignature {
+			return fmt.Errorf("release %s is not signed", release.TagName)
+		}
+		fmt.Fprintf(os.Stderr, "Warning: release %s is not signed; relying on the checksum only\n", release.TagName)
+		return nil
+	}
+
+	if !version.CosignAvailable() {
+		if requireSignature {
+			return fmt.Errorf("cosign is required to verify the release signature")
+		}
+		fmt.Fprintf(os.Stderr, "Warning: cosign not found in PATH; relying on the checksum only\n")
+		return nil
+	}
+
+	signature, err := version.Download(ctx, client, sigAsset)
+	if err != nil {
+		return err
+	}
+	certificate, err := version.Download(ctx, client, certAsset)
+	if err != nil {
+		return err
+	}
+
+	if err := version.VerifySignature(ctx, checksums, signature, certificate); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Signature verified\n")
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/mayens/ingress-to-gateway/pkg/validator"
@@ -28,28 +29,53 @@ import (
 var (
 	validateFile string
 	strict       bool
+	checkCerts   bool
+	checkCluster bool
 )
 
 // validateCmd represents the validate command
 var validateCmd = &cobra.Command{
 	Use:   "validate [file] [flags]",
-	Short: "Validate HTTPRoute resources",
-	Long: `Validate checks HTTPRoute resources for correctness and best practices.
+	Short: "Validate HTTPRoute, Gateway and GatewayClass resources",
+	Long: `Validate checks HTTPRoute, Gateway and GatewayClass resources for correctness
+and best practices, so a converted bundle can be checked as a whole. Other
+kinds in the file, such as policies or ReferenceGrants, are skipped.
 
 The validator checks:
   • YAML/JSON syntax
   • Gateway API schema compliance
   • Reference validity (Gateway, Service)
   • Timeout constraints (backendRequest <= request)
+  • With --experimental=false, experimental fields such as timeouts and
+    parentRef ports (overridable per feature, see the version command)
   • Path match conflicts
+  • Gateway listeners: unique names, ports, protocols, hostnames, TLS modes
+    and certificates, allowedRoutes, and listeners conflicting on a port
+  • GatewayClass controllerName and parametersRef
   • Best practice recommendations
+  • With --cluster, that the referenced Gateways exist, are Accepted and
+    have a listener whose protocol and hostname accept the route, and that
+    the certificates of their HTTPS listeners cover the route hostnames
+    (requires cluster access)
+  • With --check-certificates, only that the certificates of the referenced
+    Gateways' HTTPS listeners cover the route hostnames
 
 Example usage:
   # Validate a single file
   ingress-to-gateway validate httproute.yaml
 
+  # Validate the Gateways and routes convert generated
+  ingress-to-gateway convert -f ingresses.yaml --emit-gateway -o bundle.yaml
+  ingress-to-gateway validate bundle.yaml
+
   # Validate with strict mode (fail on warnings)
-  ingress-to-gateway validate httproute.yaml --strict`,
+  ingress-to-gateway validate httproute.yaml --strict
+
+  # Check the referenced Gateways and their certificates before cutover
+  ingress-to-gateway validate httproute.yaml --cluster
+
+  # Fail on experimental fields, for clusters with the standard-channel CRDs
+  ingress-to-gateway validate httproute.yaml --experimental=false`,
 	RunE: runValidate,
 	Args: cobra.ExactArgs(1),
 }
@@ -58,14 +84,31 @@ func init() {
 	rootCmd.AddCommand(validateCmd)
 
 	validateCmd.Flags().BoolVar(&strict, "strict", false, "treat warnings as errors")
+	validateCmd.Flags().BoolVar(&checkCluster, "cluster", false, "verify the referenced Gateways exist, are Accepted and have listeners for the route hostnames, and that their TLS certificates cover the hostnames and are not expired")
+	validateCmd.Flags().BoolVar(&checkCerts, "check-certificates", false, "verify the TLS certificates of the referenced Gateways cover the route hostnames and are not expired")
+	validateCmd.Flags().BoolVar(&experimentalOn, "experimental", true, "accept experimental Gateway API fields; override per feature with INGRESS_TO_GATEWAY_EXPERIMENTAL_<FEATURE>=true|false")
 }
 
 func runValidate(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 	validateFile = args[0]
 
+	gate, err := experimentalGate()
+	if err != nil {
+		return err
+	}
+
 	// Create validator
 	v := validator.NewValidator(strict)
+	v.SetExperimental(gate)
+	if checkCluster || checkCerts {
+		client, err := newClient()
+		if err != nil {
+			return fmt.Errorf("failed to create kubernetes client: %w", err)
+		}
+		v.SetClusterLookup(client)
+		v.SetParentGatewayChecks(checkCluster)
+	}
 
 	// Validate file
 	results, err := v.ValidateFile(ctx, validateFile)
@@ -76,24 +119,37 @@ func runValidate(cmd *cobra.Command, args []string) error {
 	// Print results
 	hasErrors := false
 	hasWarnings := false
+	var skipped []string
 
 	for _, result := range results {
+		if result.Skipped {
+			skipped = append(skipped, fmt.Sprintf("%s %s", result.Kind, result.ResourceName))
+			continue
+		}
+		name := fmt.Sprintf("%s %s", result.Kind, result.ResourceName)
+		if result.Document > 0 {
+			name = fmt.Sprintf("%s (document %d, line %d)", name, result.Document, result.Line)
+		}
 		if len(result.Errors) > 0 {
 			hasErrors = true
-			fmt.Fprintf(os.Stderr, "❌ Errors in %s:\n", result.ResourceName)
+			fmt.Fprintf(os.Stderr, "❌ Errors in %s:\n", name)
 			for _, e := range result.Errors {
 				fmt.Fprintf(os.Stderr, "  - %s\n", e)
 			}
 		}
 		if len(result.Warnings) > 0 {
 			hasWarnings = true
-			fmt.Fprintf(os.Stderr, "⚠️  Warnings in %s:\n", result.ResourceName)
+			fmt.Fprintf(os.Stderr, "⚠️  Warnings in %s:\n", name)
 			for _, w := range result.Warnings {
 				fmt.Fprintf(os.Stderr, "  - %s\n", w)
 			}
 		}
 	}
 
+	if len(skipped) > 0 {
+		fmt.Fprintf(os.Stderr, "Skipped %d resource(s) of kinds not validated: %s\n", len(skipped), strings.Join(skipped, ", "))
+	}
+
 	if !hasErrors && !hasWarnings {
 		fmt.Println("✅ Validation passed: No issues found")
 		return nil
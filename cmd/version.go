@@ -17,17 +17,28 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"os"
 	"runtime"
+	"text/tabwriter"
+	"time"
 
 	"github.com/mayens/ingress-to-gateway/internal/version"
+	"github.com/mayens/ingress-to-gateway/pkg/experimental"
 	"github.com/spf13/cobra"
 )
 
 var (
 	shortVersion bool
+	checkUpdate  bool
+	offline      bool
 )
 
+// updateCheckTimeout bounds the release query so version never hangs
+const updateCheckTimeout = 5 * time.Second
+
 // versionCmd represents the version command
 var versionCmd = &cobra.Command{
 	Use:   "version",
@@ -35,24 +46,33 @@ var versionCmd = &cobra.Command{
 	Long: `Print detailed version information for ingress-to-gateway.
 
 Shows the tool version, git commit, build date, and Go runtime information.
+Builds made with go install report the module version and VCS revision.
+Also lists the experimental Gateway API features the tool can emit, with
+their maturity and the environment variable overriding --experimental.
 
 Example usage:
   # Show full version details
   ingress-to-gateway version
 
   # Show short version only
-  ingress-to-gateway version --short`,
+  ingress-to-gateway version --short
+
+  # Check GitHub for a newer release
+  ingress-to-gateway version --check-update`,
 	Run: runVersion,
 }
 
 func init() {
 	rootCmd.AddCommand(versionCmd)
 	versionCmd.Flags().BoolVarP(&shortVersion, "short", "s", false, "print only the version number")
+	versionCmd.Flags().BoolVar(&checkUpdate, "check-update", false, "check GitHub releases for a newer version")
+	versionCmd.Flags().BoolVar(&offline, "offline", false, "never contact the network, e.g. in air-gapped environments")
 }
 
 func runVersion(cmd *cobra.Command, args []string) {
 	if shortVersion {
 		fmt.Println(version.Version)
+		printUpdateCheck()
 		return
 	}
 
@@ -61,4 +81,52 @@ func runVersion(cmd *cobra.Command, args []string) {
 	fmt.Printf("Build date: %s\n", version.BuildDate)
 	fmt.Printf("Go version: %s\n", runtime.Version())
 	fmt.Printf("OS/Arch: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	printExperimentalFeatures()
+	printUpdateCheck()
+}
+
+// printExperimentalFeatures lists the experimental features compiled in
+// and the environment overrides set for them
+func printExperimentalFeatures() {
+	gate, err := experimental.NewGate(true, os.Environ())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	fmt.Println("\nExperimental features (--experimental):")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  FEATURE\tMATURITY\tSINCE\tOVERRIDE")
+	for _, f := range experimental.Features {
+		override := f.EnvVar()
+		if on, ok := gate.Override(f.Name); ok {
+			override = fmt.Sprintf("%s=%t", f.EnvVar(), on)
+		}
+		fmt.Fprintf(w, "  %s\t%s\tGateway API %s\t%s\n", f.Name, f.Maturity, f.Since, override)
+	}
+	w.Flush()
+}
+
+// printUpdateCheck reports a newer release to stderr if --check-update is set
+func printUpdateCheck() {
+	if !checkUpdate {
+		return
+	}
+	if offline {
+		fmt.Fprintln(os.Stderr, "Update check skipped (--offline)")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), updateCheckTimeout)
+	defer cancel()
+
+	release, err := version.LatestRelease(ctx, http.DefaultClient)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Update check failed: %v\n", err)
+		return
+	}
+	if version.IsNewer(release.Version(), version.Version) {
+		fmt.Fprintf(os.Stderr, "A newer version is available: %s (current %s)\n  %s\n", release.Version(), version.Version, release.HTMLURL)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "ingress-to-gateway %s is up to date\n", version.Version)
 }
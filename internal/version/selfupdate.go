@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// ChecksumsAsset lists the SHA-256 of every release binary, as written by sha256sum
+	ChecksumsAsset = "checksums.txt"
+	// SignatureAsset and CertificateAsset are the keyless cosign signature of ChecksumsAsset
+	SignatureAsset   = "checksums.txt.sig"
+	CertificateAsset = "checksums.txt.pem"
+
+	// maxDownloadSize guards against truncated or hostile responses
+	maxDownloadSize = 200 << 20
+
+	// signerIdentity is the release workflow identity expected in the signing certificate
+	signerIdentity = `^https://github\.com/mayens/ingress-to-gateway/`
+	signerIssuer   = "https://token.actions.githubusercontent.com"
+)
+
+// cosignBinary is the cosign CLI used to verify release signatures
+var cosignBinary = "cosign"
+
+// BinaryAsset returns the release asset name of the binary for goos/goarch
+func BinaryAsset(goos, goarch string) string {
+	name := fmt.Sprintf("ingress-to-gateway-%s-%s", goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// Download fetches a release asset
+func Download(ctx context.Context, client *http.Client, asset *Asset) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, asset.DownloadURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "ingress-to-gateway/"+Version)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", asset.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", asset.Name, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", asset.Name, err)
+	}
+	if len(data) > maxDownloadSize {
+		return nil, fmt.Errorf("failed to download %s: larger than %d bytes", asset.Name, maxDownloadSize)
+	}
+	return data, nil
+}
+
+// VerifyChecksum checks data against the entry for name in a sha256sum
+// formatted checksums file
+func VerifyChecksum(data, checksums []byte, name string) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+
+		sum := sha256.Sum256(data)
+		if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, fields[0]) {
+			return fmt.Errorf("checksum mismatch for %s: got %s, want %s", name, got, fields[0])
+		}
+		return nil
+	}
+	return fmt.Errorf("no checksum for %s in %s", name, ChecksumsAsset)
+}
+
+// CosignAvailable reports whether the cosign CLI is installed
+func CosignAvailable() bool {
+	_, err := exec.LookPath(cosignBinary)
+	return err == nil
+}
+
+// VerifySignature verifies the keyless cosign signature of the checksums
+// file with the cosign CLI, requiring a certificate issued to this
+// repository's release workflow
+func VerifySignature(ctx context.Context, checksums, signature, certificate []byte) error {
+	if _, err := exec.LookPath(cosignBinary); err != nil {
+		return fmt.Errorf("cosign binary not found in PATH: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "ingress-to-gateway-verify-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string][]byte{
+		ChecksumsAsset:   checksums,
+		SignatureAsset:   signature,
+		CertificateAsset: certificate,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, cosignBinary, "verify-blob",
+		"--signature", filepath.Join(dir, SignatureAsset),
+		"--certificate", filepath.Join(dir, CertificateAsset),
+		"--certificate-identity-regexp", signerIdentity,
+		"--certificate-oidc-issuer", signerIssuer,
+		filepath.Join(dir, ChecksumsAsset))
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("signature verification failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// ReplaceExecutable atomically replaces the running binary with data. The
+// new file is written next to the old one so the final rename stays on
+// one filesystem.
+func ReplaceExecutable(data []byte) (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate executable: %w", err)
+	}
+	exe, err = filepath.EvalSymlinks(exe)
+	if err != nil {
+		return "", fmt.Errorf("failed to locate executable: %w", err)
+	}
+	if err := replaceFile(exe, data); err != nil {
+		return "", err
+	}
+	return exe, nil
+}
+
+// replaceFile atomically replaces the executable exe with data, keeping
+// its permissions
+func replaceFile(exe string, data []byte) error {
+	info, err := os.Stat(exe)
+	if err != nil {
+		return fmt.Errorf("failed to stat executable: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(exe), "."+filepath.Base(exe)+".new-")
+	if err != nil {
+		return fmt.Errorf("failed to write new binary (is %s writable?): %w", filepath.Dir(exe), err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()|0111); err != nil {
+		return fmt.Errorf("failed to set permissions: %w", err)
+	}
+
+	// Windows cannot overwrite a running executable but can rename it
+	old := exe + ".old"
+	_ = os.Remove(old)
+	if err := os.Rename(exe, old); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", exe, err)
+	}
+	if err := os.Rename(tmp.Name(), exe); err != nil {
+		_ = os.Rename(old, exe)
+		return fmt.Errorf("failed to replace %s: %w", exe, err)
+	}
+	_ = os.Remove(old)
+
+	return nil
+}
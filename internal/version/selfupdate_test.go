@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestVerifyChecksum(t *testing.T) {
+	data := []byte("new binary")
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	other := sha256.Sum256([]byte("other binary"))
+
+	tests := []struct {
+		name      string
+		checksums string
+		wantErr   string
+	}{
+		{
+			name:      "match",
+			checksums: hex.EncodeToString(other[:]) + "  ingress-to-gateway_darwin_arm64\n" + digest + "  ingress-to-gateway_linux_amd64\n",
+		},
+		{
+			name:      "binary mode and upper case",
+			checksums: strings.ToUpper(digest) + " *ingress-to-gateway_linux_amd64\n",
+		},
+		{
+			name:      "mismatch",
+			checksums: hex.EncodeToString(other[:]) + "  ingress-to-gateway_linux_amd64\n",
+			wantErr:   "checksum mismatch for ingress-to-gateway_linux_amd64",
+		},
+		{
+			name:      "missing entry",
+			checksums: digest + "  ingress-to-gateway_linux_amd64.tar.gz\n" + digest + "  ingress-to-gateway_darwin_amd64\n",
+			wantErr:   "no checksum for ingress-to-gateway_linux_amd64 in " + ChecksumsAsset,
+		},
+		{
+			name:      "malformed lines",
+			checksums: "\n" + digest + "\n" + digest + "  ingress-to-gateway_linux_amd64 extra\n",
+			wantErr:   "no checksum for ingress-to-gateway_linux_amd64",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyChecksum(data, []byte(tt.checksums), "ingress-to-gateway_linux_amd64")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("VerifyChecksum() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("VerifyChecksum() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSignerIdentity(t *testing.T) {
+	identity := regexp.MustCompile(signerIdentity)
+	tests := []struct {
+		identity string
+		want     bool
+	}{
+		{identity: "https://github.com/mayens/ingress-to-gateway/.github/workflows/release.yml@refs/tags/v1.2.0", want: true},
+		{identity: "https://githubXcom/mayens/ingress-to-gateway/.github/workflows/release.yml@refs/tags/v1.2.0"},
+		{identity: "https://github.com/other/ingress-to-gateway/.github/workflows/release.yml@refs/tags/v1.2.0"},
+		{identity: "https://evil.example/https://github.com/mayens/ingress-to-gateway/"},
+	}
+
+	for _, tt := range tests {
+		if got := identity.MatchString(tt.identity); got != tt.want {
+			t.Errorf("signerIdentity matches %q = %v, want %v", tt.identity, got, tt.want)
+		}
+	}
+}
+
+func TestReplaceFile(t *testing.T) {
+	dir := t.TempDir()
+	exe := filepath.Join(dir, "ingress-to-gateway")
+	if err := os.WriteFile(exe, []byte("old binary"), 0750); err != nil {
+		t.Fatalf("failed to write executable: %v", err)
+	}
+
+	if err := replaceFile(exe, []byte("new binary")); err != nil {
+		t.Fatalf("replaceFile() error = %v", err)
+	}
+
+	data, err := os.ReadFile(exe)
+	if err != nil {
+		t.Fatalf("failed to read executable: %v", err)
+	}
+	if string(data) != "new binary" {
+		t.Errorf("executable = %q, want the new binary", data)
+	}
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(exe)
+		if err != nil {
+			t.Fatalf("failed to stat executable: %v", err)
+		}
+		if info.Mode().Perm() != 0750|0111 {
+			t.Errorf("executable mode = %v, want %v", info.Mode().Perm(), os.FileMode(0750|0111))
+		}
+	}
+
+	// Neither the new file nor the renamed old binary is left behind
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read dir: %v", err)
+	}
+	if len(entries) != 1 {
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		t.Errorf("dir = %v, want only the executable", names)
+	}
+}
+
+func TestReplaceFileMissing(t *testing.T) {
+	exe := filepath.Join(t.TempDir(), "ingress-to-gateway")
+	if err := replaceFile(exe, []byte("new binary")); err == nil {
+		t.Errorf("replaceFile() of a missing executable succeeded")
+	}
+	if _, err := os.Stat(exe); !os.IsNotExist(err) {
+		t.Errorf("replaceFile() created %s after failing", exe)
+	}
+}
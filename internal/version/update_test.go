@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		latest  string
+		current string
+		want    bool
+	}{
+		{latest: "v1.3.0", current: "v1.2.9", want: true},
+		{latest: "v1.2.10", current: "v1.2.9", want: true},
+		{latest: "v2.0.0", current: "v1.99.99", want: true},
+		{latest: "v1.2.0", current: "v1.2.0"},
+		{latest: "v1.2.0", current: "v1.3.0"},
+		{latest: "1.3.0", current: "v1.2.0", want: true},
+		{latest: "v1.2.0", current: "1.2.0"},
+		{latest: "v1.2.0", current: "v1.2.0-rc.1", want: true},
+		{latest: "v1.2.0-rc.1", current: "v1.2.0"},
+		// Pre-releases of the same version are not ordered
+		{latest: "v1.2.0-rc.2", current: "v1.2.0-rc.1"},
+		{latest: "v1.3.0-rc.1", current: "v1.2.0", want: true},
+		{latest: "v1.2.1", current: "v1.2", want: true},
+		{latest: "v1.2", current: "v1.2.0"},
+		{latest: "v1.2.0", current: "v1.2"},
+		{latest: "v1.2.0.1", current: "v1.2.0", want: true},
+		{latest: "v1.2.0+build.5", current: "v1.2.0"},
+		{latest: "v1.0.0", current: "dev", want: true},
+		{latest: "v1.0.0", current: "", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.latest+"_vs_"+tt.current, func(t *testing.T) {
+			if got := IsNewer(tt.latest, tt.current); got != tt.want {
+				t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitVersion(t *testing.T) {
+	tests := []struct {
+		version   string
+		wantParts []int
+		wantPre   string
+	}{
+		{version: "v1.2.3", wantParts: []int{1, 2, 3}},
+		{version: "1.2.3", wantParts: []int{1, 2, 3}},
+		{version: "v1.2.3-rc.1", wantParts: []int{1, 2, 3}, wantPre: "rc.1"},
+		{version: "v1.2.3-rc.1+build.5", wantParts: []int{1, 2, 3}, wantPre: "rc.1+build.5"},
+		{version: "v1.2.3+build.5", wantParts: []int{1, 2, 3}},
+		{version: "v1.2", wantParts: []int{1, 2}},
+		{version: "v1.x.3", wantParts: []int{1}},
+		{version: "dev"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			parts, pre := splitVersion(tt.version)
+			if !reflect.DeepEqual(parts, tt.wantParts) || pre != tt.wantPre {
+				t.Errorf("splitVersion(%q) = %v, %q, want %v, %q", tt.version, parts, pre, tt.wantParts, tt.wantPre)
+			}
+		})
+	}
+}
@@ -16,6 +16,11 @@ limitations under the License.
 
 package version
 
+import (
+	"runtime/debug"
+	"strings"
+)
+
 var (
 	// Version is the current version of the tool
 	Version = "0.1.0"
@@ -27,6 +32,42 @@ var (
 	BuildDate = "unknown"
 )
 
+func init() {
+	applyBuildInfo()
+}
+
+// applyBuildInfo fills in version details from the module build info when
+// they were not set with -ldflags, as with go install builds
+func applyBuildInfo() {
+	if GitCommit != "unknown" {
+		return
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return
+	}
+
+	// go install module@vX.Y.Z records the module version
+	if v := info.Main.Version; v != "" && v != "(devel)" {
+		Version = strings.TrimPrefix(v, "v")
+	}
+
+	modified := false
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			GitCommit = setting.Value
+		case "vcs.time":
+			BuildDate = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+	if modified && GitCommit != "unknown" {
+		GitCommit += "-dirty"
+	}
+}
+
 // Info returns version information
 func Info() string {
 	return Version
@@ -19,13 +19,18 @@ package analyzer
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	networkingv1 "k8s.io/api/networking/v1"
+	"github.com/mayens/ingress-to-gateway/pkg/converter"
 	"github.com/mayens/ingress-to-gateway/pkg/k8s"
 )
 
-// Analyzer analyzes Ingress resources for migration readiness
+// Analyzer analyzes Ingress resources for migration readiness. It is safe
+// for concurrent use: it keeps no state besides its client, and every call
+// returns new results. The Check methods update the results passed in, which
+// callers must not share between goroutines.
 type Analyzer struct {
 	client *k8s.Client
 }
@@ -39,12 +44,18 @@ type AnalysisResult struct {
 	Hostnames         []string
 	PathCount         int
 	TLSEnabled        bool
+	TLS               []networkingv1.IngressTLS
 	Annotations       map[string]string
 	DetectedFeatures  []string
 	ComplexityScore   int
 	MigrationReadiness string
 	Issues            []string
 	Recommendations   []string
+	LoadBalancerAddresses []string
+	ManagedBy         string             // Kind/name of the owning controller resource, if any
+	Transient         string             // why the Ingress is ephemeral, e.g. a cert-manager solver
+	Fidelity          converter.Fidelity // share of nginx annotations the converter carries over
+	CertRotation      []CertRotation     // how each TLS secret is renewed, set by CheckCertRotation
 }
 
 // NewAnalyzer creates a new Analyzer
@@ -81,6 +92,8 @@ func (a *Analyzer) analyzeIngress(ing *networkingv1.Ingress) *AnalysisResult {
 		IngressClass: getIngressClass(ing),
 		Annotations:  ing.Annotations,
 		TLSEnabled:   len(ing.Spec.TLS) > 0,
+		TLS:          ing.Spec.TLS,
+		LoadBalancerAddresses: ingressAddresses(ing),
 	}
 
 	// Count hosts and paths
@@ -107,6 +120,14 @@ func (a *Analyzer) analyzeIngress(ing *networkingv1.Ingress) *AnalysisResult {
 	result.ComplexityScore = a.calculateComplexity(ing, result.DetectedFeatures)
 	result.MigrationReadiness = a.assessReadiness(result.ComplexityScore, result.DetectedFeatures)
 
+	// Machine-managed Ingresses are recreated by their controller, not migrated
+	if ref := converter.ControllerOwner(ing); ref != nil {
+		result.ManagedBy = fmt.Sprintf("%s/%s", ref.Kind, ref.Name)
+		result.MigrationReadiness = ReadinessOperatorManaged
+	}
+	result.Transient = TransientReason(ing)
+	result.Fidelity = conversionFidelity(ing)
+
 	// Identify issues and recommendations
 	result.Issues = a.identifyIssues(ing, result.DetectedFeatures)
 	result.Recommendations = a.generateRecommendations(ing, result)
@@ -136,6 +157,7 @@ func (a *Analyzer) detectFeatures(ing *networkingv1.Ingress) []string {
 		"nginx.ingress.kubernetes.io/enable-cors":            "CORS",
 		"nginx.ingress.kubernetes.io/auth-type":              "AUTHENTICATION",
 		"nginx.ingress.kubernetes.io/auth-secret":            "AUTHENTICATION",
+		"nginx.ingress.kubernetes.io/auth-url":               "EXTERNAL_AUTH",
 		"nginx.ingress.kubernetes.io/canary":                 "CANARY",
 		"nginx.ingress.kubernetes.io/canary-weight":          "CANARY_WEIGHT",
 		"nginx.ingress.kubernetes.io/canary-by-header":       "CANARY_HEADER",
@@ -143,9 +165,65 @@ func (a *Analyzer) detectFeatures(ing *networkingv1.Ingress) []string {
 		"nginx.ingress.kubernetes.io/mirror-target":          "MIRRORING",
 		"nginx.ingress.kubernetes.io/configuration-snippet":  "CUSTOM_SNIPPET",
 		"nginx.ingress.kubernetes.io/server-snippet":         "SERVER_SNIPPET",
+		"nginx.ingress.kubernetes.io/affinity":               "SESSION_AFFINITY",
+		"nginx.ingress.kubernetes.io/upstream-hash-by":       "UPSTREAM_HASH",
+		"nginx.ingress.kubernetes.io/load-balance":           "LOAD_BALANCE",
+		"nginx.ingress.kubernetes.io/whitelist-source-range": "SOURCE_RANGE",
+		"nginx.ingress.kubernetes.io/allowlist-source-range": "SOURCE_RANGE",
+		"nginx.ingress.kubernetes.io/denylist-source-range":  "SOURCE_RANGE",
+
+		// HAProxy Technologies controller
+		"haproxy.org/timeout-server":       "PROXY_READ_TIMEOUT",
+		"haproxy.org/timeout-client":       "REQUEST_TIMEOUT",
+		"haproxy.org/timeout-http-request": "REQUEST_TIMEOUT",
+		"haproxy.org/timeout-connect":      "PROXY_CONNECT_TIMEOUT",
+		"haproxy.org/ssl-redirect":         "SSL_REDIRECT",
+		"haproxy.org/request-redirect":     "TEMPORAL_REDIRECT",
+		"haproxy.org/path-rewrite":         "URL_REWRITE",
+		"haproxy.org/allow-list":           "SOURCE_RANGE",
+		"haproxy.org/whitelist":            "SOURCE_RANGE",
+		"haproxy.org/deny-list":            "SOURCE_RANGE",
+		"haproxy.org/blacklist":            "SOURCE_RANGE",
+
+		// HAProxy Ingress (community) controller
+		"ingress.kubernetes.io/timeout-server":         "PROXY_READ_TIMEOUT",
+		"ingress.kubernetes.io/timeout-client":         "REQUEST_TIMEOUT",
+		"ingress.kubernetes.io/timeout-http-request":   "REQUEST_TIMEOUT",
+		"ingress.kubernetes.io/timeout-connect":        "PROXY_CONNECT_TIMEOUT",
+		"ingress.kubernetes.io/ssl-redirect":           "SSL_REDIRECT",
+		"ingress.kubernetes.io/redirect-to":            "TEMPORAL_REDIRECT",
+		"ingress.kubernetes.io/rewrite-target":         "URL_REWRITE",
+		"ingress.kubernetes.io/whitelist-source-range": "SOURCE_RANGE",
+		"ingress.kubernetes.io/allowlist-source-range": "SOURCE_RANGE",
+		"ingress.kubernetes.io/denylist-source-range":  "SOURCE_RANGE",
+
+		// Kong Ingress Controller
+		"konghq.com/strip-path":                 "URL_REWRITE",
+		"konghq.com/host-header":                "URL_REWRITE",
+		"konghq.com/https-redirect-status-code": "SSL_REDIRECT",
+		"konghq.com/plugins":                    "KONG_PLUGINS",
+		"konghq.com/override":                   "KONG_PLUGINS",
+
+		// AWS Load Balancer Controller health checks
+		"alb.ingress.kubernetes.io/healthcheck-path":             "HEALTH_CHECK",
+		"alb.ingress.kubernetes.io/healthcheck-port":             "HEALTH_CHECK",
+		"alb.ingress.kubernetes.io/healthcheck-protocol":         "HEALTH_CHECK",
+		"alb.ingress.kubernetes.io/healthcheck-interval-seconds": "HEALTH_CHECK",
+		"alb.ingress.kubernetes.io/healthcheck-timeout-seconds":  "HEALTH_CHECK",
+		"alb.ingress.kubernetes.io/healthy-threshold-count":      "HEALTH_CHECK",
+		"alb.ingress.kubernetes.io/unhealthy-threshold-count":    "HEALTH_CHECK",
+		"alb.ingress.kubernetes.io/success-codes":                "HEALTH_CHECK",
+	}
+
+	// In annotation order, so results do not vary between runs
+	keys := make([]string, 0, len(annotationChecks))
+	for ann := range annotationChecks {
+		keys = append(keys, ann)
 	}
+	sort.Strings(keys)
 
-	for ann, feature := range annotationChecks {
+	for _, ann := range keys {
+		feature := annotationChecks[ann]
 		if _, exists := annotations[ann]; exists {
 			if !contains(features, feature) {
 				features = append(features, feature)
@@ -183,10 +261,18 @@ func (a *Analyzer) calculateComplexity(ing *networkingv1.Ingress, features []str
 		"CANARY_WEIGHT":     7,
 		"MIRRORING":         8,
 		"AUTHENTICATION":    6,
+		"EXTERNAL_AUTH":     6,
 		"CORS":              4,
 		"BACKEND_PROTOCOL":  3,
 		"PROXY_READ_TIMEOUT": 2,
 		"SSL_REDIRECT":      2,
+		"SESSION_AFFINITY":  4,
+		"UPSTREAM_HASH":     5,
+		"LOAD_BALANCE":      3,
+		"HEALTH_CHECK":      2,
+		"KONG_PLUGINS":      4,
+		"SOURCE_RANGE":      3,
+		"REQUEST_TIMEOUT":   2,
 	}
 
 	for _, feature := range features {
@@ -232,6 +318,40 @@ func (a *Analyzer) identifyIssues(ing *networkingv1.Ingress, features []string)
 		issues = append(issues, fmt.Sprintf("Non-NGINX Ingress class detected: %s", class))
 	}
 
+	// Check for Ingresses owned by progressive delivery controllers
+	for _, ref := range ing.OwnerReferences {
+		if (strings.HasPrefix(ref.APIVersion, "flagger.app/") && ref.Kind == "Canary") ||
+			(strings.HasPrefix(ref.APIVersion, "argoproj.io/") && ref.Kind == "Rollout") {
+			issues = append(issues, fmt.Sprintf("Managed by %s %s; migrate its traffic routing to Gateway API instead of converting this Ingress", ref.Kind, ref.Name))
+		}
+	}
+
+	// Check for paths nginx matches as plain string prefixes
+	if paths := stringPrefixPaths(ing); len(paths) > 0 {
+		issues = append(issues, fmt.Sprintf("ImplementationSpecific paths %s also match longer names in nginx (/foo matches /foobar) but not in Gateway API PathPrefix; verify clients or convert with --prefix-compat", strings.Join(paths, ", ")))
+	}
+
+	// Check for hosts served without TLS next to hosts with TLS
+	httpOnly, unusedTLS := converter.TLSHostMismatches(ing)
+	if len(httpOnly) > 0 {
+		issues = append(issues, fmt.Sprintf("Hosts %s have no spec.tls entry and will be HTTP-only after migration; add them to a TLS block if they should be served over HTTPS", strings.Join(httpOnly, ", ")))
+	}
+	if len(unusedTLS) > 0 {
+		issues = append(issues, fmt.Sprintf("spec.tls hosts %s are not used by any rule", strings.Join(unusedTLS, ", ")))
+	}
+
+	// Check for misspelled annotations that ingress-nginx silently ignores
+	var keys []string
+	for key := range ing.Annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if suggestion := SuggestAnnotation(key); suggestion != "" {
+			issues = append(issues, fmt.Sprintf("Unrecognized annotation %s is ignored by ingress-nginx and will not be migrated, did you mean %s?", key, suggestion))
+		}
+	}
+
 	// Check for deprecated annotations
 	deprecatedAnns := []string{
 		"kubernetes.io/ingress.class",
@@ -268,6 +388,26 @@ func (a *Analyzer) generateRecommendations(ing *networkingv1.Ingress, result *An
 		recommendations = append(recommendations, "Canary deployments will be converted to HTTPRoute backendRefs with traffic splitting")
 	}
 
+	// Session affinity recommendations
+	if contains(result.DetectedFeatures, "SESSION_AFFINITY") {
+		recommendations = append(recommendations, "Cookie affinity will be converted to an experimental BackendLBPolicy; verify your Gateway implementation supports session persistence")
+	}
+
+	// Load balancing recommendations
+	if contains(result.DetectedFeatures, "LOAD_BALANCE") || contains(result.DetectedFeatures, "UPSTREAM_HASH") {
+		recommendations = append(recommendations, "The load balancing algorithm will be converted to an Envoy Gateway BackendTrafficPolicy or Istio DestinationRule; BackendLBPolicy cannot select an algorithm")
+	}
+
+	// Kong recommendations
+	if contains(result.DetectedFeatures, "KONG_PLUGINS") {
+		recommendations = append(recommendations, "Kong plugins will be converted to filters and Envoy Gateway policies where possible; with --target=kong they stay attached to the HTTPRoutes as KongPlugins")
+	}
+
+	// Health check recommendations
+	if contains(result.DetectedFeatures, "HEALTH_CHECK") {
+		recommendations = append(recommendations, "Health checks will be converted to an Envoy Gateway BackendTrafficPolicy or GKE HealthCheckPolicy; other implementations need them configured separately")
+	}
+
 	// TLS recommendations
 	if result.TLSEnabled {
 		recommendations = append(recommendations, "Ensure Gateway has matching HTTPS listeners configured")
@@ -17,10 +17,16 @@ limitations under the License.
 package analyzer
 
 import (
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/mayens/ingress-to-gateway/pkg/converter"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 func TestDetectFeatures(t *testing.T) {
@@ -98,6 +104,65 @@ func TestDetectFeatures(t *testing.T) {
 			},
 			wantFeatures: []string{"CUSTOM_SNIPPET"},
 		},
+		{
+			name: "HAProxy annotations",
+			ingress: &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-ingress",
+					Namespace: "default",
+					Annotations: map[string]string{
+						"haproxy.org/timeout-server":                 "50s",
+						"haproxy.org/path-rewrite":                   "/",
+						"haproxy.org/allow-list":                     "10.0.0.0/8",
+						"ingress.kubernetes.io/ssl-redirect":         "true",
+						"ingress.kubernetes.io/timeout-http-request": "5s",
+					},
+				},
+			},
+			wantFeatures: []string{"PROXY_READ_TIMEOUT", "URL_REWRITE", "SOURCE_RANGE", "SSL_REDIRECT", "REQUEST_TIMEOUT"},
+		},
+		{
+			name: "load balancing annotations",
+			ingress: &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-ingress",
+					Namespace: "default",
+					Annotations: map[string]string{
+						"nginx.ingress.kubernetes.io/load-balance":     "ewma",
+						"nginx.ingress.kubernetes.io/upstream-hash-by": "$remote_addr",
+					},
+				},
+			},
+			wantFeatures: []string{"LOAD_BALANCE", "UPSTREAM_HASH"},
+		},
+		{
+			name: "ALB health check annotations",
+			ingress: &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-ingress",
+					Namespace: "default",
+					Annotations: map[string]string{
+						"alb.ingress.kubernetes.io/healthcheck-path": "/healthz",
+						"alb.ingress.kubernetes.io/success-codes":    "200-299",
+					},
+				},
+			},
+			wantFeatures: []string{"HEALTH_CHECK"},
+		},
+		{
+			name: "Kong annotations",
+			ingress: &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-ingress",
+					Namespace: "default",
+					Annotations: map[string]string{
+						"konghq.com/strip-path": "true",
+						"konghq.com/plugins":    "rate-limit",
+					},
+				},
+			},
+			wantFeatures: []string{"URL_REWRITE", "KONG_PLUGINS"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -283,6 +348,26 @@ func TestIdentifyIssues(t *testing.T) {
 			features:   []string{},
 			wantIssues: 1,
 		},
+		{
+			name: "Hosts missing from and extra in spec.tls",
+			ingress: &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-ingress",
+				},
+				Spec: networkingv1.IngressSpec{
+					IngressClassName: stringPtr("nginx"),
+					TLS: []networkingv1.IngressTLS{
+						{Hosts: []string{"secure.example.com", "old.example.com"}},
+					},
+					Rules: []networkingv1.IngressRule{
+						{Host: "secure.example.com"},
+						{Host: "plain.example.com"},
+					},
+				},
+			},
+			features:   []string{"TLS_TERMINATION"},
+			wantIssues: 2,
+		},
 	}
 
 	for _, tt := range tests {
@@ -525,7 +610,486 @@ func TestAnalyzeIngress(t *testing.T) {
 	}
 }
 
+func TestLintIngress(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		wantFindings []string
+	}{
+		{
+			name: "misspelled prefix",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes/rewrite-target": "/",
+			},
+			wantFindings: []string{"nginx.ingress.kubernetes/rewrite-target"},
+		},
+		{
+			name: "rewrite-target with app-root",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/rewrite-target": "/",
+				"nginx.ingress.kubernetes.io/app-root":       "/app",
+			},
+			wantFindings: []string{"nginx.ingress.kubernetes.io/app-root"},
+		},
+		{
+			name: "invalid duration and boolean",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/proxy-read-timeout": "60s",
+				"nginx.ingress.kubernetes.io/ssl-redirect":       "yes",
+			},
+			wantFindings: []string{
+				"nginx.ingress.kubernetes.io/proxy-read-timeout",
+				"nginx.ingress.kubernetes.io/ssl-redirect",
+			},
+		},
+		{
+			name: "clean annotations",
+			annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/proxy-read-timeout": "60",
+				"nginx.ingress.kubernetes.io/ssl-redirect":       "true",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ingress := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+			}
+
+			findings := LintIngress(ingress)
+			if len(findings) != len(tt.wantFindings) {
+				t.Fatalf("LintIngress() returned %v findings, want %v: %v", len(findings), len(tt.wantFindings), findings)
+			}
+			for i, want := range tt.wantFindings {
+				if findings[i].Annotation != want {
+					t.Errorf("finding[%d].Annotation = %v, want %v", i, findings[i].Annotation, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSuggestAnnotation(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"nginx.ingress.kubernetes.io/rewrite-taget", "nginx.ingress.kubernetes.io/rewrite-target"},
+		{"nginx.ingress.kubernetes.io/proxy-read-timout", "nginx.ingress.kubernetes.io/proxy-read-timeout"},
+		{"nginx.ingress.kubernetes/ssl-redirect", "nginx.ingress.kubernetes.io/ssl-redirect"},
+		{"nginx.ingress.kubernetes.io/rewrite-target", ""},
+		{"nginx.ingress.kubernetes.io/completely-unrelated-setting", ""},
+		{"nginx.org/proxy-connect-timeout", ""},
+		{"ingress.kubernetes.io/ssl-redirect", ""},
+		{"app.kubernetes.io/name", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := SuggestAnnotation(tt.key); got != tt.want {
+				t.Errorf("SuggestAnnotation(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostCollisions(t *testing.T) {
+	lbStatus := func(ip string) networkingv1.IngressStatus {
+		return networkingv1.IngressStatus{
+			LoadBalancer: networkingv1.IngressLoadBalancerStatus{
+				Ingress: []networkingv1.IngressLoadBalancerIngress{{IP: ip}},
+			},
+		}
+	}
+	rule := func(host string) networkingv1.IngressRule {
+		return networkingv1.IngressRule{Host: host}
+	}
+
+	ingresses := []*networkingv1.Ingress{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec:       networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{rule("app.example.com"), rule("api.example.com")}},
+			Status:     lbStatus("10.0.0.1"),
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "other"},
+			Spec:       networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{rule("app.example.com")}},
+			Status:     lbStatus("10.0.0.2"),
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "same-lb", Namespace: "other"},
+			Spec:       networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{rule("api.example.com")}},
+			Status:     lbStatus("10.0.0.1"),
+		},
+	}
+
+	wildcard := gatewayv1.Hostname("*.example.com")
+	gateways := []*gatewayv1.Gateway{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "infra"},
+			Spec: gatewayv1.GatewaySpec{
+				Listeners: []gatewayv1.Listener{{Name: "https", Hostname: &wildcard}},
+			},
+			Status: gatewayv1.GatewayStatus{
+				Addresses: []gatewayv1.GatewayStatusAddress{{Value: "10.0.0.3"}},
+			},
+		},
+	}
+
+	index := buildHostIndex(ingresses, gateways, nil)
+
+	a := NewAnalyzer(nil)
+	result := a.analyzeIngress(ingresses[0])
+	issues := index.collisions(result)
+
+	// app.example.com: legacy ingress + gateway wildcard; api.example.com: gateway wildcard only
+	if len(issues) != 3 {
+		t.Fatalf("collisions() returned %v issues, want 3: %v", len(issues), issues)
+	}
+	for _, issue := range issues {
+		if strings.Contains(issue, "same-lb") {
+			t.Errorf("collisions() reported an Ingress on the same load balancer: %v", issue)
+		}
+	}
+}
+
+func TestOperatorManagedIngress(t *testing.T) {
+	controller := true
+	tests := []struct {
+		name          string
+		owners        []metav1.OwnerReference
+		wantManagedBy string
+		wantReadiness string
+	}{
+		{
+			name:          "not owned",
+			wantReadiness: "READY",
+		},
+		{
+			name: "cert-manager solver",
+			owners: []metav1.OwnerReference{
+				{APIVersion: "acme.cert-manager.io/v1", Kind: "Challenge", Name: "web-tls-1234", Controller: &controller},
+			},
+			wantManagedBy: "Challenge/web-tls-1234",
+			wantReadiness: ReadinessOperatorManaged,
+		},
+		{
+			name: "owner that is not the controller",
+			owners: []metav1.OwnerReference{
+				{APIVersion: "v1", Kind: "ConfigMap", Name: "settings"},
+			},
+			wantReadiness: "READY",
+		},
+		{
+			name: "controller owner preferred",
+			owners: []metav1.OwnerReference{
+				{APIVersion: "v1", Kind: "ConfigMap", Name: "settings"},
+				{APIVersion: "networking.internal.knative.dev/v1alpha1", Kind: "Ingress", Name: "hello", Controller: &controller},
+			},
+			wantManagedBy: "Ingress/hello",
+			wantReadiness: ReadinessOperatorManaged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ingress := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "managed",
+					Namespace:       "default",
+					OwnerReferences: tt.owners,
+				},
+			}
+
+			a := &Analyzer{}
+			result := a.analyzeIngress(ingress)
+			if result.ManagedBy != tt.wantManagedBy {
+				t.Errorf("ManagedBy = %v, want %v", result.ManagedBy, tt.wantManagedBy)
+			}
+			if result.MigrationReadiness != tt.wantReadiness {
+				t.Errorf("MigrationReadiness = %v, want %v", result.MigrationReadiness, tt.wantReadiness)
+			}
+		})
+	}
+}
+
+func TestTransientReason(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		owners []metav1.OwnerReference
+		want   string
+	}{
+		{
+			name: "regular ingress",
+			want: "",
+		},
+		{
+			name:   "cert-manager solver",
+			labels: map[string]string{"acme.cert-manager.io/http01-solver": "true"},
+			want:   "cert-manager HTTP-01 solver",
+		},
+		{
+			name:   "knative route label",
+			labels: map[string]string{"serving.knative.dev/route": "hello"},
+			want:   "Knative route",
+		},
+		{
+			name: "knative networking owner",
+			owners: []metav1.OwnerReference{
+				{APIVersion: "networking.internal.knative.dev/v1alpha1", Kind: "Ingress", Name: "hello"},
+			},
+			want: "Knative route",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ingress := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "test",
+					Labels:          tt.labels,
+					OwnerReferences: tt.owners,
+				},
+			}
+			if got := TransientReason(ingress); got != tt.want {
+				t.Errorf("TransientReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	results := []*AnalysisResult{{Name: "web"}, {Name: "solver", Transient: "cert-manager HTTP-01 solver"}}
+	kept, skipped := ExcludeTransient(results)
+	if len(kept) != 1 || kept[0].Name != "web" || skipped != 1 {
+		t.Errorf("ExcludeTransient() = %v, %v, want [web], 1", kept, skipped)
+	}
+}
+
+func TestStringPrefixPaths(t *testing.T) {
+	prefix := networkingv1.PathTypePrefix
+	specific := networkingv1.PathTypeImplementationSpecific
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		paths       []networkingv1.HTTPIngressPath
+		want        []string
+	}{
+		{
+			name:  "prefix paths are segment-aware",
+			paths: []networkingv1.HTTPIngressPath{{Path: "/api", PathType: &prefix}},
+		},
+		{
+			name: "implementation specific paths",
+			paths: []networkingv1.HTTPIngressPath{
+				{Path: "/api", PathType: &specific},
+				{Path: "/web"},
+				{Path: "/static/", PathType: &specific},
+				{Path: "/api", PathType: &specific},
+			},
+			want: []string{"/api", "/web"},
+		},
+		{
+			name:        "regex paths",
+			annotations: map[string]string{"nginx.ingress.kubernetes.io/use-regex": "true"},
+			paths:       []networkingv1.HTTPIngressPath{{Path: "/api", PathType: &specific}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ing := &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Annotations: tt.annotations},
+				Spec: networkingv1.IngressSpec{
+					Rules: []networkingv1.IngressRule{{
+						Host: "app.example.com",
+						IngressRuleValue: networkingv1.IngressRuleValue{
+							HTTP: &networkingv1.HTTPIngressRuleValue{Paths: tt.paths},
+						},
+					}},
+				},
+			}
+
+			got := stringPrefixPaths(ing)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("stringPrefixPaths() = %v, want %v", got, tt.want)
+			}
+
+			issues := NewAnalyzer(nil).identifyIssues(ing, nil)
+			if (len(issues) > 0) != (len(tt.want) > 0) {
+				t.Errorf("identifyIssues() = %v, want prefix issue %v", issues, len(tt.want) > 0)
+			}
+		})
+	}
+}
+
+func TestConcurrentAnalyze(t *testing.T) {
+	prefix := networkingv1.PathTypePrefix
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"nginx.ingress.kubernetes.io/rewrite-target":     "/",
+				"nginx.ingress.kubernetes.io/proxy-read-timeout": "60",
+			},
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: stringPtr("nginx"),
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: "app.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     "/",
+									PathType: &prefix,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: "app",
+											Port: networkingv1.ServiceBackendPort{Number: 80},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	a := NewAnalyzer(nil)
+	want := a.analyzeIngress(ingress)
+
+	// Run with -race to catch state shared between calls
+	const workers = 8
+	got := make([]*AnalysisResult, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			got[i] = a.analyzeIngress(ingress)
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < workers; i++ {
+		if !reflect.DeepEqual(got[i], want) {
+			t.Errorf("concurrent analyzeIngress() = %+v, want %+v", got[i], want)
+		}
+	}
+}
+
+func TestCertRotation(t *testing.T) {
+	secret := func(annotations map[string]string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+	}
+	secrets := map[string]*corev1.Secret{
+		"default/issued": secret(map[string]string{certManagerCertificate: "web-cert"}),
+		"default/static": secret(nil),
+		"default/gone":   nil,
+	}
+
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		secret       string
+		target       string
+		wantSource   string
+		wantGuidance []string
+	}{
+		{
+			name:         "ingress-shim",
+			annotations:  map[string]string{certManagerClusterIssuer: "letsencrypt"},
+			secret:       "static",
+			wantSource:   CertSourceIngressShim,
+			wantGuidance: []string{"issued by ClusterIssuer letsencrypt", "deleted with the Ingress", "verify the Gateway implementation"},
+		},
+		{
+			name:         "standalone certificate",
+			secret:       "issued",
+			target:       converter.TargetEnvoyGateway,
+			wantSource:   CertSourceCertificate,
+			wantGuidance: []string{"Certificate web-cert", "renewals continue", "Envoy Gateway watches the Secret"},
+		},
+		{
+			name:         "static secret",
+			secret:       "static",
+			target:       converter.TargetIstio,
+			wantSource:   CertSourceStatic,
+			wantGuidance: []string{"renew it by hand", "SDS"},
+		},
+		{
+			name:         "unreadable secret",
+			secret:       "gone",
+			wantSource:   CertSourceUnknown,
+			wantGuidance: []string{"not found or not readable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &AnalysisResult{
+				Namespace:   "default",
+				Annotations: tt.annotations,
+				TLS: []networkingv1.IngressTLS{
+					{Hosts: []string{"a.example.com"}, SecretName: tt.secret},
+					{Hosts: []string{"b.example.com"}, SecretName: tt.secret},
+				},
+			}
+			rotations := certRotation(result, secrets, tt.target)
+			if len(rotations) != 1 {
+				t.Fatalf("certRotation() returned %d entries, want 1: %v", len(rotations), rotations)
+			}
+			if rotations[0].Secret != "default/"+tt.secret || rotations[0].Source != tt.wantSource {
+				t.Errorf("certRotation() = %s (%s), want default/%s (%s)", rotations[0].Secret, rotations[0].Source, tt.secret, tt.wantSource)
+			}
+			for _, want := range tt.wantGuidance {
+				if !strings.Contains(rotations[0].Guidance, want) {
+					t.Errorf("certRotation() guidance %q does not contain %q", rotations[0].Guidance, want)
+				}
+			}
+		})
+	}
+}
+
 // Helper function
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestControllerConfig(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "ingress-nginx-controller", Namespace: "ingress-nginx"},
+		Data: map[string]string{
+			"ssl-protocols":   "TLSv1.2 TLSv1.3",
+			"proxy-body-size": "8m",
+			"ssl-redirect":    "false",
+		},
+	}
+
+	cfg := controllerConfig(cm, converter.TargetEnvoyGateway)
+	if cfg.ConfigMap != "ingress-nginx/ingress-nginx-controller" || len(cfg.Settings) != 3 {
+		t.Fatalf("got %s with %d settings, want ingress-nginx/ingress-nginx-controller with 3", cfg.ConfigMap, len(cfg.Settings))
+	}
+	var unconverted []string
+	for _, s := range cfg.Unconverted() {
+		unconverted = append(unconverted, s.Key)
+	}
+	if want := []string{"proxy-body-size", "ssl-redirect"}; !reflect.DeepEqual(unconverted, want) {
+		t.Errorf("Unconverted() = %v, want %v", unconverted, want)
+	}
+
+	cfg = controllerConfig(cm, converter.TargetNginxGatewayFabric)
+	for _, s := range cfg.Settings {
+		if s.Key == "proxy-body-size" && (s.Status != converter.AnnotationConverted || s.ConvertedTo != "ClientSettingsPolicy") {
+			t.Errorf("proxy-body-size = %s to %q, want converted to ClientSettingsPolicy", s.Status, s.ConvertedTo)
+		}
+	}
+}
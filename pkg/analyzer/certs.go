@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mayens/ingress-to-gateway/pkg/validator"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// CheckTLSSecrets adds an issue to each result whose TLS secrets are
+// missing, expired or do not cover the hosts they are used for. Such
+// secrets fail silently behind ingress-nginx's default certificate but
+// leave Gateway listeners unprogrammed.
+func (a *Analyzer) CheckTLSSecrets(ctx context.Context, results []*AnalysisResult) error {
+	secrets := make(map[string]*corev1.Secret)
+	now := time.Now()
+
+	for _, result := range results {
+		for _, tls := range result.TLS {
+			if tls.SecretName == "" {
+				continue
+			}
+
+			key := result.Namespace + "/" + tls.SecretName
+			secret, cached := secrets[key]
+			if !cached {
+				var err error
+				secret, err = a.client.GetSecret(ctx, result.Namespace, tls.SecretName)
+				switch {
+				case apierrors.IsNotFound(err):
+					secret = nil
+				case apierrors.IsForbidden(err):
+					result.Issues = append(result.Issues, fmt.Sprintf("TLS secret %s could not be read (forbidden); certificate not checked", key))
+					continue
+				case err != nil:
+					return fmt.Errorf("failed to get secret %s: %w", key, err)
+				}
+				secrets[key] = secret
+			}
+
+			if secret == nil {
+				result.Issues = append(result.Issues, fmt.Sprintf("TLS secret %s not found; the Gateway listener for it will not be programmed", key))
+				continue
+			}
+
+			// A TLS block without hosts applies to every rule host
+			hosts := tls.Hosts
+			if len(hosts) == 0 {
+				hosts = result.Hostnames
+			}
+			errs, warnings := validator.CheckCertificate(secret, hosts, now)
+			result.Issues = append(result.Issues, errs...)
+			result.Issues = append(result.Issues, warnings...)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// hostServer is a resource that serves a hostname through a load balancer
+type hostServer struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Addresses []string
+}
+
+// hostIndex maps hostnames (possibly wildcards) to the resources serving them
+type hostIndex map[string][]hostServer
+
+// CheckHostCollisions adds an issue to each result whose hostnames are already
+// served by another load balancer in the cluster (other Ingresses or existing
+// Gateways), since cutting over such hosts risks split-brain routing
+func (a *Analyzer) CheckHostCollisions(ctx context.Context, results []*AnalysisResult) error {
+	ingresses, err := a.client.ListIngresses(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to list ingresses: %w", err)
+	}
+	gateways, err := a.client.ListGateways(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to list gateways: %w", err)
+	}
+	routes, err := a.client.ListHTTPRoutes(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to list httproutes: %w", err)
+	}
+
+	index := buildHostIndex(ingresses, gateways, routes)
+	for _, result := range results {
+		result.Issues = append(result.Issues, index.collisions(result)...)
+	}
+
+	return nil
+}
+
+// buildHostIndex indexes the hostnames served by Ingresses and Gateways
+func buildHostIndex(ingresses []*networkingv1.Ingress, gateways []*gatewayv1.Gateway, routes []*gatewayv1.HTTPRoute) hostIndex {
+	index := make(hostIndex)
+
+	for _, ing := range ingresses {
+		server := hostServer{
+			Kind:      "Ingress",
+			Namespace: ing.Namespace,
+			Name:      ing.Name,
+			Addresses: ingressAddresses(ing),
+		}
+		for _, rule := range ing.Spec.Rules {
+			if rule.Host != "" {
+				index.add(rule.Host, server)
+			}
+		}
+	}
+
+	for _, gw := range gateways {
+		server := hostServer{
+			Kind:      "Gateway",
+			Namespace: gw.Namespace,
+			Name:      gw.Name,
+		}
+		for _, addr := range gw.Status.Addresses {
+			server.Addresses = append(server.Addresses, addr.Value)
+		}
+
+		hosts := make(map[string]bool)
+		for _, listener := range gw.Spec.Listeners {
+			if listener.Hostname != nil {
+				hosts[string(*listener.Hostname)] = true
+			}
+		}
+		for _, hr := range routes {
+			if routeAttachedTo(hr, gw) {
+				for _, h := range hr.Spec.Hostnames {
+					hosts[string(h)] = true
+				}
+			}
+		}
+		for host := range hosts {
+			index.add(host, server)
+		}
+	}
+
+	return index
+}
+
+// add registers a server for a hostname
+func (idx hostIndex) add(host string, server hostServer) {
+	idx[host] = append(idx[host], server)
+}
+
+// collisions returns an issue for every host of the result that is served by
+// another resource on a different load balancer address
+func (idx hostIndex) collisions(result *AnalysisResult) []string {
+	var issues []string
+
+	hosts := append([]string(nil), result.Hostnames...)
+	sort.Strings(hosts)
+
+	for _, host := range hosts {
+		for pattern, servers := range idx {
+			if !hostMatches(pattern, host) {
+				continue
+			}
+			for _, server := range servers {
+				if server.Kind == "Ingress" && server.Namespace == result.Namespace && server.Name == result.Name {
+					continue
+				}
+				if len(server.Addresses) == 0 || overlaps(server.Addresses, result.LoadBalancerAddresses) {
+					continue
+				}
+				issues = append(issues, fmt.Sprintf("Host %s is also served by %s %s/%s at %s; cutover may cause split-brain routing",
+					host, server.Kind, server.Namespace, server.Name, strings.Join(server.Addresses, ", ")))
+			}
+		}
+	}
+
+	sort.Strings(issues)
+	return issues
+}
+
+// ingressAddresses returns the load balancer addresses published in an Ingress status
+func ingressAddresses(ing *networkingv1.Ingress) []string {
+	var addresses []string
+	for _, lb := range ing.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			addresses = append(addresses, lb.IP)
+		}
+		if lb.Hostname != "" {
+			addresses = append(addresses, lb.Hostname)
+		}
+	}
+	return addresses
+}
+
+// routeAttachedTo checks if an HTTPRoute references a Gateway as parent
+func routeAttachedTo(hr *gatewayv1.HTTPRoute, gw *gatewayv1.Gateway) bool {
+	for _, ref := range hr.Spec.ParentRefs {
+		ns := hr.Namespace
+		if ref.Namespace != nil {
+			ns = string(*ref.Namespace)
+		}
+		if string(ref.Name) == gw.Name && ns == gw.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// hostMatches checks if a hostname matches a (possibly wildcard) pattern
+func hostMatches(pattern, host string) bool {
+	if pattern == host {
+		return true
+	}
+	if strings.HasPrefix(pattern, "*.") {
+		return strings.HasSuffix(host, pattern[1:])
+	}
+	return false
+}
+
+// overlaps checks if two address lists share an entry
+func overlaps(a, b []string) bool {
+	for _, x := range a {
+		if contains(b, x) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mayens/ingress-to-gateway/pkg/converter"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ControllerConfig reports the global settings of the ingress-nginx
+// controller ConfigMap, which apply to every Ingress without showing in
+// their annotations
+type ControllerConfig struct {
+	ConfigMap string // namespace/name
+	Target    string `json:",omitempty"`
+	Settings  []converter.ControllerSetting
+}
+
+// AnalyzeControllerConfig reads the controller ConfigMap namespace/name and
+// reports how its settings carry over to target ("" for none). It returns
+// nil when the ConfigMap does not exist or cannot be read.
+func (a *Analyzer) AnalyzeControllerConfig(ctx context.Context, namespace, name, target string) (*ControllerConfig, error) {
+	cm, err := a.client.GetConfigMap(ctx, namespace, name)
+	switch {
+	case apierrors.IsNotFound(err), apierrors.IsForbidden(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to get ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	return controllerConfig(cm, target), nil
+}
+
+// controllerConfig analyzes a controller ConfigMap
+func controllerConfig(cm *corev1.ConfigMap, target string) *ControllerConfig {
+	return &ControllerConfig{
+		ConfigMap: cm.Namespace + "/" + cm.Name,
+		Target:    target,
+		Settings:  converter.ControllerSettings(cm, target),
+	}
+}
+
+// Unconverted returns the settings that are not fully converted
+func (cfg *ControllerConfig) Unconverted() []converter.ControllerSetting {
+	var settings []converter.ControllerSetting
+	for _, s := range cfg.Settings {
+		if s.Status != converter.AnnotationConverted {
+			settings = append(settings, s)
+		}
+	}
+	return settings
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mayens/ingress-to-gateway/pkg/converter"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// conversionFidelity converts ing with the default options, without a
+// policy target, and scores how much of its nginx configuration carries over
+func conversionFidelity(ing *networkingv1.Ingress) converter.Fidelity {
+	c := converter.NewConverter(converter.Options{
+		RoutingOptions: converter.RoutingOptions{
+			SplitMode: "single",
+		},
+		OwnershipOptions: converter.OwnershipOptions{
+			ProgressiveDelivery: converter.ProgressiveGenerate,
+			IncludeManaged:      true,
+		},
+	})
+	if _, err := c.Convert(context.Background(), []interface{}{ing}); err == nil {
+		if reports := c.Fidelity(); len(reports) == 1 {
+			return reports[0]
+		}
+	}
+	return converter.Fidelity{Ingress: fmt.Sprintf("%s/%s", ing.Namespace, ing.Name)}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// nginxPrefix is the annotation prefix recognized by ingress-nginx
+const nginxPrefix = "nginx.ingress.kubernetes.io/"
+
+// LintFinding describes a misconfigured annotation
+type LintFinding struct {
+	Annotation string
+	Severity   string // error, warning
+	Message    string
+}
+
+// LintResult contains the lint findings for an Ingress
+type LintResult struct {
+	Name      string
+	Namespace string
+	Findings  []LintFinding
+}
+
+// misspelledPrefixes are annotation prefixes that ingress-nginx silently ignores
+var misspelledPrefixes = []string{
+	"nginx.ingress.kubernetes/",
+	"nginx.ingress.kubernetes.com/",
+	"nginx.ingress.k8s.io/",
+	"nginx.ingress.io/",
+	"nginx.kubernetes.io/",
+	"ingress.nginx.kubernetes.io/",
+	"ingress-nginx.kubernetes.io/",
+}
+
+// integerAnnotations hold values ingress-nginx parses as integers (seconds, counts)
+var integerAnnotations = []string{
+	"proxy-read-timeout",
+	"proxy-send-timeout",
+	"proxy-connect-timeout",
+	"proxy-next-upstream-timeout",
+	"proxy-next-upstream-tries",
+	"session-cookie-max-age",
+	"session-cookie-expires",
+	"limit-rps",
+	"limit-rpm",
+	"limit-connections",
+}
+
+// booleanAnnotations hold values ingress-nginx parses as booleans
+var booleanAnnotations = []string{
+	"ssl-redirect",
+	"force-ssl-redirect",
+	"enable-cors",
+	"use-regex",
+	"canary",
+	"ssl-passthrough",
+	"hsts",
+	"hsts-include-subdomains",
+}
+
+// LintIngresses lints all Ingress resources in the specified namespaces
+func (a *Analyzer) LintIngresses(ctx context.Context, namespaces []string) ([]*LintResult, error) {
+	var results []*LintResult
+
+	for _, ns := range namespaces {
+		ingresses, err := a.client.ListIngresses(ctx, ns)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list ingresses in %s: %w", ns, err)
+		}
+
+		for _, ing := range ingresses {
+			results = append(results, &LintResult{
+				Name:      ing.Name,
+				Namespace: ing.Namespace,
+				Findings:  LintIngress(ing),
+			})
+		}
+	}
+
+	return results, nil
+}
+
+// LintIngress checks the nginx annotations of an Ingress for common mistakes
+func LintIngress(ing *networkingv1.Ingress) []LintFinding {
+	var findings []LintFinding
+	annotations := ing.Annotations
+
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Misspelled prefixes and near-miss annotation names
+	for _, key := range keys {
+		if suggestion := SuggestAnnotation(key); suggestion != "" {
+			findings = append(findings, LintFinding{
+				Annotation: key,
+				Severity:   "error",
+				Message:    fmt.Sprintf("annotation is ignored by ingress-nginx, did you mean %s?", suggestion),
+			})
+			continue
+		}
+		for _, prefix := range misspelledPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				findings = append(findings, LintFinding{
+					Annotation: key,
+					Severity:   "error",
+					Message:    fmt.Sprintf("annotation prefix %q is ignored by ingress-nginx, did you mean %s?", prefix, nginxPrefix),
+				})
+				break
+			}
+		}
+	}
+
+	// Value formats
+	for _, name := range integerAnnotations {
+		if value, exists := annotations[nginxPrefix+name]; exists {
+			if _, err := strconv.Atoi(strings.TrimSpace(value)); err != nil {
+				findings = append(findings, LintFinding{
+					Annotation: nginxPrefix + name,
+					Severity:   "error",
+					Message:    fmt.Sprintf("invalid value %q, expected an integer (durations are plain seconds, e.g. \"60\")", value),
+				})
+			}
+		}
+	}
+	for _, name := range booleanAnnotations {
+		if value, exists := annotations[nginxPrefix+name]; exists {
+			if value != "true" && value != "false" {
+				findings = append(findings, LintFinding{
+					Annotation: nginxPrefix + name,
+					Severity:   "error",
+					Message:    fmt.Sprintf("invalid value %q, expected \"true\" or \"false\"", value),
+				})
+			}
+		}
+	}
+	if value, exists := annotations[nginxPrefix+"canary-weight"]; exists {
+		if weight, err := strconv.Atoi(value); err != nil || weight < 0 || weight > 100 {
+			findings = append(findings, LintFinding{
+				Annotation: nginxPrefix + "canary-weight",
+				Severity:   "error",
+				Message:    fmt.Sprintf("invalid value %q, expected an integer between 0 and 100", value),
+			})
+		}
+	}
+
+	// Conflicting annotations
+	conflicts := [][2]string{
+		{"rewrite-target", "app-root"},
+		{"permanent-redirect", "temporal-redirect"},
+	}
+	for _, pair := range conflicts {
+		_, first := annotations[nginxPrefix+pair[0]]
+		_, second := annotations[nginxPrefix+pair[1]]
+		if first && second {
+			findings = append(findings, LintFinding{
+				Annotation: nginxPrefix + pair[1],
+				Severity:   "warning",
+				Message:    fmt.Sprintf("conflicts with %s%s, only one of them can take effect", nginxPrefix, pair[0]),
+			})
+		}
+	}
+	if annotations[nginxPrefix+"ssl-redirect"] == "false" && annotations[nginxPrefix+"force-ssl-redirect"] == "true" {
+		findings = append(findings, LintFinding{
+			Annotation: nginxPrefix + "force-ssl-redirect",
+			Severity:   "warning",
+			Message:    "force-ssl-redirect overrides ssl-redirect=false",
+		})
+	}
+
+	return findings
+}
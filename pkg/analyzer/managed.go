@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analyzer
+
+import (
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// ReadinessOperatorManaged marks Ingresses generated by another controller,
+// such as an operator, Knative or a cert-manager solver
+const ReadinessOperatorManaged = "OPERATOR_MANAGED"
+
+// Labels set on short-lived Ingresses created by other controllers
+const (
+	certManagerSolverLabel = "acme.cert-manager.io/http01-solver"
+	knativeRouteLabel      = "serving.knative.dev/route"
+	knativeNetworkingGroup = "networking.internal.knative.dev/"
+)
+
+// TransientReason describes why an Ingress is an ephemeral resource that
+// should not count towards migration totals, or returns "" otherwise
+func TransientReason(ing *networkingv1.Ingress) string {
+	if ing.Labels[certManagerSolverLabel] == "true" {
+		return "cert-manager HTTP-01 solver"
+	}
+	if _, ok := ing.Labels[knativeRouteLabel]; ok {
+		return "Knative route"
+	}
+	for _, ref := range ing.OwnerReferences {
+		if strings.HasPrefix(ref.APIVersion, knativeNetworkingGroup) {
+			return "Knative route"
+		}
+	}
+	return ""
+}
+
+// ExcludeTransient drops results for ephemeral Ingresses and returns how many were dropped
+func ExcludeTransient(results []*AnalysisResult) ([]*AnalysisResult, int) {
+	var kept []*AnalysisResult
+	for _, result := range results {
+		if result.Transient == "" {
+			kept = append(kept, result)
+		}
+	}
+	return kept, len(results) - len(kept)
+}
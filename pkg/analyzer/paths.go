@@ -0,0 +1,47 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analyzer
+
+import (
+	"github.com/mayens/ingress-to-gateway/pkg/converter"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// stringPrefixPaths returns the paths nginx matches as plain string
+// prefixes, see converter.HasStringPrefixSemantics
+func stringPrefixPaths(ing *networkingv1.Ingress) []string {
+	// Regex paths are converted to RegularExpression matches instead
+	if ing.Annotations["nginx.ingress.kubernetes.io/use-regex"] == "true" {
+		return nil
+	}
+
+	var paths []string
+	seen := make(map[string]bool)
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if !converter.HasStringPrefixSemantics(path) || seen[path.Path] {
+				continue
+			}
+			seen[path.Path] = true
+			paths = append(paths, path.Path)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mayens/ingress-to-gateway/pkg/converter"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// cert-manager annotations on Ingresses (ingress-shim) and issued secrets
+const (
+	certManagerIssuer        = "cert-manager.io/issuer"
+	certManagerClusterIssuer = "cert-manager.io/cluster-issuer"
+	certManagerCertificate   = "cert-manager.io/certificate-name"
+)
+
+// How the certificate in a TLS secret is renewed
+const (
+	CertSourceIngressShim = "cert-manager-ingress-shim" // Certificate created for and owned by the Ingress
+	CertSourceCertificate = "cert-manager-certificate"  // standalone cert-manager Certificate
+	CertSourceStatic      = "static"                    // not managed by cert-manager, renewed by hand
+	CertSourceUnknown     = "unknown"                   // secret could not be read
+)
+
+// CertRotation describes how the certificate of a TLS secret is renewed and
+// what to do so renewals keep reaching clients after the migration
+type CertRotation struct {
+	Secret   string // namespace/name
+	Source   string // one of the CertSource constants
+	Guidance string
+}
+
+// targetRotation describes how each Gateway implementation picks up a
+// renewed certificate
+var targetRotation = map[string]string{
+	converter.TargetEnvoyGateway:       "Envoy Gateway watches the Secret and pushes renewed certificates to Envoy without a restart",
+	converter.TargetNginxGatewayFabric: "NGINX Gateway Fabric watches the Secret and reloads nginx when it changes",
+	converter.TargetIstio:              "Istio serves the certificate over SDS and picks up Secret updates; the Secret must be in the Gateway namespace or allowed by a ReferenceGrant",
+	converter.TargetCilium:             "Cilium copies the Secret into its secrets namespace and resyncs the copy when the Secret changes",
+	converter.TargetKong:               "Kong watches the Secret and updates its certificate when it changes",
+	converter.TargetTraefik:            "Traefik watches the Secret and reloads the certificate when it changes",
+	converter.TargetGKE:                "GKE uploads the Secret to the load balancer as a self-managed SSL certificate and re-uploads it when the Secret changes",
+}
+
+// CheckCertRotation records for each TLS secret of the results whether its
+// certificate is renewed by cert-manager for the Ingress, by a standalone
+// cert-manager Certificate or by hand, with guidance for the target
+// implementation ("" for none). Certificates cert-manager creates for an
+// Ingress are deleted with it, so their renewals silently stop once the
+// Ingress is removed; those secrets are also reported as issues.
+func (a *Analyzer) CheckCertRotation(ctx context.Context, results []*AnalysisResult, target string) error {
+	secrets := make(map[string]*corev1.Secret)
+
+	for _, result := range results {
+		if ingressShimIssuer(result.Annotations) != "" {
+			continue
+		}
+		for _, tls := range result.TLS {
+			if tls.SecretName == "" {
+				continue
+			}
+			key := result.Namespace + "/" + tls.SecretName
+			if _, cached := secrets[key]; cached {
+				continue
+			}
+			secret, err := a.client.GetSecret(ctx, result.Namespace, tls.SecretName)
+			switch {
+			case apierrors.IsNotFound(err), apierrors.IsForbidden(err):
+				secret = nil
+			case err != nil:
+				return fmt.Errorf("failed to get secret %s: %w", key, err)
+			}
+			secrets[key] = secret
+		}
+	}
+
+	for _, result := range results {
+		result.CertRotation = certRotation(result, secrets, target)
+		for _, rotation := range result.CertRotation {
+			if rotation.Source == CertSourceIngressShim {
+				result.Issues = append(result.Issues, fmt.Sprintf(
+					"TLS secret %s is renewed by a cert-manager Certificate owned by this Ingress; removing the Ingress stops its renewals", rotation.Secret))
+			}
+		}
+	}
+	return nil
+}
+
+// certRotation classifies the TLS secrets of result; secrets holds the
+// secrets read from the cluster, nil when missing or unreadable
+func certRotation(result *AnalysisResult, secrets map[string]*corev1.Secret, target string) []CertRotation {
+	issuer := ingressShimIssuer(result.Annotations)
+
+	var rotations []CertRotation
+	seen := make(map[string]bool)
+	for _, tls := range result.TLS {
+		key := result.Namespace + "/" + tls.SecretName
+		if tls.SecretName == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		rotation := CertRotation{Secret: key}
+		switch secret := secrets[key]; {
+		case issuer != "":
+			rotation.Source = CertSourceIngressShim
+			rotation.Guidance = fmt.Sprintf("issued by %s for this Ingress; the Certificate is deleted with the Ingress, so before removing it "+
+				"annotate the Gateway with the issuer (cert-manager gateway-shim) or create a standalone Certificate for %s", issuer, tls.SecretName)
+		case secret == nil:
+			rotation.Source = CertSourceUnknown
+			rotation.Guidance = "secret not found or not readable; check how it is renewed before migrating"
+		case secret.Annotations[certManagerCertificate] != "":
+			rotation.Source = CertSourceCertificate
+			rotation.Guidance = fmt.Sprintf("renewed by cert-manager Certificate %s independently of the Ingress; renewals continue after migration",
+				secret.Annotations[certManagerCertificate])
+		default:
+			rotation.Source = CertSourceStatic
+			rotation.Guidance = "not managed by cert-manager; renew it by hand before it expires, as today"
+		}
+
+		if note, ok := targetRotation[target]; ok {
+			rotation.Guidance += "; " + note
+		} else if rotation.Source != CertSourceUnknown {
+			rotation.Guidance += "; verify the Gateway implementation reloads the Secret when it changes"
+		}
+		rotations = append(rotations, rotation)
+	}
+	return rotations
+}
+
+// ingressShimIssuer returns the cert-manager issuer annotated on an Ingress,
+// e.g. "ClusterIssuer letsencrypt", or "" if cert-manager does not issue
+// its certificates
+func ingressShimIssuer(annotations map[string]string) string {
+	if name := annotations[certManagerClusterIssuer]; name != "" {
+		return "ClusterIssuer " + name
+	}
+	if name := annotations[certManagerIssuer]; name != "" {
+		return "Issuer " + name
+	}
+	return ""
+}
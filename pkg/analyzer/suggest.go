@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analyzer
+
+import (
+	"sort"
+	"strings"
+)
+
+// knownNginxAnnotations lists the annotation names (without prefix) that
+// ingress-nginx understands
+var knownNginxAnnotations = []string{
+	"affinity", "affinity-canary-behavior", "affinity-mode", "app-root",
+	"auth-cache-duration", "auth-cache-key", "auth-method", "auth-realm", "auth-response-headers",
+	"auth-secret", "auth-secret-type", "auth-signin", "auth-signin-redirect-param", "auth-snippet",
+	"auth-tls-error-page", "auth-tls-match-cn", "auth-tls-pass-certificate-to-upstream",
+	"auth-tls-secret", "auth-tls-verify-client", "auth-tls-verify-depth", "auth-type", "auth-url",
+	"backend-protocol", "canary", "canary-by-cookie", "canary-by-header", "canary-by-header-pattern",
+	"canary-by-header-value", "canary-weight", "canary-weight-total", "client-body-buffer-size",
+	"configuration-snippet", "connection-proxy-header", "cors-allow-credentials", "cors-allow-headers",
+	"cors-allow-methods", "cors-allow-origin", "cors-expose-headers", "cors-max-age",
+	"custom-headers", "custom-http-errors", "default-backend", "denylist-source-range",
+	"enable-access-log", "enable-cors", "enable-modsecurity", "enable-opentelemetry",
+	"enable-rewrite-log", "force-ssl-redirect", "from-to-www-redirect", "global-rate-limit",
+	"hsts", "hsts-include-subdomains", "hsts-max-age", "hsts-preload",
+	"http2-push-preload", "limit-burst-multiplier", "limit-connections", "limit-rate",
+	"limit-rate-after", "limit-rpm", "limit-rps", "limit-whitelist", "load-balance",
+	"mirror-host", "mirror-request-body", "mirror-target", "mirror-uri",
+	"opentelemetry-operation-name", "opentelemetry-trust-incoming-span", "permanent-redirect",
+	"permanent-redirect-code", "preserve-trailing-slash", "proxy-body-size", "proxy-buffer-size",
+	"proxy-buffering", "proxy-buffers-number", "proxy-connect-timeout", "proxy-cookie-domain",
+	"proxy-cookie-path", "proxy-http-version", "proxy-max-temp-file-size", "proxy-next-upstream",
+	"proxy-next-upstream-timeout", "proxy-next-upstream-tries", "proxy-read-timeout",
+	"proxy-redirect-from", "proxy-redirect-to", "proxy-request-buffering", "proxy-send-timeout",
+	"proxy-ssl-ciphers", "proxy-ssl-name", "proxy-ssl-protocols", "proxy-ssl-secret",
+	"proxy-ssl-server-name", "proxy-ssl-verify", "proxy-ssl-verify-depth", "rewrite-target",
+	"satisfy", "server-alias", "server-snippet", "service-upstream", "session-cookie-change-on-failure",
+	"session-cookie-domain", "session-cookie-expires", "session-cookie-max-age", "session-cookie-name",
+	"session-cookie-path", "session-cookie-samesite", "session-cookie-secure", "ssl-ciphers",
+	"ssl-passthrough", "ssl-prefer-server-ciphers", "ssl-redirect", "stream-snippet",
+	"temporal-redirect", "temporal-redirect-code", "upstream-hash-by", "upstream-hash-by-subset",
+	"upstream-hash-by-subset-size", "upstream-vhost", "use-regex", "whitelist-source-range",
+	"x-forwarded-prefix",
+}
+
+// maxSuggestionDistance is the largest edit distance considered a typo
+const maxSuggestionDistance = 3
+
+// SuggestAnnotation returns the annotation an unrecognized key most likely
+// meant, or an empty string when the key is recognized or has no close match.
+// Keys with a wrong prefix (e.g. nginx.ingress.kubernetes/) and near-miss
+// names (e.g. rewrite-taget) are both detected.
+func SuggestAnnotation(key string) string {
+	prefix, name := key, ""
+	if idx := strings.LastIndex(key, "/"); idx >= 0 {
+		prefix, name = key[:idx+1], key[idx+1:]
+	}
+	if name == "" || !strings.Contains(prefix, "nginx") {
+		return ""
+	}
+
+	wrongPrefix := prefix != nginxPrefix
+	if isKnownAnnotation(name) {
+		if wrongPrefix && prefix != "nginx.org/" && prefix != "nginx.com/" {
+			return nginxPrefix + name
+		}
+		return ""
+	}
+	if prefix == "nginx.org/" || prefix == "nginx.com/" {
+		// NGINX Inc controller annotations use their own vocabulary
+		return ""
+	}
+
+	best, bestDistance := "", maxSuggestionDistance+1
+	for _, known := range knownNginxAnnotations {
+		if d := levenshtein(name, known); d < bestDistance {
+			best, bestDistance = known, d
+		}
+	}
+	if best == "" || bestDistance > len(name)/3+1 {
+		return ""
+	}
+	return nginxPrefix + best
+}
+
+// isKnownAnnotation checks if an annotation name is understood by ingress-nginx
+func isKnownAnnotation(name string) bool {
+	i := sort.SearchStrings(knownNginxAnnotations, name)
+	return i < len(knownNginxAnnotations) && knownNginxAnnotations[i] == name
+}
+
+// levenshtein computes the edit distance between two strings
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+const annotationServerAlias = "nginx.ingress.kubernetes.io/server-alias"
+
+// ingressRules returns the Ingress rules with a valid or empty host,
+// followed by one rule per server-alias host
+func (c *Converter) ingressRules(ing *networkingv1.Ingress) []networkingv1.IngressRule {
+	var rules []networkingv1.IngressRule
+	for _, rule := range c.aliasRules(ing) {
+		if rule.Host != "" && !c.validHostname(ing, rule.Host) {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// aliasRules returns the Ingress rules followed by one rule per
+// server-alias host. ingress-nginx attaches aliases to the server of the
+// first host, so each alias routes like the first host rule.
+func (c *Converter) aliasRules(ing *networkingv1.Ingress) []networkingv1.IngressRule {
+	value, exists := ing.Annotations[annotationServerAlias]
+	if !exists {
+		return ing.Spec.Rules
+	}
+
+	var primary *networkingv1.IngressRule
+	hosts := make(map[string]bool)
+	for i, rule := range ing.Spec.Rules {
+		if rule.Host == "" {
+			continue
+		}
+		if primary == nil {
+			primary = &ing.Spec.Rules[i]
+		}
+		hosts[rule.Host] = true
+	}
+	if primary == nil {
+		c.addDiagnostic(ing, annotationServerAlias, SeverityWarning,
+			"server aliases %q ignored, the Ingress has no host rule to alias", value)
+		return ing.Spec.Rules
+	}
+
+	rules := append([]networkingv1.IngressRule{}, ing.Spec.Rules...)
+	aliases := strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' })
+	for _, alias := range aliases {
+		if hosts[alias] {
+			continue
+		}
+		hosts[alias] = true
+
+		rule := *primary
+		rule.Host = alias
+		rules = append(rules, rule)
+		c.addDiagnostic(ing, annotationServerAlias, SeverityInfo, "alias %s routed like %s", alias, primary.Host)
+	}
+	return rules
+}
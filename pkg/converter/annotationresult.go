@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import "strings"
+
+// Annotation conversion statuses
+const (
+	AnnotationConverted = "converted" // converted without warnings
+	AnnotationPartial   = "partial"   // converted with warnings
+	AnnotationSkipped   = "skipped"   // not converted
+)
+
+// AnnotationResult reports what happened to one nginx annotation of an Ingress
+type AnnotationResult struct {
+	Ingress     string `json:"ingress"` // namespace/name of the source Ingress
+	Annotation  string `json:"annotation"`
+	Status      string `json:"status"`                // converted, partial, skipped
+	ConvertedTo string `json:"convertedTo,omitempty"` // the Gateway API construct or policy produced
+	Reason      string `json:"reason,omitempty"`      // why the annotation is partial or skipped
+}
+
+// annotationTargets names the Gateway API construct each annotation converts
+// to. Annotations converted to implementation policies are listed in the
+// target profiles instead.
+var annotationTargets = map[string]string{
+	annotationRewriteTarget:         "URLRewrite filter",
+	annotationUseRegex:              "RegularExpression path matches",
+	annotationXForwardedPrefix:      "RequestHeaderModifier filter",
+	annotationServerAlias:           "HTTPRoute hostnames",
+	annotationConfigurationSnippet:  "header modifier and redirect filters",
+	annotationDefaultBackend:        "catch-all HTTPRoute",
+	annotationCustomHTTPErrors:      "catch-all HTTPRoute",
+	annotationSSLPassthrough:        "TLSRoute",
+	annotationAffinity:              "BackendLBPolicy session persistence",
+	annotationSessionCookieName:     "BackendLBPolicy session persistence",
+	annotationSessionCookieAge:      "BackendLBPolicy session persistence",
+	annotationProxyReadTimeout:      "HTTPRoute timeouts",
+	annotationProxySendTimeout:      "HTTPRoute timeouts",
+	annotationHSTS:                  "ResponseHeaderModifier filter",
+	annotationHSTSMaxAge:            "ResponseHeaderModifier filter",
+	annotationHSTSIncludeSubdomains: "ResponseHeaderModifier filter",
+	annotationHSTSPreload:           "ResponseHeaderModifier filter",
+	annotationPermanentRedirect:     "RequestRedirect filter",
+	annotationTraefikMiddlewares:    "HTTPRoute filters",
+	annotationTraefikEntryPoints:    "Gateway listeners",
+	annotationTraefikTLS:            "HTTPS listener",
+
+	annotationHAProxyTimeoutServer:             "HTTPRoute timeouts",
+	annotationHAProxyTimeoutClient:             "HTTPRoute timeouts",
+	annotationHAProxyTimeoutHTTPRequest:        "HTTPRoute timeouts",
+	annotationHAProxyRequestRedirect:           "RequestRedirect filter",
+	annotationHAProxyRequestRedirectCode:       "RequestRedirect filter",
+	annotationHAProxyPathRewrite:               "URLRewrite filter",
+	annotationHAProxyIngressTimeoutServer:      "HTTPRoute timeouts",
+	annotationHAProxyIngressTimeoutClient:      "HTTPRoute timeouts",
+	annotationHAProxyIngressTimeoutHTTPRequest: "HTTPRoute timeouts",
+	annotationHAProxyIngressRedirectTo:         "RequestRedirect filter",
+	annotationHAProxyIngressRewriteTarget:      "URLRewrite filter",
+	annotationGKEFrontendConfig:                "GCPGatewayPolicy",
+	annotationGKEStaticIP:                      "Gateway addresses",
+	annotationGKERegionalStaticIP:              "Gateway addresses",
+}
+
+// convertedTo names what annotation converts to for the configured target
+func (c *Converter) convertedTo(annotation string) string {
+	if target, ok := annotationTargets[annotation]; ok {
+		return target
+	}
+	if p, ok := TargetProfile(c.opts.Target); ok {
+		for _, policy := range p.Policies {
+			for _, a := range policy.Annotations {
+				if a == annotation {
+					return policy.Kind
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// annotationResult classifies an annotation by the worst diagnostic
+// recorded for it, giving the diagnostic messages at that severity as
+// reason. Annotations with an invalid value are skipped.
+func (c *Converter) annotationResult(ingress, annotation string, valid bool, diags []Diagnostic) AnnotationResult {
+	r := AnnotationResult{Ingress: ingress, Annotation: annotation}
+
+	worst := ""
+	var messages []string
+	for _, d := range diags {
+		switch rank := severityRank(d.Severity); {
+		case rank > severityRank(worst):
+			worst, messages = d.Severity, []string{d.Message}
+		case rank == severityRank(worst):
+			messages = append(messages, d.Message)
+		}
+	}
+
+	_, translated := c.translators().Lookup(annotation)
+	translated = translated || c.hooked[hookedKey(ingress, annotation)]
+	switch {
+	case !isConvertedAnnotation(annotation) && !translated:
+		r.Status, r.Reason = AnnotationSkipped, "no conversion exists for this annotation"
+	case worst == SeverityError, !valid:
+		r.Status, r.Reason = AnnotationSkipped, strings.Join(messages, "; ")
+	case worst == SeverityWarning:
+		r.Status, r.Reason = AnnotationPartial, strings.Join(messages, "; ")
+		r.ConvertedTo = c.convertedTo(annotation)
+	default:
+		r.Status = AnnotationConverted
+		r.ConvertedTo = c.convertedTo(annotation)
+	}
+	return r
+}
+
+// AnnotationResults flattens the per-annotation results of fidelity reports
+func AnnotationResults(reports []Fidelity) []AnnotationResult {
+	var results []AnnotationResult
+	for _, f := range reports {
+		results = append(results, f.Annotations...)
+	}
+	return results
+}
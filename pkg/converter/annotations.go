@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+const (
+	annotationPermanentRedirect = "nginx.ingress.kubernetes.io/permanent-redirect"
+	annotationSSLRedirect       = "nginx.ingress.kubernetes.io/ssl-redirect"
+	annotationForceSSLRedirect  = "nginx.ingress.kubernetes.io/force-ssl-redirect"
+)
+
+// valueType is the kind of value an annotation expects
+type valueType int
+
+const (
+	valueBool     valueType = iota // true or false, as strconv.ParseBool reads it
+	valueCount                     // positive integer
+	valueSeconds                   // non-negative integer of seconds
+	valueDuration                  // positive seconds, or a duration of at least 1ms such as 90s
+	valueURL                       // absolute http or https URL
+)
+
+// String describes the expected value in diagnostics
+func (t valueType) String() string {
+	switch t {
+	case valueBool:
+		return "true or false"
+	case valueCount:
+		return "a positive integer"
+	case valueSeconds:
+		return "a non-negative number of seconds"
+	case valueDuration:
+		return "a positive number of seconds or a duration such as 90s"
+	case valueURL:
+		return "an absolute http or https URL"
+	}
+	return "a valid value"
+}
+
+// annotationValues lists the value type of converted annotations
+var annotationValues = map[string]valueType{
+	annotationSSLPassthrough:        valueBool,
+	annotationUseRegex:              valueBool,
+	annotationSSLRedirect:           valueBool,
+	annotationForceSSLRedirect:      valueBool,
+	annotationHSTS:                  valueBool,
+	annotationHSTSIncludeSubdomains: valueBool,
+	annotationHSTSPreload:           valueBool,
+	annotationHSTSMaxAge:            valueSeconds,
+	annotationLimitRPS:              valueCount,
+	annotationLimitRPM:              valueCount,
+	annotationLimitConnections:      valueCount,
+	annotationSessionCookieAge:      valueCount,
+	annotationProxyReadTimeout:      valueDuration,
+	annotationProxySendTimeout:      valueDuration,
+	annotationAuthURL:               valueURL,
+	annotationPermanentRedirect:     valueURL,
+}
+
+// validValue reports whether value is of type t, returning booleans in the
+// "true"/"false" form the conversion compares against
+func validValue(t valueType, value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	switch t {
+	case valueBool:
+		b, err := strconv.ParseBool(value)
+		return strconv.FormatBool(b), err == nil
+	case valueCount:
+		n, err := strconv.Atoi(value)
+		return value, err == nil && n > 0
+	case valueSeconds:
+		n, err := strconv.Atoi(value)
+		return value, err == nil && n >= 0
+	case valueDuration:
+		// Timeouts under a millisecond are not converted, see parseTimeout
+		if n, err := strconv.Atoi(value); err == nil {
+			return value, n > 0
+		}
+		d, err := time.ParseDuration(value)
+		return value, err == nil && d >= time.Millisecond
+	case valueURL:
+		u, err := url.Parse(value)
+		return value, err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+	}
+	return value, true
+}
+
+// checkAnnotations validates the values of converted annotations before
+// conversion. Malformed values are reported with the annotation named and
+// left out, so no feature is dropped silently; with StrictAnnotations they
+// fail the conversion instead. Booleans are normalized, so True and 1 are
+// read as nginx reads them. The Ingress is copied before any change.
+func (c *Converter) checkAnnotations(ing *networkingv1.Ingress) (*networkingv1.Ingress, error) {
+	var keys []string
+	for key := range ing.Annotations {
+		if _, known := annotationValues[key]; known {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	checked := ing
+	for _, key := range keys {
+		t := annotationValues[key]
+		value := ing.Annotations[key]
+		normalized, ok := validValue(t, value)
+		if ok && normalized == value {
+			continue
+		}
+		if !ok && c.opts.StrictAnnotations {
+			return nil, fmt.Errorf("invalid value %q for annotation %s: expected %s", value, key, t)
+		}
+
+		if checked == ing {
+			checked = ing.DeepCopy()
+		}
+		if ok {
+			checked.Annotations[key] = normalized
+			continue
+		}
+		c.addDiagnostic(ing, key, SeverityWarning, "invalid value %q, expected %s; annotation not converted", value, t)
+		delete(checked.Annotations, key)
+	}
+	return checked, nil
+}
@@ -0,0 +1,374 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// Service port appProtocol values that change the conversion
+const (
+	appProtocolGRPC  = "grpc"
+	appProtocolH2C   = "kubernetes.io/h2c"
+	appProtocolHTTPS = "https"
+	appProtocolWSS   = "kubernetes.io/wss"
+)
+
+// ServiceLookup resolves the backend Services of Ingresses
+type ServiceLookup interface {
+	GetService(ctx context.Context, namespace, name string) (*corev1.Service, error)
+}
+
+// SetServiceLookup enables reading the appProtocol of backend Service
+// ports, so gRPC backends get GRPCRoutes and TLS backends a
+// BackendTLSPolicy, and resolving named backend ports to numbers. Without
+// it only annotations are used and named ports cannot be converted. The
+// lookup must be safe for concurrent use if Convert is called concurrently.
+func (c *Converter) SetServiceLookup(lookup ServiceLookup) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.services = lookup
+}
+
+// resolveAppProtocols reads the appProtocol and number of every Service
+// port the Ingress routes to. Missing or unreadable Services are reported
+// and treated as plain HTTP.
+func (c *Converter) resolveAppProtocols(ctx context.Context, ing *networkingv1.Ingress) error {
+	if c.services == nil {
+		return nil
+	}
+	if c.appProtocols == nil {
+		c.appProtocols = make(map[string]string)
+		c.portNumbers = make(map[string]int32)
+		c.fetchedServices = make(map[string]*corev1.Service)
+	}
+	services := make(map[string]*corev1.Service)
+
+	for _, backend := range serviceBackends(ing) {
+		key := backendKey(ing, backend)
+		if _, done := c.appProtocols[key]; done {
+			continue
+		}
+
+		svc, fetched := services[backend.Name]
+		if !fetched {
+			var err error
+			svc, err = c.services.GetService(ctx, ing.Namespace, backend.Name)
+			switch {
+			case apierrors.IsNotFound(err):
+				c.addDiagnostic(ing, "", SeverityWarning, "backend Service %s/%s not found", ing.Namespace, backend.Name)
+				svc = nil
+			case apierrors.IsForbidden(err):
+				c.addDiagnostic(ing, "", SeverityWarning,
+					"backend Service %s/%s could not be read (forbidden); appProtocol not checked", ing.Namespace, backend.Name)
+				svc = nil
+			case err != nil:
+				return fmt.Errorf("failed to get service %s/%s: %w", ing.Namespace, backend.Name, err)
+			}
+			services[backend.Name] = svc
+			if svc != nil {
+				c.fetchedServices[ing.Namespace+"/"+backend.Name] = svc
+			}
+		}
+
+		c.appProtocols[key] = ""
+		port := servicePort(svc, backend.Port)
+		if port == nil {
+			continue
+		}
+		c.portNumbers[key] = port.Port
+		if port.AppProtocol != nil {
+			c.appProtocols[key] = strings.ToLower(*port.AppProtocol)
+		}
+	}
+	return nil
+}
+
+// appProtocol returns the resolved appProtocol of a backend, or "" if unknown
+func (c *Converter) appProtocol(ing *networkingv1.Ingress, backend *networkingv1.IngressServiceBackend) string {
+	return c.appProtocols[backendKey(ing, backend)]
+}
+
+// backendPort returns the port number of a backend, resolving named ports
+// through the Service. A named port that cannot be resolved is reported as
+// an error and ok is false, so the caller drops the backend instead of
+// emitting port 0.
+func (c *Converter) backendPort(ing *networkingv1.Ingress, backend *networkingv1.IngressServiceBackend) (port gatewayv1.PortNumber, ok bool) {
+	if backend.Port.Name == "" {
+		return gatewayv1.PortNumber(backend.Port.Number), true
+	}
+	if number, found := c.portNumbers[backendKey(ing, backend)]; found {
+		return gatewayv1.PortNumber(number), true
+	}
+
+	if c.services == nil {
+		c.addDiagnostic(ing, "", SeverityError,
+			"backend %s uses named port %q, which needs the Service to resolve; convert from the cluster or use a port number (backend dropped)",
+			backend.Name, backend.Port.Name)
+	} else {
+		c.addDiagnostic(ing, "", SeverityError,
+			"backend %s named port %q not found on Service %s/%s (backend dropped)",
+			backend.Name, backend.Port.Name, ing.Namespace, backend.Name)
+	}
+	return 0, false
+}
+
+// serviceBackends lists the Service backends of an Ingress, default backend included
+func serviceBackends(ing *networkingv1.Ingress) []*networkingv1.IngressServiceBackend {
+	var backends []*networkingv1.IngressServiceBackend
+	if ing.Spec.DefaultBackend != nil && ing.Spec.DefaultBackend.Service != nil {
+		backends = append(backends, ing.Spec.DefaultBackend.Service)
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			if svc := rule.HTTP.Paths[i].Backend.Service; svc != nil {
+				backends = append(backends, svc)
+			}
+		}
+	}
+	return backends
+}
+
+// backendKey identifies a Service port across Ingresses
+func backendKey(ing *networkingv1.Ingress, backend *networkingv1.IngressServiceBackend) string {
+	return fmt.Sprintf("%s/%s:%d:%s", ing.Namespace, backend.Name, backend.Port.Number, backend.Port.Name)
+}
+
+// servicePort finds the Service port an Ingress backend port refers to
+func servicePort(svc *corev1.Service, port networkingv1.ServiceBackendPort) *corev1.ServicePort {
+	if svc == nil {
+		return nil
+	}
+	for i := range svc.Spec.Ports {
+		p := &svc.Spec.Ports[i]
+		if (port.Name != "" && p.Name == port.Name) || (port.Name == "" && p.Port == port.Number) {
+			return p
+		}
+	}
+	return nil
+}
+
+// isGRPC reports whether every backend of the Ingress serves gRPC, so it
+// converts to GRPCRoutes. Mixed Ingresses stay HTTPRoutes with a warning.
+func (c *Converter) isGRPC(ing *networkingv1.Ingress) bool {
+	backends := serviceBackends(ing)
+	var grpc []string
+	for _, backend := range backends {
+		if c.appProtocol(ing, backend) == appProtocolGRPC {
+			grpc = append(grpc, backend.Name)
+		}
+	}
+	if len(grpc) == 0 {
+		return false
+	}
+	if len(grpc) < len(backends) {
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"backends %s use appProtocol grpc but share the Ingress with HTTP backends; kept in an HTTPRoute, split the Ingress to get a GRPCRoute",
+			strings.Join(grpc, ", "))
+		return false
+	}
+	return true
+}
+
+// convertGRPC converts an Ingress whose backends all serve gRPC to
+// GRPCRoutes, grouping hosts as in single mode. Paths must name a gRPC
+// service or method (/pkg.Service/Method); otherwise ok is false and the
+// Ingress is converted to HTTPRoutes instead.
+func (c *Converter) convertGRPC(ing *networkingv1.Ingress) (routes []interface{}, ok bool) {
+	parentRef := c.parentRef(ing)
+
+	groups := c.groupByPaths(ing, c.ingressRules(ing))
+	for i, g := range groups {
+		var rules []gatewayv1alpha2.GRPCRouteRule
+		for _, path := range g.paths {
+			if path.Backend.Service == nil {
+				return nil, false
+			}
+			method, valid := grpcMethodMatch(path.Path)
+			if !valid {
+				c.addDiagnostic(ing, "", SeverityWarning,
+					"path %q is not a gRPC service or method path, so the gRPC backends are kept in an HTTPRoute", path.Path)
+				return nil, false
+			}
+			backendRef, resolved := c.grpcBackendRef(ing, path.Backend.Service)
+			if !resolved {
+				return nil, false
+			}
+			rule := gatewayv1alpha2.GRPCRouteRule{
+				BackendRefs: []gatewayv1alpha2.GRPCBackendRef{backendRef},
+			}
+			if method != nil {
+				rule.Matches = []gatewayv1alpha2.GRPCRouteMatch{{Method: method}}
+			}
+			rules = append(rules, rule)
+		}
+		// The default backend takes the calls no other rule matches
+		if ing.Spec.DefaultBackend != nil && ing.Spec.DefaultBackend.Service != nil {
+			if backendRef, resolved := c.grpcBackendRef(ing, ing.Spec.DefaultBackend.Service); resolved {
+				rules = append(rules, gatewayv1alpha2.GRPCRouteRule{
+					BackendRefs: []gatewayv1alpha2.GRPCBackendRef{backendRef},
+				})
+			}
+		}
+		if len(rules) == 0 {
+			continue
+		}
+
+		name := fmt.Sprintf("%s-grpcroute", ing.Name)
+		if i > 0 {
+			name = fmt.Sprintf("%s-grpcroute-%d", ing.Name, i+1)
+		}
+		routes = append(routes, &gatewayv1alpha2.GRPCRoute{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: gatewayv1alpha2.GroupVersion.String(),
+				Kind:       "GRPCRoute",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   ing.Namespace,
+				Labels:      c.routeLabels(ing),
+				Annotations: c.routeAnnotations(ing),
+			},
+			Spec: gatewayv1alpha2.GRPCRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{parentRef},
+				},
+				Hostnames: g.hostnames,
+				Rules:     rules,
+			},
+		})
+	}
+
+	if len(routes) > 0 {
+		c.addDiagnostic(ing, "", SeverityInfo,
+			"converted to GRPCRoute (experimental channel) since every backend uses appProtocol grpc; annotation filters and policies are not applied to it")
+	}
+	return routes, true
+}
+
+// grpcMethodMatch maps an Ingress path to a gRPC method match: / matches
+// every call, /pkg.Service every method of a service and
+// /pkg.Service/Method a single method
+func grpcMethodMatch(path string) (*gatewayv1alpha2.GRPCMethodMatch, bool) {
+	trimmed := strings.Trim(path, "/")
+	if trimmed == "" {
+		return nil, true
+	}
+
+	parts := strings.Split(trimmed, "/")
+	if len(parts) > 2 || strings.ContainsAny(trimmed, "*$^()[]") {
+		return nil, false
+	}
+	matchType := gatewayv1alpha2.GRPCMethodMatchExact
+	match := &gatewayv1alpha2.GRPCMethodMatch{Type: &matchType, Service: &parts[0]}
+	if len(parts) == 2 {
+		match.Method = &parts[1]
+	}
+	return match, true
+}
+
+// grpcBackendRef references the Service of an Ingress backend
+func (c *Converter) grpcBackendRef(ing *networkingv1.Ingress, backend *networkingv1.IngressServiceBackend) (gatewayv1alpha2.GRPCBackendRef, bool) {
+	port, ok := c.backendPort(ing, backend)
+	if !ok {
+		return gatewayv1alpha2.GRPCBackendRef{}, false
+	}
+	return gatewayv1alpha2.GRPCBackendRef{
+		BackendRef: gatewayv1.BackendRef{
+			BackendObjectReference: gatewayv1.BackendObjectReference{
+				Name: gatewayv1.ObjectName(backend.Name),
+				Port: &port,
+			},
+		},
+	}, true
+}
+
+// extractBackendProtocols emits a BackendTLSPolicy for backends whose
+// Service port expects TLS and notes HTTP/2 cleartext backends, which
+// Gateway implementations pick up from appProtocol on their own
+func (c *Converter) extractBackendProtocols(ing *networkingv1.Ingress) []interface{} {
+	var policies []interface{}
+
+	for _, backend := range serviceBackends(ing) {
+		switch c.appProtocol(ing, backend) {
+		case appProtocolH2C:
+			c.addDiagnostic(ing, "", SeverityInfo,
+				"backend %s uses appProtocol %s; implementations supporting backend protocol selection connect with HTTP/2 cleartext", backend.Name, appProtocolH2C)
+		case appProtocolHTTPS, appProtocolWSS:
+			if !c.supports(FeatureBackendTLSPolicy) {
+				c.unsupportedFeature(ing, "", FeatureBackendTLSPolicy, fmt.Sprintf("TLS to backend %s", backend.Name))
+				continue
+			}
+			// One policy per Service, shared by every Ingress using it
+			key := ing.Namespace + "/" + backend.Name
+			if c.backendTLS[key] {
+				continue
+			}
+			if c.backendTLS == nil {
+				c.backendTLS = make(map[string]bool)
+			}
+			c.backendTLS[key] = true
+			policies = append(policies, backendTLSPolicy(ing.Namespace, backend.Name))
+			c.addDiagnostic(ing, "", SeverityWarning,
+				"backend %s expects TLS (appProtocol %s); generated a BackendTLSPolicy trusting system CAs, set caCertRefs if it uses a private CA",
+				backend.Name, c.appProtocol(ing, backend))
+		}
+	}
+	return policies
+}
+
+// backendTLSPolicy builds an (experimental) BackendTLSPolicy validating
+// the Service certificate against its cluster DNS name
+func backendTLSPolicy(namespace, service string) *gatewayv1alpha2.BackendTLSPolicy {
+	wellKnown := gatewayv1alpha2.WellKnownCACertSystem
+	return &gatewayv1alpha2.BackendTLSPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gatewayv1alpha2.GroupVersion.String(),
+			Kind:       "BackendTLSPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sanitizeName(fmt.Sprintf("%s-backend-tls", service)),
+			Namespace: namespace,
+		},
+		Spec: gatewayv1alpha2.BackendTLSPolicySpec{
+			TargetRef: gatewayv1alpha2.PolicyTargetReferenceWithSectionName{
+				PolicyTargetReference: gatewayv1alpha2.PolicyTargetReference{
+					Group: "",
+					Kind:  "Service",
+					Name:  gatewayv1alpha2.ObjectName(service),
+				},
+			},
+			TLS: gatewayv1alpha2.BackendTLSPolicyConfig{
+				WellKnownCACerts: &wellKnown,
+				Hostname:         gatewayv1beta1.PreciseHostname(fmt.Sprintf("%s.%s.svc", service, namespace)),
+			},
+		},
+	}
+}
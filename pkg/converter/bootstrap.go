@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// BootstrapImplementations returns the implementations Bootstrap can
+// generate a GatewayClass for, sorted
+func BootstrapImplementations() []string {
+	var implementations []string
+	for target, p := range profiles {
+		if p.ControllerName != "" {
+			implementations = append(implementations, target)
+		}
+	}
+	sort.Strings(implementations)
+	return implementations
+}
+
+// Bootstrap returns the GatewayClass of an implementation and a minimal
+// Gateway of that class to attach converted routes to: one HTTP listener on
+// port 80 admitting routes from all namespaces. The GatewayClass is named
+// after the configured gateway class, else the implementation's default, so
+// converted routes need no further flags. The implementation's controller
+// must be installed for the Gateway to be programmed.
+func (c *Converter) Bootstrap(implementation, namespace, name string) ([]interface{}, error) {
+	p, ok := TargetProfile(implementation)
+	if !ok || p.ControllerName == "" {
+		return nil, fmt.Errorf("unsupported implementation %q, must be one of: %s",
+			implementation, strings.Join(BootstrapImplementations(), ", "))
+	}
+
+	className := p.GatewayClass
+	if c.opts.GatewayClass != "" {
+		className = c.opts.GatewayClass
+	}
+	apiVersion := c.gatewayAPIVersion()
+
+	class := &gatewayv1.GatewayClass{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiVersion,
+			Kind:       "GatewayClass",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: className,
+		},
+		Spec: gatewayv1.GatewayClassSpec{
+			ControllerName: gatewayv1.GatewayController(p.ControllerName),
+		},
+	}
+
+	b := c.newGatewayBuilder(namespace, name)
+	b.gateway.APIVersion = apiVersion
+	b.gateway.Spec.GatewayClassName = gatewayv1.ObjectName(className)
+	b.allowAllNamespaces()
+
+	return []interface{}{class, b.gateway}, nil
+}
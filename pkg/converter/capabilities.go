@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import "sort"
+
+// Capabilities summarizes what the converter generates for a target
+// implementation, for choosing a target before converting
+type Capabilities struct {
+	Name           string             `json:"name"`
+	GatewayClass   string             `json:"gatewayClass"`
+	ControllerName string             `json:"controllerName,omitempty"`
+	Features       []string           `json:"features"`
+	Unsupported    []string           `json:"unsupported,omitempty"`
+	Policies       []PolicyCapability `json:"policies,omitempty"`
+	CRDs           []string           `json:"crds"`
+}
+
+// routeFeatures are the features implementations differ on, in display order
+var routeFeatures = []string{FeatureGRPCRoute, FeatureTLSRoute, FeatureTCPRoute, FeatureUDPRoute, FeatureBackendTLSPolicy, FeatureBackendLBPolicy, FeatureRegexPath}
+
+// featureCRDs are the Gateway API CRDs a feature needs beyond the standard ones
+var featureCRDs = map[string]string{
+	FeatureGRPCRoute:        "grpcroutes.gateway.networking.k8s.io",
+	FeatureTLSRoute:         "tlsroutes.gateway.networking.k8s.io",
+	FeatureTCPRoute:         "tcproutes.gateway.networking.k8s.io",
+	FeatureUDPRoute:         "udproutes.gateway.networking.k8s.io",
+	FeatureBackendTLSPolicy: "backendtlspolicies.gateway.networking.k8s.io",
+	FeatureBackendLBPolicy:  "backendlbpolicies.gateway.networking.k8s.io",
+}
+
+// standardCRDs are the Gateway API CRDs every conversion needs
+var standardCRDs = []string{
+	"gatewayclasses.gateway.networking.k8s.io",
+	"gateways.gateway.networking.k8s.io",
+	"httproutes.gateway.networking.k8s.io",
+	"referencegrants.gateway.networking.k8s.io",
+}
+
+// Implementations returns the capabilities of every target, in Targets order
+func Implementations() []Capabilities {
+	caps := make([]Capabilities, 0, len(Targets))
+	for _, target := range Targets {
+		p := profiles[target]
+		c := Capabilities{
+			Name:           target,
+			GatewayClass:   p.GatewayClass,
+			ControllerName: p.ControllerName,
+			Unsupported:    p.Unsupported,
+			Policies:       p.Policies,
+			CRDs:           append([]string(nil), standardCRDs...),
+		}
+
+		var extra []string
+		for _, feature := range routeFeatures {
+			if !p.Supports(feature) {
+				continue
+			}
+			c.Features = append(c.Features, feature)
+			if crd, ok := featureCRDs[feature]; ok {
+				extra = append(extra, crd)
+			}
+		}
+		for _, policy := range p.Policies {
+			extra = append(extra, policy.CRD)
+		}
+		sort.Strings(extra)
+		c.CRDs = append(c.CRDs, extra...)
+		caps = append(caps, c)
+	}
+	return caps
+}
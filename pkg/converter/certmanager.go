@@ -0,0 +1,227 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// cert-manager annotations read by its ingress-shim and gateway-shim
+const (
+	certManagerPrefix             = "cert-manager.io/"
+	certManagerIssuer             = "cert-manager.io/issuer"
+	certManagerClusterIssuer      = "cert-manager.io/cluster-issuer"
+	certManagerIssuerKind         = "cert-manager.io/issuer-kind"
+	certManagerIssuerGroup        = "cert-manager.io/issuer-group"
+	certManagerCommonName         = "cert-manager.io/common-name"
+	certManagerDuration           = "cert-manager.io/duration"
+	certManagerRenewBefore        = "cert-manager.io/renew-before"
+	certManagerUsages             = "cert-manager.io/usages"
+	certManagerKeyAlgorithm       = "cert-manager.io/private-key-algorithm"
+	certManagerKeyEncoding        = "cert-manager.io/private-key-encoding"
+	certManagerKeySize            = "cert-manager.io/private-key-size"
+	certManagerKeyRotation        = "cert-manager.io/private-key-rotation-policy"
+	certManagerHTTP01IngressClass = "acme.cert-manager.io/http01-ingress-class"
+	certManagerHTTP01EditInPlace  = "acme.cert-manager.io/http01-edit-in-place"
+)
+
+// cert-manager handling modes
+const (
+	CertManagerGateway     = "gateway"     // annotations carried to generated Gateways (default)
+	CertManagerCertificate = "certificate" // a Certificate per spec.tls secret
+	CertManagerNone        = "none"        // report only
+)
+
+// CertManagerModes lists the accepted cert-manager modes
+var CertManagerModes = []string{CertManagerGateway, CertManagerCertificate, CertManagerNone}
+
+// certManagerMode returns the cert-manager mode, gateway by default
+func (c *Converter) certManagerMode() string {
+	if c.opts.CertManager == "" {
+		return CertManagerGateway
+	}
+	return c.opts.CertManager
+}
+
+// certManagerIssuerRef returns the issuerRef cert-manager's ingress-shim
+// builds from the annotations of ing, nil when it names no issuer
+func certManagerIssuerRef(ing *networkingv1.Ingress) map[string]interface{} {
+	if name := strings.TrimSpace(ing.Annotations[certManagerClusterIssuer]); name != "" {
+		return map[string]interface{}{"name": name, "kind": "ClusterIssuer", "group": "cert-manager.io"}
+	}
+	name := strings.TrimSpace(ing.Annotations[certManagerIssuer])
+	if name == "" {
+		return nil
+	}
+	ref := map[string]interface{}{"name": name, "kind": "Issuer", "group": "cert-manager.io"}
+	if kind := strings.TrimSpace(ing.Annotations[certManagerIssuerKind]); kind != "" {
+		ref["kind"] = kind
+	}
+	if group := strings.TrimSpace(ing.Annotations[certManagerIssuerGroup]); group != "" {
+		ref["group"] = group
+	}
+	return ref
+}
+
+// checkCertManager reports the cert-manager settings of ing that do not
+// survive the migration as they are
+func (c *Converter) checkCertManager(ing *networkingv1.Ingress) {
+	if certManagerIssuerRef(ing) == nil || len(ing.Spec.TLS) == 0 {
+		return
+	}
+	for _, annotation := range []string{certManagerHTTP01IngressClass, certManagerHTTP01EditInPlace} {
+		if _, ok := ing.Annotations[annotation]; ok {
+			c.addDiagnostic(ing, annotation, SeverityWarning,
+				"HTTP-01 solver settings apply to Ingresses only; configure the issuer's http01.gatewayHTTPRoute solver with parentRefs to the Gateway")
+		}
+	}
+	gateway := c.gatewayRef(ing)
+	switch c.certManagerMode() {
+	case CertManagerNone:
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"cert-manager issues the certificates of this Ingress; copy the cert-manager.io annotations to Gateway %s/%s or create Certificates for its TLS secrets",
+			gateway.Namespace, gateway.Name)
+	case CertManagerGateway:
+		// Generated Gateways carry the annotations, see addCertManagerAnnotations
+		if !c.opts.EmitGateway {
+			c.addDiagnostic(ing, "", SeverityWarning,
+				"cert-manager issues the certificates of this Ingress; unless its Gateway is generated with --emit-gateway, copy the cert-manager.io annotations to Gateway %s/%s or use --cert-manager=certificate",
+				gateway.Namespace, gateway.Name)
+		}
+	}
+}
+
+// addCertManagerAnnotations carries the cert-manager.io annotations of ing
+// to the Gateway, whose listener certificates cert-manager's gateway-shim
+// then issues. The shim only handles listeners with a hostname and
+// certificates in the Gateway namespace.
+func (c *Converter) addCertManagerAnnotations(b *gatewayBuilder, ing *networkingv1.Ingress) {
+	if c.certManagerMode() != CertManagerGateway || certManagerIssuerRef(ing) == nil ||
+		len(ing.Spec.TLS) == 0 || isPassthrough(ing) {
+		return
+	}
+
+	gw := b.gateway
+	c.addGatewayAnnotations(b, ing, annotationsWithPrefix(ing, certManagerPrefix),
+		"give the Ingresses separate Gateways or issue the certificate with a Certificate")
+
+	for _, tls := range ing.Spec.TLS {
+		switch {
+		case tls.SecretName == "":
+		case len(tls.Hosts) == 0:
+			c.addDiagnostic(ing, "", SeverityWarning,
+				"cert-manager does not issue %s for a listener without hostname; add hosts to the spec.tls entry", tls.SecretName)
+		case ing.Namespace != gw.Namespace:
+			c.addDiagnostic(ing, "", SeverityWarning,
+				"cert-manager only issues certificates in the Gateway namespace %s; create a Certificate for %s/%s instead",
+				gw.Namespace, ing.Namespace, tls.SecretName)
+		}
+	}
+}
+
+// extractCertificates builds a cert-manager Certificate for each spec.tls
+// secret of ing, as ingress-shim would, so issuance no longer depends on
+// the Ingress. A secret shared by several Ingresses gets one Certificate.
+func (c *Converter) extractCertificates(ing *networkingv1.Ingress) []interface{} {
+	if c.certManagerMode() != CertManagerCertificate {
+		return nil
+	}
+	if certManagerIssuerRef(ing) == nil {
+		return nil
+	}
+
+	var certificates []interface{}
+	for _, tls := range ing.Spec.TLS {
+		if tls.SecretName == "" {
+			continue
+		}
+		if len(tls.Hosts) == 0 {
+			c.addDiagnostic(ing, "", SeverityWarning,
+				"spec.tls entry for %s has no hosts to issue a certificate for; create its Certificate manually", tls.SecretName)
+			continue
+		}
+		key := ing.Namespace + "/" + tls.SecretName
+		if c.certificates[key] {
+			continue
+		}
+		if c.certificates == nil {
+			c.certificates = make(map[string]bool)
+		}
+		c.certificates[key] = true
+
+		spec := certificateSpec(ing)
+		spec["secretName"] = tls.SecretName
+		spec["issuerRef"] = certManagerIssuerRef(ing)
+		dnsNames := make([]interface{}, 0, len(tls.Hosts))
+		for _, host := range tls.Hosts {
+			dnsNames = append(dnsNames, host)
+		}
+		spec["dnsNames"] = dnsNames
+		certificates = append(certificates, newPolicy("cert-manager.io/v1", "Certificate", tls.SecretName, ing.Namespace, spec))
+	}
+
+	if len(certificates) > 0 {
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"ingress-shim owns a Certificate of the same name, deleted with the Ingress; remove the cert-manager.io annotations from the Ingress and delete that Certificate before applying the generated one")
+	}
+	return certificates
+}
+
+// certificateSpec converts the Certificate settings ingress-shim reads from
+// annotations
+func certificateSpec(ing *networkingv1.Ingress) map[string]interface{} {
+	spec := make(map[string]interface{})
+	for annotation, field := range map[string]string{
+		certManagerCommonName:  "commonName",
+		certManagerDuration:    "duration",
+		certManagerRenewBefore: "renewBefore",
+	} {
+		if value := strings.TrimSpace(ing.Annotations[annotation]); value != "" {
+			spec[field] = value
+		}
+	}
+	if usages := splitList(ing.Annotations[certManagerUsages]); len(usages) > 0 {
+		list := make([]interface{}, 0, len(usages))
+		for _, usage := range usages {
+			list = append(list, usage)
+		}
+		spec["usages"] = list
+	}
+
+	privateKey := make(map[string]interface{})
+	for annotation, field := range map[string]string{
+		certManagerKeyAlgorithm: "algorithm",
+		certManagerKeyEncoding:  "encoding",
+		certManagerKeyRotation:  "rotationPolicy",
+	} {
+		if value := strings.TrimSpace(ing.Annotations[annotation]); value != "" {
+			privateKey[field] = value
+		}
+	}
+	if size := strings.TrimSpace(ing.Annotations[certManagerKeySize]); size != "" {
+		if n, err := strconv.Atoi(size); err == nil {
+			privateKey["size"] = n
+		}
+	}
+	if len(privateKey) > 0 {
+		spec["privateKey"] = privateKey
+	}
+	return spec
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+
+	"github.com/mayens/ingress-to-gateway/pkg/experimental"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// Gateway API versions of the generated Gateways and HTTPRoutes
+const (
+	APIVersionV1      = "v1" // default
+	APIVersionV1beta1 = "v1beta1"
+)
+
+// APIVersions lists the accepted Gateway API versions
+var APIVersions = []string{APIVersionV1, APIVersionV1beta1}
+
+// Gateway API release channels of the installed CRDs
+const (
+	ChannelExperimental = "experimental" // every resource and field the conversion needs (default)
+	ChannelStandard     = "standard"     // standard-channel resources and fields only
+)
+
+// Channels lists the accepted release channels
+var Channels = []string{ChannelExperimental, ChannelStandard}
+
+// Experimental-channel fields of standard resources
+const (
+	FeatureHTTPRouteTimeouts = experimental.HTTPRouteTimeouts
+	FeatureParentRefPort     = experimental.ParentRefPort
+	// FeatureHTTPRouteRuleNames was added in Gateway API v1.2
+	FeatureHTTPRouteRuleNames = experimental.HTTPRouteRuleNames
+)
+
+// inChannel reports whether the CRDs of the configured channel define
+// feature and the experimental gate lets it through. The resources and
+// fields of experimental.Features only exist in the experimental-channel
+// CRDs.
+func (c *Converter) inChannel(feature string) bool {
+	if c.opts.Channel == ChannelStandard && experimental.IsExperimental(feature) {
+		return false
+	}
+	return c.opts.Experimental.Enabled(feature)
+}
+
+// channelGap explains why inChannel leaves feature out
+func (c *Converter) channelGap(feature string) string {
+	if c.opts.Channel != ChannelStandard {
+		return experimental.Disabled(feature)
+	}
+	return fmt.Sprintf("%s is only in the experimental channel; install its CRDs and use the experimental channel", feature)
+}
+
+// gatewayAPIVersion returns the apiVersion of generated Gateways and HTTPRoutes
+func (c *Converter) gatewayAPIVersion() string {
+	version := c.opts.APIVersion
+	if version == "" {
+		version = APIVersionV1
+	}
+	return gatewayv1.GroupName + "/" + version
+}
+
+// setAPIVersion moves the generated Gateways and HTTPRoutes to the
+// configured API version. v1beta1 serves the same schema in Gateway API v1.0.
+func (c *Converter) setAPIVersion(resources []interface{}) {
+	apiVersion := c.gatewayAPIVersion()
+	for _, res := range resources {
+		switch r := res.(type) {
+		case *gatewayv1.GatewayClass:
+			r.APIVersion = apiVersion
+		case *gatewayv1.Gateway:
+			r.APIVersion = apiVersion
+		case *gatewayv1.HTTPRoute:
+			r.APIVersion = apiVersion
+		}
+	}
+}
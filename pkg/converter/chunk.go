@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// chunkSeparator is written between documents of the same file
+const chunkSeparator = "---\n"
+
+// ChunkLimits bounds the size of each output file
+type ChunkLimits struct {
+	MaxBytes int // maximum file size in bytes, 0 for no limit
+	MaxDocs  int // maximum documents per file, 0 for no limit
+}
+
+// Enabled reports whether any limit is set
+func (l ChunkLimits) Enabled() bool {
+	return l.MaxBytes > 0 || l.MaxDocs > 0
+}
+
+// ChunkOutput groups resources into chunks that stay within the limits,
+// keeping their order so repeated runs produce the same files. A document
+// larger than MaxBytes is placed in a chunk of its own.
+func (c *Converter) ChunkOutput(resources []interface{}, limits ChunkLimits) ([][]interface{}, error) {
+	if c.opts.OutputFormat == FormatHelm {
+		return nil, fmt.Errorf("chunked output is not supported for helm templates")
+	}
+
+	var chunks [][]interface{}
+	var current []interface{}
+	size := 0
+
+	for _, res := range resources {
+		var buf bytes.Buffer
+		if err := c.WriteOutput([]interface{}{res}, &buf); err != nil {
+			return nil, err
+		}
+		docSize := buf.Len()
+		if len(current) > 0 {
+			docSize += len(chunkSeparator)
+		}
+
+		full := limits.MaxDocs > 0 && len(current) >= limits.MaxDocs
+		tooBig := limits.MaxBytes > 0 && len(current) > 0 && size+docSize > limits.MaxBytes
+		if full || tooBig {
+			chunks = append(chunks, current)
+			current = nil
+			size = 0
+			docSize = buf.Len()
+		}
+
+		current = append(current, res)
+		size += docSize
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+
+	return chunks, nil
+}
+
+// WriteChunks writes resources to numbered files derived from path, e.g.
+// routes.yaml becomes routes-001.yaml, routes-002.yaml, and returns the
+// files written
+func (c *Converter) WriteChunks(resources []interface{}, path string, limits ChunkLimits) ([]string, error) {
+	chunks, err := c.ChunkOutput(resources, limits)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+
+	var files []string
+	for i, chunk := range chunks {
+		name := fmt.Sprintf("%s-%03d%s", base, i+1, ext)
+		f, err := os.Create(name)
+		if err != nil {
+			return files, fmt.Errorf("failed to create output file: %w", err)
+		}
+		if err := c.WriteOutput(chunk, f); err != nil {
+			f.Close()
+			return files, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		if err := f.Close(); err != nil {
+			return files, fmt.Errorf("failed to close %s: %w", name, err)
+		}
+		files = append(files, name)
+	}
+
+	return files, nil
+}
+
+// WriteCombined writes resources to the single file path, or to numbered
+// files as WriteChunks does when limits are set, and returns the files
+// written
+func (c *Converter) WriteCombined(resources []interface{}, path string, limits ChunkLimits) ([]string, error) {
+	if limits.Enabled() {
+		return c.WriteChunks(resources, path, limits)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := c.WriteOutput(resources, f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close %s: %w", path, err)
+	}
+	return []string{path}, nil
+}
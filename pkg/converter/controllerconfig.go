@@ -0,0 +1,407 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// DefaultControllerConfig is the controller ConfigMap of a default
+// ingress-nginx installation
+const DefaultControllerConfig = "ingress-nginx/ingress-nginx-controller"
+
+// ControllerSetting reports what happens to one global setting of the
+// ingress-nginx controller ConfigMap, with the statuses of AnnotationResult
+type ControllerSetting struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Status      string `json:"status"`                // converted, partial, skipped
+	ConvertedTo string `json:"convertedTo,omitempty"` // the policy produced
+	Reason      string `json:"reason,omitempty"`      // why the setting is partial or skipped
+}
+
+// gatewayPolicies collects the specs of the policies targeting the Gateway
+// that controller settings convert to, by kind
+type gatewayPolicies map[string]map[string]interface{}
+
+// set sets a field of the spec of policy kind
+func (p gatewayPolicies) set(kind string, value interface{}, fields ...string) {
+	if p[kind] == nil {
+		p[kind] = make(map[string]interface{})
+	}
+	_ = unstructured.SetNestedField(p[kind], value, fields...)
+}
+
+// gatewayPolicyNames are the name suffixes of the policies by kind
+var gatewayPolicyNames = map[string]string{
+	"ClientSettingsPolicy": "client-settings",
+	"ClientTrafficPolicy":  "client-traffic",
+	"BackendTrafficPolicy": "backend-traffic",
+}
+
+// controllerSettingFunc converts a setting for the configured target into
+// policies, returning the policy kind and, when the conversion is partial,
+// the reason. An empty kind with a reason skips the setting.
+type controllerSettingFunc func(c *Converter, p gatewayPolicies, value string) (kind, reason string)
+
+// controllerSettings converts the ConfigMap settings that have a
+// Gateway-level equivalent
+var controllerSettings = map[string]controllerSettingFunc{
+	"proxy-body-size":       settingBodySize,
+	"client-body-timeout":   settingClientBodyTimeout,
+	"keep-alive":            settingKeepAlive,
+	"keep-alive-requests":   settingKeepAliveRequests,
+	"client-header-timeout": settingClientHeaderTimeout,
+	"ssl-protocols":         settingSSLProtocols,
+	"ssl-ciphers":           settingSSLCiphers,
+	"ssl-ecdh-curve":        settingECDHCurve,
+	"use-forwarded-headers": settingForwardedHeaders,
+	"use-proxy-protocol":    settingProxyProtocol,
+	"proxy-connect-timeout": settingConnectTimeout,
+	"proxy-read-timeout":    settingReadTimeout,
+}
+
+// controllerSettingGuidance explains how to carry over settings that are
+// not converted
+var controllerSettingGuidance = map[string]string{
+	"hsts":                      "add a ResponseHeaderModifier setting Strict-Transport-Security to the HTTPS routes",
+	"hsts-max-age":              "add a ResponseHeaderModifier setting Strict-Transport-Security to the HTTPS routes",
+	"hsts-include-subdomains":   "add a ResponseHeaderModifier setting Strict-Transport-Security to the HTTPS routes",
+	"hsts-preload":              "add a ResponseHeaderModifier setting Strict-Transport-Security to the HTTPS routes",
+	"ssl-redirect":              "Gateways do not redirect HTTP to HTTPS; attach an HTTPRoute with a RequestRedirect filter to the HTTP listener",
+	"force-ssl-redirect":        "Gateways do not redirect HTTP to HTTPS; attach an HTTPRoute with a RequestRedirect filter to the HTTP listener",
+	"proxy-send-timeout":        "route timeouts cover the whole request; set HTTPRoute timeouts where the send timeout matters",
+	"allow-snippet-annotations": "snippets are raw nginx configuration; review the snippet annotations the converter reports",
+	"server-snippet":            "raw nginx configuration has no Gateway API equivalent",
+	"http-snippet":              "raw nginx configuration has no Gateway API equivalent",
+	"main-snippet":              "raw nginx configuration has no Gateway API equivalent",
+	"location-snippet":          "raw nginx configuration has no Gateway API equivalent",
+	"stream-snippet":            "raw nginx configuration has no Gateway API equivalent",
+	"enable-opentelemetry":      "configure tracing on the Gateway implementation, e.g. the NginxProxy or EnvoyProxy of the GatewayClass",
+	"log-format-upstream":       "configure access logs on the Gateway implementation",
+}
+
+// ControllerSettings reports how the settings of the ingress-nginx
+// controller ConfigMap carry over to target, "" for none, sorted by key
+func ControllerSettings(cm *corev1.ConfigMap, target string) []ControllerSetting {
+	c := NewConverter(Options{}, WithTarget(target))
+	_, settings := c.controllerConfig(cm)
+	return settings
+}
+
+// ConvertControllerConfig converts the global settings of the ingress-nginx
+// controller ConfigMap to policies of the target implementation attached to
+// the Gateway taking over from the controller, gateway-nginx in the
+// ConfigMap namespace by default. Settings left out are reported. It resets
+// Diagnostics like Convert does.
+func (c *Converter) ConvertControllerConfig(ctx context.Context, cm *corev1.ConfigMap) ([]interface{}, error) {
+	run := c.newRun()
+	resources, settings := run.controllerConfig(cm)
+	source := configMapSource(cm)
+	for _, s := range settings {
+		switch s.Status {
+		case AnnotationConverted:
+			run.addDiagnostic(source, s.Key, SeverityInfo, "converted to %s", s.ConvertedTo)
+		case AnnotationPartial:
+			run.addDiagnostic(source, s.Key, SeverityWarning, "converted to %s: %s", s.ConvertedTo, s.Reason)
+		default:
+			// Settings of the nginx process itself, such as worker-processes, are noted only
+			severity := SeverityInfo
+			if _, known := controllerSettings[s.Key]; known || controllerSettingGuidance[s.Key] != "" {
+				severity = SeverityWarning
+			}
+			run.addDiagnostic(source, s.Key, severity, "not converted: %s", s.Reason)
+		}
+	}
+	c.publish(run)
+	return resources, nil
+}
+
+// controllerConfig converts the settings of cm into policies
+func (c *Converter) controllerConfig(cm *corev1.ConfigMap) ([]interface{}, []ControllerSetting) {
+	keys := make([]string, 0, len(cm.Data))
+	for key := range cm.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	policies := make(gatewayPolicies)
+	settings := make([]ControllerSetting, 0, len(keys))
+	for _, key := range keys {
+		s := ControllerSetting{Key: key, Value: cm.Data[key]}
+		convert, ok := controllerSettings[key]
+		switch {
+		case ok:
+			kind, reason := convert(c, policies, strings.TrimSpace(s.Value))
+			switch {
+			case kind == "":
+				s.Status, s.Reason = AnnotationSkipped, reason
+			case reason != "":
+				s.Status, s.ConvertedTo, s.Reason = AnnotationPartial, kind, reason
+			default:
+				s.Status, s.ConvertedTo = AnnotationConverted, kind
+			}
+		case controllerSettingGuidance[key] != "":
+			s.Status, s.Reason = AnnotationSkipped, controllerSettingGuidance[key]
+		default:
+			s.Status, s.Reason = AnnotationSkipped, "no Gateway-level equivalent is converted; configure the implementation directly if it matters"
+		}
+		settings = append(settings, s)
+	}
+
+	gateway := c.controllerGateway(cm)
+	targetRef := map[string]interface{}{
+		"group": gatewayv1.GroupName,
+		"kind":  "Gateway",
+		"name":  gateway.Name,
+	}
+	kinds := make([]string, 0, len(policies))
+	for kind := range policies {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	var resources []interface{}
+	for _, kind := range kinds {
+		spec := policies[kind]
+		apiVersion := "gateway.envoyproxy.io/v1alpha1"
+		if kind == "ClientSettingsPolicy" {
+			// ClientSettingsPolicy accepts a single targetRef
+			apiVersion = "gateway.nginx.org/v1alpha1"
+			spec["targetRef"] = targetRef
+		} else {
+			spec["targetRefs"] = []interface{}{targetRef}
+		}
+		resources = append(resources, newPolicy(apiVersion, kind,
+			sanitizeName(fmt.Sprintf("%s-%s", gateway.Name, gatewayPolicyNames[kind])), gateway.Namespace, spec))
+	}
+	return resources, settings
+}
+
+// noPolicy is the reason of settings without a policy for the target
+func (c *Converter) noPolicy(what string) (string, string) {
+	if c.opts.Target == "" {
+		return "", fmt.Sprintf("%s needs an implementation policy; select a --target", what)
+	}
+	return "", fmt.Sprintf("no policy of target %q converts %s; configure it on the implementation", c.opts.Target, what)
+}
+
+// nginxDuration parses an nginx time setting: plain seconds or a duration
+// such as 75s or 1m
+func nginxDuration(value string) (gatewayv1.Duration, bool) {
+	var d time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		d = time.Duration(seconds) * time.Second
+	} else if parsed, err := time.ParseDuration(value); err == nil {
+		d = parsed
+	} else {
+		return "", false
+	}
+	if d < time.Millisecond {
+		return "", false
+	}
+	return formatDuration(d), true
+}
+
+func settingBodySize(c *Converter, p gatewayPolicies, value string) (string, string) {
+	if !nginxSizeRegex.MatchString(value) {
+		return "", fmt.Sprintf("invalid body size %q", value)
+	}
+	if c.opts.Target != TargetNginxGatewayFabric {
+		return c.noPolicy("the request body size limit")
+	}
+	p.set("ClientSettingsPolicy", strings.ToLower(value), "body", "maxSize")
+	return "ClientSettingsPolicy", ""
+}
+
+func settingClientBodyTimeout(c *Converter, p gatewayPolicies, value string) (string, string) {
+	timeout, ok := nginxDuration(value)
+	if !ok {
+		return "", fmt.Sprintf("invalid timeout %q", value)
+	}
+	if c.opts.Target != TargetNginxGatewayFabric {
+		return c.noPolicy("the client body timeout")
+	}
+	p.set("ClientSettingsPolicy", string(timeout), "body", "timeout")
+	return "ClientSettingsPolicy", ""
+}
+
+func settingKeepAlive(c *Converter, p gatewayPolicies, value string) (string, string) {
+	timeout, ok := nginxDuration(value)
+	if !ok {
+		return "", fmt.Sprintf("invalid timeout %q", value)
+	}
+	switch c.opts.Target {
+	case TargetNginxGatewayFabric:
+		p.set("ClientSettingsPolicy", string(timeout), "keepAlive", "timeout", "server")
+		return "ClientSettingsPolicy", ""
+	case TargetEnvoyGateway:
+		p.set("ClientTrafficPolicy", string(timeout), "timeout", "http", "idleTimeout")
+		return "ClientTrafficPolicy", ""
+	}
+	return c.noPolicy("the client keep-alive timeout")
+}
+
+func settingKeepAliveRequests(c *Converter, p gatewayPolicies, value string) (string, string) {
+	requests, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || requests < 0 {
+		return "", fmt.Sprintf("invalid request count %q", value)
+	}
+	if c.opts.Target != TargetNginxGatewayFabric {
+		return c.noPolicy("the keep-alive request limit")
+	}
+	p.set("ClientSettingsPolicy", requests, "keepAlive", "requests")
+	return "ClientSettingsPolicy", ""
+}
+
+func settingClientHeaderTimeout(c *Converter, p gatewayPolicies, value string) (string, string) {
+	timeout, ok := nginxDuration(value)
+	if !ok {
+		return "", fmt.Sprintf("invalid timeout %q", value)
+	}
+	if c.opts.Target != TargetEnvoyGateway {
+		return c.noPolicy("the client header timeout")
+	}
+	p.set("ClientTrafficPolicy", string(timeout), "timeout", "http", "requestReceivedTimeout")
+	return "ClientTrafficPolicy", "Envoy times out receiving the whole request, not only its headers"
+}
+
+// tlsVersions maps nginx ssl_protocols to Envoy Gateway TLS versions
+var tlsVersions = map[string]string{
+	"TLSv1":   "1.0",
+	"TLSv1.1": "1.1",
+	"TLSv1.2": "1.2",
+	"TLSv1.3": "1.3",
+}
+
+func settingSSLProtocols(c *Converter, p gatewayPolicies, value string) (string, string) {
+	var versions []string
+	for _, protocol := range strings.Fields(value) {
+		version, ok := tlsVersions[protocol]
+		if !ok {
+			return "", fmt.Sprintf("unknown protocol %q", protocol)
+		}
+		versions = append(versions, version)
+	}
+	if len(versions) == 0 {
+		return "", "no protocols listed"
+	}
+	if c.opts.Target != TargetEnvoyGateway {
+		return c.noPolicy("the TLS protocol versions")
+	}
+	sort.Strings(versions)
+	p.set("ClientTrafficPolicy", versions[0], "tls", "minVersion")
+	p.set("ClientTrafficPolicy", versions[len(versions)-1], "tls", "maxVersion")
+	if len(versions) != int(versions[len(versions)-1][2]-versions[0][2])+1 {
+		return "ClientTrafficPolicy", "the protocols are not contiguous; every version between minVersion and maxVersion is enabled"
+	}
+	return "ClientTrafficPolicy", ""
+}
+
+func settingSSLCiphers(c *Converter, p gatewayPolicies, value string) (string, string) {
+	if c.opts.Target != TargetEnvoyGateway {
+		return c.noPolicy("the TLS ciphers")
+	}
+	var ciphers []interface{}
+	for _, cipher := range strings.Split(value, ":") {
+		if cipher = strings.TrimSpace(cipher); cipher != "" {
+			ciphers = append(ciphers, cipher)
+		}
+	}
+	p.set("ClientTrafficPolicy", ciphers, "tls", "ciphers")
+	return "ClientTrafficPolicy", "Envoy uses BoringSSL cipher names, which differ from some OpenSSL ones; verify each cipher"
+}
+
+func settingECDHCurve(c *Converter, p gatewayPolicies, value string) (string, string) {
+	if c.opts.Target != TargetEnvoyGateway {
+		return c.noPolicy("the ECDH curves")
+	}
+	if strings.EqualFold(value, "auto") {
+		return "ClientTrafficPolicy", "auto keeps the Envoy default curves"
+	}
+	var curves []interface{}
+	for _, curve := range strings.Split(value, ":") {
+		if curve = strings.TrimSpace(curve); curve != "" {
+			curves = append(curves, curve)
+		}
+	}
+	p.set("ClientTrafficPolicy", curves, "tls", "ecdhCurves")
+	return "ClientTrafficPolicy", ""
+}
+
+func settingForwardedHeaders(c *Converter, p gatewayPolicies, value string) (string, string) {
+	on, err := strconv.ParseBool(value)
+	if err != nil {
+		return "", fmt.Sprintf("invalid value %q, want true or false", value)
+	}
+	if !on {
+		return "implementation default", ""
+	}
+	if c.opts.Target != TargetEnvoyGateway {
+		return c.noPolicy("trusting X-Forwarded-For")
+	}
+	p.set("ClientTrafficPolicy", int64(1), "clientIPDetection", "xForwardedFor", "numTrustedHops")
+	return "ClientTrafficPolicy", "one proxy in front of the Gateway is trusted; raise numTrustedHops for more, proxy-real-ip-cidr is not converted"
+}
+
+func settingProxyProtocol(c *Converter, p gatewayPolicies, value string) (string, string) {
+	on, err := strconv.ParseBool(value)
+	if err != nil {
+		return "", fmt.Sprintf("invalid value %q, want true or false", value)
+	}
+	if !on {
+		return "implementation default", ""
+	}
+	if c.opts.Target != TargetEnvoyGateway {
+		return c.noPolicy("the PROXY protocol")
+	}
+	p.set("ClientTrafficPolicy", true, "enableProxyProtocol")
+	return "ClientTrafficPolicy", ""
+}
+
+func settingConnectTimeout(c *Converter, p gatewayPolicies, value string) (string, string) {
+	timeout, ok := nginxDuration(value)
+	if !ok {
+		return "", fmt.Sprintf("invalid timeout %q", value)
+	}
+	if c.opts.Target != TargetEnvoyGateway {
+		return c.noPolicy("the default connect timeout")
+	}
+	p.set("BackendTrafficPolicy", string(timeout), "timeout", "tcp", "connectTimeout")
+	return "BackendTrafficPolicy", ""
+}
+
+func settingReadTimeout(c *Converter, p gatewayPolicies, value string) (string, string) {
+	timeout, ok := nginxDuration(value)
+	if !ok {
+		return "", fmt.Sprintf("invalid timeout %q", value)
+	}
+	if c.opts.Target != TargetEnvoyGateway {
+		return c.noPolicy("the default read timeout")
+	}
+	p.set("BackendTrafficPolicy", string(timeout), "timeout", "http", "requestTimeout")
+	return "BackendTrafficPolicy", "the request timeout covers the whole response, not the time between two reads; HTTPRoute timeouts take precedence"
+}
@@ -18,148 +18,595 @@ package converter
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"regexp"
-	"strconv"
+	"sort"
 	"strings"
+	"sync"
+	"text/template"
 
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/yaml"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
-// Options contains converter configuration
-type Options struct {
-	SplitMode    string // single, per-host, per-pattern
-	GatewayName  string
-	GatewayClass string
-	OutputFormat string // yaml, json
-}
+// Output formats
+const (
+	FormatYAML      = "yaml"
+	FormatJSON      = "json"   // a single object, or a v1 List of several
+	FormatNDJSON    = "ndjson" // one object per line
+	FormatList      = "list"   // a v1 List as JSON, even of one object
+	FormatHelm      = "helm"
+	FormatHelmChart = "helm-chart" // a chart directory, see WriteHelmChart
+)
+
+// OutputFormats lists the accepted output formats
+var OutputFormats = []string{FormatYAML, FormatJSON, FormatNDJSON, FormatList, FormatHelm, FormatHelmChart}
 
-// Converter handles Ingress to HTTPRoute conversion
+// Converter handles Ingress to HTTPRoute conversion. It is safe for
+// concurrent use: every Convert call works on its own copy of the
+// conversion state, and Diagnostics, Fidelity and SkippedFiles report the
+// call that finished last.
 type Converter struct {
 	opts Options
+
+	// mu guards the results published by the last call and services
+	mu           sync.Mutex
+	diagnostics  []Diagnostic
+	fidelity     []Fidelity
+	skippedFiles []string
+
+	// nameTemplate is NameTemplate, parsed on first use
+	nameTemplate *template.Template
+
+	// services resolves backend Services; fetchedServices caches them by
+	// namespace/name, appProtocols their port protocols and backendTLS the
+	// Services given a BackendTLSPolicy
+	services        ServiceLookup
+	fetchedServices map[string]*corev1.Service
+	appProtocols    map[string]string
+	portNumbers     map[string]int32
+	backendTLS      map[string]bool
+
+	// referenced indexes resources converted ones reference, such as
+	// Traefik Middlewares; see AddReferenced
+	referenced map[string]*unstructured.Unstructured
+
+	// translatedPolicies are the policies translators added for the
+	// Ingress being converted
+	translatedPolicies []*unstructured.Unstructured
+
+	// hooked holds the annotations the registry hook handled, see hookedKey
+	hooked map[string]bool
+
+	// certificates holds the namespace/secret of the cert-manager
+	// Certificates generated so far
+	certificates map[string]bool
 }
 
-// NewConverter creates a new Converter
-func NewConverter(opts Options) *Converter {
+// NewConverter creates a new Converter from opts with options applied in order
+func NewConverter(opts Options, options ...Option) *Converter {
+	for _, option := range options {
+		option(&opts)
+	}
 	return &Converter{
 		opts: opts,
 	}
 }
 
-// LoadFromFile loads Ingress resources from a file
+// LoadFromFile loads Ingress resources from a file. The file may hold
+// several YAML documents and List resources, such as the output of
+// kubectl get ingress -A -o yaml; other kinds are skipped.
 func (c *Converter) LoadFromFile(path string) ([]interface{}, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
+	return loadIngresses(data, path)
+}
+
+// LoadFromReader loads Ingress resources from r, e.g. stdin, accepting the
+// same input as LoadFromFile
+func (c *Converter) LoadFromReader(r io.Reader) ([]interface{}, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	return loadIngresses(data, "input")
+}
+
+// loadIngresses parses data read from source
+func loadIngresses(data []byte, source string) ([]interface{}, error) {
+	parsed, err := parseManifests(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("no Ingress resources found in %s", source)
+	}
+	return parsed, nil
+}
+
+// parseIngresses parses the Ingress resources out of a multi-document YAML
+// stream, skipping documents of any other kind
+func parseIngresses(data []byte) ([]*networkingv1.Ingress, error) {
+	parsed, err := parseManifests(data)
+	if err != nil {
+		return nil, err
+	}
+	var ingresses []*networkingv1.Ingress
+	for _, obj := range parsed {
+		if ing, ok := obj.(*networkingv1.Ingress); ok {
+			ingresses = append(ingresses, ing)
+		}
+	}
+	return ingresses, nil
+}
+
+// parseManifests parses the Ingresses and the Traefik IngressRoutes and
+// Middlewares out of a multi-document YAML stream, skipping documents of
+// any other kind. Traefik resources are returned as Unstructured.
+func parseManifests(data []byte) ([]interface{}, error) {
+	var objs []interface{}
+
+	docs := regexp.MustCompile(`(?m)^---\s*$`).Split(string(data), -1)
+	for i, doc := range docs {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+
+		found, err := parseObject([]byte(doc), "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal document %d: %w", i+1, err)
+		}
+		objs = append(objs, found...)
+	}
+
+	return objs, nil
+}
+
+// parseObject returns the resources in a single object, descending into
+// List and IngressList items. defaultKind applies to items without a kind.
+func parseObject(data []byte, defaultKind string) ([]interface{}, error) {
+	var meta metav1.TypeMeta
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+	kind := meta.Kind
+	if kind == "" {
+		kind = defaultKind
+	}
 
-	var ingress networkingv1.Ingress
-	if err := yaml.Unmarshal(data, &ingress); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal ingress: %w", err)
+	switch kind {
+	case "Ingress":
+		var ingress networkingv1.Ingress
+		if err := yaml.Unmarshal(data, &ingress); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ingress: %w", err)
+		}
+		return []interface{}{&ingress}, nil
+
+	case "List", "IngressList":
+		var list struct {
+			Items []json.RawMessage `json:"items"`
+		}
+		if err := yaml.Unmarshal(data, &list); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s: %w", kind, err)
+		}
+
+		itemKind := ""
+		if kind == "IngressList" {
+			itemKind = "Ingress"
+		}
+		var objs []interface{}
+		for i, item := range list.Items {
+			found, err := parseObject(item, itemKind)
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %w", i+1, err)
+			}
+			objs = append(objs, found...)
+		}
+		return objs, nil
 	}
 
-	return []interface{}{&ingress}, nil
+	if isTraefikKind(meta.APIVersion, kind) || isIstioKind(meta.APIVersion, kind) || isGKEKind(meta.APIVersion, kind) ||
+		isKongKind(meta.APIVersion, kind) || isNginxIncKind(meta.APIVersion, kind) || kind == kindService || kind == kindIngressClass {
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(data, &obj.Object); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s: %w", kind, err)
+		}
+		// Services only matter for the BackendConfigs they select
+		if kind == kindService && !hasBackendConfig(obj.GetAnnotations()) {
+			return nil, nil
+		}
+		return []interface{}{obj}, nil
+	}
+
+	return nil, nil
 }
 
-// Convert converts Ingress resources to HTTPRoutes
+// Convert converts Ingress resources to HTTPRoutes. Traefik IngressRoutes,
+// Istio VirtualServices and NGINX VirtualServers are converted as well,
+// with the Middlewares, DestinationRules and VirtualServerRoutes among
+// ingresses or added by AddReferenced.
 func (c *Converter) Convert(ctx context.Context, ingresses []interface{}) ([]interface{}, error) {
+	c.AddReferenced(ingresses)
+	run := c.newRun()
+	httpRoutes, err := run.convert(ctx, ingresses)
+	c.publish(run)
+	return httpRoutes, err
+}
+
+// newRun returns a converter with the options and Service lookup of c and
+// empty conversion state, so concurrent calls share nothing they write
+func (c *Converter) newRun() *Converter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &Converter{opts: c.opts, services: c.services, referenced: c.referenced}
+}
+
+// publish makes the diagnostics and fidelity of a finished run those
+// reported by c
+func (c *Converter) publish(run *Converter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.diagnostics = run.diagnostics
+	c.fidelity = run.fidelity
+}
+
+// convert converts Ingress resources on a converter returned by newRun
+func (c *Converter) convert(ctx context.Context, ingresses []interface{}) ([]interface{}, error) {
 	var httpRoutes []interface{}
+	var converted []*networkingv1.Ingress
+	var merge hostMerge
+	// Whether rules are named; the options decide, and each Ingress reports why not
+	var ruleNames bool
 
 	for _, ing := range ingresses {
+		if obj, ok := ing.(*unstructured.Unstructured); ok {
+			if isReferencedKind(obj) {
+				continue
+			}
+			resources, stand, err := c.convertCustomResource(ctx, obj)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert %s %s: %w", obj.GetKind(), obj.GetName(), err)
+			}
+			converted = append(converted, stand)
+			ruleNames = c.ruleNamesEnabled(stand)
+			httpRoutes = append(httpRoutes, resources...)
+			continue
+		}
 		ingress, ok := ing.(*networkingv1.Ingress)
 		if !ok {
 			return nil, fmt.Errorf("invalid ingress type")
 		}
 
-		routes, err := c.convertIngress(ingress)
+		if c.skipManaged(ingress) {
+			continue
+		}
+		checked, err := c.checkAnnotations(ingress)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert ingress %s: %w", ingress.Name, err)
 		}
+		converted = append(converted, checked)
 
-		httpRoutes = append(httpRoutes, routes...)
+		resources, err := c.convertOne(ctx, checked)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert ingress %s: %w", ingress.Name, err)
+		}
+		c.checkTargetSupport(checked, resources)
+		c.checkExternalDNS(checked)
+		ruleNames = c.ruleNamesEnabled(ingress)
+		c.recordFidelity(ingress, checked)
+		if c.opts.MergeHosts {
+			resources = merge.add(c, checked, resources)
+		}
+		httpRoutes = append(httpRoutes, resources...)
 	}
+	httpRoutes = append(httpRoutes, merge.routes(c)...)
 
+	// Gateways come first so they exist when the routes are applied
+	if c.opts.EmitGateway {
+		httpRoutes = append(c.generateGateways(converted), httpRoutes...)
+	}
+
+	// Cross-namespace references are not accepted without a grant
+	httpRoutes = append(httpRoutes, referenceGrants(httpRoutes)...)
+	c.setAPIVersion(httpRoutes)
+
+	if ruleNames {
+		return nameRules(httpRoutes)
+	}
 	return httpRoutes, nil
 }
 
+// convertCustomResource converts a custom resource of another controller,
+// returning the Ingress standing in for it in Gateway generation
+func (c *Converter) convertCustomResource(ctx context.Context, obj *unstructured.Unstructured) ([]interface{}, *networkingv1.Ingress, error) {
+	ing := customResourceIngress(obj)
+	if ing == nil {
+		return nil, nil, fmt.Errorf("unsupported kind %s", obj.GetAPIVersion()+"/"+obj.GetKind())
+	}
+	if err := c.resolveAppProtocols(ctx, ing); err != nil {
+		return nil, nil, err
+	}
+
+	var routes []interface{}
+	var err error
+	switch obj.GetKind() {
+	case kindVirtualService:
+		routes, err = c.convertVirtualService(obj, ing)
+	case kindVirtualServer:
+		routes, err = c.convertVirtualServer(obj, ing)
+	default:
+		routes, err = c.convertIngressRoute(obj, ing)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	c.checkTargetSupport(ing, routes)
+	return routes, ing, nil
+}
+
+// customResourceIngress returns the Ingress a converted custom resource
+// stands in for, nil for kinds the converter does not convert
+func customResourceIngress(obj *unstructured.Unstructured) *networkingv1.Ingress {
+	switch {
+	case obj.GetKind() == kindIngressRoute && isTraefikKind(obj.GetAPIVersion(), obj.GetKind()):
+		return ingressRouteIngress(obj)
+	case obj.GetKind() == kindVirtualService && isIstioKind(obj.GetAPIVersion(), obj.GetKind()):
+		return virtualServiceIngress(obj)
+	case obj.GetKind() == kindVirtualServer && isNginxIncKind(obj.GetAPIVersion(), obj.GetKind()):
+		return virtualServerIngress(obj)
+	}
+	return nil
+}
+
+// convertOne converts an Ingress to its routes and policies
+func (c *Converter) convertOne(ctx context.Context, ingress *networkingv1.Ingress) ([]interface{}, error) {
+	if isPassthrough(ingress) {
+		if !c.inChannel(FeatureTLSRoute) {
+			c.unsupportedFeature(ingress, annotationSSLPassthrough, FeatureTLSRoute, "ssl-passthrough")
+			return nil, nil
+		}
+		return c.convertPassthrough(ingress), nil
+	}
+
+	if err := c.resolveAppProtocols(ctx, ingress); err != nil {
+		return nil, err
+	}
+	if c.supports(FeatureGRPCRoute) && c.isGRPC(ingress) {
+		if routes, ok := c.convertGRPC(ingress); ok {
+			c.checkTLSHosts(ingress)
+			c.checkCertManager(ingress)
+			return append(routes, c.extractCertificates(ingress)...), nil
+		}
+	}
+
+	routes, err := c.convertIngress(ctx, ingress)
+	if err != nil {
+		return nil, err
+	}
+	c.markManaged(ingress, routes)
+	c.checkTLSHosts(ingress)
+	c.checkTraefikRouter(ingress)
+	c.checkKongRoute(ingress)
+	c.attachKongPlugins(ingress, routes)
+	c.checkGCEIngress(ingress)
+	c.checkCertManager(ingress)
+
+	resources := routes
+	resources = append(resources, c.extractPolicies(ingress, routes)...)
+	resources = append(resources, c.extractSpecDefaultBackend(ingress)...)
+	resources = append(resources, c.extractDefaultBackend(ingress)...)
+	resources = append(resources, c.extractBackendProtocols(ingress)...)
+	resources = append(resources, c.extractCertificates(ingress)...)
+	for _, policy := range c.translatedPolicies {
+		resources = append(resources, policy)
+	}
+	c.translatedPolicies = nil
+	return resources, nil
+}
+
 // convertIngress converts a single Ingress to HTTPRoute(s)
-func (c *Converter) convertIngress(ing *networkingv1.Ingress) ([]interface{}, error) {
+func (c *Converter) convertIngress(ctx context.Context, ing *networkingv1.Ingress) ([]interface{}, error) {
+	// Routes are merged by hostname afterwards
+	if c.opts.MergeHosts {
+		return c.convertPerHost(ctx, ing)
+	}
+
 	switch c.opts.SplitMode {
 	case "single":
-		return c.convertSingle(ing)
+		return c.convertSingle(ctx, ing)
 	case "per-host":
-		return c.convertPerHost(ing)
+		return c.convertPerHost(ctx, ing)
 	case "per-pattern":
-		return c.convertPerPattern(ing)
+		return c.convertPerPattern(ctx, ing)
 	default:
 		return nil, fmt.Errorf("invalid split mode: %s", c.opts.SplitMode)
 	}
 }
 
-// convertSingle creates one HTTPRoute for all hosts
-func (c *Converter) convertSingle(ing *networkingv1.Ingress) ([]interface{}, error) {
-	httpRoute := &gatewayv1.HTTPRoute{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: "gateway.networking.k8s.io/v1",
-			Kind:       "HTTPRoute",
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-httproute", ing.Name),
-			Namespace: ing.Namespace,
-			Labels:    ing.Labels,
-		},
-	}
-
-	// Collect all hostnames
-	var hostnames []gatewayv1.Hostname
-	for _, rule := range ing.Spec.Rules {
-		if rule.Host != "" {
-			hostnames = append(hostnames, gatewayv1.Hostname(rule.Host))
+// convertSingle creates one HTTPRoute for all hosts. Hosts whose paths
+// differ cannot share a route, since rules do not match on hostname, so each
+// distinct set of paths gets its own route. The route of the host set that
+// sorts first keeps the base name and the others are named after a hash of
+// their host set, so names do not change when rules are reordered.
+func (c *Converter) convertSingle(ctx context.Context, ing *networkingv1.Ingress) ([]interface{}, error) {
+	groups := c.groupByPaths(ing, c.ingressRules(ing))
+	first := 0
+	for i, g := range groups {
+		if hostSetKey(g.hostnames) < hostSetKey(groups[first].hostnames) {
+			first = i
 		}
 	}
-	httpRoute.Spec.Hostnames = hostnames
 
 	// Set parent refs (Gateway)
-	gatewayName := c.opts.GatewayName
-	if gatewayName == "" {
-		gatewayName = c.deriveGatewayName(ing)
-	}
-	httpRoute.Spec.ParentRefs = []gatewayv1.ParentReference{
-		{
-			Name: gatewayv1.ObjectName(gatewayName),
-		},
+	parentRef := c.parentRef(ing)
+
+	baseName, err := c.routeName(ing)
+	if err != nil {
+		return nil, err
 	}
 
-	// Convert rules (deduplicated - process only first rule's paths)
-	if len(ing.Spec.Rules) > 0 && ing.Spec.Rules[0].HTTP != nil {
-		rules, err := c.convertHTTPRules(ing, ing.Spec.Rules[0].HTTP.Paths)
+	var httpRoutes []interface{}
+	for i, g := range groups {
+		name := baseName
+		if i != first {
+			name = hostSetRouteName(baseName, g.hostnames)
+		}
+
+		httpRoute := &gatewayv1.HTTPRoute{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "gateway.networking.k8s.io/v1",
+				Kind:       "HTTPRoute",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   ing.Namespace,
+				Labels:      c.routeLabels(ing),
+				Annotations: c.routeAnnotations(ing),
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Hostnames: g.hostnames,
+			},
+		}
+		httpRoute.Spec.ParentRefs = []gatewayv1.ParentReference{parentRef}
+
+		rules, err := c.convertHTTPRules(ctx, ing, g.paths)
 		if err != nil {
 			return nil, err
 		}
 		httpRoute.Spec.Rules = rules
+
+		// Handle default backend, the fallback for unmatched paths on every host
+		if ing.Spec.DefaultBackend != nil && c.specDefaultBackendMode() == SpecDefaultBackendRule {
+			if defaultRule, ok := c.createDefaultBackendRule(ing, ing.Spec.DefaultBackend); ok {
+				httpRoute.Spec.Rules = append(httpRoute.Spec.Rules, defaultRule)
+			}
+		}
+
+		if len(httpRoute.Spec.Rules) == 0 {
+			continue
+		}
+		httpRoutes = append(httpRoutes, httpRoute)
+	}
+
+	// An Ingress with only a default backend
+	if len(groups) == 0 && ing.Spec.DefaultBackend != nil && c.specDefaultBackendMode() == SpecDefaultBackendRule {
+		defaultRule, ok := c.createDefaultBackendRule(ing, ing.Spec.DefaultBackend)
+		if !ok {
+			return httpRoutes, nil
+		}
+		httpRoutes = append(httpRoutes, &gatewayv1.HTTPRoute{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "gateway.networking.k8s.io/v1",
+				Kind:       "HTTPRoute",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        baseName,
+				Namespace:   ing.Namespace,
+				Labels:      c.routeLabels(ing),
+				Annotations: c.routeAnnotations(ing),
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{parentRef},
+				},
+				Rules: []gatewayv1.HTTPRouteRule{defaultRule},
+			},
+		})
+	}
+
+	return httpRoutes, nil
+}
+
+// hostGroup is a set of hosts routing the same paths
+type hostGroup struct {
+	hostnames []gatewayv1.Hostname
+	paths     []networkingv1.HTTPIngressPath
+}
+
+// groupByPaths groups rules by their paths, in order of first appearance
+func (c *Converter) groupByPaths(ing *networkingv1.Ingress, rules []networkingv1.IngressRule) []*hostGroup {
+	var groups []*hostGroup
+	byPaths := make(map[string]*hostGroup)
+
+	for _, rule := range rules {
+		var paths []networkingv1.HTTPIngressPath
+		if rule.HTTP != nil {
+			paths = rule.HTTP.Paths
+		}
+		// A rule without host matches every hostname, so it never shares
+		// a route restricted to the hostnames of other rules
+		key := pathsKey(paths)
+		if rule.Host == "" {
+			key = "*\n" + key
+		}
+		g, exists := byPaths[key]
+		if !exists {
+			g = &hostGroup{paths: paths}
+			byPaths[key] = g
+			groups = append(groups, g)
+		}
+		if rule.Host != "" {
+			g.hostnames = append(g.hostnames, c.hostname(ing, rule.Host))
+		}
 	}
+	return groups
+}
 
-	// Handle default backend
-	if ing.Spec.DefaultBackend != nil {
-		defaultRule := c.createDefaultBackendRule(ing, ing.Spec.DefaultBackend)
-		httpRoute.Spec.Rules = append(httpRoute.Spec.Rules, defaultRule)
+// pathTypeString returns the path type of an Ingress path, "" if unset
+func pathTypeString(path networkingv1.HTTPIngressPath) string {
+	if path.PathType == nil {
+		return ""
 	}
+	return string(*path.PathType)
+}
 
-	return []interface{}{httpRoute}, nil
+// pathsKey identifies a set of Ingress paths, so hosts routing the same
+// paths to the same backends can share a route
+func pathsKey(paths []networkingv1.HTTPIngressPath) string {
+	var b strings.Builder
+	for _, path := range paths {
+		fmt.Fprintf(&b, "%s|%s|", path.Path, pathTypeString(path))
+		if svc := path.Backend.Service; svc != nil {
+			fmt.Fprintf(&b, "%s:%d:%s", svc.Name, svc.Port.Number, svc.Port.Name)
+		} else if res := path.Backend.Resource; res != nil {
+			fmt.Fprintf(&b, "%s/%s", res.Kind, res.Name)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
 }
 
-// convertPerHost creates separate HTTPRoute per hostname
-func (c *Converter) convertPerHost(ing *networkingv1.Ingress) ([]interface{}, error) {
+// convertPerHost creates separate HTTPRoute per hostname. Rules without
+// host get a hostname-less route named after the Ingress, matching every
+// hostname the listener accepts.
+func (c *Converter) convertPerHost(ctx context.Context, ing *networkingv1.Ingress) ([]interface{}, error) {
+	baseName, err := c.routeName(ing)
+	if err != nil {
+		return nil, err
+	}
+
 	var httpRoutes []interface{}
+	names := make(map[string]int)
 
-	for i, rule := range ing.Spec.Rules {
-		if rule.Host == "" {
-			continue
+	for _, rule := range c.ingressRules(ing) {
+		// A host listed in several rules gets a route per rule
+		name := baseName
+		var hostnames []gatewayv1.Hostname
+		if rule.Host != "" {
+			name = hostRouteName(baseName, rule.Host)
+			hostnames = []gatewayv1.Hostname{c.hostname(ing, rule.Host)}
+		}
+		if names[name]++; names[name] > 1 {
+			name = fmt.Sprintf("%s-%d", name, names[name])
 		}
 
 		httpRoute := &gatewayv1.HTTPRoute{
@@ -168,29 +615,22 @@ func (c *Converter) convertPerHost(ing *networkingv1.Ingress) ([]interface{}, er
 				Kind:       "HTTPRoute",
 			},
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      fmt.Sprintf("%s-httproute-%d", ing.Name, i+1),
-				Namespace: ing.Namespace,
-				Labels:    ing.Labels,
+				Name:        name,
+				Namespace:   ing.Namespace,
+				Labels:      c.routeLabels(ing),
+				Annotations: c.routeAnnotations(ing),
 			},
 			Spec: gatewayv1.HTTPRouteSpec{
-				Hostnames: []gatewayv1.Hostname{gatewayv1.Hostname(rule.Host)},
+				Hostnames: hostnames,
 			},
 		}
 
 		// Set parent refs
-		gatewayName := c.opts.GatewayName
-		if gatewayName == "" {
-			gatewayName = c.deriveGatewayName(ing)
-		}
-		httpRoute.Spec.ParentRefs = []gatewayv1.ParentReference{
-			{
-				Name: gatewayv1.ObjectName(gatewayName),
-			},
-		}
+		httpRoute.Spec.ParentRefs = []gatewayv1.ParentReference{c.parentRef(ing)}
 
 		// Convert rules
 		if rule.HTTP != nil {
-			rules, err := c.convertHTTPRules(ing, rule.HTTP.Paths)
+			rules, err := c.convertHTTPRules(ctx, ing, rule.HTTP.Paths)
 			if err != nil {
 				return nil, err
 			}
@@ -203,95 +643,136 @@ func (c *Converter) convertPerHost(ing *networkingv1.Ingress) ([]interface{}, er
 	return httpRoutes, nil
 }
 
-// convertPerPattern groups hosts by pattern
-func (c *Converter) convertPerPattern(ing *networkingv1.Ingress) ([]interface{}, error) {
+// convertPerPattern groups hosts by pattern, wildcard hosts with the
+// domain they cover. Hosts of a pattern with different paths get a route
+// each, named as in single mode; rules without host share a hostname-less
+// route.
+func (c *Converter) convertPerPattern(ctx context.Context, ing *networkingv1.Ingress) ([]interface{}, error) {
+	baseName, err := c.routeName(ing)
+	if err != nil {
+		return nil, err
+	}
+
 	// Group hosts by pattern (e.g., *.example.com, *.dev.example.com)
-	groups := make(map[string][]string)
+	groups := make(map[string][]networkingv1.IngressRule)
+	var patterns []string
 
-	for _, rule := range ing.Spec.Rules {
-		if rule.Host == "" {
-			continue
+	for _, rule := range c.ingressRules(ing) {
+		var pattern string
+		if rule.Host != "" {
+			pattern = c.extractHostPattern(rule.Host)
 		}
-
-		pattern := c.extractHostPattern(rule.Host)
-		groups[pattern] = append(groups[pattern], rule.Host)
+		if _, exists := groups[pattern]; !exists {
+			patterns = append(patterns, pattern)
+		}
+		groups[pattern] = append(groups[pattern], rule)
 	}
 
-	var httpRoutes []interface{}
-	i := 0
+	// Names derive from the pattern and the sorted hosts only, so re-runs
+	// and reordered Ingress rules never rename a route
+	sort.Strings(patterns)
 
-	for pattern, hosts := range groups {
-		httpRoute := &gatewayv1.HTTPRoute{
-			TypeMeta: metav1.TypeMeta{
-				APIVersion: "gateway.networking.k8s.io/v1",
-				Kind:       "HTTPRoute",
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      fmt.Sprintf("%s-httproute-%s", ing.Name, sanitizeName(pattern)),
-				Namespace: ing.Namespace,
-				Labels:    ing.Labels,
-			},
-		}
+	var httpRoutes []interface{}
 
-		// Add hostnames
-		for _, host := range hosts {
-			httpRoute.Spec.Hostnames = append(httpRoute.Spec.Hostnames, gatewayv1.Hostname(host))
-		}
+	for _, pattern := range patterns {
+		rules := groups[pattern]
+		sort.SliceStable(rules, func(i, j int) bool { return rules[i].Host < rules[j].Host })
 
-		// Set parent refs
-		gatewayName := c.opts.GatewayName
-		if gatewayName == "" {
-			gatewayName = c.deriveGatewayName(ing)
+		var pathGroups []*hostGroup
+		for _, g := range c.groupByPaths(ing, rules) {
+			if len(g.paths) > 0 {
+				pathGroups = append(pathGroups, g)
+			}
 		}
-		httpRoute.Spec.ParentRefs = []gatewayv1.ParentReference{
-			{
-				Name: gatewayv1.ObjectName(gatewayName),
-			},
+		first := 0
+		for i, g := range pathGroups {
+			if hostSetKey(g.hostnames) < hostSetKey(pathGroups[first].hostnames) {
+				first = i
+			}
 		}
 
-		// Convert rules (use first rule's paths)
-		if len(ing.Spec.Rules) > 0 && ing.Spec.Rules[0].HTTP != nil {
-			rules, err := c.convertHTTPRules(ing, ing.Spec.Rules[0].HTTP.Paths)
+		for i, g := range pathGroups {
+			name := baseName
+			if pattern != "" {
+				name = fmt.Sprintf("%s-%s", baseName, sanitizeName(pattern))
+			}
+			if i != first {
+				name = hostSetRouteName(name, g.hostnames)
+			}
+
+			httpRoute := &gatewayv1.HTTPRoute{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "gateway.networking.k8s.io/v1",
+					Kind:       "HTTPRoute",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   ing.Namespace,
+					Labels:      c.routeLabels(ing),
+					Annotations: c.routeAnnotations(ing),
+				},
+				Spec: gatewayv1.HTTPRouteSpec{
+					Hostnames: g.hostnames,
+				},
+			}
+
+			// Set parent refs
+			httpRoute.Spec.ParentRefs = []gatewayv1.ParentReference{c.parentRef(ing)}
+
+			// Convert the group's own paths
+			routeRules, err := c.convertHTTPRules(ctx, ing, g.paths)
 			if err != nil {
 				return nil, err
 			}
-			httpRoute.Spec.Rules = rules
-		}
+			httpRoute.Spec.Rules = routeRules
 
-		httpRoutes = append(httpRoutes, httpRoute)
-		i++
+			httpRoutes = append(httpRoutes, httpRoute)
+		}
 	}
 
 	return httpRoutes, nil
 }
 
 // convertHTTPRules converts Ingress HTTP paths to HTTPRoute rules
-func (c *Converter) convertHTTPRules(ing *networkingv1.Ingress, paths []networkingv1.HTTPIngressPath) ([]gatewayv1.HTTPRouteRule, error) {
+func (c *Converter) convertHTTPRules(ctx context.Context, ing *networkingv1.Ingress, paths []networkingv1.HTTPIngressPath) ([]gatewayv1.HTTPRouteRule, error) {
 	var rules []gatewayv1.HTTPRouteRule
 
-	// Deduplicate paths by path+backend combination
+	// Deduplicate paths by path, path type and backend port; a repeated
+	// match with another backend is kept but can never be reached
 	seen := make(map[string]bool)
+	matched := make(map[string]string)
 
 	for _, path := range paths {
-		key := fmt.Sprintf("%s:%s", path.Path, path.Backend.Service.Name)
-		if seen[key] {
+		if path.Backend.Service == nil {
+			c.addDiagnostic(ing, "", SeverityError,
+				"path %q uses a resource backend, which HTTPRoute cannot reference; path dropped", path.Path)
 			continue
 		}
-		seen[key] = true
-
-		rule := gatewayv1.HTTPRouteRule{}
 
-		// Path match
-		pathType := gatewayv1.PathMatchPathPrefix
-		if path.PathType != nil && *path.PathType == networkingv1.PathTypeExact {
-			pathType = gatewayv1.PathMatchExact
+		svc := path.Backend.Service
+		match := fmt.Sprintf("%s|%s", path.Path, pathTypeString(path))
+		backend := fmt.Sprintf("%s:%d", svc.Name, svc.Port.Number)
+		if svc.Port.Name != "" {
+			backend = fmt.Sprintf("%s:%s", svc.Name, svc.Port.Name)
 		}
-
-		pathValue := path.Path
-		if pathValue == "" {
-			pathValue = "/"
+		if seen[match+"|"+backend] {
+			continue
+		}
+		seen[match+"|"+backend] = true
+		if first, exists := matched[match]; exists {
+			c.addDiagnostic(ing, "", SeverityWarning,
+				"path %q is listed for backends %s and %s; only the first matches", path.Path, first, backend)
+		} else {
+			matched[match] = backend
 		}
 
+		rule := gatewayv1.HTTPRouteRule{}
+
+		// Path match, taking rewrite-target and use-regex into account
+		pathTranslation := c.translatePath(ing, path)
+		pathType := pathTranslation.matchType
+		pathValue := pathTranslation.value
+
 		rule.Matches = []gatewayv1.HTTPRouteMatch{
 			{
 				Path: &gatewayv1.HTTPPathMatch{
@@ -300,9 +781,16 @@ func (c *Converter) convertHTTPRules(ing *networkingv1.Ingress, paths []networki
 				},
 			},
 		}
+		if match := c.prefixCompatMatch(ing, path, pathTranslation); match != nil {
+			rule.Matches = append(rule.Matches, *match)
+		}
+		rule.Matches = c.kongMatches(ing, rule.Matches)
 
 		// Backend refs
-		port := gatewayv1.PortNumber(path.Backend.Service.Port.Number)
+		port, ok := c.backendPort(ing, path.Backend.Service)
+		if !ok {
+			continue
+		}
 		weight := int32(1)
 
 		rule.BackendRefs = []gatewayv1.HTTPBackendRef{
@@ -317,101 +805,133 @@ func (c *Converter) convertHTTPRules(ing *networkingv1.Ingress, paths []networki
 			},
 		}
 
-		// Apply filters from annotations
-		filters, err := c.extractFilters(ing)
+		// Apply filters and timeouts from annotations
+		translation, err := c.translate(ctx, ing, *rule.Matches[0].Path)
 		if err != nil {
 			return nil, err
 		}
+		filters := applyRewrite(translation.Filters, pathTranslation.rewrite)
+		if _, ok := ing.Annotations[annotationTraefikMiddlewares]; ok {
+			filters = append(filters, c.middlewareFilters(ing, annotationTraefikMiddlewares, c.annotationMiddlewares(ing), *rule.Matches[0].Path)...)
+		}
+		if rewrite := c.haproxyRewriteFilter(ing, *rule.Matches[0].Path); rewrite != nil {
+			filters = append(filters, gatewayv1.HTTPRouteFilter{Type: gatewayv1.HTTPRouteFilterURLRewrite, URLRewrite: rewrite})
+		}
+		filters = append(filters, c.kongFilters(ing, *rule.Matches[0].Path)...)
 		if len(filters) > 0 {
 			rule.Filters = filters
 		}
 
-		// Apply timeouts from annotations
-		timeouts := c.extractTimeouts(ing)
-		if timeouts != nil {
-			rule.Timeouts = timeouts
+		if translation.Timeouts != nil {
+			rule.Timeouts = translation.Timeouts
 		}
+		c.applyBackendConfig(ing, path.Backend.Service, &rule)
 
-		rules = append(rules, rule)
+		rules = append(rules, splitPrefixRewrite(rule)...)
 	}
 
 	return rules, nil
 }
 
-// extractFilters extracts HTTPRoute filters from annotations
-func (c *Converter) extractFilters(ing *networkingv1.Ingress) ([]gatewayv1.HTTPRouteFilter, error) {
-	var filters []gatewayv1.HTTPRouteFilter
-
-	// URL Rewrite
-	if rewriteTarget, exists := ing.Annotations["nginx.ingress.kubernetes.io/rewrite-target"]; exists {
-		filterType := gatewayv1.HTTPRouteFilterURLRewrite
-		filters = append(filters, gatewayv1.HTTPRouteFilter{
-			Type: filterType,
-			URLRewrite: &gatewayv1.HTTPURLRewriteFilter{
-				Path: &gatewayv1.HTTPPathModifier{
-					Type:               gatewayv1.FullPathHTTPPathModifier,
-					ReplaceFullPath:    &rewriteTarget,
-				},
-			},
+// translateRewriteTarget converts rewrite-target to a URLRewrite filter
+func translateRewriteTarget(t *Translation) error {
+	if rewrite := rewriteFilter(t.Ingress.Annotations[annotationRewriteTarget]); rewrite != nil {
+		t.Filters = append(t.Filters, gatewayv1.HTTPRouteFilter{
+			Type:       gatewayv1.HTTPRouteFilterURLRewrite,
+			URLRewrite: rewrite,
 		})
 	}
+	return nil
+}
 
-	// Redirect
-	if redirect, exists := ing.Annotations["nginx.ingress.kubernetes.io/permanent-redirect"]; exists {
-		filterType := gatewayv1.HTTPRouteFilterRequestRedirect
-		statusCode := 301
-		filters = append(filters, gatewayv1.HTTPRouteFilter{
-			Type: filterType,
-			RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
-				Hostname:   (*gatewayv1.PreciseHostname)(&redirect),
-				StatusCode: &statusCode,
-			},
+// translatePermanentRedirect converts permanent-redirect to a
+// RequestRedirect filter
+func translatePermanentRedirect(t *Translation) error {
+	target := t.Ingress.Annotations[annotationPermanentRedirect]
+	if redirect := redirectFilter(301, target); redirect != nil {
+		t.Filters = append(t.Filters, gatewayv1.HTTPRouteFilter{
+			Type:            gatewayv1.HTTPRouteFilterRequestRedirect,
+			RequestRedirect: redirect,
 		})
+	} else {
+		t.Report(annotationPermanentRedirect, SeverityWarning,
+			"redirect to %q cannot be expressed as a RequestRedirect filter, not converted", target)
 	}
-
-	return filters, nil
+	return nil
 }
 
-// extractTimeouts extracts timeout configuration from annotations
-func (c *Converter) extractTimeouts(ing *networkingv1.Ingress) *gatewayv1.HTTPRouteTimeouts {
-	var timeouts *gatewayv1.HTTPRouteTimeouts
+// translateSnippet converts the common directives of configuration-snippet
+func translateSnippet(t *Translation) error {
+	result := parseSnippet(t.Ingress.Annotations[annotationConfigurationSnippet])
+	t.Filters = append(t.Filters, result.filters()...)
+	for _, stmt := range result.unrecognized {
+		t.Report(annotationConfigurationSnippet, SeverityWarning, "directive requires manual review: %s", stmt)
+	}
+	if len(result.filters()) > 0 {
+		t.Report(annotationConfigurationSnippet, SeverityInfo, "converted %d snippet filter(s)", len(result.filters()))
+	}
+	return nil
+}
 
-	// Check for proxy-read-timeout
-	if readTimeout, exists := ing.Annotations["nginx.ingress.kubernetes.io/proxy-read-timeout"]; exists {
-		if seconds, err := strconv.Atoi(readTimeout); err == nil {
-			duration := gatewayv1.Duration(fmt.Sprintf("%ds", seconds))
-			if timeouts == nil {
-				timeouts = &gatewayv1.HTTPRouteTimeouts{}
-			}
-			// Set both timeouts for complete timeout control
-			timeouts.Request = &duration
-			timeouts.BackendRequest = &duration
+// setRequestHeader sets a header on the rule's RequestHeaderModifier filter,
+// adding the filter if needed since a rule may only carry one of them
+func setRequestHeader(filters []gatewayv1.HTTPRouteFilter, name, value string) []gatewayv1.HTTPRouteFilter {
+	header := gatewayv1.HTTPHeader{Name: gatewayv1.HTTPHeaderName(name), Value: value}
+	for i := range filters {
+		if filters[i].Type == gatewayv1.HTTPRouteFilterRequestHeaderModifier && filters[i].RequestHeaderModifier != nil {
+			filters[i].RequestHeaderModifier.Set = setHeader(filters[i].RequestHeaderModifier.Set, header)
+			return filters
 		}
 	}
+	return append(filters, gatewayv1.HTTPRouteFilter{
+		Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+		RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+			Set: []gatewayv1.HTTPHeader{header},
+		},
+	})
+}
 
-	// Check for proxy-send-timeout (also applies to backend)
-	if sendTimeout, exists := ing.Annotations["nginx.ingress.kubernetes.io/proxy-send-timeout"]; exists {
-		if seconds, err := strconv.Atoi(sendTimeout); err == nil {
-			duration := gatewayv1.Duration(fmt.Sprintf("%ds", seconds))
-			if timeouts == nil {
-				timeouts = &gatewayv1.HTTPRouteTimeouts{}
-			}
-			// Only set if not already set
-			if timeouts.BackendRequest == nil {
-				timeouts.BackendRequest = &duration
-			}
-			if timeouts.Request == nil {
-				timeouts.Request = &duration
-			}
+// setResponseHeader sets a header on the rule's ResponseHeaderModifier filter,
+// adding the filter if needed since a rule may only carry one of them
+func setResponseHeader(filters []gatewayv1.HTTPRouteFilter, name, value string) []gatewayv1.HTTPRouteFilter {
+	header := gatewayv1.HTTPHeader{Name: gatewayv1.HTTPHeaderName(name), Value: value}
+	for i := range filters {
+		if filters[i].Type == gatewayv1.HTTPRouteFilterResponseHeaderModifier && filters[i].ResponseHeaderModifier != nil {
+			filters[i].ResponseHeaderModifier.Set = setHeader(filters[i].ResponseHeaderModifier.Set, header)
+			return filters
 		}
 	}
+	return append(filters, gatewayv1.HTTPRouteFilter{
+		Type: gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+		ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+			Set: []gatewayv1.HTTPHeader{header},
+		},
+	})
+}
 
-	return timeouts
+// setHeader replaces a header of the same name or appends it
+func setHeader(headers []gatewayv1.HTTPHeader, header gatewayv1.HTTPHeader) []gatewayv1.HTTPHeader {
+	for i := range headers {
+		if strings.EqualFold(string(headers[i].Name), string(header.Name)) {
+			headers[i].Value = header.Value
+			return headers
+		}
+	}
+	return append(headers, header)
 }
 
-// createDefaultBackendRule creates a rule for default backend
-func (c *Converter) createDefaultBackendRule(ing *networkingv1.Ingress, backend *networkingv1.IngressBackend) gatewayv1.HTTPRouteRule {
-	port := gatewayv1.PortNumber(backend.Service.Port.Number)
+// createDefaultBackendRule creates a rule for default backend, ok is false
+// when it is not a Service or its port cannot be resolved
+func (c *Converter) createDefaultBackendRule(ing *networkingv1.Ingress, backend *networkingv1.IngressBackend) (gatewayv1.HTTPRouteRule, bool) {
+	if backend.Service == nil {
+		c.addDiagnostic(ing, "", SeverityError,
+			"default backend is a resource backend, which HTTPRoute cannot reference; default backend dropped")
+		return gatewayv1.HTTPRouteRule{}, false
+	}
+	port, ok := c.backendPort(ing, backend.Service)
+	if !ok {
+		return gatewayv1.HTTPRouteRule{}, false
+	}
 	weight := int32(1)
 	pathValue := "/"
 	pathType := gatewayv1.PathMatchPathPrefix
@@ -436,15 +956,12 @@ func (c *Converter) createDefaultBackendRule(ing *networkingv1.Ingress, backend
 				},
 			},
 		},
-	}
+	}, true
 }
 
 // deriveGatewayName derives Gateway name from Ingress class
 func (c *Converter) deriveGatewayName(ing *networkingv1.Ingress) string {
-	if ing.Spec.IngressClassName != nil {
-		return fmt.Sprintf("gateway-%s", *ing.Spec.IngressClassName)
-	}
-	if class, exists := ing.Annotations["kubernetes.io/ingress.class"]; exists {
+	if class := ingressClass(ing); class != "" {
 		return fmt.Sprintf("gateway-%s", class)
 	}
 	return "gateway-nginx"
@@ -464,38 +981,80 @@ func sanitizeName(name string) string {
 	reg := regexp.MustCompile(`[^a-z0-9-]`)
 	sanitized := reg.ReplaceAllString(strings.ToLower(name), "-")
 	sanitized = strings.Trim(sanitized, "-")
-	if len(sanitized) > 63 {
-		sanitized = sanitized[:63]
+	if len(sanitized) > maxNameLength {
+		sanitized = sanitized[:maxNameLength]
 	}
 	return sanitized
 }
 
-// WriteOutput writes HTTPRoutes to output
+// WriteOutput writes HTTPRoutes to output. JSON output is a single object,
+// or a v1 List for several resources as kubectl prints them; list output is
+// always a List. NDJSON writes one compact object per line for streaming tools.
 func (c *Converter) WriteOutput(httpRoutes []interface{}, w io.Writer) error {
-	for i, route := range httpRoutes {
-		if i > 0 {
-			fmt.Fprintln(w, "---")
-		}
+	switch c.opts.OutputFormat {
+	case FormatHelm:
+		return c.writeHelmTemplates(httpRoutes, w)
+	case FormatJSON:
+		return writeJSON(httpRoutes, w)
+	case FormatNDJSON:
+		return writeNDJSON(httpRoutes, w)
+	case FormatList:
+		return writeList(httpRoutes, w)
+	case FormatHelmChart:
+		return fmt.Errorf("helm chart output is written to a directory with WriteHelmChart")
+	}
 
-		var data []byte
-		var err error
+	return writeYAML(httpRoutes, w)
+}
 
-		if c.opts.OutputFormat == "json" {
-			data, err = yaml.Marshal(route)
-			if err != nil {
-				return fmt.Errorf("failed to marshal HTTPRoute: %w", err)
-			}
-		} else {
-			data, err = yaml.Marshal(route)
-			if err != nil {
-				return fmt.Errorf("failed to marshal HTTPRoute: %w", err)
-			}
-		}
+// resourceList wraps several resources in a v1 List
+type resourceList struct {
+	APIVersion string        `json:"apiVersion"`
+	Kind       string        `json:"kind"`
+	Items      []interface{} `json:"items"`
+}
 
-		if _, err := w.Write(data); err != nil {
+// writeJSON writes resources as indented JSON, a single object or a v1
+// List of several
+func writeJSON(resources []interface{}, w io.Writer) error {
+	if len(resources) == 1 {
+		return writeIndentedJSON(resources[0], w)
+	}
+	return writeList(resources, w)
+}
+
+// writeList writes resources as a v1 List, whatever their number, so tools
+// reading a single document such as kubectl apply --prune -f - get one
+func writeList(resources []interface{}, w io.Writer) error {
+	return writeIndentedJSON(resourceList{
+		APIVersion: "v1",
+		Kind:       "List",
+		Items:      append([]interface{}{}, resources...),
+	}, w)
+}
+
+// writeIndentedJSON writes obj as indented JSON
+func writeIndentedJSON(obj interface{}, w io.Writer) error {
+	data, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal HTTPRoute: %w", err)
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// writeNDJSON writes one compact JSON object per line
+func writeNDJSON(resources []interface{}, w io.Writer) error {
+	for _, res := range resources {
+		data, err := json.Marshal(res)
+		if err != nil {
+			return fmt.Errorf("failed to marshal HTTPRoute: %w", err)
+		}
+		if _, err := w.Write(append(data, '\n')); err != nil {
 			return fmt.Errorf("failed to write output: %w", err)
 		}
 	}
-
 	return nil
 }
@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	annotationEnableCORS           = "nginx.ingress.kubernetes.io/enable-cors"
+	annotationCORSAllowOrigin      = "nginx.ingress.kubernetes.io/cors-allow-origin"
+	annotationCORSAllowMethods     = "nginx.ingress.kubernetes.io/cors-allow-methods"
+	annotationCORSAllowHeaders     = "nginx.ingress.kubernetes.io/cors-allow-headers"
+	annotationCORSExposeHeaders    = "nginx.ingress.kubernetes.io/cors-expose-headers"
+	annotationCORSAllowCredentials = "nginx.ingress.kubernetes.io/cors-allow-credentials"
+	annotationCORSMaxAge           = "nginx.ingress.kubernetes.io/cors-max-age"
+)
+
+// corsAnnotations are the annotations configuring CORS once enable-cors is set
+var corsAnnotations = []string{
+	annotationCORSAllowOrigin, annotationCORSAllowMethods, annotationCORSAllowHeaders,
+	annotationCORSExposeHeaders, annotationCORSAllowCredentials, annotationCORSMaxAge,
+}
+
+// ingress-nginx CORS defaults
+const (
+	defaultCORSAllowMethods = "GET, PUT, POST, DELETE, PATCH, OPTIONS"
+	defaultCORSAllowHeaders = "DNT,Keep-Alive,User-Agent,X-Requested-With,If-Modified-Since,Cache-Control,Content-Type,Authorization"
+	defaultCORSMaxAge       = 1728000
+)
+
+// corsConfig is the CORS configuration of an Ingress with ingress-nginx
+// defaults applied
+type corsConfig struct {
+	origins       []string
+	methods       []string
+	headers       []string
+	exposeHeaders []string
+	credentials   bool
+	maxAge        int64
+}
+
+// nginxCORS reads enable-cors and the cors-* annotations, or returns nil
+// when CORS is not enabled
+func (c *Converter) nginxCORS(ing *networkingv1.Ingress) *corsConfig {
+	if enabled, _ := strconv.ParseBool(ing.Annotations[annotationEnableCORS]); !enabled {
+		for _, annotation := range corsAnnotations {
+			if _, ok := ing.Annotations[annotation]; ok {
+				c.addDiagnostic(ing, annotation, SeverityInfo, "ignored without enable-cors: \"true\"")
+			}
+		}
+		return nil
+	}
+
+	cors := &corsConfig{
+		origins:       []string{"*"},
+		methods:       splitList(defaultCORSAllowMethods),
+		headers:       splitList(defaultCORSAllowHeaders),
+		exposeHeaders: splitList(ing.Annotations[annotationCORSExposeHeaders]),
+		credentials:   true,
+		maxAge:        defaultCORSMaxAge,
+	}
+	if value, ok := ing.Annotations[annotationCORSAllowOrigin]; ok {
+		cors.origins = splitList(value)
+	}
+	if value, ok := ing.Annotations[annotationCORSAllowMethods]; ok {
+		cors.methods = splitList(value)
+	}
+	if value, ok := ing.Annotations[annotationCORSAllowHeaders]; ok {
+		cors.headers = splitList(value)
+	}
+	if value, ok := ing.Annotations[annotationCORSAllowCredentials]; ok {
+		credentials, err := strconv.ParseBool(value)
+		if err != nil {
+			c.addDiagnostic(ing, annotationCORSAllowCredentials, SeverityWarning, "invalid value %q, using true", value)
+			credentials = true
+		}
+		cors.credentials = credentials
+	}
+	if value, ok := ing.Annotations[annotationCORSMaxAge]; ok {
+		maxAge, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || maxAge < 0 {
+			c.addDiagnostic(ing, annotationCORSMaxAge, SeverityWarning, "invalid max age %q, using %d", value, defaultCORSMaxAge)
+			maxAge = defaultCORSMaxAge
+		}
+		cors.maxAge = maxAge
+	}
+	return cors
+}
+
+// splitList splits a comma-separated annotation value
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// envoyCORS converts the CORS configuration to the cors section of an Envoy
+// Gateway SecurityPolicy, or records a manual-policy diagnostic for targets
+// generating CORS elsewhere or not at all
+func (c *Converter) envoyCORS(ing *networkingv1.Ingress, cors *corsConfig) map[string]interface{} {
+	if c.opts.Target == TargetKong {
+		return nil
+	}
+	if c.opts.Target != TargetEnvoyGateway {
+		c.addDiagnostic(ing, annotationEnableCORS, SeverityWarning,
+			"CORS needs manual policy (no Gateway API v1.0 filter); select --target=envoy-gateway or kong to generate one")
+		return nil
+	}
+
+	policy := map[string]interface{}{
+		"allowOrigins":     stringList(cors.origins),
+		"allowMethods":     stringList(cors.methods),
+		"allowHeaders":     stringList(cors.headers),
+		"allowCredentials": cors.credentials,
+		"maxAge":           fmt.Sprintf("%ds", cors.maxAge),
+	}
+	if len(cors.exposeHeaders) > 0 {
+		policy["exposeHeaders"] = stringList(cors.exposeHeaders)
+	}
+	c.addDiagnostic(ing, annotationEnableCORS, SeverityInfo, "CORS converted to the cors of an Envoy Gateway SecurityPolicy")
+	return policy
+}
+
+// kongCORSPlugin converts the CORS configuration to a KongPlugin attached
+// to the routes, for --target=kong
+func (c *Converter) kongCORSPlugin(ing *networkingv1.Ingress, cors *corsConfig, routes []interface{}) *unstructured.Unstructured {
+	if c.opts.Target != TargetKong {
+		return nil
+	}
+
+	config := map[string]interface{}{
+		"origins":     stringList(cors.origins),
+		"methods":     stringList(cors.methods),
+		"headers":     stringList(cors.headers),
+		"credentials": cors.credentials,
+		"max_age":     cors.maxAge,
+	}
+	if len(cors.exposeHeaders) > 0 {
+		config["exposed_headers"] = stringList(cors.exposeHeaders)
+	}
+	plugin := &unstructured.Unstructured{Object: map[string]interface{}{
+		"plugin": "cors",
+		"config": config,
+	}}
+	plugin.SetAPIVersion("configuration.konghq.com/v1")
+	plugin.SetKind(kindKongPlugin)
+	plugin.SetName(sanitizeName(fmt.Sprintf("%s-cors", ing.Name)))
+	plugin.SetNamespace(ing.Namespace)
+
+	for _, r := range routes {
+		if route, ok := r.(*gatewayv1.HTTPRoute); ok {
+			plugins := plugin.GetName()
+			if existing := route.Annotations[annotationKongPlugins]; existing != "" {
+				plugins = existing + "," + plugins
+			}
+			route.Annotations = withEntry(route.Annotations, annotationKongPlugins, plugins)
+		}
+	}
+	c.addDiagnostic(ing, annotationEnableCORS, SeverityInfo, "CORS converted to KongPlugin %s attached to the HTTPRoutes", plugin.GetName())
+	return plugin
+}
+
+// stringList converts strings for an unstructured resource
+func stringList(values []string) []interface{} {
+	list := make([]interface{}, len(values))
+	for i, v := range values {
+		list[i] = v
+	}
+	return list
+}
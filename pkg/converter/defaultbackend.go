@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	annotationDefaultBackend   = "nginx.ingress.kubernetes.io/default-backend"
+	annotationCustomHTTPErrors = "nginx.ingress.kubernetes.io/custom-http-errors"
+)
+
+// Default backend modes for the default-backend annotation
+const (
+	DefaultBackendCatchAll = "catch-all" // hostname-less HTTPRoute, lowest precedence (default)
+	DefaultBackendListener = "listener"  // HTTPRoute attached to a dedicated Gateway listener
+	DefaultBackendNone     = "none"      // report only
+)
+
+// DefaultBackendModes lists the accepted default backend modes
+var DefaultBackendModes = []string{DefaultBackendCatchAll, DefaultBackendListener, DefaultBackendNone}
+
+// Modes for spec.defaultBackend
+const (
+	SpecDefaultBackendRule     = "rule"      // "/" rule appended to the Ingress routes (default)
+	SpecDefaultBackendCatchAll = "catch-all" // hostname-less HTTPRoute, lowest precedence
+	SpecDefaultBackendNone     = "none"      // report only
+)
+
+// SpecDefaultBackendModes lists the accepted spec.defaultBackend modes
+var SpecDefaultBackendModes = []string{SpecDefaultBackendRule, SpecDefaultBackendCatchAll, SpecDefaultBackendNone}
+
+const (
+	// defaultBackendListener is the Gateway listener used in listener mode
+	defaultBackendListener = "default-backend"
+	// defaultBackendPort is assumed since the annotation names only the Service
+	defaultBackendPort = 80
+)
+
+// extractDefaultBackend builds the route for the default-backend annotation
+func (c *Converter) extractDefaultBackend(ing *networkingv1.Ingress) []interface{} {
+	service, hasBackend := ing.Annotations[annotationDefaultBackend]
+	codes, hasErrors := ing.Annotations[annotationCustomHTTPErrors]
+
+	if hasErrors {
+		if hasBackend {
+			c.addDiagnostic(ing, annotationCustomHTTPErrors, SeverityWarning,
+				"Gateway API cannot intercept upstream %s responses; only unmatched requests reach %s", codes, service)
+		} else {
+			c.addDiagnostic(ing, annotationCustomHTTPErrors, SeverityWarning,
+				"custom error pages for %s are served by the controller's default backend and need an implementation-specific policy", codes)
+		}
+	}
+	if !hasBackend || service == "" {
+		return nil
+	}
+
+	mode := c.opts.DefaultBackendMode
+	if mode == "" {
+		mode = DefaultBackendCatchAll
+	}
+	if mode == DefaultBackendNone {
+		c.addDiagnostic(ing, annotationDefaultBackend, SeverityWarning,
+			"default backend %s was not converted; route unmatched traffic to it manually", service)
+		return nil
+	}
+
+	parentRef := c.parentRef(ing)
+	if mode == DefaultBackendListener {
+		sectionName := gatewayv1.SectionName(defaultBackendListener)
+		parentRef.SectionName = &sectionName
+		parentRef.Port = nil
+		c.addDiagnostic(ing, annotationDefaultBackend, SeverityInfo,
+			"default backend attached to listener %q; add it to Gateway %s", defaultBackendListener, parentRef.Name)
+	}
+
+	backend := &networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: service,
+			Port: networkingv1.ServiceBackendPort{Number: defaultBackendPort},
+		},
+	}
+	c.addDiagnostic(ing, annotationDefaultBackend, SeverityWarning,
+		"default backend %s assumed to listen on port %d; verify the Service port", service, defaultBackendPort)
+	rule, _ := c.createDefaultBackendRule(ing, backend)
+
+	return []interface{}{c.catchAllRoute(ing, fmt.Sprintf("%s-default-backend", ing.Name), parentRef, rule)}
+}
+
+// specDefaultBackendMode returns the configured spec.defaultBackend mode
+func (c *Converter) specDefaultBackendMode() string {
+	if c.opts.SpecDefaultBackendMode == "" {
+		return SpecDefaultBackendRule
+	}
+	return c.opts.SpecDefaultBackendMode
+}
+
+// extractSpecDefaultBackend builds the catch-all route for spec.defaultBackend
+// outside rule mode, where it would otherwise be a "/" rule next to the
+// Ingress paths and could shadow them on implementations that order
+// ImplementationSpecific or regex matches differently
+func (c *Converter) extractSpecDefaultBackend(ing *networkingv1.Ingress) []interface{} {
+	backend := ing.Spec.DefaultBackend
+	if backend == nil {
+		return nil
+	}
+
+	switch c.specDefaultBackendMode() {
+	case SpecDefaultBackendNone:
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"spec.defaultBackend was not converted; route unmatched traffic to it manually")
+		return nil
+	case SpecDefaultBackendCatchAll:
+		rule, ok := c.createDefaultBackendRule(ing, backend)
+		if !ok {
+			return nil
+		}
+		name := fmt.Sprintf("%s-catch-all", ing.Name)
+		c.addDiagnostic(ing, "", SeverityInfo,
+			"spec.defaultBackend %s emitted as HTTPRoute %s without hostnames: routes with a matching hostname take precedence, so it only receives requests they do not match, including hosts of other routes on the same Gateway listener",
+			backend.Service.Name, name)
+		return []interface{}{c.catchAllRoute(ing, name, c.parentRef(ing), rule)}
+	}
+	return nil
+}
+
+// catchAllRoute builds an HTTPRoute without hostnames. Every
+// hostname-specific route on the listener takes precedence over it.
+func (c *Converter) catchAllRoute(ing *networkingv1.Ingress, name string, parentRef gatewayv1.ParentReference, rule gatewayv1.HTTPRouteRule) *gatewayv1.HTTPRoute {
+	return &gatewayv1.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "gateway.networking.k8s.io/v1",
+			Kind:       "HTTPRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   ing.Namespace,
+			Labels:      c.routeLabels(ing),
+			Annotations: c.routeAnnotations(ing),
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{parentRef},
+			},
+			Rules: []gatewayv1.HTTPRouteRule{rule},
+		},
+	}
+}
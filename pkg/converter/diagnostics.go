@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// Diagnostic severities
+const (
+	SeverityInfo    = "info"
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+// Diagnostic describes a conversion decision that needs the user's attention
+type Diagnostic struct {
+	Ingress    string `json:"ingress"`              // namespace/name of the source Ingress
+	Annotation string `json:"annotation,omitempty"` // source annotation, empty for spec-level findings
+	Severity   string `json:"severity"`             // info, warning, error
+	Message    string `json:"message"`
+}
+
+// String formats the diagnostic for CLI output
+func (d Diagnostic) String() string {
+	if d.Annotation != "" {
+		return fmt.Sprintf("%s: %s: %s", d.Ingress, d.Annotation, d.Message)
+	}
+	return fmt.Sprintf("%s: %s", d.Ingress, d.Message)
+}
+
+// Diagnostics returns the diagnostics recorded by the last Convert call
+func (c *Converter) Diagnostics() []Diagnostic {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.diagnostics
+}
+
+// addDiagnostic records a diagnostic for an Ingress. Identical diagnostics
+// are recorded once, since annotations are evaluated for every generated rule.
+func (c *Converter) addDiagnostic(ing *networkingv1.Ingress, annotation, severity, format string, args ...interface{}) {
+	d := Diagnostic{
+		Ingress:    fmt.Sprintf("%s/%s", ing.Namespace, ing.Name),
+		Annotation: annotation,
+		Severity:   severity,
+		Message:    fmt.Sprintf(format, args...),
+	}
+	for _, existing := range c.diagnostics {
+		if existing == d {
+			return
+		}
+	}
+	c.diagnostics = append(c.diagnostics, d)
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// manifestExtensions are the file extensions searched in directories
+var manifestExtensions = map[string]bool{
+	".yaml": true,
+	".yml":  true,
+	".json": true,
+}
+
+// LoadFromPath loads Ingress resources from a file, a directory searched
+// recursively, or a glob pattern where ** matches any number of
+// directories. Discovered files that are not valid manifests, such as Helm
+// templates in a GitOps repository, are skipped and reported by
+// SkippedFiles; a file named explicitly must parse.
+func (c *Converter) LoadFromPath(path string) ([]interface{}, error) {
+	ingresses, skipped, err := c.loadFromPath(path)
+	c.mu.Lock()
+	c.skippedFiles = skipped
+	c.mu.Unlock()
+	return ingresses, err
+}
+
+// loadFromPath loads the Ingresses below path, returning the files skipped
+func (c *Converter) loadFromPath(path string) ([]interface{}, []string, error) {
+	var files []string
+	if hasGlobMeta(path) {
+		matches, err := globFiles(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(matches) == 0 {
+			return nil, nil, fmt.Errorf("no files match %s", path)
+		}
+		files = matches
+	} else {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read path: %w", err)
+		}
+		if !info.IsDir() {
+			ingresses, err := c.LoadFromFile(path)
+			return ingresses, nil, err
+		}
+		files, err = manifestFiles(path)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var ingresses []interface{}
+	var skipped []string
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		parsed, err := parseManifests(data)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s: %v", file, err))
+			continue
+		}
+		ingresses = append(ingresses, parsed...)
+	}
+
+	if len(ingresses) == 0 {
+		return nil, skipped, fmt.Errorf("no Ingress resources found in %s", path)
+	}
+	return ingresses, skipped, nil
+}
+
+// SkippedFiles returns the files LoadFromPath could not parse, with the reason
+func (c *Converter) SkippedFiles() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.skippedFiles
+}
+
+// manifestFiles lists the manifest files below dir in lexical order,
+// skipping hidden directories such as .git
+func manifestFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if manifestExtensions[strings.ToLower(filepath.Ext(path))] {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory: %w", err)
+	}
+	return files, nil
+}
+
+// hasGlobMeta reports whether path contains glob metacharacters
+func hasGlobMeta(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// globFiles returns the manifest files matching pattern. Matched
+// directories are searched recursively.
+func globFiles(pattern string) ([]string, error) {
+	pattern = filepath.ToSlash(filepath.Clean(pattern))
+	segments := strings.Split(pattern, "/")
+
+	// Walk from the longest prefix without metacharacters
+	base := 0
+	for base < len(segments) && !hasGlobMeta(segments[base]) {
+		base++
+	}
+	root := strings.Join(segments[:base], "/")
+	if root == "" {
+		root = "."
+		if strings.HasPrefix(pattern, "/") {
+			root = "/"
+		}
+	}
+	rest := segments[base:]
+	recursive := false
+	for _, segment := range rest {
+		if segment == "**" {
+			recursive = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	err := filepath.WalkDir(filepath.FromSlash(root), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(filepath.FromSlash(root), path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && rel != "." && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if rel == "." {
+			return nil
+		}
+		relSegments := strings.Split(filepath.ToSlash(rel), "/")
+		if !matchSegments(rest, relSegments) {
+			// Without ** nothing deeper than the pattern can match
+			if d.IsDir() && !recursive && len(relSegments) >= len(rest) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// A matched directory contributes all of its manifests
+		if d.IsDir() {
+			found, err := manifestFiles(path)
+			if err != nil {
+				return err
+			}
+			for _, f := range found {
+				if !seen[f] {
+					seen[f] = true
+					files = append(files, f)
+				}
+			}
+			return filepath.SkipDir
+		}
+		if manifestExtensions[strings.ToLower(filepath.Ext(path))] && !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %s: %w", pattern, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// matchSegments matches path segments against pattern segments, where a
+// ** segment matches zero or more path segments
+func matchSegments(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if matchSegments(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 {
+		return false
+	}
+	if ok, _ := filepath.Match(pattern[0], path[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], path[1:])
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// defaultHookTimeout bounds a run of a hook executable
+const defaultHookTimeout = 30 * time.Second
+
+// HookRequest is the JSON an ExecHook writes to the stdin of its executable,
+// once for each rule of an Ingress with unhandled annotations
+type HookRequest struct {
+	// Ingress is the Ingress being converted
+	Ingress *networkingv1.Ingress `json:"ingress"`
+	// Annotations are the annotations of the Ingress no translator converts
+	Annotations map[string]string `json:"annotations"`
+	// Path is the path match of the rule
+	Path gatewayv1.HTTPPathMatch `json:"path"`
+	// Target is the implementation given with --target, if any
+	Target string `json:"target,omitempty"`
+}
+
+// HookResponse is the JSON the executable of an ExecHook writes to stdout
+type HookResponse struct {
+	// Handled lists the annotations the hook converted
+	Handled []string `json:"handled,omitempty"`
+	// Filters are added to the rule; header modifiers merge into those of the rule
+	Filters []gatewayv1.HTTPRouteFilter `json:"filters,omitempty"`
+	// Resources are added to the output, in the Ingress namespace unless
+	// they set one
+	Resources []map[string]interface{} `json:"resources,omitempty"`
+	// Diagnostics are recorded for the Ingress
+	Diagnostics []HookDiagnostic `json:"diagnostics,omitempty"`
+}
+
+// HookDiagnostic is a diagnostic returned by a hook executable
+type HookDiagnostic struct {
+	Annotation string `json:"annotation,omitempty"`
+	Severity   string `json:"severity"` // info, warning, error
+	Message    string `json:"message"`
+}
+
+// ExecHook is a Translator handing the annotations no other translator
+// converts to an executable, so platform teams convert their own
+// annotations without changing the converter. Set it with Registry.SetHook.
+type ExecHook struct {
+	path    string
+	args    []string
+	timeout time.Duration
+}
+
+// NewExecHook returns a hook running the executable at path with args
+func NewExecHook(path string, args ...string) *ExecHook {
+	return &ExecHook{path: path, args: args, timeout: defaultHookTimeout}
+}
+
+func (h *ExecHook) Name() string          { return "exec:" + filepath.Base(h.path) }
+func (h *ExecHook) Annotations() []string { return nil }
+
+// Translate runs the executable with the unhandled annotations of the rule
+// and applies its response. A failing run or an invalid response fails the
+// conversion of the Ingress.
+func (h *ExecHook) Translate(t *Translation) error {
+	unhandled := t.Unhandled()
+	if len(unhandled) == 0 {
+		return nil
+	}
+	input, err := json.Marshal(HookRequest{
+		Ingress:     t.Ingress,
+		Annotations: unhandled,
+		Path:        t.Path,
+		Target:      t.c.opts.Target,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode hook request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), h.timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, h.path, h.args...)
+	// Children of a killed hook may hold its output open
+	cmd.WaitDelay = time.Second
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("failed to run hook %s: %w: %s", h.path, err, msg)
+		}
+		return fmt.Errorf("failed to run hook %s: %w", h.path, err)
+	}
+
+	var response HookResponse
+	if stdout.Len() > 0 {
+		decoder := json.NewDecoder(&stdout)
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&response); err != nil {
+			return fmt.Errorf("failed to decode response of hook %s: %w", h.path, err)
+		}
+	}
+	return h.apply(t, unhandled, &response)
+}
+
+// apply adds the filters, resources and diagnostics of a hook response to
+// the rule
+func (h *ExecHook) apply(t *Translation, unhandled map[string]string, response *HookResponse) error {
+	for _, annotation := range response.Handled {
+		if _, ok := unhandled[annotation]; !ok {
+			return fmt.Errorf("hook %s handled %s, which is not an unhandled annotation of the Ingress", h.path, annotation)
+		}
+		t.Handle(annotation)
+	}
+	for _, d := range response.Diagnostics {
+		switch d.Severity {
+		case SeverityInfo, SeverityWarning, SeverityError:
+		default:
+			return fmt.Errorf("hook %s returned a diagnostic with severity %q, want info, warning or error", h.path, d.Severity)
+		}
+		t.Report(d.Annotation, d.Severity, "%s", d.Message)
+	}
+
+	annotation := ""
+	if len(response.Handled) == 1 {
+		annotation = response.Handled[0]
+	}
+	for i, filter := range response.Filters {
+		if filter.Type == "" {
+			return fmt.Errorf("hook %s returned filter %d without type", h.path, i+1)
+		}
+		t.Filters = mergeFilter(t, annotation, filter)
+	}
+	for i, object := range response.Resources {
+		resource := &unstructured.Unstructured{Object: object}
+		if resource.GetAPIVersion() == "" || resource.GetKind() == "" || resource.GetName() == "" {
+			return fmt.Errorf("hook %s returned resource %d without apiVersion, kind or metadata.name", h.path, i+1)
+		}
+		if resource.GetNamespace() == "" {
+			resource.SetNamespace(t.Ingress.Namespace)
+		}
+		t.AddPolicy(resource)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	annotationAuthURL             = "nginx.ingress.kubernetes.io/auth-url"
+	annotationAuthSignin          = "nginx.ingress.kubernetes.io/auth-signin"
+	annotationAuthResponseHeaders = "nginx.ingress.kubernetes.io/auth-response-headers"
+)
+
+// authService is the in-cluster Service an auth-url points at
+type authService struct {
+	name      string
+	namespace string
+	port      int64
+	path      string
+}
+
+// parseAuthURL resolves an auth-url to an in-cluster Service. URLs using
+// nginx variables or external hosts cannot be resolved.
+func parseAuthURL(raw, defaultNamespace string) (*authService, bool) {
+	if strings.Contains(raw, "$") {
+		return nil, false
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Hostname() == "" {
+		return nil, false
+	}
+
+	// name, name.namespace, name.namespace.svc or name.namespace.svc.cluster.local
+	parts := strings.Split(u.Hostname(), ".")
+	svc := &authService{name: parts[0], namespace: defaultNamespace, path: u.Path}
+	switch {
+	case len(parts) == 1:
+	case len(parts) == 2, len(parts) >= 3 && parts[2] == "svc":
+		svc.namespace = parts[1]
+	default:
+		return nil, false
+	}
+	if len(parts) > 3 && strings.Join(parts[3:], ".") != "cluster.local" {
+		return nil, false
+	}
+
+	switch {
+	case u.Port() != "":
+		port, err := strconv.ParseInt(u.Port(), 10, 32)
+		if err != nil {
+			return nil, false
+		}
+		svc.port = port
+	case u.Scheme == "https":
+		svc.port = 443
+	default:
+		svc.port = 80
+	}
+	return svc, true
+}
+
+// extractExtAuth converts auth-url into the target implementation's external
+// authorization policy, or records a migration task
+func (c *Converter) extractExtAuth(ing *networkingv1.Ingress, routes []interface{}) *unstructured.Unstructured {
+	authURL, exists := ing.Annotations[annotationAuthURL]
+	if !exists {
+		return nil
+	}
+
+	if signin, exists := ing.Annotations[annotationAuthSignin]; exists {
+		c.addDiagnostic(ing, annotationAuthSignin, SeverityWarning,
+			"redirect to %s on 401 is not part of external authorization; use an OIDC policy or let the auth service redirect", signin)
+	}
+
+	if c.opts.Target != TargetEnvoyGateway && c.opts.Target != TargetIstio {
+		c.addDiagnostic(ing, annotationAuthURL, SeverityWarning,
+			"external auth via %s needs a manual ext-auth policy (no core Gateway API equivalent); select --target=envoy-gateway or istio to generate one", authURL)
+		return nil
+	}
+
+	svc, ok := parseAuthURL(authURL, ing.Namespace)
+	if !ok {
+		c.addDiagnostic(ing, annotationAuthURL, SeverityWarning,
+			"auth service %s is not an in-cluster Service URL, needs a manual ext-auth policy", authURL)
+		return nil
+	}
+
+	var headers []interface{}
+	for _, h := range strings.Split(ing.Annotations[annotationAuthResponseHeaders], ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			headers = append(headers, strings.ToLower(h))
+		}
+	}
+
+	if c.opts.Target == TargetIstio {
+		return c.istioExtAuth(ing, svc, headers)
+	}
+
+	if svc.namespace != ing.Namespace {
+		c.addDiagnostic(ing, annotationAuthURL, SeverityInfo,
+			"auth service %s/%s is in another namespace; a ReferenceGrant for the SecurityPolicy is generated in %s", svc.namespace, svc.name, svc.namespace)
+	}
+
+	httpAuth := map[string]interface{}{
+		"backendRef": map[string]interface{}{
+			"name":      svc.name,
+			"namespace": svc.namespace,
+			"port":      svc.port,
+		},
+	}
+	if svc.path != "" {
+		httpAuth["path"] = svc.path
+	}
+	if len(headers) > 0 {
+		httpAuth["headersToBackend"] = headers
+	}
+
+	c.addDiagnostic(ing, annotationAuthURL, SeverityInfo, "external auth converted to an Envoy Gateway SecurityPolicy")
+	return newPolicy("gateway.envoyproxy.io/v1alpha1", "SecurityPolicy",
+		sanitizeName(fmt.Sprintf("%s-ext-auth", ing.Name)), ing.Namespace,
+		map[string]interface{}{
+			"targetRefs": routeTargetRefs(routes),
+			"extAuth": map[string]interface{}{
+				"http": httpAuth,
+			},
+		})
+}
+
+// istioExtAuth builds a CUSTOM AuthorizationPolicy on the Gateway, limited
+// to the Ingress hosts. The named provider must be declared in meshConfig.
+func (c *Converter) istioExtAuth(ing *networkingv1.Ingress, svc *authService, headers []interface{}) *unstructured.Unstructured {
+	rule := map[string]interface{}{}
+	if to := c.istioHostOperation(ing); to != nil {
+		rule["to"] = to
+	}
+	spec := map[string]interface{}{
+		"action": "CUSTOM",
+		"provider": map[string]interface{}{
+			"name": svc.name,
+		},
+		"rules": []interface{}{rule},
+	}
+
+	headerNote := ""
+	if len(headers) > 0 {
+		headerNote = fmt.Sprintf(", headersToUpstreamOnAllow: %v", headers)
+	}
+	c.addDiagnostic(ing, annotationAuthURL, SeverityInfo,
+		"external auth converted to an Istio AuthorizationPolicy; declare meshConfig extensionProviders %q with envoyExtAuthzHttp service %s.%s.svc.cluster.local, port %d, pathPrefix %q%s",
+		svc.name, svc.name, svc.namespace, svc.port, svc.path, headerNote)
+	return c.istioAuthorizationPolicy(ing, "ext-auth", spec)
+}
+
+// istioAuthorizationPolicy returns an AuthorizationPolicy with spec
+// targeting the Gateway of the Ingress, named after its use. The policy
+// lives in the Gateway namespace.
+func (c *Converter) istioAuthorizationPolicy(ing *networkingv1.Ingress, use string, spec map[string]interface{}) *unstructured.Unstructured {
+	gateway := c.gatewayRef(ing)
+	spec["targetRefs"] = []interface{}{
+		map[string]interface{}{
+			"group": gatewayv1.GroupName,
+			"kind":  "Gateway",
+			"name":  gateway.Name,
+		},
+	}
+
+	name := fmt.Sprintf("%s-%s", ing.Name, use)
+	if gateway.Namespace != ing.Namespace {
+		name = fmt.Sprintf("%s-%s-%s", ing.Namespace, ing.Name, use)
+	}
+	return newPolicy("security.istio.io/v1", "AuthorizationPolicy",
+		sanitizeName(name), gateway.Namespace, spec)
+}
+
+// istioHostOperation limits an AuthorizationPolicy rule on the Gateway to
+// the Ingress hosts, or returns nil for an Ingress without hosts
+func (c *Converter) istioHostOperation(ing *networkingv1.Ingress) []interface{} {
+	var hosts []interface{}
+	for _, rule := range c.ingressRules(ing) {
+		if rule.Host != "" {
+			hosts = append(hosts, string(c.hostname(ing, rule.Host)))
+		}
+	}
+	if len(hosts) == 0 {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"operation": map[string]interface{}{"hosts": hosts},
+		},
+	}
+}
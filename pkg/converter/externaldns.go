@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// external-dns annotations. Its Gateway API sources read the target from
+// the Gateway and everything else, such as hostname and ttl, from routes.
+const (
+	externalDNSPrefix         = "external-dns.alpha.kubernetes.io/"
+	externalDNSTarget         = "external-dns.alpha.kubernetes.io/target"
+	externalDNSHostnameSource = "external-dns.alpha.kubernetes.io/ingress-hostname-source"
+)
+
+// checkExternalDNS reports the external-dns annotations of ing that routes
+// and the Gateway do not carry, since DNS records would silently stop
+// following the Ingress
+func (c *Converter) checkExternalDNS(ing *networkingv1.Ingress) {
+	keys := annotationsWithPrefix(ing, externalDNSPrefix)
+	if len(keys) == 0 {
+		return
+	}
+
+	routeAnnotations := c.routeAnnotations(ing)
+	var missing []string
+	for _, key := range keys {
+		switch key {
+		case externalDNSTarget:
+			if !c.opts.EmitGateway {
+				gateway := c.gatewayRef(ing)
+				c.addDiagnostic(ing, key, SeverityWarning,
+					"external-dns reads the target from the Gateway; unless it is generated with --emit-gateway, set %s=%s on Gateway %s/%s",
+					key, ing.Annotations[key], gateway.Namespace, gateway.Name)
+			}
+		case externalDNSHostnameSource:
+			c.addDiagnostic(ing, key, SeverityInfo,
+				"applies to Ingresses only; external-dns takes route hostnames from spec.hostnames and the hostname annotation")
+		default:
+			if _, ok := routeAnnotations[key]; !ok {
+				missing = append(missing, key)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"external-dns reads %s from routes, which do not carry them; add --copy-annotations=%s*",
+			strings.Join(missing, ", "), externalDNSPrefix)
+	}
+	c.addDiagnostic(ing, "", SeverityInfo,
+		"DNS records follow the routes only when external-dns runs with a Gateway API source, e.g. --source=gateway-httproute")
+}
+
+// addExternalDNSAnnotations carries the external-dns target of ing to the
+// Gateway, where external-dns' Gateway API sources read it
+func (c *Converter) addExternalDNSAnnotations(b *gatewayBuilder, ing *networkingv1.Ingress) {
+	if _, ok := ing.Annotations[externalDNSTarget]; !ok {
+		return
+	}
+	c.addGatewayAnnotations(b, ing, []string{externalDNSTarget},
+		"give the Ingresses separate Gateways or publish a single target")
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+const nginxAnnotationPrefix = "nginx.ingress.kubernetes.io/"
+
+// controllerAnnotationPrefixes are the prefixes of the controller
+// annotations fidelity is scored on; of the ALB annotations only the
+// health check ones are scored
+var controllerAnnotationPrefixes = append([]string{
+	nginxAnnotationPrefix, traefikAnnotationPrefix, kongAnnotationPrefix,
+	haproxyAnnotationPrefix, haproxyIngressAnnotationPrefix,
+	"networking.gke.io/", "ingress.gcp.kubernetes.io/",
+	annotationGKEStaticIP, annotationGKERegionalStaticIP, annotationGKEAllowHTTP,
+}, albHealthCheckAnnotations...)
+
+// isControllerAnnotation reports whether key configures an ingress controller
+func isControllerAnnotation(key string) bool {
+	for _, prefix := range controllerAnnotationPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertedAnnotations are the nginx annotations the converter translates
+var convertedAnnotations = map[string]bool{
+	annotationRewriteTarget:         true,
+	annotationUseRegex:              true,
+	annotationXForwardedPrefix:      true,
+	annotationServerAlias:           true,
+	annotationConfigurationSnippet:  true,
+	annotationDefaultBackend:        true,
+	annotationCustomHTTPErrors:      true,
+	annotationSSLPassthrough:        true,
+	annotationAffinity:              true,
+	annotationSessionCookieName:     true,
+	annotationSessionCookieAge:      true,
+	annotationUpstreamHashBy:        true,
+	annotationLoadBalance:           true,
+	annotationLimitRPS:              true,
+	annotationLimitRPM:              true,
+	annotationLimitConnections:      true,
+	annotationProxyBodySize:         true,
+	annotationConnectTimeout:        true,
+	annotationProxyReadTimeout:      true,
+	annotationProxySendTimeout:      true,
+	annotationAuthURL:               true,
+	annotationAuthSignin:            true,
+	annotationAuthResponseHeaders:   true,
+	annotationHSTS:                  true,
+	annotationHSTSMaxAge:            true,
+	annotationHSTSIncludeSubdomains: true,
+	annotationHSTSPreload:           true,
+	annotationAuthTLSSecret:         true,
+	annotationAuthTLSVerifyClient:   true,
+	annotationAuthTLSVerifyDepth:    true,
+	annotationAuthTLSErrorPage:      true,
+	annotationAuthTLSPassCert:       true,
+	annotationAuthTLSMatchCN:        true,
+	annotationWhitelistSourceRange:  true,
+	annotationAllowlistSourceRange:  true,
+	annotationDenylistSourceRange:   true,
+	annotationLimitWhitelist:        true,
+	annotationLimitAllowlist:        true,
+	annotationTraefikMiddlewares:    true,
+	annotationTraefikEntryPoints:    true,
+	annotationTraefikTLS:            true,
+
+	annotationEnableCORS:                 true,
+	annotationCORSAllowOrigin:            true,
+	annotationCORSAllowMethods:           true,
+	annotationCORSAllowHeaders:           true,
+	annotationCORSExposeHeaders:          true,
+	annotationCORSAllowCredentials:       true,
+	annotationCORSMaxAge:                 true,
+	annotationProxyNextUpstream:          true,
+	annotationProxyNextUpstreamTries:     true,
+	annotationEnableOpenTelemetry:        true,
+	annotationOpenTelemetryTrustSpan:     true,
+	annotationOpenTelemetryOperationName: true,
+
+	annotationHAProxyTimeoutServer:             true,
+	annotationHAProxyTimeoutClient:             true,
+	annotationHAProxyTimeoutHTTPRequest:        true,
+	annotationHAProxyRequestRedirect:           true,
+	annotationHAProxyRequestRedirectCode:       true,
+	annotationHAProxyPathRewrite:               true,
+	annotationHAProxyAllowList:                 true,
+	annotationHAProxyWhitelist:                 true,
+	annotationHAProxyDenyList:                  true,
+	annotationHAProxyBlacklist:                 true,
+	annotationHAProxyIngressTimeoutServer:      true,
+	annotationHAProxyIngressTimeoutClient:      true,
+	annotationHAProxyIngressTimeoutHTTPRequest: true,
+	annotationHAProxyIngressRedirectTo:         true,
+	annotationHAProxyIngressRewriteTarget:      true,
+	annotationHAProxyIngressWhitelist:          true,
+	annotationHAProxyIngressAllowlist:          true,
+	annotationHAProxyIngressDenylist:           true,
+	annotationGKEFrontendConfig:                true,
+	annotationGKEStaticIP:                      true,
+	annotationGKERegionalStaticIP:              true,
+	annotationALBHealthcheckPath:               true,
+	annotationALBHealthcheckPort:               true,
+	annotationALBHealthcheckProtocol:           true,
+	annotationALBHealthcheckInterval:           true,
+	annotationALBHealthcheckTimeout:            true,
+	annotationALBHealthyThreshold:              true,
+	annotationALBUnhealthyThreshold:            true,
+	annotationALBSuccessCodes:                  true,
+	annotationKongMethods:                      true,
+	annotationKongStripPath:                    true,
+	annotationKongPlugins:                      true,
+	annotationKongOverride:                     true,
+	annotationKongHostHeader:                   true,
+	annotationKongPreserveHost:                 true,
+	annotationKongProtocols:                    true,
+
+	nginxAnnotationPrefix + "permanent-redirect": true,
+}
+
+// isConvertedAnnotation reports whether the converter translates key. The
+// Kong headers.* annotations are named after the header they match.
+func isConvertedAnnotation(key string) bool {
+	return convertedAnnotations[key] || strings.HasPrefix(key, kongHeadersPrefix)
+}
+
+// annotationWeights rates annotations that change routing or security
+// above tuning knobs; unlisted annotations weigh 1
+var annotationWeights = map[string]float64{
+	annotationRewriteTarget:                      3,
+	annotationConfigurationSnippet:               3,
+	annotationSSLPassthrough:                     3,
+	annotationAuthURL:                            3,
+	annotationAuthTLSSecret:                      3,
+	nginxAnnotationPrefix + "server-snippet":     3,
+	nginxAnnotationPrefix + "auth-type":          3,
+	nginxAnnotationPrefix + "canary":             3,
+	annotationWhitelistSourceRange:               3,
+	annotationAllowlistSourceRange:               3,
+	annotationDenylistSourceRange:                3,
+	annotationUseRegex:                           2,
+	annotationDefaultBackend:                     2,
+	annotationAffinity:                           2,
+	annotationLimitRPS:                           2,
+	annotationLimitRPM:                           2,
+	nginxAnnotationPrefix + "permanent-redirect": 2,
+	nginxAnnotationPrefix + "ssl-redirect":       2,
+	nginxAnnotationPrefix + "backend-protocol":   2,
+}
+
+// Fidelity measures how much of an Ingress's nginx configuration survived
+// conversion. Annotations converted cleanly count fully, annotations with
+// warnings count half and annotations dropped or not translated count zero,
+// each scaled by its weight.
+type Fidelity struct {
+	Ingress   string   `json:"ingress"`           // namespace/name of the source Ingress
+	Detected  int      `json:"detected"`          // controller annotations on the Ingress
+	Converted int      `json:"converted"`         // converted without warnings
+	Partial   int      `json:"partial"`           // converted with warnings
+	Dropped   []string `json:"dropped,omitempty"` // annotations not converted
+	Weight    float64  `json:"weight"`            // total weight of the detected annotations
+	Score     float64  `json:"score"`             // weighted converted share of Weight
+
+	// Annotations reports each detected annotation, in name order
+	Annotations []AnnotationResult `json:"annotations,omitempty"`
+}
+
+// Percent returns the fidelity as a percentage; an Ingress without nginx
+// annotations converts fully
+func (f Fidelity) Percent() float64 {
+	if f.Weight == 0 {
+		return 100
+	}
+	return 100 * f.Score / f.Weight
+}
+
+// String formats the fidelity for CLI output
+func (f Fidelity) String() string {
+	return fmt.Sprintf("%.0f%% (%d of %d annotations converted, %d partially)",
+		f.Percent(), f.Converted, f.Detected, f.Partial)
+}
+
+// AggregateFidelity combines per-Ingress fidelity into one weighted percentage
+func AggregateFidelity(reports []Fidelity) float64 {
+	var score, weight float64
+	for _, f := range reports {
+		score += f.Score
+		weight += f.Weight
+	}
+	if weight == 0 {
+		return 100
+	}
+	return 100 * score / weight
+}
+
+// Fidelity returns the fidelity of each Ingress converted by the last
+// Convert call
+func (c *Converter) Fidelity() []Fidelity {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.fidelity
+}
+
+// recordFidelity scores ing against the diagnostics recorded for it.
+// Annotations missing from checked were dropped as invalid.
+func (c *Converter) recordFidelity(ing, checked *networkingv1.Ingress) {
+	id := fmt.Sprintf("%s/%s", ing.Namespace, ing.Name)
+	diags := make(map[string][]Diagnostic)
+	for _, d := range c.diagnostics {
+		if d.Ingress != id || d.Annotation == "" {
+			continue
+		}
+		diags[d.Annotation] = append(diags[d.Annotation], d)
+	}
+
+	var keys []string
+	for key := range ing.Annotations {
+		if isControllerAnnotation(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	f := Fidelity{Ingress: id, Detected: len(keys)}
+	for _, key := range keys {
+		weight := annotationWeights[key]
+		if weight == 0 {
+			weight = 1
+		}
+		f.Weight += weight
+
+		_, valid := checked.Annotations[key]
+		result := c.annotationResult(id, key, valid, diags[key])
+		f.Annotations = append(f.Annotations, result)
+		switch result.Status {
+		case AnnotationSkipped:
+			f.Dropped = append(f.Dropped, key)
+		case AnnotationPartial:
+			f.Partial++
+			f.Score += weight / 2
+		default:
+			f.Converted++
+			f.Score += weight
+		}
+	}
+	c.fidelity = append(c.fidelity, f)
+}
+
+// severityRank orders severities from none to error
+func severityRank(severity string) int {
+	switch severity {
+	case SeverityInfo:
+		return 1
+	case SeverityWarning:
+		return 2
+	case SeverityError:
+		return 3
+	}
+	return 0
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"math/rand"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FixtureOptions controls the Ingresses fabricated by GenerateFixtures
+type FixtureOptions struct {
+	Count                 int
+	AnnotationsPerIngress int
+	// Namespaces spreads the Ingresses over team-01, team-02, ...; default 1
+	Namespaces int
+	// Seed makes runs reproducible; the same options give the same fixtures
+	Seed int64
+}
+
+// fixtureAnnotation is an annotation with a value ingress-nginx accepts
+type fixtureAnnotation struct {
+	name  string
+	value string
+}
+
+// fixtureAnnotations mixes converted annotations with common ones the
+// converter only reports, as found in real clusters
+var fixtureAnnotations = []fixtureAnnotation{
+	{annotationProxyReadTimeout, "60"},
+	{annotationProxySendTimeout, "120"},
+	{annotationConnectTimeout, "5"},
+	{annotationProxyBodySize, "8m"},
+	{annotationLimitRPS, "20"},
+	{annotationLimitConnections, "10"},
+	{annotationAffinity, "cookie"},
+	{annotationUpstreamHashBy, "$request_uri"},
+	{annotationWhitelistSourceRange, "10.0.0.0/8,192.168.0.0/16"},
+	{annotationAuthURL, "https://auth.example.com/oauth2/auth"},
+	{annotationConfigurationSnippet, `more_set_headers "X-Frame-Options: DENY";`},
+	{annotationHSTS, "true"},
+	{nginxAnnotationPrefix + "ssl-redirect", "true"},
+	{annotationEnableCORS, "true"},
+	{nginxAnnotationPrefix + "proxy-buffer-size", "16k"},
+	{annotationProxyNextUpstream, "error timeout"},
+}
+
+var (
+	fixtureApps    = []string{"shop", "api", "auth", "search", "media", "billing", "admin", "docs", "status", "cart"}
+	fixtureDomains = []string{"example.com", "example.org", "internal.example.net", "corp.example.io"}
+	fixturePaths   = []string{"/", "/api", "/v1", "/v2/users", "/static", "/healthz", "/graphql", "/admin"}
+	fixtureTypes   = []networkingv1.PathType{networkingv1.PathTypePrefix, networkingv1.PathTypePrefix, networkingv1.PathTypeExact, networkingv1.PathTypeImplementationSpecific}
+)
+
+// GenerateFixtures fabricates Ingresses with varied hosts, paths, TLS and
+// annotations, for benchmarks and for trying the converter on a realistic
+// volume before a production run
+func GenerateFixtures(opts FixtureOptions) []*networkingv1.Ingress {
+	if opts.Namespaces < 1 {
+		opts.Namespaces = 1
+	}
+	annotations := opts.AnnotationsPerIngress
+	if annotations > len(fixtureAnnotations) {
+		annotations = len(fixtureAnnotations)
+	}
+	rnd := rand.New(rand.NewSource(opts.Seed))
+	className := "nginx"
+
+	ingresses := make([]*networkingv1.Ingress, 0, opts.Count)
+	for i := 0; i < opts.Count; i++ {
+		app := fixtureApps[rnd.Intn(len(fixtureApps))]
+		name := fmt.Sprintf("%s-%d", app, i)
+
+		ing := &networkingv1.Ingress{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "networking.k8s.io/v1",
+				Kind:       "Ingress",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: fmt.Sprintf("team-%02d", i%opts.Namespaces+1),
+				Labels:    map[string]string{"app": app},
+			},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: &className,
+			},
+		}
+
+		if annotations > 0 {
+			ing.Annotations = make(map[string]string, annotations)
+			for _, j := range rnd.Perm(len(fixtureAnnotations))[:annotations] {
+				ing.Annotations[fixtureAnnotations[j].name] = fixtureAnnotations[j].value
+			}
+		}
+
+		var hosts []string
+		for h := 0; h < 1+rnd.Intn(3); h++ {
+			label := app
+			if h > 0 {
+				label = fmt.Sprintf("%s%d", app, h+1)
+			}
+			host := fmt.Sprintf("%s-%d.%s", label, i, fixtureDomains[rnd.Intn(len(fixtureDomains))])
+			hosts = append(hosts, host)
+			ing.Spec.Rules = append(ing.Spec.Rules, fixtureRule(rnd, host, name))
+		}
+
+		if rnd.Intn(2) == 0 {
+			ing.Spec.TLS = []networkingv1.IngressTLS{{Hosts: hosts, SecretName: name + "-tls"}}
+		}
+
+		ingresses = append(ingresses, ing)
+	}
+	return ingresses
+}
+
+// fixtureRule returns a rule for host routing one to four distinct paths
+// to the services of the Ingress
+func fixtureRule(rnd *rand.Rand, host, service string) networkingv1.IngressRule {
+	var paths []networkingv1.HTTPIngressPath
+	for _, p := range rnd.Perm(len(fixturePaths))[:1+rnd.Intn(4)] {
+		pathType := fixtureTypes[rnd.Intn(len(fixtureTypes))]
+		paths = append(paths, networkingv1.HTTPIngressPath{
+			Path:     fixturePaths[p],
+			PathType: &pathType,
+			Backend: networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: fmt.Sprintf("%s-%d", service, p%2),
+					Port: networkingv1.ServiceBackendPort{Number: int32(8080 + p%2)},
+				},
+			},
+		})
+	}
+	return networkingv1.IngressRule{
+		Host: host,
+		IngressRuleValue: networkingv1.IngressRuleValue{
+			HTTP: &networkingv1.HTTPIngressRuleValue{Paths: paths},
+		},
+	}
+}
@@ -0,0 +1,358 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	httpListener  = "http"
+	httpsListener = "https"
+)
+
+// gatewayBuilder collects the listeners of one generated Gateway
+type gatewayBuilder struct {
+	gateway *gatewayv1.Gateway
+	sources map[string]string // listener name to the Ingress that added it
+	shared  bool              // routes from other namespaces attach to it
+}
+
+// GenerateGateways synthesizes the Gateways referenced by the routes of
+// ingresses: one per namespace and Gateway name (i.e. per ingress class),
+// with an HTTP listener and an HTTPS listener per spec.tls host, followed
+// by the ReferenceGrants for certificates in other namespaces. The
+// GatewayClasses of IngressClasses added with AddReferenced come first. It
+// resets Diagnostics like Convert does.
+func (c *Converter) GenerateGateways(ingresses []interface{}) ([]interface{}, error) {
+	run := c.newRun()
+	gateways, err := run.generateGatewaysFor(ingresses)
+	c.publish(run)
+	return gateways, err
+}
+
+// generateGatewaysFor generates Gateways on a converter returned by newRun
+func (c *Converter) generateGatewaysFor(ingresses []interface{}) ([]interface{}, error) {
+	var converted []*networkingv1.Ingress
+	for _, ing := range ingresses {
+		if obj, ok := ing.(*unstructured.Unstructured); ok {
+			if ing := customResourceIngress(obj); ing != nil {
+				converted = append(converted, ing)
+			}
+			continue
+		}
+		ingress, ok := ing.(*networkingv1.Ingress)
+		if !ok {
+			return nil, fmt.Errorf("invalid ingress type")
+		}
+		if !c.skipManaged(ingress) {
+			converted = append(converted, ingress)
+		}
+	}
+	gateways := c.generateGateways(converted)
+	c.setAPIVersion(gateways)
+	return append(gateways, referenceGrants(gateways)...), nil
+}
+
+// generateGateways builds the Gateways for already filtered Ingresses
+func (c *Converter) generateGateways(ingresses []*networkingv1.Ingress) []interface{} {
+	builders := make(map[string]*gatewayBuilder)
+	var keys []string
+
+	for _, ing := range ingresses {
+		ref := c.gatewayRef(ing)
+		key := ref.Namespace + "/" + ref.Name
+
+		b, exists := builders[key]
+		if !exists {
+			b = c.newGatewayBuilder(ref.Namespace, ref.Name)
+			b.gateway.Spec.GatewayClassName = gatewayv1.ObjectName(c.gatewayClassFor(ing))
+			builders[key] = b
+			keys = append(keys, key)
+		}
+		if ing.Namespace != ref.Namespace {
+			b.shared = true
+		}
+		c.addListeners(b, ing)
+		c.addAddresses(b, ing)
+		c.addCertManagerAnnotations(b, ing)
+		c.addExternalDNSAnnotations(b, ing)
+		if ref.SectionName != "" && !b.hasListener(ref.SectionName) {
+			c.addDiagnostic(ing, "", SeverityWarning,
+				"routes attach to listener %q, which Gateway %s/%s does not generate; add it or rename a generated listener",
+				ref.SectionName, ref.Namespace, ref.Name)
+		}
+	}
+
+	sort.Strings(keys)
+	gateways := c.generateGatewayClasses(ingresses)
+	for _, key := range keys {
+		b := builders[key]
+		if b.shared {
+			b.allowAllNamespaces()
+		}
+		gateways = append(gateways, b.gateway)
+	}
+	return gateways
+}
+
+// addGatewayAnnotations sets the annotations keys of ing on the Gateway.
+// An annotation another Ingress already set to another value is kept and
+// reported with hint.
+func (c *Converter) addGatewayAnnotations(b *gatewayBuilder, ing *networkingv1.Ingress, keys []string, hint string) {
+	gw := b.gateway
+	for _, key := range keys {
+		value := ing.Annotations[key]
+		if existing, ok := gw.Annotations[key]; ok {
+			if existing != value {
+				c.addDiagnostic(ing, key, SeverityWarning,
+					"%q differs from %q, already set on Gateway %s/%s; %s", value, existing, gw.Namespace, gw.Name, hint)
+			}
+			continue
+		}
+		if gw.Annotations == nil {
+			gw.Annotations = make(map[string]string)
+		}
+		gw.Annotations[key] = value
+	}
+}
+
+// annotationsWithPrefix returns the sorted annotation keys of ing with prefix
+func annotationsWithPrefix(ing *networkingv1.Ingress, prefix string) []string {
+	var keys []string
+	for key := range ing.Annotations {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// hasListener reports whether the Gateway has a listener named name
+func (b *gatewayBuilder) hasListener(name string) bool {
+	for _, listener := range b.gateway.Spec.Listeners {
+		if string(listener.Name) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// allowAllNamespaces admits routes from every namespace on each listener;
+// listeners only accept routes from their own namespace by default
+func (b *gatewayBuilder) allowAllNamespaces() {
+	from := gatewayv1.NamespacesFromAll
+	for i := range b.gateway.Spec.Listeners {
+		listener := &b.gateway.Spec.Listeners[i]
+		if listener.AllowedRoutes == nil {
+			listener.AllowedRoutes = &gatewayv1.AllowedRoutes{}
+		}
+		listener.AllowedRoutes.Namespaces = &gatewayv1.RouteNamespaces{From: &from}
+	}
+}
+
+// newGatewayBuilder starts a Gateway with a hostname-less HTTP listener
+func (c *Converter) newGatewayBuilder(namespace, name string) *gatewayBuilder {
+	gatewayClass := c.gatewayClass()
+
+	return &gatewayBuilder{
+		gateway: &gatewayv1.Gateway{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "gateway.networking.k8s.io/v1",
+				Kind:       "Gateway",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: gatewayv1.ObjectName(gatewayClass),
+				Listeners: []gatewayv1.Listener{
+					{
+						Name:     httpListener,
+						Port:     80,
+						Protocol: gatewayv1.HTTPProtocolType,
+					},
+				},
+			},
+		},
+		sources: make(map[string]string),
+	}
+}
+
+// addListeners adds the listeners an Ingress needs to the Gateway
+func (c *Converter) addListeners(b *gatewayBuilder, ing *networkingv1.Ingress) {
+	// Routes of the default-backend annotation attach to a named listener
+	if c.opts.DefaultBackendMode == DefaultBackendListener && ing.Annotations[annotationDefaultBackend] != "" {
+		b.gateway.Spec.Listeners[0].Name = defaultBackendListener
+	}
+
+	if isPassthrough(ing) {
+		// Without TLSRoute, passthrough Ingresses are not converted
+		if !c.inChannel(FeatureTLSRoute) {
+			return
+		}
+		for _, rule := range c.ingressRules(ing) {
+			var hostname gatewayv1.Hostname
+			if rule.Host != "" {
+				hostname = c.hostname(ing, rule.Host)
+			}
+			c.addListener(b, ing, passthroughListener(hostname))
+		}
+		return
+	}
+
+	for _, tls := range ing.Spec.TLS {
+		if tls.SecretName == "" {
+			c.addDiagnostic(ing, "", SeverityWarning,
+				"spec.tls entry for %s has no secretName; nginx serves its default certificate, so add certificateRefs to the HTTPS listener manually",
+				strings.Join(tls.Hosts, ", "))
+			continue
+		}
+		// Certificates stay in the Ingress namespace, next to the routes
+		var secretNamespace string
+		if ing.Namespace != b.gateway.Namespace {
+			secretNamespace = ing.Namespace
+		}
+		if len(tls.Hosts) == 0 {
+			c.addListener(b, ing, httpsListenerFor("", secretNamespace, tls.SecretName))
+			continue
+		}
+		for _, host := range tls.Hosts {
+			if !c.validHostname(ing, host) {
+				continue
+			}
+			c.addListener(b, ing, httpsListenerFor(c.hostname(ing, host), secretNamespace, tls.SecretName))
+		}
+	}
+}
+
+// addListener adds listener unless an identical one exists. A listener for
+// the same hostname and port with another certificate or protocol
+// conflicts; the first Ingress wins.
+func (c *Converter) addListener(b *gatewayBuilder, ing *networkingv1.Ingress, listener gatewayv1.Listener) {
+	source := fmt.Sprintf("%s/%s", ing.Namespace, ing.Name)
+
+	for _, existing := range b.gateway.Spec.Listeners {
+		if existing.Port != listener.Port || !sameHostname(existing.Hostname, listener.Hostname) {
+			continue
+		}
+		if existing.Name == listener.Name && sameCertificates(existing.TLS, listener.TLS) {
+			return
+		}
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"listener %q on Gateway %s conflicts with listener %q from %s (same hostname and port); keeping the first",
+			listener.Name, b.gateway.Name, existing.Name, b.sources[string(existing.Name)])
+		return
+	}
+
+	b.gateway.Spec.Listeners = append(b.gateway.Spec.Listeners, listener)
+	b.sources[string(listener.Name)] = source
+}
+
+// httpsListenerFor builds a TLS-terminating listener for hostname. The
+// secret namespace is only set for secrets outside the Gateway namespace.
+func httpsListenerFor(hostname gatewayv1.Hostname, secretNamespace, secretName string) gatewayv1.Listener {
+	mode := gatewayv1.TLSModeTerminate
+	certRef := gatewayv1.SecretObjectReference{Name: gatewayv1.ObjectName(secretName)}
+	if secretNamespace != "" {
+		namespace := gatewayv1.Namespace(secretNamespace)
+		certRef.Namespace = &namespace
+	}
+	listener := gatewayv1.Listener{
+		Name:     listenerName(httpsListener, hostname),
+		Port:     443,
+		Protocol: gatewayv1.HTTPSProtocolType,
+		TLS: &gatewayv1.GatewayTLSConfig{
+			Mode:            &mode,
+			CertificateRefs: []gatewayv1.SecretObjectReference{certRef},
+		},
+	}
+	if hostname != "" {
+		listener.Hostname = &hostname
+	}
+	return listener
+}
+
+// passthroughListener builds a TLS passthrough listener for TLSRoutes
+func passthroughListener(hostname gatewayv1.Hostname) gatewayv1.Listener {
+	mode := gatewayv1.TLSModePassthrough
+	listener := gatewayv1.Listener{
+		Name:     listenerName("tls", hostname),
+		Port:     443,
+		Protocol: gatewayv1.TLSProtocolType,
+		TLS:      &gatewayv1.GatewayTLSConfig{Mode: &mode},
+		AllowedRoutes: &gatewayv1.AllowedRoutes{
+			Kinds: []gatewayv1.RouteGroupKind{{Kind: "TLSRoute"}},
+		},
+	}
+	if hostname != "" {
+		listener.Hostname = &hostname
+	}
+	return listener
+}
+
+// listenerName derives a listener name from its hostname, e.g.
+// https-app.example.com or https-wildcard.example.com
+func listenerName(prefix string, hostname gatewayv1.Hostname) gatewayv1.SectionName {
+	if hostname == "" {
+		return gatewayv1.SectionName(prefix)
+	}
+	host := strings.Replace(string(hostname), "*", "wildcard", 1)
+	return gatewayv1.SectionName(prefix + "-" + strings.ToLower(host))
+}
+
+// sameHostname compares optional listener hostnames
+func sameHostname(a, b *gatewayv1.Hostname) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// sameCertificates compares the certificates of two listener TLS configs
+func sameCertificates(a, b *gatewayv1.GatewayTLSConfig) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if len(a.CertificateRefs) != len(b.CertificateRefs) {
+		return false
+	}
+	for i := range a.CertificateRefs {
+		if a.CertificateRefs[i].Name != b.CertificateRefs[i].Name ||
+			!sameNamespace(a.CertificateRefs[i].Namespace, b.CertificateRefs[i].Namespace) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameNamespace compares optional reference namespaces
+func sameNamespace(a, b *gatewayv1.Namespace) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	kindIngressClass = "IngressClass"
+
+	// annotationDefaultIngressClass marks the IngressClass of Ingresses without class
+	annotationDefaultIngressClass = "ingressclass.kubernetes.io/is-default-class"
+)
+
+// ingressControllers maps the spec.controller of IngressClasses to the
+// target implementation taking over their traffic
+var ingressControllers = map[string]string{
+	"k8s.io/ingress-nginx":                TargetNginxGatewayFabric,
+	"nginx.org/ingress-controller":        TargetNginxGatewayFabric,
+	"istio.io/ingress-controller":         TargetIstio,
+	"cilium.io/ingress-controller":        TargetCilium,
+	"ingress-controllers.konghq.com/kong": TargetKong,
+	"traefik.io/ingress-controller":       TargetTraefik,
+}
+
+// IngressClassResources returns IngressClasses read from the cluster as
+// resources for AddReferenced
+func IngressClassResources(classes []*networkingv1.IngressClass) ([]interface{}, error) {
+	resources := make([]interface{}, 0, len(classes))
+	for _, class := range classes {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(class)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert IngressClass %s: %w", class.Name, err)
+		}
+		obj := &unstructured.Unstructured{Object: content}
+		obj.SetAPIVersion(networkingv1.SchemeGroupVersion.String())
+		obj.SetKind(kindIngressClass)
+		resources = append(resources, obj)
+	}
+	return resources, nil
+}
+
+// ingressClassObject returns the IngressClass of ing among the referenced
+// resources, the default IngressClass for Ingresses without class, nil
+// when it is not known
+func (c *Converter) ingressClassObject(ing *networkingv1.Ingress) *networkingv1.IngressClass {
+	var obj *unstructured.Unstructured
+	if name := ingressClass(ing); name != "" {
+		obj = c.referenced[referencedKey(kindIngressClass, "", name)]
+	} else {
+		for _, ref := range c.referenced {
+			if ref.GetKind() == kindIngressClass && ref.GetAnnotations()[annotationDefaultIngressClass] == "true" {
+				obj = ref
+				break
+			}
+		}
+	}
+	if obj == nil {
+		return nil
+	}
+
+	var class networkingv1.IngressClass
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &class); err != nil {
+		c.addDiagnostic(ing, "", SeverityWarning, "invalid IngressClass %s: %v", obj.GetName(), err)
+		return nil
+	}
+	return &class
+}
+
+// classTarget returns the target implementation for Gateways of an
+// IngressClass: the configured target, else the one taking over from its
+// controller, "" when unknown
+func (c *Converter) classTarget(class *networkingv1.IngressClass) string {
+	if c.opts.Target != "" {
+		return c.opts.Target
+	}
+	return ingressControllers[class.Spec.Controller]
+}
+
+// gatewayClassFor returns the GatewayClass of the Gateway for ing: the
+// configured one, else that of the implementation replacing the controller
+// of its IngressClass, else the default of gatewayClass. IngressClasses
+// replaced by the same implementation with different parameters each get
+// their own GatewayClass, named after the IngressClass.
+func (c *Converter) gatewayClassFor(ing *networkingv1.Ingress) string {
+	if c.opts.GatewayClass != "" {
+		return c.opts.GatewayClass
+	}
+	if class := c.ingressClassObject(ing); class != nil {
+		if p, ok := TargetProfile(c.classTarget(class)); ok {
+			if classParameters(class, p) != "" && c.parametersDiffer(p) {
+				return p.GatewayClass + "-" + class.Name
+			}
+			return p.GatewayClass
+		}
+	}
+	return c.gatewayClass()
+}
+
+// classParameters identifies the parameters of an IngressClass that become
+// the parametersRef of its GatewayClass, "" when there are none
+func classParameters(class *networkingv1.IngressClass, p Profile) string {
+	params := class.Spec.Parameters
+	if params == nil || p.ParametersKind == "" || params.Kind != p.ParametersKind {
+		return ""
+	}
+	var group, namespace string
+	if params.APIGroup != nil {
+		group = *params.APIGroup
+	}
+	if group != p.ParametersGroup {
+		return ""
+	}
+	if params.Namespace != nil {
+		namespace = *params.Namespace
+	}
+	return fmt.Sprintf("%s/%s/%s", groupKind(group, params.Kind), namespace, params.Name)
+}
+
+// parametersDiffer reports whether the known IngressClasses replaced by the
+// implementation of p would give its GatewayClass different parameters
+func (c *Converter) parametersDiffer(p Profile) bool {
+	params := make(map[string]bool)
+	for _, obj := range c.referenced {
+		if obj.GetKind() != kindIngressClass {
+			continue
+		}
+		var class networkingv1.IngressClass
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &class); err != nil {
+			continue
+		}
+		if other, ok := TargetProfile(c.classTarget(&class)); ok && other.GatewayClass == p.GatewayClass {
+			params[classParameters(&class, other)] = true
+		}
+	}
+	return len(params) > 1
+}
+
+// generateGatewayClasses builds the GatewayClasses of the Gateways for
+// ingresses whose IngressClass is known, with the controller of the
+// implementation replacing the ingress controller and the IngressClass
+// parameters when that implementation accepts them
+func (c *Converter) generateGatewayClasses(ingresses []*networkingv1.Ingress) []interface{} {
+	var classes []interface{}
+	// Parameters of the generated GatewayClasses, by name
+	seen := make(map[string]string)
+
+	for _, ing := range ingresses {
+		class := c.ingressClassObject(ing)
+		if class == nil {
+			continue
+		}
+		name := c.gatewayClassFor(ing)
+		target := c.classTarget(class)
+		p, ok := TargetProfile(target)
+		if params, exists := seen[name]; exists {
+			if ok && classParameters(class, p) != params {
+				c.addDiagnostic(ing, "", SeverityWarning,
+					"IngressClass %s parameters differ from those of GatewayClass %s, which all its Gateways share; not converted",
+					class.Name, name)
+			}
+			continue
+		}
+		if ok {
+			seen[name] = classParameters(class, p)
+		} else {
+			seen[name] = ""
+		}
+
+		switch {
+		case !ok:
+			c.addDiagnostic(ing, "", SeverityWarning,
+				"no Gateway implementation is known to replace controller %s of IngressClass %s; create GatewayClass %s or set --target",
+				class.Spec.Controller, class.Name, name)
+			continue
+		case p.ControllerName == "":
+			c.addDiagnostic(ing, "", SeverityInfo,
+				"GatewayClass %s is installed with %s and not generated", name, target)
+			continue
+		}
+
+		gatewayClass := &gatewayv1.GatewayClass{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "gateway.networking.k8s.io/v1",
+				Kind:       "GatewayClass",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: gatewayv1.GatewayClassSpec{
+				ControllerName: gatewayv1.GatewayController(p.ControllerName),
+				ParametersRef:  c.gatewayClassParameters(ing, class, p),
+			},
+		}
+		if c.opts.GatewayClass == "" && name != p.GatewayClass {
+			c.addDiagnostic(ing, "", SeverityInfo,
+				"IngressClasses replaced by %s have different parameters; GatewayClass %s generated for IngressClass %s",
+				target, name, class.Name)
+		}
+		classes = append(classes, gatewayClass)
+	}
+	return classes
+}
+
+// gatewayClassParameters converts the parameters of an IngressClass to a
+// parametersRef, when they are of the kind the implementation's
+// GatewayClasses take. Ingress controller parameters are reported.
+func (c *Converter) gatewayClassParameters(ing *networkingv1.Ingress, class *networkingv1.IngressClass, p Profile) *gatewayv1.ParametersReference {
+	params := class.Spec.Parameters
+	if params == nil {
+		return nil
+	}
+	var group string
+	if params.APIGroup != nil {
+		group = *params.APIGroup
+	}
+
+	if p.ParametersKind == "" || group != p.ParametersGroup || params.Kind != p.ParametersKind {
+		hint := "its GatewayClasses take no parameters"
+		if p.ParametersKind != "" {
+			hint = fmt.Sprintf("create a %s from them and set it as parametersRef", groupKind(p.ParametersGroup, p.ParametersKind))
+		}
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"IngressClass %s parameters %s %s configure the ingress controller; %s",
+			class.Name, groupKind(group, params.Kind), params.Name, hint)
+		return nil
+	}
+
+	ref := &gatewayv1.ParametersReference{
+		Group: gatewayv1.Group(group),
+		Kind:  gatewayv1.Kind(params.Kind),
+		Name:  params.Name,
+	}
+	if params.Namespace != nil {
+		namespace := gatewayv1.Namespace(*params.Namespace)
+		ref.Namespace = &namespace
+	}
+	return ref
+}
+
+// groupKind formats a kind with its API group, if any
+func groupKind(group, kind string) string {
+	if group == "" {
+		return kind
+	}
+	return kind + "." + group
+}
@@ -0,0 +1,474 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// GKE Ingress annotations
+const (
+	annotationGKEFrontendConfig      = "networking.gke.io/v1beta1.FrontendConfig"
+	annotationGKEManagedCertificates = "networking.gke.io/managed-certificates"
+	annotationGKEStaticIP            = "kubernetes.io/ingress.global-static-ip-name"
+	annotationGKERegionalStaticIP    = "kubernetes.io/ingress.regional-static-ip-name"
+	annotationGKEAllowHTTP           = "kubernetes.io/ingress.allow-http"
+	annotationGKEPreSharedCert       = "ingress.gcp.kubernetes.io/pre-shared-cert"
+
+	// Service annotations selecting the BackendConfig of each port
+	annotationGKEBackendConfig     = "cloud.google.com/backend-config"
+	annotationGKEBetaBackendConfig = "beta.cloud.google.com/backend-config"
+)
+
+const (
+	kindFrontendConfig = "FrontendConfig"
+	kindBackendConfig  = "BackendConfig"
+	kindService        = "Service"
+
+	// gceInternalClass selects the internal Application Load Balancer
+	gceInternalClass = "gce-internal"
+	// gkeInternalGatewayClass is the GKE GatewayClass of that load balancer
+	gkeInternalGatewayClass = "gke-l7-rilb"
+)
+
+// isGKEKind reports whether apiVersion and kind are a GKE FrontendConfig or
+// BackendConfig
+func isGKEKind(apiVersion, kind string) bool {
+	group, _, _ := strings.Cut(apiVersion, "/")
+	return (group == "networking.gke.io" && kind == kindFrontendConfig) ||
+		(group == "cloud.google.com" && kind == kindBackendConfig)
+}
+
+// hasBackendConfig reports whether a Service selects BackendConfigs
+func hasBackendConfig(annotations map[string]string) bool {
+	_, ok := annotations[annotationGKEBackendConfig]
+	_, beta := annotations[annotationGKEBetaBackendConfig]
+	return ok || beta
+}
+
+// backendConfigRefs is the value of the backend-config Service annotation
+type backendConfigRefs struct {
+	Default string            `json:"default,omitempty"`
+	Ports   map[string]string `json:"ports,omitempty"`
+}
+
+// backendConfigName returns the BackendConfig a Service port uses: the
+// one listed for its name or number, else the default, "" for none
+func backendConfigName(svc *corev1.Service, port networkingv1.ServiceBackendPort) (string, error) {
+	value, ok := svc.Annotations[annotationGKEBackendConfig]
+	if !ok {
+		value, ok = svc.Annotations[annotationGKEBetaBackendConfig]
+	}
+	if !ok {
+		return "", nil
+	}
+	var refs backendConfigRefs
+	if err := json.Unmarshal([]byte(value), &refs); err != nil {
+		return "", fmt.Errorf("invalid backend-config annotation on Service %s/%s: %w", svc.Namespace, svc.Name, err)
+	}
+
+	keys := []string{port.Name, strconv.Itoa(int(port.Number))}
+	if p := servicePort(svc, port); p != nil {
+		keys = append(keys, p.Name, strconv.Itoa(int(p.Port)))
+	}
+	for _, key := range keys {
+		if name, ok := refs.Ports[key]; ok && key != "" && key != "0" {
+			return name, nil
+		}
+	}
+	return refs.Default, nil
+}
+
+// backendService returns the Service of a backend, read from the cluster
+// when a Service lookup is set, else from the input manifests; nil if unknown
+func (c *Converter) backendService(namespace, name string) *corev1.Service {
+	if svc, ok := c.fetchedServices[namespace+"/"+name]; ok {
+		return svc
+	}
+	obj, ok := c.referenced[referencedKey(kindService, namespace, name)]
+	if !ok {
+		return nil
+	}
+	var svc corev1.Service
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &svc); err != nil {
+		return nil
+	}
+	return &svc
+}
+
+// BackendConfig spec fields the converter reads
+type backendConfigSpec struct {
+	TimeoutSec            *int64              `json:"timeoutSec,omitempty"`
+	ConnectionDraining    *connectionDraining `json:"connectionDraining,omitempty"`
+	SessionAffinity       *sessionAffinity    `json:"sessionAffinity,omitempty"`
+	SecurityPolicy        *securityPolicy     `json:"securityPolicy,omitempty"`
+	IAP                   *iapConfig          `json:"iap,omitempty"`
+	CDN                   *enabledConfig      `json:"cdn,omitempty"`
+	Logging               *loggingConfig      `json:"logging,omitempty"`
+	HealthCheck           *gkeHealthCheck     `json:"healthCheck,omitempty"`
+	CustomRequestHeaders  *customHeaders      `json:"customRequestHeaders,omitempty"`
+	CustomResponseHeaders *customHeaders      `json:"customResponseHeaders,omitempty"`
+}
+
+// gkeHealthCheck is the health check of a BackendConfig
+type gkeHealthCheck struct {
+	CheckIntervalSec   *int64 `json:"checkIntervalSec,omitempty"`
+	TimeoutSec         *int64 `json:"timeoutSec,omitempty"`
+	HealthyThreshold   *int64 `json:"healthyThreshold,omitempty"`
+	UnhealthyThreshold *int64 `json:"unhealthyThreshold,omitempty"`
+	Type               string `json:"type,omitempty"`
+	RequestPath        string `json:"requestPath,omitempty"`
+	Port               *int64 `json:"port,omitempty"`
+}
+
+type connectionDraining struct {
+	DrainingTimeoutSec int64 `json:"drainingTimeoutSec"`
+}
+
+type sessionAffinity struct {
+	AffinityType         string `json:"affinityType"`
+	AffinityCookieTtlSec *int64 `json:"affinityCookieTtlSec,omitempty"`
+}
+
+type securityPolicy struct {
+	Name string `json:"name"`
+}
+
+type iapConfig struct {
+	Enabled                bool `json:"enabled"`
+	OAuthClientCredentials *struct {
+		SecretName string `json:"secretName"`
+	} `json:"oauthclientCredentials,omitempty"`
+}
+
+type enabledConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
+type loggingConfig struct {
+	Enable     bool     `json:"enable"`
+	SampleRate *float64 `json:"sampleRate,omitempty"`
+}
+
+type customHeaders struct {
+	Headers []string `json:"headers"`
+}
+
+// FrontendConfig spec fields the converter reads
+type frontendConfigSpec struct {
+	SSLPolicy       *string `json:"sslPolicy,omitempty"`
+	RedirectToHTTPS *struct {
+		Enabled          bool   `json:"enabled"`
+		ResponseCodeName string `json:"responseCodeName,omitempty"`
+	} `json:"redirectToHttps,omitempty"`
+}
+
+// decodeSpec decodes the spec of a custom resource into out
+func decodeSpec(obj *unstructured.Unstructured, out interface{}) error {
+	data, err := json.Marshal(obj.Object["spec"])
+	if err != nil {
+		return fmt.Errorf("failed to decode %s spec: %w", obj.GetKind(), err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode %s spec: %w", obj.GetKind(), err)
+	}
+	return nil
+}
+
+// backendConfig returns the BackendConfig of an Ingress backend, nil when
+// its Service selects none or it is not in the input
+func (c *Converter) backendConfig(ing *networkingv1.Ingress, backend *networkingv1.IngressServiceBackend) (string, *backendConfigSpec) {
+	svc := c.backendService(ing.Namespace, backend.Name)
+	if svc == nil {
+		return "", nil
+	}
+	name, err := backendConfigName(svc, backend.Port)
+	if err != nil {
+		c.addDiagnostic(ing, "", SeverityWarning, "%v", err)
+		return "", nil
+	}
+	if name == "" {
+		return "", nil
+	}
+
+	obj, ok := c.referenced[referencedKey(kindBackendConfig, ing.Namespace, name)]
+	if !ok {
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"BackendConfig %s/%s of Service %s not found; add its manifest to the input to convert it", ing.Namespace, name, backend.Name)
+		return "", nil
+	}
+	var spec backendConfigSpec
+	if err := decodeSpec(obj, &spec); err != nil {
+		c.addDiagnostic(ing, "", SeverityWarning, "BackendConfig %s/%s not converted: %v", ing.Namespace, name, err)
+		return "", nil
+	}
+	return name, &spec
+}
+
+// applyBackendConfig adds the BackendConfig settings of a rule's backend
+// that HTTPRoute expresses: custom headers, and the backend timeout unless
+// a GCPBackendPolicy carries it
+func (c *Converter) applyBackendConfig(ing *networkingv1.Ingress, backend *networkingv1.IngressServiceBackend, rule *gatewayv1.HTTPRouteRule) {
+	name, spec := c.backendConfig(ing, backend)
+	if spec == nil {
+		return
+	}
+
+	if spec.CustomRequestHeaders != nil {
+		for _, header := range spec.CustomRequestHeaders.Headers {
+			if key, value, ok := c.gkeHeader(ing, name, header); ok {
+				rule.Filters = setRequestHeader(rule.Filters, key, value)
+			}
+		}
+	}
+	if spec.CustomResponseHeaders != nil {
+		for _, header := range spec.CustomResponseHeaders.Headers {
+			if key, value, ok := c.gkeHeader(ing, name, header); ok {
+				rule.Filters = setResponseHeader(rule.Filters, key, value)
+			}
+		}
+	}
+
+	if spec.TimeoutSec != nil && c.opts.Target != TargetGKE && rule.Timeouts == nil {
+		if !c.inChannel(FeatureHTTPRouteTimeouts) {
+			c.unsupportedFeature(ing, "", FeatureHTTPRouteTimeouts, "BackendConfig timeout")
+			return
+		}
+		timeout := gatewayv1.Duration(fmt.Sprintf("%ds", *spec.TimeoutSec))
+		rule.Timeouts = &gatewayv1.HTTPRouteTimeouts{BackendRequest: &timeout}
+	}
+}
+
+// gkeHeader splits a BackendConfig "Name:Value" header. Values with load
+// balancer variables such as {client_region} are only expanded on GKE.
+func (c *Converter) gkeHeader(ing *networkingv1.Ingress, config, header string) (string, string, bool) {
+	key, value, ok := strings.Cut(header, ":")
+	key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+	if !ok || key == "" {
+		c.addDiagnostic(ing, "", SeverityWarning, "BackendConfig %s header %q is not Name:Value, not converted", config, header)
+		return "", "", false
+	}
+	if strings.Contains(value, "{") && c.opts.Target != TargetGKE {
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"BackendConfig %s header %s uses load balancer variables, which only GKE expands", config, key)
+	}
+	return key, value, true
+}
+
+// extractGKEPolicies converts the BackendConfigs of the Ingress backends
+// and its FrontendConfig into GCPBackendPolicies and a GCPGatewayPolicy for
+// the gke target, reporting the settings Gateway API cannot express
+func (c *Converter) extractGKEPolicies(ing *networkingv1.Ingress) []interface{} {
+	var policies []interface{}
+
+	seen := make(map[string]bool)
+	for _, backend := range serviceBackends(ing) {
+		if seen[backend.Name] {
+			continue
+		}
+		seen[backend.Name] = true
+		name, spec := c.backendConfig(ing, backend)
+		if spec == nil {
+			continue
+		}
+		if policy := c.gcpBackendPolicy(ing, backend.Name, name, spec); policy != nil {
+			policies = append(policies, policy)
+		}
+	}
+
+	if policy := c.gcpGatewayPolicy(ing); policy != nil {
+		policies = append(policies, policy)
+	}
+	return policies
+}
+
+// gcpBackendPolicy converts the load balancer settings of a BackendConfig
+// into a GCPBackendPolicy for the Service, nil for other targets or when
+// there are none
+func (c *Converter) gcpBackendPolicy(ing *networkingv1.Ingress, service, config string, spec *backendConfigSpec) *unstructured.Unstructured {
+	if spec.CDN != nil && spec.CDN.Enabled {
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"BackendConfig %s enables Cloud CDN, which GKE Gateway policies do not configure; set it up on the backend service manually", config)
+	}
+
+	settings := make(map[string]interface{})
+	if spec.TimeoutSec != nil && c.opts.Target == TargetGKE {
+		settings["timeoutSec"] = *spec.TimeoutSec
+	}
+	if spec.ConnectionDraining != nil {
+		settings["connectionDraining"] = map[string]interface{}{"drainingTimeoutSec": spec.ConnectionDraining.DrainingTimeoutSec}
+	}
+	if spec.SessionAffinity != nil && spec.SessionAffinity.AffinityType != "" {
+		affinity := map[string]interface{}{"type": spec.SessionAffinity.AffinityType}
+		if spec.SessionAffinity.AffinityCookieTtlSec != nil {
+			affinity["cookieTtlSec"] = *spec.SessionAffinity.AffinityCookieTtlSec
+		}
+		settings["sessionAffinity"] = affinity
+	}
+	if spec.SecurityPolicy != nil && spec.SecurityPolicy.Name != "" {
+		settings["securityPolicy"] = spec.SecurityPolicy.Name
+	}
+	if spec.IAP != nil {
+		iap := map[string]interface{}{"enabled": spec.IAP.Enabled}
+		if spec.IAP.OAuthClientCredentials != nil && spec.IAP.OAuthClientCredentials.SecretName != "" {
+			iap["oauth2ClientSecret"] = map[string]interface{}{"name": spec.IAP.OAuthClientCredentials.SecretName}
+		}
+		settings["iap"] = iap
+	}
+	if spec.Logging != nil {
+		logging := map[string]interface{}{"enabled": spec.Logging.Enable}
+		if spec.Logging.SampleRate != nil {
+			// GCPBackendPolicy counts the rate in millionths
+			logging["sampleRate"] = int64(*spec.Logging.SampleRate * 1000000)
+		}
+		settings["logging"] = logging
+	}
+	if len(settings) == 0 {
+		return nil
+	}
+
+	var keys []string
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if c.opts.Target != TargetGKE {
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"BackendConfig %s settings %s have no Gateway API equivalent; select --target=gke to generate a GCPBackendPolicy", config, strings.Join(keys, ", "))
+		return nil
+	}
+	if _, ok := settings["iap"]; ok && spec.IAP.Enabled {
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"BackendConfig %s enables IAP; set iap.clientID in the GCPBackendPolicy and store the OAuth client secret under the key \"key\" of its Secret", config)
+	}
+
+	c.addDiagnostic(ing, "", SeverityInfo, "BackendConfig %s converted to a GCPBackendPolicy for Service %s", config, service)
+	return newPolicy("networking.gke.io/v1", "GCPBackendPolicy", sanitizeName(service+"-backendpolicy"), ing.Namespace,
+		map[string]interface{}{
+			"default": settings,
+			"targetRef": map[string]interface{}{
+				"group": "",
+				"kind":  kindService,
+				"name":  service,
+			},
+		})
+}
+
+// frontendConfig returns the FrontendConfig the Ingress selects, nil when
+// none or it is not in the input
+func (c *Converter) frontendConfig(ing *networkingv1.Ingress) (string, *frontendConfigSpec) {
+	name, ok := ing.Annotations[annotationGKEFrontendConfig]
+	if !ok {
+		return "", nil
+	}
+	obj, ok := c.referenced[referencedKey(kindFrontendConfig, ing.Namespace, name)]
+	if !ok {
+		c.addDiagnostic(ing, annotationGKEFrontendConfig, SeverityWarning,
+			"FrontendConfig %s/%s not found; add its manifest to the input to convert it", ing.Namespace, name)
+		return "", nil
+	}
+	var spec frontendConfigSpec
+	if err := decodeSpec(obj, &spec); err != nil {
+		c.addDiagnostic(ing, annotationGKEFrontendConfig, SeverityWarning, "FrontendConfig %s/%s not converted: %v", ing.Namespace, name, err)
+		return "", nil
+	}
+	return name, &spec
+}
+
+// gcpGatewayPolicy converts the SSL policy of the Ingress's FrontendConfig
+// into a GCPGatewayPolicy for its Gateway, nil for other targets
+func (c *Converter) gcpGatewayPolicy(ing *networkingv1.Ingress) *unstructured.Unstructured {
+	name, spec := c.frontendConfig(ing)
+	if spec == nil {
+		return nil
+	}
+
+	if spec.RedirectToHTTPS != nil && spec.RedirectToHTTPS.Enabled {
+		c.addDiagnostic(ing, annotationGKEFrontendConfig, SeverityWarning,
+			"FrontendConfig %s redirectToHttps not converted: the route serves every Gateway listener, so redirect from a separate HTTPRoute attached to the HTTP listener", name)
+	}
+	if spec.SSLPolicy == nil || *spec.SSLPolicy == "" {
+		return nil
+	}
+	if c.opts.Target != TargetGKE {
+		c.addDiagnostic(ing, annotationGKEFrontendConfig, SeverityWarning,
+			"FrontendConfig %s SSL policy %s has no Gateway API equivalent; select --target=gke to generate a GCPGatewayPolicy", name, *spec.SSLPolicy)
+		return nil
+	}
+
+	ref := c.gatewayRef(ing)
+	return newPolicy("networking.gke.io/v1", "GCPGatewayPolicy", sanitizeName(ref.Name+"-"+name), ref.Namespace,
+		map[string]interface{}{
+			"default": map[string]interface{}{"sslPolicy": *spec.SSLPolicy},
+			"targetRef": map[string]interface{}{
+				"group": gatewayv1.GroupName,
+				"kind":  "Gateway",
+				"name":  ref.Name,
+			},
+		})
+}
+
+// addAddresses sets the static IP an Ingress reserves as the address of
+// its Gateway
+func (c *Converter) addAddresses(b *gatewayBuilder, ing *networkingv1.Ingress) {
+	annotation, name, ok := firstAnnotation(ing, []string{annotationGKEStaticIP, annotationGKERegionalStaticIP})
+	if !ok || name == "" {
+		return
+	}
+	addressType := gatewayv1.NamedAddressType
+	for _, address := range b.gateway.Spec.Addresses {
+		if address.Value == name {
+			return
+		}
+		c.addDiagnostic(ing, annotation, SeverityWarning,
+			"static IP %s differs from %s, already set on Gateway %s/%s; give the Ingresses separate Gateways", name, address.Value, b.gateway.Namespace, b.gateway.Name)
+		return
+	}
+	b.gateway.Spec.Addresses = append(b.gateway.Spec.Addresses, gatewayv1.GatewayAddress{Type: &addressType, Value: name})
+}
+
+// checkGCEIngress reports GKE Ingress settings the Gateway or GKE
+// resources need to reproduce
+func (c *Converter) checkGCEIngress(ing *networkingv1.Ingress) {
+	if ingressClass(ing) == gceInternalClass && c.gatewayClass() != gkeInternalGatewayClass {
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"gce-internal Ingresses use the internal load balancer; set --gateway-class=%s for their Gateway", gkeInternalGatewayClass)
+	}
+	if ing.Annotations[annotationGKEAllowHTTP] == "false" {
+		c.addDiagnostic(ing, annotationGKEAllowHTTP, SeverityWarning,
+			"HTTP is disabled; remove the HTTP listener from the Gateway")
+	}
+	if certs, ok := ing.Annotations[annotationGKEPreSharedCert]; ok {
+		c.addDiagnostic(ing, annotationGKEPreSharedCert, SeverityWarning,
+			"pre-shared certificates %s not converted; set them in the networking.gke.io/pre-shared-certs TLS option of the Gateway's HTTPS listener", certs)
+	}
+	if certs, ok := ing.Annotations[annotationGKEManagedCertificates]; ok {
+		c.addDiagnostic(ing, annotationGKEManagedCertificates, SeverityWarning,
+			"ManagedCertificates %s are not used by Gateways; issue the certificates with Certificate Manager and set the networking.gke.io/certmap Gateway annotation", certs)
+	}
+}
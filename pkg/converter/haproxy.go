@@ -0,0 +1,291 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// HAProxy Technologies controller annotations
+const (
+	haproxyAnnotationPrefix = "haproxy.org/"
+
+	annotationHAProxyTimeoutServer       = "haproxy.org/timeout-server"
+	annotationHAProxyTimeoutClient       = "haproxy.org/timeout-client"
+	annotationHAProxyTimeoutHTTPRequest  = "haproxy.org/timeout-http-request"
+	annotationHAProxySSLRedirect         = "haproxy.org/ssl-redirect"
+	annotationHAProxyRequestRedirect     = "haproxy.org/request-redirect"
+	annotationHAProxyRequestRedirectCode = "haproxy.org/request-redirect-code"
+	annotationHAProxyPathRewrite         = "haproxy.org/path-rewrite"
+	annotationHAProxyAllowList           = "haproxy.org/allow-list"
+	annotationHAProxyWhitelist           = "haproxy.org/whitelist"
+	annotationHAProxyDenyList            = "haproxy.org/deny-list"
+	annotationHAProxyBlacklist           = "haproxy.org/blacklist"
+)
+
+// HAProxy Ingress (community) controller annotations
+const (
+	haproxyIngressAnnotationPrefix = "ingress.kubernetes.io/"
+
+	annotationHAProxyIngressTimeoutServer      = "ingress.kubernetes.io/timeout-server"
+	annotationHAProxyIngressTimeoutClient      = "ingress.kubernetes.io/timeout-client"
+	annotationHAProxyIngressTimeoutHTTPRequest = "ingress.kubernetes.io/timeout-http-request"
+	annotationHAProxyIngressSSLRedirect        = "ingress.kubernetes.io/ssl-redirect"
+	annotationHAProxyIngressRedirectTo         = "ingress.kubernetes.io/redirect-to"
+	annotationHAProxyIngressRewriteTarget      = "ingress.kubernetes.io/rewrite-target"
+	annotationHAProxyIngressWhitelist          = "ingress.kubernetes.io/whitelist-source-range"
+	annotationHAProxyIngressAllowlist          = "ingress.kubernetes.io/allowlist-source-range"
+	annotationHAProxyIngressDenylist           = "ingress.kubernetes.io/denylist-source-range"
+)
+
+var (
+	// haproxyBackendTimeouts bound the time HAProxy waits for the backend
+	haproxyBackendTimeouts = []string{annotationHAProxyTimeoutServer, annotationHAProxyIngressTimeoutServer}
+
+	// haproxyRequestTimeouts bound the time HAProxy waits for the client
+	haproxyRequestTimeouts = []string{
+		annotationHAProxyTimeoutHTTPRequest, annotationHAProxyTimeoutClient,
+		annotationHAProxyIngressTimeoutHTTPRequest, annotationHAProxyIngressTimeoutClient,
+	}
+
+	// haproxyDurationRegex matches HAProxy times: a number with an optional
+	// unit, milliseconds when none is given
+	haproxyDurationRegex = regexp.MustCompile(`^([0-9]+)(us|ms|s|m|h|d)?$`)
+
+	// haproxyPrefixRewriteRegex matches path-rewrite expressions such as
+	// ^/foo/(.*) that keep everything after a literal prefix
+	haproxyPrefixRewriteRegex = regexp.MustCompile(`^\^?(/[A-Za-z0-9._~/-]*)\(\.\*\)\$?$`)
+
+	// haproxyCaptureRegex matches path-rewrite replacements such as /bar/\1
+	haproxyCaptureRegex = regexp.MustCompile(`^(/[^\\]*)\\1$`)
+
+	// haproxyBackrefRegex matches the \1 style references of a replacement
+	haproxyBackrefRegex = regexp.MustCompile(`\\([0-9])`)
+)
+
+var haproxyDurationUnits = map[string]time.Duration{
+	"us": time.Microsecond,
+	"":   time.Millisecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+}
+
+// parseHAProxyDuration reads an HAProxy time such as 30s or 5000
+func parseHAProxyDuration(value string) (time.Duration, bool) {
+	m := haproxyDurationRegex.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(n) * haproxyDurationUnits[m[2]], true
+}
+
+// firstAnnotation returns the first of annotations set on the Ingress
+func firstAnnotation(ing *networkingv1.Ingress, annotations []string) (string, string, bool) {
+	for _, annotation := range annotations {
+		if value, ok := ing.Annotations[annotation]; ok {
+			return annotation, value, true
+		}
+	}
+	return "", "", false
+}
+
+// haproxyTimeout reads the first of the given HAProxy timeout annotations
+func (c *Converter) haproxyTimeout(ing *networkingv1.Ingress, annotations []string) (time.Duration, string, bool) {
+	annotation, value, ok := firstAnnotation(ing, annotations)
+	if !ok {
+		return 0, "", false
+	}
+	timeout, ok := parseHAProxyDuration(value)
+	if !ok || timeout < time.Millisecond {
+		c.addDiagnostic(ing, annotation, SeverityWarning, "invalid timeout %q, not converted", value)
+		return 0, "", false
+	}
+	return timeout, annotation, true
+}
+
+// haproxyTimeouts maps timeout-server to the backendRequest timeout and
+// timeout-http-request or timeout-client to the request timeout
+func (c *Converter) haproxyTimeouts(ing *networkingv1.Ingress) *gatewayv1.HTTPRouteTimeouts {
+	backend, _, hasBackend := c.haproxyTimeout(ing, haproxyBackendTimeouts)
+	request, annotation, hasRequest := c.haproxyTimeout(ing, haproxyRequestTimeouts)
+	if !hasBackend && !hasRequest {
+		return nil
+	}
+
+	timeouts := &gatewayv1.HTTPRouteTimeouts{}
+	if hasBackend {
+		backendRequest := formatDuration(backend)
+		timeouts.BackendRequest = &backendRequest
+	}
+	if hasRequest {
+		if strings.HasSuffix(annotation, "/timeout-client") {
+			c.addDiagnostic(ing, annotation, SeverityInfo,
+				"timeout-client is an inactivity timeout in HAProxy, converted to the request timeout which bounds the whole request")
+		}
+		// backendRequest must not exceed the request timeout
+		if hasBackend && request < backend {
+			c.addDiagnostic(ing, annotation, SeverityWarning,
+				"request timeout %s is shorter than the server timeout %s; using %s for both",
+				formatDuration(request), formatDuration(backend), formatDuration(backend))
+			request = backend
+		}
+		value := formatDuration(request)
+		timeouts.Request = &value
+	}
+	return timeouts
+}
+
+// haproxyRedirectFilters converts request-redirect and redirect-to into
+// RequestRedirect filters. HTTP to HTTPS redirects are left to a separate
+// route since the converted routes serve every listener.
+func (c *Converter) haproxyRedirectFilters(ing *networkingv1.Ingress) []gatewayv1.HTTPRouteFilter {
+	for _, annotation := range []string{annotationHAProxySSLRedirect, annotationHAProxyIngressSSLRedirect} {
+		if ing.Annotations[annotation] == "true" {
+			c.addDiagnostic(ing, annotation, SeverityWarning,
+				"ssl-redirect not converted: the route serves every Gateway listener, so redirect from a separate HTTPRoute attached to the HTTP listener")
+		}
+	}
+
+	var filters []gatewayv1.HTTPRouteFilter
+	if target, ok := ing.Annotations[annotationHAProxyRequestRedirect]; ok {
+		code := 302
+		if value, ok := ing.Annotations[annotationHAProxyRequestRedirectCode]; ok {
+			n, err := strconv.Atoi(value)
+			if err != nil || (n != 301 && n != 302) {
+				c.addDiagnostic(ing, annotationHAProxyRequestRedirectCode, SeverityWarning,
+					"redirect code %q is not supported by RequestRedirect filters, using 302", value)
+			} else {
+				code = n
+			}
+		}
+		// The target is a host with an optional port; the path is kept
+		if redirect := redirectFilter(code, "//"+strings.TrimSpace(target)); redirect != nil && redirect.Path == nil {
+			filters = append(filters, gatewayv1.HTTPRouteFilter{
+				Type:            gatewayv1.HTTPRouteFilterRequestRedirect,
+				RequestRedirect: redirect,
+			})
+		} else {
+			c.addDiagnostic(ing, annotationHAProxyRequestRedirect, SeverityWarning,
+				"redirect to %q cannot be expressed as a RequestRedirect filter, not converted", target)
+		}
+	}
+
+	if target, ok := ing.Annotations[annotationHAProxyIngressRedirectTo]; ok {
+		if redirect := redirectFilter(302, strings.TrimSpace(target)); redirect != nil {
+			filters = append(filters, gatewayv1.HTTPRouteFilter{
+				Type:            gatewayv1.HTTPRouteFilterRequestRedirect,
+				RequestRedirect: redirect,
+			})
+		} else {
+			c.addDiagnostic(ing, annotationHAProxyIngressRedirectTo, SeverityWarning,
+				"redirect to %q cannot be expressed as a RequestRedirect filter, not converted", target)
+		}
+	}
+	return filters
+}
+
+// haproxyRewriteFilter converts path-rewrite and rewrite-target for a rule
+// matching path, nil when the Ingress rewrites nothing
+func (c *Converter) haproxyRewriteFilter(ing *networkingv1.Ingress, path gatewayv1.HTTPPathMatch) *gatewayv1.HTTPURLRewriteFilter {
+	rewrite := &pathRewrite{matchType: *path.Type, matched: *path.Value, value: *path.Value}
+
+	annotation := annotationHAProxyPathRewrite
+	if expr, ok := ing.Annotations[annotationHAProxyPathRewrite]; ok {
+		if err := rewrite.haproxyPathRewrite(expr); err != nil {
+			c.addDiagnostic(ing, annotation, SeverityWarning, "path-rewrite %q on path %q not converted: %v", expr, *path.Value, err)
+			return nil
+		}
+	} else if target, ok := ing.Annotations[annotationHAProxyIngressRewriteTarget]; ok {
+		annotation = annotationHAProxyIngressRewriteTarget
+		rewrite.value = target
+	} else {
+		return nil
+	}
+
+	f := rewrite.filter()
+	if f != nil && *path.Type == gatewayv1.PathMatchRegularExpression {
+		c.addDiagnostic(ing, annotation, SeverityWarning,
+			"path rewrite of regular expression path %q cannot be expressed in Gateway API, not converted", *path.Value)
+		return nil
+	}
+	return f
+}
+
+// haproxyPathRewrite applies a path-rewrite expression: a single path
+// replacing the whole path, or a regex and replacement. Regexes are
+// converted when they keep everything after a literal prefix, or when the
+// rule matches one exact path.
+func (r *pathRewrite) haproxyPathRewrite(expr string) error {
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 1:
+		r.value, r.full = fields[0], true
+		return nil
+	case 2:
+	default:
+		return fmt.Errorf("expected a path, or a regex and a replacement")
+	}
+
+	pattern, replacement := fields[0], fields[1]
+	if r.matchType == gatewayv1.PathMatchExact {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+		// HAProxy writes captures as \1, Go as ${1}
+		template := haproxyBackrefRegex.ReplaceAllString(replacement, "$${$1}")
+		r.value = re.ReplaceAllString(r.matched, template)
+		return nil
+	}
+
+	pm := haproxyPrefixRewriteRegex.FindStringSubmatch(pattern)
+	rm := haproxyCaptureRegex.FindStringSubmatch(replacement)
+	if pm == nil || rm == nil {
+		return fmt.Errorf("only prefix replacements such as ^/foo/(.*) /bar/\\1 can be expressed in Gateway API")
+	}
+	// ReplacePrefixMatch keeps the separator after the prefix
+	if strings.HasSuffix(pm[1], "/") != strings.HasSuffix(rm[1], "/") {
+		return fmt.Errorf("the regex and replacement disagree on the trailing slash")
+	}
+	if trimPathSlash(pm[1]) != trimPathSlash(r.matched) {
+		return fmt.Errorf("the regex prefix %s differs from the matched path", pm[1])
+	}
+	r.value = trimPathSlash(rm[1])
+	return nil
+}
+
+// trimPathSlash removes the trailing slash of a path other than /
+func trimPathSlash(path string) string {
+	if trimmed := strings.TrimSuffix(path, "/"); trimmed != "" {
+		return trimmed
+	}
+	return "/"
+}
@@ -0,0 +1,343 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// AWS Load Balancer Controller health check annotations
+const (
+	annotationALBHealthcheckPath     = "alb.ingress.kubernetes.io/healthcheck-path"
+	annotationALBHealthcheckPort     = "alb.ingress.kubernetes.io/healthcheck-port"
+	annotationALBHealthcheckProtocol = "alb.ingress.kubernetes.io/healthcheck-protocol"
+	annotationALBHealthcheckInterval = "alb.ingress.kubernetes.io/healthcheck-interval-seconds"
+	annotationALBHealthcheckTimeout  = "alb.ingress.kubernetes.io/healthcheck-timeout-seconds"
+	annotationALBHealthyThreshold    = "alb.ingress.kubernetes.io/healthy-threshold-count"
+	annotationALBUnhealthyThreshold  = "alb.ingress.kubernetes.io/unhealthy-threshold-count"
+	annotationALBSuccessCodes        = "alb.ingress.kubernetes.io/success-codes"
+)
+
+// albHealthCheckAnnotations are the ALB annotations a health check is built from
+var albHealthCheckAnnotations = []string{
+	annotationALBHealthcheckPath, annotationALBHealthcheckPort, annotationALBHealthcheckProtocol,
+	annotationALBHealthcheckInterval, annotationALBHealthcheckTimeout,
+	annotationALBHealthyThreshold, annotationALBUnhealthyThreshold, annotationALBSuccessCodes,
+}
+
+// maxExpectedStatuses bounds the status codes a success-codes range expands to
+const maxExpectedStatuses = 100
+
+// healthCheck is an active backend health check
+type healthCheck struct {
+	source     string // what configured it, for diagnostics
+	annotation string // the annotation diagnostics are recorded against
+	protocol   string // HTTP, HTTPS, HTTP2 or TCP
+	path       string
+	port       int64 // 0 for the serving port
+	interval   int64 // seconds, 0 when unset
+	timeout    int64
+	healthy    int64
+	unhealthy  int64
+	statuses   []int64 // expected status codes, nil for 200
+}
+
+// serviceHealthCheck is the health check of a backend Service
+type serviceHealthCheck struct {
+	service string
+	check   *healthCheck
+}
+
+// healthChecks returns the health check of each backend Service: the one
+// of its GKE BackendConfig, else the one of the Ingress's ALB annotations.
+// Gateway API has no health check, so targets without a policy for it get
+// a warning instead.
+func (c *Converter) healthChecks(ing *networkingv1.Ingress) []serviceHealthCheck {
+	alb := c.albHealthCheck(ing)
+
+	var checks []serviceHealthCheck
+	for _, svc := range backendServiceNames(ing) {
+		check := alb
+		backend := &networkingv1.IngressServiceBackend{Name: svc}
+		for _, b := range serviceBackends(ing) {
+			if b.Name == svc {
+				backend = b
+				break
+			}
+		}
+		if name, spec := c.backendConfig(ing, backend); spec != nil && spec.HealthCheck != nil {
+			check = gkeBackendHealthCheck(name, spec.HealthCheck)
+		}
+		if check != nil {
+			checks = append(checks, serviceHealthCheck{service: svc, check: check})
+		}
+	}
+	if len(checks) == 0 {
+		return nil
+	}
+
+	switch c.opts.Target {
+	case TargetEnvoyGateway, TargetGKE:
+		return checks
+	}
+	for _, sc := range checks {
+		c.addDiagnostic(ing, sc.check.annotation, SeverityWarning,
+			"health check of Service %s from %s not converted: Gateway API has no health checks; configure it on %s, or use --target=envoy-gateway or gke",
+			sc.service, sc.check.source, c.targetName())
+	}
+	return nil
+}
+
+// albHealthCheck parses the ALB health check annotations, nil when there
+// are none
+func (c *Converter) albHealthCheck(ing *networkingv1.Ingress) *healthCheck {
+	annotation, _, ok := firstAnnotation(ing, albHealthCheckAnnotations)
+	if !ok {
+		return nil
+	}
+	hc := &healthCheck{source: "ALB annotations", annotation: annotation, protocol: "HTTP", path: "/"}
+
+	if protocol, ok := ing.Annotations[annotationALBHealthcheckProtocol]; ok {
+		hc.protocol = strings.ToUpper(protocol)
+	}
+	if path, ok := ing.Annotations[annotationALBHealthcheckPath]; ok {
+		hc.path = path
+	}
+	if port, ok := ing.Annotations[annotationALBHealthcheckPort]; ok && port != "traffic-port" {
+		n, err := strconv.ParseInt(port, 10, 32)
+		if err != nil || n <= 0 {
+			c.addDiagnostic(ing, annotationALBHealthcheckPort, SeverityWarning, "invalid health check port %q, the serving port is checked", port)
+		} else {
+			hc.port = n
+		}
+	}
+	for _, field := range []struct {
+		annotation string
+		value      *int64
+	}{
+		{annotationALBHealthcheckInterval, &hc.interval},
+		{annotationALBHealthcheckTimeout, &hc.timeout},
+		{annotationALBHealthyThreshold, &hc.healthy},
+		{annotationALBUnhealthyThreshold, &hc.unhealthy},
+	} {
+		value, ok := ing.Annotations[field.annotation]
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n <= 0 {
+			c.addDiagnostic(ing, field.annotation, SeverityWarning, "invalid value %q, not converted", value)
+			continue
+		}
+		*field.value = n
+	}
+	if codes, ok := ing.Annotations[annotationALBSuccessCodes]; ok {
+		statuses, err := parseSuccessCodes(codes)
+		if err != nil {
+			c.addDiagnostic(ing, annotationALBSuccessCodes, SeverityWarning, "success codes %q not converted: %v", codes, err)
+		} else {
+			hc.statuses = statuses
+		}
+	}
+	return hc
+}
+
+// parseSuccessCodes expands ALB success codes such as 200,202 or 200-299
+func parseSuccessCodes(codes string) ([]int64, error) {
+	var statuses []int64
+	for _, part := range strings.Split(codes, ",") {
+		low, high, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		if !isRange {
+			high = low
+		}
+		from, err := strconv.ParseInt(low, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid code %q", part)
+		}
+		to, err := strconv.ParseInt(high, 10, 64)
+		if err != nil || to < from || from < 100 || to > 599 {
+			return nil, fmt.Errorf("invalid code %q", part)
+		}
+		for code := from; code <= to; code++ {
+			statuses = append(statuses, code)
+		}
+		if len(statuses) > maxExpectedStatuses {
+			return nil, fmt.Errorf("more than %d codes", maxExpectedStatuses)
+		}
+	}
+	return statuses, nil
+}
+
+// gkeBackendHealthCheck converts the health check of a BackendConfig
+func gkeBackendHealthCheck(config string, spec *gkeHealthCheck) *healthCheck {
+	hc := &healthCheck{source: "BackendConfig " + config, protocol: "HTTP", path: spec.RequestPath}
+	if spec.Type != "" {
+		hc.protocol = strings.ToUpper(spec.Type)
+	}
+	for _, field := range []struct {
+		from *int64
+		to   *int64
+	}{
+		{spec.Port, &hc.port},
+		{spec.CheckIntervalSec, &hc.interval},
+		{spec.TimeoutSec, &hc.timeout},
+		{spec.HealthyThreshold, &hc.healthy},
+		{spec.UnhealthyThreshold, &hc.unhealthy},
+	} {
+		if field.from != nil {
+			*field.to = *field.from
+		}
+	}
+	return hc
+}
+
+// envoyHealthCheck returns the healthCheck section of an Envoy Gateway
+// BackendTrafficPolicy. The policy applies to every backend of the routes,
+// so the Services must share one health check.
+func (c *Converter) envoyHealthCheck(ing *networkingv1.Ingress, checks []serviceHealthCheck) map[string]interface{} {
+	if len(checks) == 0 || c.opts.Target != TargetEnvoyGateway {
+		return nil
+	}
+	hc := checks[0].check
+	for _, sc := range checks[1:] {
+		if !reflect.DeepEqual(sc.check, hc) {
+			c.addDiagnostic(ing, hc.annotation, SeverityWarning,
+				"backend Services have different health checks, which one BackendTrafficPolicy cannot express; health checks not converted")
+			return nil
+		}
+	}
+	if len(checks) < len(backendServiceNames(ing)) {
+		c.addDiagnostic(ing, hc.annotation, SeverityWarning,
+			"the health check from %s applies to every backend Service of the routes", hc.source)
+	}
+	if hc.port != 0 {
+		c.addDiagnostic(ing, hc.annotation, SeverityWarning,
+			"health check port %d not converted: Envoy checks the serving port", hc.port)
+	}
+
+	active := map[string]interface{}{}
+	switch hc.protocol {
+	case "TCP":
+		active["type"] = "TCP"
+	case "HTTP", "HTTPS", "HTTP2":
+		if hc.protocol != "HTTP" {
+			c.addDiagnostic(ing, hc.annotation, SeverityWarning,
+				"%s health check converted to HTTP; Envoy health checks use the backend's connection settings", hc.protocol)
+		}
+		http := map[string]interface{}{"path": hc.path}
+		if hc.path == "" {
+			http["path"] = "/"
+		}
+		if hc.statuses != nil {
+			statuses := make([]interface{}, len(hc.statuses))
+			for i, status := range hc.statuses {
+				statuses[i] = status
+			}
+			http["expectedStatuses"] = statuses
+		}
+		active["type"] = "HTTP"
+		active["http"] = http
+	default:
+		c.addDiagnostic(ing, hc.annotation, SeverityWarning, "health check protocol %s not converted", hc.protocol)
+		return nil
+	}
+	for key, value := range map[string]int64{"healthyThreshold": hc.healthy, "unhealthyThreshold": hc.unhealthy} {
+		if value != 0 {
+			active[key] = value
+		}
+	}
+	for key, value := range map[string]int64{"interval": hc.interval, "timeout": hc.timeout} {
+		if value != 0 {
+			active[key] = fmt.Sprintf("%ds", value)
+		}
+	}
+
+	c.addDiagnostic(ing, hc.annotation, SeverityInfo, "health check from %s converted to an Envoy Gateway BackendTrafficPolicy", hc.source)
+	return map[string]interface{}{"active": active}
+}
+
+// gkeHealthCheckPolicies converts the health checks into a GKE
+// HealthCheckPolicy per Service
+func (c *Converter) gkeHealthCheckPolicies(ing *networkingv1.Ingress, checks []serviceHealthCheck) []interface{} {
+	if c.opts.Target != TargetGKE {
+		return nil
+	}
+
+	var policies []interface{}
+	for _, sc := range checks {
+		hc := sc.check
+		configKey := map[string]string{
+			"HTTP": "httpHealthCheck", "HTTPS": "httpsHealthCheck", "HTTP2": "http2HealthCheck", "TCP": "tcpHealthCheck",
+		}[hc.protocol]
+		if configKey == "" {
+			c.addDiagnostic(ing, hc.annotation, SeverityWarning,
+				"health check protocol %s of Service %s not converted", hc.protocol, sc.service)
+			continue
+		}
+		if hc.statuses != nil && !reflect.DeepEqual(hc.statuses, []int64{200}) {
+			c.addDiagnostic(ing, hc.annotation, SeverityWarning,
+				"health check of Service %s expects status codes GKE health checks cannot configure; only 200 is healthy", sc.service)
+		}
+
+		config := map[string]interface{}{"portSpecification": "USE_SERVING_PORT"}
+		if hc.port != 0 {
+			config["portSpecification"] = "USE_FIXED_PORT"
+			config["port"] = hc.port
+		}
+		if hc.path != "" && hc.protocol != "TCP" {
+			config["requestPath"] = hc.path
+		}
+		settings := map[string]interface{}{
+			"config": map[string]interface{}{"type": hc.protocol, configKey: config},
+		}
+		for key, value := range map[string]int64{
+			"checkIntervalSec": hc.interval, "timeoutSec": hc.timeout,
+			"healthyThreshold": hc.healthy, "unhealthyThreshold": hc.unhealthy,
+		} {
+			if value != 0 {
+				settings[key] = value
+			}
+		}
+
+		c.addDiagnostic(ing, hc.annotation, SeverityInfo, "health check from %s converted to a HealthCheckPolicy for Service %s", hc.source, sc.service)
+		policies = append(policies, newPolicy("networking.gke.io/v1", "HealthCheckPolicy",
+			sanitizeName(sc.service+"-healthcheck"), ing.Namespace,
+			map[string]interface{}{
+				"default": settings,
+				"targetRef": map[string]interface{}{
+					"group": "",
+					"kind":  kindService,
+					"name":  sc.service,
+				},
+			}))
+	}
+	return policies
+}
+
+// setEnvoyHealthCheck adds a health check to an Envoy Gateway
+// BackendTrafficPolicy
+func (c *Converter) setEnvoyHealthCheck(ing *networkingv1.Ingress, check map[string]interface{}, policy *unstructured.Unstructured) {
+	if err := unstructured.SetNestedField(policy.Object, check, "spec", "healthCheck"); err != nil {
+		c.addDiagnostic(ing, "", SeverityError, "failed to set health check: %v", err)
+	}
+}
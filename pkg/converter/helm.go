@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// HelmOptions configures local rendering of a Helm chart
+type HelmOptions struct {
+	ChartPath   string
+	ValuesFiles []string
+	ReleaseName string // defaults to the chart directory name
+	Namespace   string
+}
+
+// LoadFromHelmChart renders a Helm chart locally and loads the Ingress
+// resources found in the rendered templates. The chart is rendered with the
+// Helm SDK as `helm template` does: a client-only dry-run install, with the
+// values files merged in order over the chart values.
+func (c *Converter) LoadFromHelmChart(ctx context.Context, opts HelmOptions) ([]interface{}, error) {
+	chart, err := loader.Load(opts.ChartPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chart %s: %w", opts.ChartPath, err)
+	}
+	if err := action.CheckDependencies(chart, chart.Metadata.Dependencies); err != nil {
+		return nil, fmt.Errorf("chart %s has missing dependencies, run helm dependency build: %w", opts.ChartPath, err)
+	}
+
+	values := make(map[string]interface{})
+	for _, file := range opts.ValuesFiles {
+		fileValues, err := chartutil.ReadValuesFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read values file %s: %w", file, err)
+		}
+		// Later files override earlier ones
+		values = chartutil.CoalesceTables(fileValues.AsMap(), values)
+	}
+
+	releaseName := opts.ReleaseName
+	if releaseName == "" {
+		releaseName = sanitizeName(filepath.Base(filepath.Clean(opts.ChartPath)))
+	}
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	install := action.NewInstall(&action.Configuration{Log: func(string, ...interface{}) {}})
+	install.DryRun = true
+	install.ClientOnly = true
+	install.Replace = true
+	install.ReleaseName = releaseName
+	install.Namespace = namespace
+	release, err := install.RunWithContext(ctx, chart, values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render chart %s: %w", opts.ChartPath, err)
+	}
+
+	manifests := []string{release.Manifest}
+	for _, hook := range release.Hooks {
+		manifests = append(manifests, hook.Manifest)
+	}
+	objs, err := parseManifests([]byte(strings.Join(manifests, "\n---\n")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rendered chart: %w", err)
+	}
+
+	// Rendered manifests omit the namespace unless the template sets it
+	for _, obj := range objs {
+		if o := obj.(metav1.Object); o.GetNamespace() == "" {
+			o.SetNamespace(opts.Namespace)
+		}
+	}
+	return objs, nil
+}
+
+// helmValuesKey is the values.yaml key that guards the generated templates
+const helmValuesKey = "httpRoute"
+
+// writeHelmTemplates writes the converted resources as Helm templates guarded
+// by `.Values.httpRoute.enabled`, with hostnames and parentRefs read from
+// values so charts can ship Ingress and Gateway API side by side
+func (c *Converter) writeHelmTemplates(resources []interface{}, w io.Writer) error {
+	routeValues := make(map[string]interface{})
+
+	var docs []string
+	for _, res := range resources {
+		var data []byte
+		var err error
+
+		if hr, ok := res.(*gatewayv1.HTTPRoute); ok {
+			routeValues[hr.Name] = map[string]interface{}{
+				"parentRefs": hr.Spec.ParentRefs,
+				"hostnames":  hr.Spec.Hostnames,
+			}
+			data, err = helmRouteTemplate(hr)
+		} else {
+			data, err = marshalYAML(res)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to marshal resource: %w", err)
+		}
+
+		// Install into the release namespace rather than the source namespace
+		doc := string(data)
+		if obj, err := meta.Accessor(res); err == nil && obj.GetNamespace() != "" {
+			doc = strings.Replace(doc, fmt.Sprintf("\n  namespace: %s\n", obj.GetNamespace()), "\n  namespace: {{ .Release.Namespace }}\n", 1)
+		}
+		docs = append(docs, doc)
+	}
+
+	values, err := yaml.Marshal(map[string]interface{}{
+		helmValuesKey: map[string]interface{}{
+			"enabled": false,
+			"routes":  routeValues,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal helm values: %w", err)
+	}
+
+	fmt.Fprintf(w, "{{- /*\nValues consumed by these templates:\n\n%s*/}}\n", values)
+	fmt.Fprintf(w, "{{- if .Values.%s.enabled }}\n", helmValuesKey)
+	for i, doc := range docs {
+		if i > 0 {
+			fmt.Fprintln(w, "---")
+		}
+		if _, err := io.WriteString(w, doc); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+	fmt.Fprintln(w, "{{- end }}")
+
+	return nil
+}
+
+// helmRouteTemplate marshals an HTTPRoute with its hostnames and parentRefs
+// replaced by lookups into the chart values
+func helmRouteTemplate(hr *gatewayv1.HTTPRoute) ([]byte, error) {
+	route := hr.DeepCopy()
+	route.Spec.ParentRefs = nil
+	route.Spec.Hostnames = nil
+
+	data, err := marshalYAML(route)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := fmt.Sprintf(`spec:
+  {{- $route := index .Values.%s.routes %q }}
+  parentRefs:
+    {{- toYaml $route.parentRefs | nindent 4 }}
+  {{- with $route.hostnames }}
+  hostnames:
+    {{- toYaml . | nindent 4 }}
+  {{- end }}
+`, helmValuesKey, hr.Name)
+
+	return []byte(strings.Replace(string(data), "spec:\n", lookup, 1)), nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// chartValues is the values.yaml of a generated chart
+type chartValues struct {
+	Gateway chartGateway                `json:"gateway"`
+	Routes  map[string]chartRouteValues `json:"routes"`
+}
+
+// chartGateway is the Gateway routes attach to unless they set parentRefs
+type chartGateway struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace,omitempty"` // empty for the release namespace
+	SectionName string `json:"sectionName,omitempty"`
+	ClassName   string `json:"className"`
+}
+
+// chartRouteValues holds the per-route values of a generated chart
+type chartRouteValues struct {
+	Hostnames  []gatewayv1.Hostname        `json:"hostnames"`
+	ParentRefs []gatewayv1.ParentReference `json:"parentRefs,omitempty"` // only where they differ from the gateway values
+}
+
+// WriteHelmChart writes resources as a minimal Helm chart in dir, named after
+// it: Chart.yaml, values.yaml with the Gateway name and class and each
+// route's hostnames, templates/httproutes.yaml with the HTTPRoutes reading
+// them, and templates/resources.yaml with the other resources. Resources of
+// the namespace of the routes are installed into the release namespace, so
+// one chart serves every environment. It returns the files written.
+func (c *Converter) WriteHelmChart(resources []interface{}, dir string) ([]string, error) {
+	var routes []*gatewayv1.HTTPRoute
+	var others []interface{}
+	for _, res := range resources {
+		if hr, ok := res.(*gatewayv1.HTTPRoute); ok {
+			routes = append(routes, hr)
+			continue
+		}
+		others = append(others, res)
+	}
+
+	// The first route's parentRef becomes the chart's Gateway
+	var namespace string
+	values := chartValues{
+		Gateway: chartGateway{ClassName: c.gatewayClass()},
+		Routes:  make(map[string]chartRouteValues),
+	}
+	var defaultRefs []gatewayv1.ParentReference
+	if len(routes) > 0 {
+		namespace = routes[0].Namespace
+		if len(routes[0].Spec.ParentRefs) > 0 {
+			ref := routes[0].Spec.ParentRefs[0]
+			defaultRefs = routes[0].Spec.ParentRefs[:1]
+			values.Gateway.Name = string(ref.Name)
+			if ref.Namespace != nil && string(*ref.Namespace) != namespace {
+				values.Gateway.Namespace = string(*ref.Namespace)
+			}
+			if ref.SectionName != nil {
+				values.Gateway.SectionName = string(*ref.SectionName)
+			}
+		}
+	}
+
+	var templates []string
+	for _, hr := range routes {
+		route := chartRouteValues{Hostnames: hr.Spec.Hostnames}
+		if hr.Namespace != namespace || !reflect.DeepEqual(hr.Spec.ParentRefs, defaultRefs) {
+			route.ParentRefs = hr.Spec.ParentRefs
+		}
+		values.Routes[hr.Name] = route
+
+		data, err := chartRouteTemplate(hr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal HTTPRoute %s: %w", hr.Name, err)
+		}
+		templates = append(templates, releaseNamespace(string(data), hr, namespace))
+	}
+
+	var otherTemplates []string
+	for _, res := range others {
+		data, err := marshalYAML(res)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal resource: %w", err)
+		}
+		doc := releaseNamespace(string(data), res, namespace)
+		if gw, ok := res.(*gatewayv1.Gateway); ok {
+			doc = strings.Replace(doc, fmt.Sprintf("\n  gatewayClassName: %s\n", gw.Spec.GatewayClassName),
+				"\n  gatewayClassName: {{ .Values.gateway.className }}\n", 1)
+			// The chart's Gateway is renamed with the gateway values
+			if gw.Name == values.Gateway.Name && gw.Namespace == namespace && values.Gateway.Namespace == "" {
+				doc = strings.Replace(doc, fmt.Sprintf("\n  name: %s\n", gw.Name), "\n  name: {{ .Values.gateway.name }}\n", 1)
+			}
+		}
+		otherTemplates = append(otherTemplates, doc)
+	}
+
+	chart, err := yaml.Marshal(map[string]string{
+		"apiVersion":  "v2",
+		"name":        sanitizeName(filepath.Base(filepath.Clean(dir))),
+		"description": "Gateway API resources converted from Ingress by ingress-to-gateway",
+		"type":        "application",
+		"version":     "0.1.0",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Chart.yaml: %w", err)
+	}
+	valuesData, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal values.yaml: %w", err)
+	}
+
+	files := []struct {
+		path string
+		data []byte
+	}{
+		{"Chart.yaml", chart},
+		{"values.yaml", valuesData},
+		{filepath.Join("templates", "httproutes.yaml"), []byte(strings.Join(templates, "---\n"))},
+		{filepath.Join("templates", "resources.yaml"), []byte(strings.Join(otherTemplates, "---\n"))},
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "templates"), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create chart directory: %w", err)
+	}
+	var written []string
+	for _, f := range files {
+		if len(f.data) == 0 {
+			continue
+		}
+		path := filepath.Join(dir, f.path)
+		if err := os.WriteFile(path, f.data, 0644); err != nil {
+			return written, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// chartRouteTemplate marshals an HTTPRoute with its hostnames read from the
+// chart values and its parentRefs from the route values, else the gateway
+// values
+func chartRouteTemplate(hr *gatewayv1.HTTPRoute) ([]byte, error) {
+	route := hr.DeepCopy()
+	route.Spec.ParentRefs = nil
+	route.Spec.Hostnames = nil
+
+	data, err := marshalYAML(route)
+	if err != nil {
+		return nil, err
+	}
+
+	var lookup bytes.Buffer
+	fmt.Fprintf(&lookup, `spec:
+  {{- $route := index .Values.routes %q }}
+  parentRefs:
+  {{- if $route.parentRefs }}
+    {{- toYaml $route.parentRefs | nindent 4 }}
+  {{- else }}
+    - name: {{ .Values.gateway.name }}
+      {{- with .Values.gateway.namespace }}
+      namespace: {{ . }}
+      {{- end }}
+      {{- with .Values.gateway.sectionName }}
+      sectionName: {{ . }}
+      {{- end }}
+  {{- end }}
+  {{- with $route.hostnames }}
+  hostnames:
+    {{- toYaml . | nindent 4 }}
+  {{- end }}
+`, hr.Name)
+
+	return []byte(strings.Replace(string(data), "spec:\n", lookup.String(), 1)), nil
+}
+
+// releaseNamespace moves a marshaled resource of namespace into the release
+// namespace; resources of other namespaces keep theirs
+func releaseNamespace(doc string, res interface{}, namespace string) string {
+	obj, err := meta.Accessor(res)
+	if err != nil || obj.GetNamespace() == "" || obj.GetNamespace() != namespace {
+		return doc
+	}
+	return strings.Replace(doc, fmt.Sprintf("\n  namespace: %s\n", namespace), "\n  namespace: {{ .Release.Namespace }}\n", 1)
+}
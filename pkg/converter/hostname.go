@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/idna"
+	networkingv1 "k8s.io/api/networking/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// ToASCIIHostname converts an internationalized hostname (optionally with a
+// leading wildcard label) to its punycode form
+func ToASCIIHostname(host string) (string, error) {
+	wildcard := strings.HasPrefix(host, "*.")
+	if wildcard {
+		host = host[2:]
+	}
+
+	ascii, err := idna.Lookup.ToASCII(host)
+	if err != nil {
+		return "", err
+	}
+
+	if wildcard {
+		ascii = "*." + ascii
+	}
+	return ascii, nil
+}
+
+// hostnameLabels matches a DNS-1123 subdomain, the form Gateway API requires
+// after an optional leading wildcard label
+var hostnameLabels = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+// ValidateHostname checks an Ingress host against the Gateway API hostname
+// rules: a wildcard must be the whole leftmost label, never a bare "*", and
+// IP addresses are not hostnames
+func ValidateHostname(host string) error {
+	ascii, err := ToASCIIHostname(host)
+	if err != nil {
+		ascii = strings.ToLower(host)
+	}
+	if ascii == "*" {
+		return fmt.Errorf("a bare wildcard is not a hostname, omit the host to match every hostname")
+	}
+	name := strings.TrimPrefix(ascii, "*.")
+	if strings.Contains(name, "*") {
+		return fmt.Errorf("a wildcard must be the whole leftmost label, as in *.example.com")
+	}
+	if net.ParseIP(name) != nil {
+		return fmt.Errorf("IP addresses are not allowed as hostnames")
+	}
+	if len(name) > 253 || !hostnameLabels.MatchString(name) {
+		return fmt.Errorf("not a valid DNS subdomain")
+	}
+	return nil
+}
+
+// validHostname reports whether host can be used as a Gateway API hostname,
+// with an error diagnostic if not
+func (c *Converter) validHostname(ing *networkingv1.Ingress, host string) bool {
+	if err := ValidateHostname(host); err != nil {
+		c.addDiagnostic(ing, "", SeverityError, "host %q skipped: %v", host, err)
+		return false
+	}
+	return true
+}
+
+// hostname converts an Ingress host to a Gateway API hostname, normalizing
+// unicode hostnames to punycode since Gateway API only accepts ASCII
+func (c *Converter) hostname(ing *networkingv1.Ingress, host string) gatewayv1.Hostname {
+	ascii, err := ToASCIIHostname(host)
+	if err != nil {
+		c.addDiagnostic(ing, "", SeverityWarning, "hostname %q could not be normalized to punycode: %v", host, err)
+		return gatewayv1.Hostname(host)
+	}
+	if ascii != host {
+		c.addDiagnostic(ing, "", SeverityInfo, "hostname %q normalized to %q", host, ascii)
+	}
+	return gatewayv1.Hostname(ascii)
+}
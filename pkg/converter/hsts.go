@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"strconv"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+const (
+	annotationHSTS                  = "nginx.ingress.kubernetes.io/hsts"
+	annotationHSTSMaxAge            = "nginx.ingress.kubernetes.io/hsts-max-age"
+	annotationHSTSIncludeSubdomains = "nginx.ingress.kubernetes.io/hsts-include-subdomains"
+	annotationHSTSPreload           = "nginx.ingress.kubernetes.io/hsts-preload"
+
+	// defaultHSTSMaxAge matches the ingress-nginx default of 180 days
+	defaultHSTSMaxAge = 15724800
+)
+
+// hstsHeader builds the Strict-Transport-Security value from the hsts
+// annotations, using the ingress-nginx defaults for unset fields
+func (c *Converter) hstsHeader(ing *networkingv1.Ingress) (string, bool) {
+	_, hasEnable := ing.Annotations[annotationHSTS]
+	maxAgeValue, hasMaxAge := ing.Annotations[annotationHSTSMaxAge]
+	_, hasSubdomains := ing.Annotations[annotationHSTSIncludeSubdomains]
+	_, hasPreload := ing.Annotations[annotationHSTSPreload]
+	if !hasEnable && !hasMaxAge && !hasSubdomains && !hasPreload {
+		return "", false
+	}
+	if ing.Annotations[annotationHSTS] == "false" {
+		return "", false
+	}
+
+	maxAge := defaultHSTSMaxAge
+	if hasMaxAge {
+		age, err := strconv.Atoi(maxAgeValue)
+		if err != nil || age < 0 {
+			c.addDiagnostic(ing, annotationHSTSMaxAge, SeverityWarning,
+				"invalid max-age %q, using the default of %d", maxAgeValue, defaultHSTSMaxAge)
+		} else {
+			maxAge = age
+		}
+	}
+
+	value := fmt.Sprintf("max-age=%d", maxAge)
+	if ing.Annotations[annotationHSTSIncludeSubdomains] != "false" {
+		value += "; includeSubDomains"
+	}
+	if ing.Annotations[annotationHSTSPreload] == "true" {
+		value += "; preload"
+	}
+
+	if len(ing.Spec.TLS) == 0 {
+		c.addDiagnostic(ing, annotationHSTS, SeverityWarning,
+			"Strict-Transport-Security is ignored by browsers over plain HTTP and the Ingress has no TLS")
+	}
+	return value, true
+}
@@ -0,0 +1,643 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// maxRuleTerms bounds the conditions a Traefik rule expands to
+const maxRuleTerms = 64
+
+// traefikMatcher is one matcher of a Traefik router rule, such as
+// PathPrefix(`/api`)
+type traefikMatcher struct {
+	name string
+	args []string
+}
+
+// ruleToken is a token of a Traefik router rule: an operator, a matcher
+// name or a quoted argument
+type ruleToken struct {
+	text   string
+	quoted bool
+}
+
+// tokenizeRule splits a Traefik router rule into tokens
+func tokenizeRule(rule string) ([]ruleToken, error) {
+	var tokens []ruleToken
+	for i := 0; i < len(rule); {
+		switch ch := rule[i]; {
+		case ch == ' ' || ch == '\t' || ch == '\n':
+			i++
+		case strings.HasPrefix(rule[i:], "&&"), strings.HasPrefix(rule[i:], "||"):
+			tokens = append(tokens, ruleToken{text: rule[i : i+2]})
+			i += 2
+		case strings.ContainsRune("()!,", rune(ch)):
+			tokens = append(tokens, ruleToken{text: string(ch)})
+			i++
+		case ch == '`' || ch == '"':
+			end := strings.IndexByte(rule[i+1:], ch)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			tokens = append(tokens, ruleToken{text: rule[i+1 : i+1+end], quoted: true})
+			i += end + 2
+		case ch >= 'A' && ch <= 'Z' || ch >= 'a' && ch <= 'z':
+			j := i
+			for j < len(rule) && (rule[j] >= 'A' && rule[j] <= 'Z' || rule[j] >= 'a' && rule[j] <= 'z') {
+				j++
+			}
+			tokens = append(tokens, ruleToken{text: rule[i:j]})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected %q at offset %d", ch, i)
+		}
+	}
+	return tokens, nil
+}
+
+// ruleParser parses tokenized Traefik rules
+type ruleParser struct {
+	tokens []ruleToken
+	pos    int
+}
+
+// parseTraefikRule parses a router rule into disjunctive normal form: the
+// rule matches when all matchers of any of the returned terms match.
+// Negations are not supported, since HTTPRoute cannot express them.
+func parseTraefikRule(rule string) ([][]traefikMatcher, error) {
+	tokens, err := tokenizeRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	p := &ruleParser{tokens: tokens}
+	terms, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	return terms, nil
+}
+
+// peek reports whether the next token is the operator op
+func (p *ruleParser) peek(op string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && p.tokens[p.pos].text == op
+}
+
+// expect consumes the operator op
+func (p *ruleParser) expect(op string) error {
+	if !p.peek(op) {
+		return fmt.Errorf("expected %q", op)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *ruleParser) or() ([][]traefikMatcher, error) {
+	terms, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek("||") {
+		p.pos++
+		more, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, more...)
+		if len(terms) > maxRuleTerms {
+			return nil, fmt.Errorf("rule expands to more than %d conditions", maxRuleTerms)
+		}
+	}
+	return terms, nil
+}
+
+func (p *ruleParser) and() ([][]traefikMatcher, error) {
+	terms, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek("&&") {
+		p.pos++
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		if len(terms)*len(right) > maxRuleTerms {
+			return nil, fmt.Errorf("rule expands to more than %d conditions", maxRuleTerms)
+		}
+		var product [][]traefikMatcher
+		for _, l := range terms {
+			for _, r := range right {
+				product = append(product, append(append([]traefikMatcher{}, l...), r...))
+			}
+		}
+		terms = product
+	}
+	return terms, nil
+}
+
+func (p *ruleParser) unary() ([][]traefikMatcher, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of rule")
+	}
+	switch tok := p.tokens[p.pos]; {
+	case p.peek("!"):
+		return nil, fmt.Errorf("negated matchers have no HTTPRoute equivalent")
+	case p.peek("("):
+		p.pos++
+		terms, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return terms, p.expect(")")
+	case !tok.quoted && tok.text != "" && strings.ContainsAny(tok.text[:1], "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"):
+		p.pos++
+		return p.matcher(tok.text)
+	default:
+		return nil, fmt.Errorf("unexpected %q", tok.text)
+	}
+}
+
+// matcher parses the arguments of matcher name. Path, PathPrefix and
+// Method with several arguments match any of them.
+func (p *ruleParser) matcher(name string) ([][]traefikMatcher, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var args []string
+	for !p.peek(")") {
+		if len(args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		if p.pos >= len(p.tokens) || !p.tokens[p.pos].quoted {
+			return nil, fmt.Errorf("%s expects quoted arguments", name)
+		}
+		args = append(args, p.tokens[p.pos].text)
+		p.pos++
+	}
+	p.pos++
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%s has no arguments", name)
+	}
+
+	switch name {
+	case "Path", "PathPrefix", "Method":
+		var terms [][]traefikMatcher
+		for _, arg := range args {
+			terms = append(terms, []traefikMatcher{{name: name, args: []string{arg}}})
+		}
+		return terms, nil
+	}
+	return [][]traefikMatcher{{{name: name, args: args}}}, nil
+}
+
+// termMatch converts a conjunction of matchers to the hostnames and the
+// HTTPRoute match it stands for
+func termMatch(term []traefikMatcher) ([]string, gatewayv1.HTTPRouteMatch, error) {
+	var hosts []string
+	var match gatewayv1.HTTPRouteMatch
+
+	for _, m := range term {
+		switch m.name {
+		case "Host", "HostHeader":
+			if hosts != nil {
+				return nil, match, fmt.Errorf("several Host matchers in one condition")
+			}
+			for _, host := range m.args {
+				hosts = append(hosts, strings.ToLower(host))
+			}
+
+		case "Path", "PathPrefix", "PathRegexp":
+			if match.Path != nil {
+				return nil, match, fmt.Errorf("several path matchers in one condition")
+			}
+			pathType := map[string]gatewayv1.PathMatchType{
+				"Path":       gatewayv1.PathMatchExact,
+				"PathPrefix": gatewayv1.PathMatchPathPrefix,
+				"PathRegexp": gatewayv1.PathMatchRegularExpression,
+			}[m.name]
+			value := m.args[0]
+			match.Path = &gatewayv1.HTTPPathMatch{Type: &pathType, Value: &value}
+
+		case "Method":
+			if match.Method != nil {
+				return nil, match, fmt.Errorf("several Method matchers in one condition")
+			}
+			method := gatewayv1.HTTPMethod(strings.ToUpper(m.args[0]))
+			match.Method = &method
+
+		case "Header", "Headers", "HeaderRegexp", "HeadersRegexp":
+			if len(m.args) != 2 {
+				return nil, match, fmt.Errorf("%s expects a name and a value", m.name)
+			}
+			matchType := gatewayv1.HeaderMatchExact
+			if strings.HasSuffix(m.name, "Regexp") {
+				matchType = gatewayv1.HeaderMatchRegularExpression
+			}
+			match.Headers = append(match.Headers, gatewayv1.HTTPHeaderMatch{
+				Type:  &matchType,
+				Name:  gatewayv1.HTTPHeaderName(m.args[0]),
+				Value: m.args[1],
+			})
+
+		case "Query", "QueryRegexp":
+			matchType := gatewayv1.QueryParamMatchExact
+			if m.name == "QueryRegexp" {
+				matchType = gatewayv1.QueryParamMatchRegularExpression
+			}
+			// Query(`name`, `value`), or Query(`name=value`, ...) before Traefik v3
+			pairs := [][2]string{}
+			if len(m.args) == 2 && !strings.Contains(m.args[0], "=") {
+				pairs = append(pairs, [2]string{m.args[0], m.args[1]})
+			} else {
+				for _, arg := range m.args {
+					name, value, ok := strings.Cut(arg, "=")
+					if !ok {
+						return nil, match, fmt.Errorf("%s argument %q is not name=value", m.name, arg)
+					}
+					pairs = append(pairs, [2]string{name, value})
+				}
+			}
+			for _, pair := range pairs {
+				match.QueryParams = append(match.QueryParams, gatewayv1.HTTPQueryParamMatch{
+					Type:  &matchType,
+					Name:  gatewayv1.HTTPHeaderName(pair[0]),
+					Value: pair[1],
+				})
+			}
+
+		default:
+			return nil, match, fmt.Errorf("matcher %s has no HTTPRoute equivalent", m.name)
+		}
+	}
+
+	if match.Path == nil {
+		pathType := gatewayv1.PathMatchPathPrefix
+		value := "/"
+		match.Path = &gatewayv1.HTTPPathMatch{Type: &pathType, Value: &value}
+	}
+	sort.Strings(hosts)
+	return hosts, match, nil
+}
+
+// ingressRouteService is a backend of an IngressRoute route
+type ingressRouteService struct {
+	Name      string      `json:"name"`
+	Namespace string      `json:"namespace,omitempty"`
+	Kind      string      `json:"kind,omitempty"`
+	Port      interface{} `json:"port,omitempty"`
+	Weight    *int32      `json:"weight,omitempty"`
+	Scheme    string      `json:"scheme,omitempty"`
+	Sticky    interface{} `json:"sticky,omitempty"`
+}
+
+// ingressRouteSpec is the part of the IngressRoute spec the converter reads
+type ingressRouteSpec struct {
+	EntryPoints []string `json:"entryPoints,omitempty"`
+	Routes      []struct {
+		Match       string                `json:"match"`
+		Kind        string                `json:"kind,omitempty"`
+		Priority    int                   `json:"priority,omitempty"`
+		Services    []ingressRouteService `json:"services,omitempty"`
+		Middlewares []struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace,omitempty"`
+		} `json:"middlewares,omitempty"`
+	} `json:"routes"`
+	TLS *struct {
+		SecretName   string      `json:"secretName,omitempty"`
+		CertResolver string      `json:"certResolver,omitempty"`
+		Options      interface{} `json:"options,omitempty"`
+	} `json:"tls,omitempty"`
+}
+
+// ingressRouteSpecOf decodes the spec of an IngressRoute
+func ingressRouteSpecOf(obj *unstructured.Unstructured) (*ingressRouteSpec, error) {
+	data, err := json.Marshal(obj.Object["spec"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode IngressRoute spec: %w", err)
+	}
+	var s ingressRouteSpec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to decode IngressRoute spec: %w", err)
+	}
+	return &s, nil
+}
+
+// ingressRouteIngress returns the Ingress an IngressRoute stands in for
+// where the converter works on Ingresses: route names, parentRefs, named
+// port lookups and the listeners of generated Gateways. IngressRoutes
+// without ingress class annotation belong to the traefik class.
+func ingressRouteIngress(obj *unstructured.Unstructured) *networkingv1.Ingress {
+	className := obj.GetAnnotations()["kubernetes.io/ingress.class"]
+	if className == "" {
+		className = "traefik"
+	}
+	ing := &networkingv1.Ingress{
+		TypeMeta: metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "Ingress"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        obj.GetName(),
+			Namespace:   obj.GetNamespace(),
+			Labels:      obj.GetLabels(),
+			Annotations: obj.GetAnnotations(),
+		},
+		Spec: networkingv1.IngressSpec{IngressClassName: &className},
+	}
+
+	spec, err := ingressRouteSpecOf(obj)
+	if err != nil {
+		return ing
+	}
+
+	byHost := make(map[string]*networkingv1.IngressRule)
+	var hosts []string
+	for _, route := range spec.Routes {
+		terms, err := parseTraefikRule(route.Match)
+		if err != nil {
+			continue
+		}
+		for _, term := range terms {
+			termHosts, _, err := termMatch(term)
+			if err != nil {
+				continue
+			}
+			if len(termHosts) == 0 {
+				termHosts = []string{""}
+			}
+			for _, host := range termHosts {
+				rule, exists := byHost[host]
+				if !exists {
+					rule = &networkingv1.IngressRule{Host: host, IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{}}}
+					byHost[host] = rule
+					hosts = append(hosts, host)
+				}
+				for _, svc := range route.Services {
+					if backend, ok := serviceBackend(svc); ok && svc.Namespace == "" {
+						pathType := networkingv1.PathTypePrefix
+						rule.HTTP.Paths = append(rule.HTTP.Paths, networkingv1.HTTPIngressPath{
+							Path: "/", PathType: &pathType,
+							Backend: networkingv1.IngressBackend{Service: backend},
+						})
+					}
+				}
+			}
+		}
+	}
+	for _, host := range hosts {
+		ing.Spec.Rules = append(ing.Spec.Rules, *byHost[host])
+	}
+
+	if spec.TLS != nil && spec.TLS.SecretName != "" {
+		tls := networkingv1.IngressTLS{SecretName: spec.TLS.SecretName}
+		for _, host := range hosts {
+			if host != "" {
+				tls.Hosts = append(tls.Hosts, host)
+			}
+		}
+		ing.Spec.TLS = []networkingv1.IngressTLS{tls}
+	}
+	return ing
+}
+
+// serviceBackend returns the Ingress backend of an IngressRoute Service,
+// false for TraefikServices
+func serviceBackend(svc ingressRouteService) (*networkingv1.IngressServiceBackend, bool) {
+	if svc.Kind != "" && svc.Kind != "Service" {
+		return nil, false
+	}
+	backend := &networkingv1.IngressServiceBackend{Name: svc.Name}
+	switch port := svc.Port.(type) {
+	case string:
+		backend.Port.Name = port
+	case float64:
+		backend.Port.Number = int32(port)
+	}
+	return backend, true
+}
+
+// routeGroup is an HTTPRoute being built for a set of hostnames
+type routeGroup struct {
+	hosts []string
+	rules []gatewayv1.HTTPRouteRule
+}
+
+// convertIngressRoute converts a Traefik IngressRoute to HTTPRoutes, one
+// per set of hostnames its routes match; ing is its stand-in Ingress.
+// Every condition of a route rule becomes an HTTPRoute rule, since
+// middlewares may rewrite each matched path differently.
+func (c *Converter) convertIngressRoute(obj *unstructured.Unstructured, ing *networkingv1.Ingress) ([]interface{}, error) {
+	spec, err := ingressRouteSpecOf(obj)
+	if err != nil {
+		return nil, err
+	}
+	c.checkEntryPoints(ing, "", spec.EntryPoints)
+	if spec.TLS != nil {
+		if spec.TLS.SecretName == "" {
+			c.addDiagnostic(ing, "", SeverityWarning,
+				"tls without secretName (certResolver %q) has no certificate to reference; add certificateRefs to the HTTPS listener, e.g. from cert-manager", spec.TLS.CertResolver)
+		}
+		if spec.TLS.Options != nil {
+			c.addDiagnostic(ing, "", SeverityWarning, "tls.options not converted; configure TLS settings on the Gateway listener")
+		}
+	}
+
+	var groups []*routeGroup
+	byHosts := make(map[string]*routeGroup)
+
+	for i, route := range spec.Routes {
+		if route.Kind != "" && route.Kind != "Rule" {
+			c.addDiagnostic(ing, "", SeverityError, "route %d has kind %s, not Rule; route dropped", i+1, route.Kind)
+			continue
+		}
+		if route.Priority != 0 {
+			c.addDiagnostic(ing, "", SeverityWarning,
+				"route %d priority %d not converted: Gateway API orders matches by specificity, check overlapping routes", i+1, route.Priority)
+		}
+		terms, err := parseTraefikRule(route.Match)
+		if err != nil {
+			c.addDiagnostic(ing, "", SeverityError, "route %d rule %q not converted: %v", i+1, route.Match, err)
+			continue
+		}
+
+		backends := c.ingressRouteBackends(ing, i, route.Services)
+		if len(backends) == 0 {
+			c.addDiagnostic(ing, "", SeverityError, "route %d has no Service backend; route dropped", i+1)
+			continue
+		}
+		var refs []middlewareRef
+		for _, m := range route.Middlewares {
+			ref := middlewareRef{namespace: obj.GetNamespace(), name: m.Name}
+			if m.Namespace != "" {
+				ref.namespace = m.Namespace
+			}
+			refs = append(refs, ref)
+		}
+
+		for _, term := range terms {
+			hosts, match, err := termMatch(term)
+			if err != nil {
+				c.addDiagnostic(ing, "", SeverityError, "route %d rule %q not converted: %v", i+1, route.Match, err)
+				continue
+			}
+			rule := gatewayv1.HTTPRouteRule{
+				Matches:     []gatewayv1.HTTPRouteMatch{match},
+				BackendRefs: backends,
+				Filters:     c.middlewareFilters(ing, "", refs, *match.Path),
+			}
+
+			key := strings.Join(hosts, ",")
+			g, exists := byHosts[key]
+			if !exists {
+				g = &routeGroup{hosts: hosts}
+				byHosts[key] = g
+				groups = append(groups, g)
+			}
+			g.rules = append(g.rules, rule)
+		}
+	}
+	groups = mergeRouteGroups(groups)
+
+	baseName, err := c.routeName(ing)
+	if err != nil {
+		return nil, err
+	}
+	var routes []interface{}
+	for i, g := range groups {
+		name := baseName
+		if i > 0 {
+			name = fmt.Sprintf("%s-%d", baseName, i+1)
+		}
+		var hostnames []gatewayv1.Hostname
+		for _, host := range g.hosts {
+			if c.validHostname(ing, host) {
+				hostnames = append(hostnames, c.hostname(ing, host))
+			}
+		}
+		routes = append(routes, &gatewayv1.HTTPRoute{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "gateway.networking.k8s.io/v1",
+				Kind:       "HTTPRoute",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   ing.Namespace,
+				Labels:      c.routeLabels(ing),
+				Annotations: c.routeAnnotations(ing),
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{c.parentRef(ing)},
+				},
+				Hostnames: hostnames,
+				Rules:     g.rules,
+			},
+		})
+	}
+	return routes, nil
+}
+
+// mergeRouteGroups merges host groups with the same rules into one, as
+// Host(`a`) || Host(`b`) expands to a group per host
+func mergeRouteGroups(groups []*routeGroup) []*routeGroup {
+	if len(groups) < 2 {
+		return groups
+	}
+	for _, g := range groups {
+		if len(g.hosts) == 0 || !reflect.DeepEqual(g.rules, groups[0].rules) {
+			return groups
+		}
+	}
+	merged := &routeGroup{rules: groups[0].rules}
+	seen := make(map[string]bool)
+	for _, g := range groups {
+		for _, host := range g.hosts {
+			if !seen[host] {
+				seen[host] = true
+				merged.hosts = append(merged.hosts, host)
+			}
+		}
+	}
+	return []*routeGroup{merged}
+}
+
+// ingressRouteBackends converts the services of route i to backendRefs
+func (c *Converter) ingressRouteBackends(ing *networkingv1.Ingress, i int, services []ingressRouteService) []gatewayv1.HTTPBackendRef {
+	var refs []gatewayv1.HTTPBackendRef
+	for _, svc := range services {
+		backend, ok := serviceBackend(svc)
+		if !ok {
+			c.addDiagnostic(ing, "", SeverityError,
+				"route %d backend %s is a %s, which HTTPRoute cannot reference; backend dropped", i+1, svc.Name, svc.Kind)
+			continue
+		}
+		if svc.Scheme == "https" {
+			c.addDiagnostic(ing, "", SeverityWarning,
+				"route %d backend %s uses scheme https; add a BackendTLSPolicy for the Service", i+1, svc.Name)
+		}
+		if svc.Sticky != nil {
+			c.addDiagnostic(ing, "", SeverityWarning,
+				"route %d backend %s sticky sessions not converted; see the session persistence options of the target implementation", i+1, svc.Name)
+		}
+
+		var port gatewayv1.PortNumber
+		if svc.Namespace != "" && svc.Namespace != ing.Namespace {
+			if backend.Port.Name != "" {
+				c.addDiagnostic(ing, "", SeverityError,
+					"route %d backend %s/%s uses named port %q in another namespace; use a port number (backend dropped)", i+1, svc.Namespace, svc.Name, backend.Port.Name)
+				continue
+			}
+			port = gatewayv1.PortNumber(backend.Port.Number)
+		} else if port, ok = c.backendPort(ing, backend); !ok {
+			continue
+		}
+
+		weight := int32(1)
+		if svc.Weight != nil {
+			weight = *svc.Weight
+		}
+		ref := gatewayv1.HTTPBackendRef{
+			BackendRef: gatewayv1.BackendRef{
+				BackendObjectReference: gatewayv1.BackendObjectReference{
+					Name: gatewayv1.ObjectName(svc.Name),
+					Port: &port,
+				},
+				Weight: &weight,
+			},
+		}
+		if svc.Namespace != "" && svc.Namespace != ing.Namespace {
+			namespace := gatewayv1.Namespace(svc.Namespace)
+			ref.Namespace = &namespace
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
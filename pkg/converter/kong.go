@@ -0,0 +1,581 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const kongAnnotationPrefix = "konghq.com/"
+
+// Kong Ingress Controller annotations
+const (
+	annotationKongMethods       = kongAnnotationPrefix + "methods"
+	annotationKongStripPath     = kongAnnotationPrefix + "strip-path"
+	annotationKongPlugins       = kongAnnotationPrefix + "plugins"
+	annotationKongOverride      = kongAnnotationPrefix + "override"
+	annotationKongHostHeader    = kongAnnotationPrefix + "host-header"
+	annotationKongPreserveHost  = kongAnnotationPrefix + "preserve-host"
+	annotationKongProtocols     = kongAnnotationPrefix + "protocols"
+	annotationKongHTTPSRedirect = kongAnnotationPrefix + "https-redirect-status-code"
+	annotationKongRegexPriority = kongAnnotationPrefix + "regex-priority"
+
+	// kongHeadersPrefix starts the annotations matching a request header,
+	// e.g. konghq.com/headers.x-version: v1,v2
+	kongHeadersPrefix = kongAnnotationPrefix + "headers."
+)
+
+// Kong custom resource kinds
+const (
+	kindKongPlugin        = "KongPlugin"
+	kindKongClusterPlugin = "KongClusterPlugin"
+	kindKongIngress       = "KongIngress"
+)
+
+// kongRegexPrefix starts the ImplementationSpecific paths Kong matches as
+// regular expressions
+const kongRegexPrefix = "/~"
+
+// isKongKind reports whether apiVersion and kind name a Kong CRD the
+// converter reads
+func isKongKind(apiVersion, kind string) bool {
+	group, _, _ := strings.Cut(apiVersion, "/")
+	return group == "configuration.konghq.com" &&
+		(kind == kindKongPlugin || kind == kindKongClusterPlugin || kind == kindKongIngress)
+}
+
+// kongPlugin is a KongPlugin or KongClusterPlugin an Ingress uses
+type kongPlugin struct {
+	name   string // resource name, as listed in the plugins annotation
+	plugin string // Kong plugin, e.g. rate-limiting
+	config map[string]interface{}
+}
+
+// kongPlugins resolves the plugins annotation to the loaded KongPlugins of
+// the Ingress namespace, falling back to KongClusterPlugins
+func (c *Converter) kongPlugins(ing *networkingv1.Ingress) []kongPlugin {
+	value, ok := ing.Annotations[annotationKongPlugins]
+	if !ok {
+		return nil
+	}
+
+	var plugins []kongPlugin
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		obj, found := c.referenced[referencedKey(kindKongPlugin, ing.Namespace, name)]
+		if !found {
+			obj, found = c.referenced[referencedKey(kindKongClusterPlugin, "", name)]
+		}
+		if !found {
+			c.addDiagnostic(ing, annotationKongPlugins, SeverityWarning,
+				"plugin %s not found; add its KongPlugin or KongClusterPlugin manifest to the input to convert it", name)
+			continue
+		}
+		if disabled, _, _ := unstructured.NestedBool(obj.Object, "disabled"); disabled {
+			continue
+		}
+		p := kongPlugin{name: name}
+		p.plugin, _, _ = unstructured.NestedString(obj.Object, "plugin")
+		p.config, _, _ = unstructured.NestedMap(obj.Object, "config")
+		if _, ok := obj.Object["configFrom"]; ok && c.opts.Target != TargetKong {
+			c.addDiagnostic(ing, annotationKongPlugins, SeverityWarning,
+				"plugin %s reads its configuration from a Secret, not converted", name)
+			continue
+		}
+		plugins = append(plugins, p)
+	}
+	return plugins
+}
+
+// kongRoute returns the route section of the KongIngress the override
+// annotation names, nil if none
+func (c *Converter) kongRoute(ing *networkingv1.Ingress) map[string]interface{} {
+	name, ok := ing.Annotations[annotationKongOverride]
+	if !ok {
+		return nil
+	}
+	obj, found := c.referenced[referencedKey(kindKongIngress, ing.Namespace, name)]
+	if !found {
+		c.addDiagnostic(ing, annotationKongOverride, SeverityWarning,
+			"KongIngress %s not found; add its manifest to the input to convert it", name)
+		return nil
+	}
+	route, _, _ := unstructured.NestedMap(obj.Object, "route")
+	return route
+}
+
+// kongMethods returns the HTTP methods a Kong Ingress matches, from the
+// methods annotation or the KongIngress route
+func (c *Converter) kongMethods(ing *networkingv1.Ingress) ([]string, string) {
+	var methods []string
+	annotation := annotationKongMethods
+	if value, ok := ing.Annotations[annotationKongMethods]; ok {
+		methods = strings.Split(value, ",")
+	} else if route := c.kongRoute(ing); route != nil {
+		methods, _, _ = unstructured.NestedStringSlice(route, "methods")
+		annotation = annotationKongOverride
+	}
+
+	var valid []string
+	for _, method := range methods {
+		method = strings.ToUpper(strings.TrimSpace(method))
+		switch gatewayv1.HTTPMethod(method) {
+		case gatewayv1.HTTPMethodGet, gatewayv1.HTTPMethodHead, gatewayv1.HTTPMethodPost, gatewayv1.HTTPMethodPut,
+			gatewayv1.HTTPMethodDelete, gatewayv1.HTTPMethodConnect, gatewayv1.HTTPMethodOptions,
+			gatewayv1.HTTPMethodTrace, gatewayv1.HTTPMethodPatch:
+			valid = append(valid, method)
+		case "":
+		default:
+			c.addDiagnostic(ing, annotation, SeverityWarning, "method %q cannot be matched in Gateway API, not converted", method)
+		}
+	}
+	return valid, annotation
+}
+
+// kongHeaders returns the header values a Kong Ingress matches, by header
+// name, from the headers.* annotations or the KongIngress route
+func (c *Converter) kongHeaders(ing *networkingv1.Ingress) map[string][]string {
+	headers := make(map[string][]string)
+	for key, value := range ing.Annotations {
+		if name, ok := strings.CutPrefix(key, kongHeadersPrefix); ok && name != "" {
+			for _, v := range strings.Split(value, ",") {
+				headers[name] = append(headers[name], strings.TrimSpace(v))
+			}
+		}
+	}
+	if len(headers) > 0 {
+		return headers
+	}
+	if route := c.kongRoute(ing); route != nil {
+		values, _, _ := unstructured.NestedMap(route, "headers")
+		for name, v := range values {
+			list, _ := v.([]interface{})
+			for _, item := range list {
+				headers[name] = append(headers[name], fmt.Sprint(item))
+			}
+		}
+	}
+	return headers
+}
+
+// kongMatches adds the method and header matches of a Kong Ingress to the
+// matches of a rule. Kong matches any of the listed methods, so each match
+// is repeated per method.
+func (c *Converter) kongMatches(ing *networkingv1.Ingress, matches []gatewayv1.HTTPRouteMatch) []gatewayv1.HTTPRouteMatch {
+	headers := c.kongHeaders(ing)
+	for _, name := range sortedKeys(headers) {
+		values := headers[name]
+		match := gatewayv1.HTTPHeaderMatch{Name: gatewayv1.HTTPHeaderName(name), Value: values[0]}
+		if len(values) > 1 {
+			// Kong matches any of the values
+			quoted := make([]string, len(values))
+			for i, v := range values {
+				quoted[i] = regexp.QuoteMeta(v)
+			}
+			matchType := gatewayv1.HeaderMatchRegularExpression
+			match.Type = &matchType
+			match.Value = "^(" + strings.Join(quoted, "|") + ")$"
+		}
+		for i := range matches {
+			matches[i].Headers = append(matches[i].Headers, match)
+		}
+	}
+
+	methods, _ := c.kongMethods(ing)
+	if len(methods) == 0 {
+		return matches
+	}
+	var expanded []gatewayv1.HTTPRouteMatch
+	for _, match := range matches {
+		for _, method := range methods {
+			m := *match.DeepCopy()
+			httpMethod := gatewayv1.HTTPMethod(method)
+			m.Method = &httpMethod
+			expanded = append(expanded, m)
+		}
+	}
+	return expanded
+}
+
+// kongStripPath reports whether Kong strips the matched path, from the
+// strip-path annotation or the KongIngress route
+func (c *Converter) kongStripPath(ing *networkingv1.Ingress) (bool, string) {
+	if value, ok := ing.Annotations[annotationKongStripPath]; ok {
+		strip, err := strconv.ParseBool(value)
+		if err != nil {
+			c.addDiagnostic(ing, annotationKongStripPath, SeverityWarning, "invalid value %q, not converted", value)
+		}
+		return strip, annotationKongStripPath
+	}
+	if route := c.kongRoute(ing); route != nil {
+		strip, _, _ := unstructured.NestedBool(route, "strip_path")
+		return strip, annotationKongOverride
+	}
+	return false, ""
+}
+
+// kongFilters converts the path and host rewrites and the transformer
+// plugins of a Kong Ingress for a rule matching path
+func (c *Converter) kongFilters(ing *networkingv1.Ingress, path gatewayv1.HTTPPathMatch) []gatewayv1.HTTPRouteFilter {
+	var filters []gatewayv1.HTTPRouteFilter
+
+	rewrite := &gatewayv1.HTTPURLRewriteFilter{}
+	if strip, annotation := c.kongStripPath(ing); strip {
+		root := "/"
+		switch *path.Type {
+		case gatewayv1.PathMatchPathPrefix:
+			if *path.Value != "/" {
+				rewrite.Path = &gatewayv1.HTTPPathModifier{Type: gatewayv1.PrefixMatchHTTPPathModifier, ReplacePrefixMatch: &root}
+			}
+		case gatewayv1.PathMatchExact:
+			rewrite.Path = &gatewayv1.HTTPPathModifier{Type: gatewayv1.FullPathHTTPPathModifier, ReplaceFullPath: &root}
+		default:
+			c.addDiagnostic(ing, annotation, SeverityWarning,
+				"strip-path on regular expression path %q cannot be expressed in Gateway API, not converted", *path.Value)
+		}
+	}
+	if host, ok := ing.Annotations[annotationKongHostHeader]; ok && host != "" {
+		hostname := gatewayv1.PreciseHostname(host)
+		rewrite.Hostname = &hostname
+	}
+	if rewrite.Path != nil || rewrite.Hostname != nil {
+		filters = append(filters, gatewayv1.HTTPRouteFilter{Type: gatewayv1.HTTPRouteFilterURLRewrite, URLRewrite: rewrite})
+	}
+
+	// With --target=kong the plugins are attached to the routes instead
+	if c.opts.Target == TargetKong {
+		return filters
+	}
+	for _, p := range c.kongPlugins(ing) {
+		switch p.plugin {
+		case "request-transformer":
+			filters = c.transformerFilters(ing, p, true, filters)
+		case "response-transformer":
+			filters = c.transformerFilters(ing, p, false, filters)
+		}
+	}
+	return filters
+}
+
+// transformerFilters converts the header operations of a request- or
+// response-transformer plugin to header modifiers
+func (c *Converter) transformerFilters(ing *networkingv1.Ingress, p kongPlugin, request bool, filters []gatewayv1.HTTPRouteFilter) []gatewayv1.HTTPRouteFilter {
+	headers := func(op string) []string {
+		values, _, _ := unstructured.NestedStringSlice(p.config, op, "headers")
+		return values
+	}
+
+	var approximated []string
+	for _, op := range []string{"add", "replace", "append"} {
+		for _, header := range headers(op) {
+			name, value, ok := strings.Cut(header, ":")
+			if !ok {
+				c.addDiagnostic(ing, annotationKongPlugins, SeverityWarning,
+					"plugin %s header %q is not name:value, not converted", p.name, header)
+				continue
+			}
+			name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+			if op == "append" {
+				filters = addHeader(filters, request, name, value)
+				continue
+			}
+			// Kong adds only missing headers and replaces only present ones
+			approximated = append(approximated, op)
+			if request {
+				filters = setRequestHeader(filters, name, value)
+			} else {
+				filters = setResponseHeader(filters, name, value)
+			}
+		}
+	}
+	for _, name := range headers("remove") {
+		filters = modifyHeader(filters, request, name, "")
+	}
+	if len(approximated) > 0 {
+		c.addDiagnostic(ing, annotationKongPlugins, SeverityInfo,
+			"plugin %s add and replace headers converted to set, which also overwrites present headers and adds missing ones", p.name)
+	}
+
+	var unconverted []string
+	for _, op := range []string{"add", "append", "replace", "remove", "rename"} {
+		section, _, _ := unstructured.NestedMap(p.config, op)
+		for _, field := range sortedKeys(section) {
+			if field != "headers" || op == "rename" {
+				unconverted = append(unconverted, op+"."+field)
+			}
+		}
+	}
+	if len(unconverted) > 0 {
+		c.addDiagnostic(ing, annotationKongPlugins, SeverityWarning,
+			"plugin %s %s not converted: Gateway API filters only modify headers", p.name, strings.Join(unconverted, ", "))
+	}
+	return filters
+}
+
+// addHeader adds a header value on the rule's request or response header
+// modifier, keeping the values already present
+func addHeader(filters []gatewayv1.HTTPRouteFilter, request bool, name, value string) []gatewayv1.HTTPRouteFilter {
+	header := gatewayv1.HTTPHeader{Name: gatewayv1.HTTPHeaderName(name), Value: value}
+	filterType := gatewayv1.HTTPRouteFilterResponseHeaderModifier
+	if request {
+		filterType = gatewayv1.HTTPRouteFilterRequestHeaderModifier
+	}
+	for i := range filters {
+		if filters[i].Type != filterType {
+			continue
+		}
+		modifier := filters[i].ResponseHeaderModifier
+		if request {
+			modifier = filters[i].RequestHeaderModifier
+		}
+		if modifier != nil {
+			modifier.Add = append(modifier.Add, header)
+			return filters
+		}
+	}
+
+	filter := gatewayv1.HTTPRouteFilter{Type: filterType}
+	modifier := &gatewayv1.HTTPHeaderFilter{Add: []gatewayv1.HTTPHeader{header}}
+	if request {
+		filter.RequestHeaderModifier = modifier
+	} else {
+		filter.ResponseHeaderModifier = modifier
+	}
+	return append(filters, filter)
+}
+
+// kongRateLimitUnits maps the rate-limiting plugin windows to Envoy Gateway units
+var kongRateLimitUnits = []struct {
+	window string
+	unit   string
+}{
+	{"second", "Second"},
+	{"minute", "Minute"},
+	{"hour", "Hour"},
+	{"day", "Day"},
+}
+
+// kongRateLimitRules converts rate-limiting plugins to Envoy Gateway rate
+// limit rules
+func (c *Converter) kongRateLimitRules(ing *networkingv1.Ingress) []interface{} {
+	var rules []interface{}
+	for _, p := range c.kongPlugins(ing) {
+		if p.plugin != "rate-limiting" || c.opts.Target == TargetKong {
+			continue
+		}
+		if c.opts.Target != TargetEnvoyGateway {
+			c.addDiagnostic(ing, annotationKongPlugins, SeverityWarning,
+				"plugin %s rate limit needs manual policy (no core Gateway API equivalent); use --target=envoy-gateway or kong", p.name)
+			continue
+		}
+
+		var selector map[string]interface{}
+		switch limitBy, _, _ := unstructured.NestedString(p.config, "limit_by"); limitBy {
+		case "", "consumer", "ip":
+			// Without authenticated consumers Kong limits per client address
+			selector = map[string]interface{}{
+				"sourceCIDR": map[string]interface{}{"type": "Distinct", "value": "0.0.0.0/0"},
+			}
+		case "header":
+			name, _, _ := unstructured.NestedString(p.config, "header_name")
+			selector = map[string]interface{}{
+				"headers": []interface{}{map[string]interface{}{"name": name, "type": "Distinct"}},
+			}
+		default:
+			c.addDiagnostic(ing, annotationKongPlugins, SeverityWarning,
+				"plugin %s limits by %s, which Envoy Gateway cannot select on; not converted", p.name, limitBy)
+			continue
+		}
+
+		for _, u := range kongRateLimitUnits {
+			requests, ok := p.config[u.window]
+			if !ok {
+				continue
+			}
+			n, err := strconv.ParseInt(headerValue(requests), 10, 64)
+			if err != nil || n <= 0 {
+				c.addDiagnostic(ing, annotationKongPlugins, SeverityWarning, "plugin %s %s limit %v is invalid, not converted", p.name, u.window, requests)
+				continue
+			}
+			rules = append(rules, map[string]interface{}{
+				"clientSelectors": []interface{}{selector},
+				"limit":           map[string]interface{}{"requests": n, "unit": u.unit},
+			})
+		}
+		for _, window := range []string{"month", "year"} {
+			if _, ok := p.config[window]; ok {
+				c.addDiagnostic(ing, annotationKongPlugins, SeverityWarning,
+					"plugin %s %s limit not converted: Envoy Gateway limits per second, minute, hour or day", p.name, window)
+			}
+		}
+	}
+	return rules
+}
+
+// kongCORS converts a cors plugin to the cors section of an Envoy Gateway
+// SecurityPolicy
+func (c *Converter) kongCORS(ing *networkingv1.Ingress) map[string]interface{} {
+	for _, p := range c.kongPlugins(ing) {
+		if p.plugin != "cors" || c.opts.Target == TargetKong {
+			continue
+		}
+		if c.opts.Target != TargetEnvoyGateway {
+			c.addDiagnostic(ing, annotationKongPlugins, SeverityWarning,
+				"plugin %s CORS needs manual policy (no Gateway API v1.0 filter); use --target=envoy-gateway or kong", p.name)
+			return nil
+		}
+
+		cors := map[string]interface{}{}
+		for field, key := range map[string]string{
+			"origins": "allowOrigins", "methods": "allowMethods", "headers": "allowHeaders", "exposed_headers": "exposeHeaders",
+		} {
+			if values, ok, _ := unstructured.NestedStringSlice(p.config, field); ok {
+				list := make([]interface{}, len(values))
+				for i, v := range values {
+					list[i] = v
+				}
+				cors[key] = list
+			}
+		}
+		if maxAge, ok := p.config["max_age"]; ok {
+			cors["maxAge"] = headerValue(maxAge) + "s"
+		}
+		if credentials, ok := p.config["credentials"].(bool); ok {
+			cors["allowCredentials"] = credentials
+		}
+		c.addDiagnostic(ing, annotationKongPlugins, SeverityInfo, "plugin %s converted to the cors of an Envoy Gateway SecurityPolicy", p.name)
+		return cors
+	}
+	return nil
+}
+
+// kongConvertedPlugins are the plugins converted for targets other than Kong
+var kongConvertedPlugins = map[string]bool{
+	"request-transformer":  true,
+	"response-transformer": true,
+	"rate-limiting":        true,
+	"cors":                 true,
+}
+
+// attachKongPlugins attaches the plugins of a Kong Ingress to its routes
+// with the plugins annotation Kong reads on HTTPRoutes, for --target=kong.
+// Other targets get a warning for each plugin without a conversion.
+func (c *Converter) attachKongPlugins(ing *networkingv1.Ingress, routes []interface{}) {
+	value, ok := ing.Annotations[annotationKongPlugins]
+	if !ok {
+		return
+	}
+	if c.opts.Target == TargetKong {
+		for _, r := range routes {
+			if route, ok := r.(*gatewayv1.HTTPRoute); ok {
+				route.Annotations = withEntry(route.Annotations, annotationKongPlugins, value)
+			}
+		}
+		c.addDiagnostic(ing, annotationKongPlugins, SeverityInfo,
+			"plugins %s attached to the HTTPRoutes; keep their KongPlugin resources", value)
+		return
+	}
+	for _, p := range c.kongPlugins(ing) {
+		if !kongConvertedPlugins[p.plugin] {
+			c.addDiagnostic(ing, annotationKongPlugins, SeverityWarning,
+				"plugin %s (%s) has no Gateway API equivalent, not converted; with --target=kong it is attached to the routes", p.name, p.plugin)
+		}
+	}
+}
+
+// checkKongRoute reports the Kong route settings that have no effect on
+// the converted routes
+func (c *Converter) checkKongRoute(ing *networkingv1.Ingress) {
+	if value, ok := ing.Annotations[annotationKongProtocols]; ok {
+		var protocols []string
+		for _, p := range strings.Split(value, ",") {
+			switch strings.TrimSpace(p) {
+			case "https", "grpcs":
+				protocols = append(protocols, "websecure")
+			default:
+				protocols = append(protocols, strings.TrimSpace(p))
+			}
+		}
+		c.checkEntryPoints(ing, annotationKongProtocols, protocols)
+	}
+	if _, ok := ing.Annotations[annotationKongHTTPSRedirect]; ok {
+		c.addDiagnostic(ing, annotationKongHTTPSRedirect, SeverityWarning,
+			"HTTPS redirect not converted: the route serves every Gateway listener, so redirect from a separate HTTPRoute attached to the HTTP listener")
+	}
+	if _, ok := ing.Annotations[annotationKongRegexPriority]; ok {
+		c.addDiagnostic(ing, annotationKongRegexPriority, SeverityWarning,
+			"regex priority not converted: Gateway API orders matches by specificity, check overlapping routes")
+	}
+	if value, ok := ing.Annotations[annotationKongPreserveHost]; ok && value == "false" {
+		if _, rewritten := ing.Annotations[annotationKongHostHeader]; !rewritten {
+			c.addDiagnostic(ing, annotationKongPreserveHost, SeverityWarning,
+				"preserve-host false not converted: Gateway API forwards the client Host header; set host-header to rewrite it")
+		}
+	}
+
+	route := c.kongRoute(ing)
+	var unconverted []string
+	for _, field := range sortedKeys(route) {
+		switch field {
+		case "methods", "strip_path", "headers":
+		case "preserve_host":
+			if route[field] == true {
+				continue
+			}
+			unconverted = append(unconverted, field)
+		default:
+			unconverted = append(unconverted, field)
+		}
+	}
+	if name, ok := ing.Annotations[annotationKongOverride]; ok {
+		if obj, found := c.referenced[referencedKey(kindKongIngress, ing.Namespace, name)]; found {
+			for _, section := range []string{"proxy", "upstream"} {
+				if _, ok := obj.Object[section]; ok {
+					unconverted = append(unconverted, section)
+				}
+			}
+		}
+	}
+	if len(unconverted) > 0 {
+		c.addDiagnostic(ing, annotationKongOverride, SeverityWarning,
+			"KongIngress %s not converted; set them with Kong's Service annotations or policies", strings.Join(unconverted, ", "))
+	}
+}
+
+// kongRegexPath returns the regular expression of a Kong regex path. Kong
+// anchors it at the start of the path only.
+func kongRegexPath(path networkingv1.HTTPIngressPath) (string, bool) {
+	if path.PathType == nil || *path.PathType != networkingv1.PathTypeImplementationSpecific ||
+		!strings.HasPrefix(path.Path, kongRegexPrefix) {
+		return "", false
+	}
+	regex := strings.TrimPrefix(strings.TrimPrefix(path.Path, kongRegexPrefix), "^")
+	if strings.HasSuffix(regex, "$") {
+		return strings.TrimSuffix(regex, "$"), true
+	}
+	return regex + ".*", true
+}
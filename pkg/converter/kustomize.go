@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+)
+
+// KustomizationFile is the file kustomize reads from a directory
+const KustomizationFile = "kustomization.yaml"
+
+// kustomization is the part of a kustomize Kustomization the output uses
+type kustomization struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Resources  []string `json:"resources"`
+}
+
+// WriteKustomization writes a kustomization.yaml to dir listing resources,
+// files or directories relative to dir, so kubectl apply -k and GitOps
+// tools consume the directory as is. Resources are sorted so repeated runs
+// produce the same file.
+func WriteKustomization(dir string, resources []string) error {
+	sorted := append([]string(nil), resources...)
+	sort.Strings(sorted)
+
+	data, err := yaml.Marshal(kustomization{
+		APIVersion: "kustomize.config.k8s.io/v1beta1",
+		Kind:       "Kustomization",
+		Resources:  sorted,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal kustomization: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, KustomizationFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write kustomization: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	annotationLoadBalance          = "nginx.ingress.kubernetes.io/load-balance"
+	annotationUpstreamHashBySubset = "nginx.ingress.kubernetes.io/upstream-hash-by-subset"
+)
+
+// Load balancing algorithms, named as Envoy Gateway names them
+const (
+	lbRoundRobin     = "RoundRobin"
+	lbLeastRequest   = "LeastRequest"
+	lbConsistentHash = "ConsistentHash"
+)
+
+// Consistent hash keys
+const (
+	hashSourceIP = "SourceIP"
+	hashHeader   = "Header"
+	hashCookie   = "Cookie"
+)
+
+// hashVariableRegex matches the nginx variables upstream-hash-by can hash on
+var hashVariableRegex = regexp.MustCompile(`^\$(remote_addr|binary_remote_addr|http_([a-zA-Z0-9_]+)|cookie_([a-zA-Z0-9_-]+))$`)
+
+// loadBalancer is the load balancing the annotations of an Ingress select
+type loadBalancer struct {
+	annotation string // the annotation that selected it
+	algorithm  string
+	hashType   string // for ConsistentHash
+	hashName   string // the header or cookie hashed on
+}
+
+// loadBalancer returns the load balancing that upstream-hash-by or
+// load-balance select, nil for the default or when neither converts.
+// Gateway API has no algorithm field, BackendLBPolicy only configures
+// session persistence, so only targets with a policy for it convert them.
+func (c *Converter) loadBalancer(ing *networkingv1.Ingress) *loadBalancer {
+	if subset, exists := ing.Annotations[annotationUpstreamHashBySubset]; exists && subset == "true" {
+		c.addDiagnostic(ing, annotationUpstreamHashBySubset, SeverityWarning,
+			"hashing to subsets of endpoints has no equivalent; requests are hashed to single endpoints")
+	}
+
+	var lb *loadBalancer
+	if hashBy, exists := ing.Annotations[annotationUpstreamHashBy]; exists {
+		m := hashVariableRegex.FindStringSubmatch(strings.TrimSpace(hashBy))
+		switch {
+		case m == nil:
+			c.addDiagnostic(ing, annotationUpstreamHashBy, SeverityWarning,
+				"consistent hashing on %q not converted: only $remote_addr, $http_<header> and $cookie_<name> can be hashed on", hashBy)
+			return nil
+		case m[2] != "":
+			lb = &loadBalancer{annotation: annotationUpstreamHashBy, algorithm: lbConsistentHash, hashType: hashHeader,
+				hashName: strings.ReplaceAll(m[2], "_", "-")}
+		case m[3] != "":
+			lb = &loadBalancer{annotation: annotationUpstreamHashBy, algorithm: lbConsistentHash, hashType: hashCookie, hashName: m[3]}
+		default:
+			lb = &loadBalancer{annotation: annotationUpstreamHashBy, algorithm: lbConsistentHash, hashType: hashSourceIP}
+		}
+		if _, both := ing.Annotations[annotationLoadBalance]; both {
+			c.addDiagnostic(ing, annotationLoadBalance, SeverityInfo, "load-balance is ignored since upstream-hash-by is set")
+		}
+	} else if algorithm, exists := ing.Annotations[annotationLoadBalance]; exists {
+		switch algorithm {
+		case "round_robin":
+			lb = &loadBalancer{annotation: annotationLoadBalance, algorithm: lbRoundRobin}
+		case "ewma":
+			c.addDiagnostic(ing, annotationLoadBalance, SeverityWarning,
+				"ewma is approximated by least-request load balancing, which favors endpoints with fewer active requests instead of lower latency")
+			lb = &loadBalancer{annotation: annotationLoadBalance, algorithm: lbLeastRequest}
+		default:
+			c.addDiagnostic(ing, annotationLoadBalance, SeverityWarning, "unknown load balancing algorithm %q, not converted", algorithm)
+			return nil
+		}
+	} else {
+		return nil
+	}
+
+	switch c.opts.Target {
+	case TargetEnvoyGateway, TargetIstio:
+		return lb
+	}
+	c.addDiagnostic(ing, lb.annotation, SeverityWarning,
+		"%s load balancing not converted: BackendLBPolicy cannot select an algorithm; configure it on %s, or use --target=envoy-gateway or istio",
+		lb.describe(), c.targetName())
+	return nil
+}
+
+// describe names the load balancing for diagnostics
+func (lb *loadBalancer) describe() string {
+	switch lb.hashType {
+	case hashSourceIP:
+		return "client address hash"
+	case hashHeader:
+		return fmt.Sprintf("header %s hash", lb.hashName)
+	case hashCookie:
+		return fmt.Sprintf("cookie %s hash", lb.hashName)
+	}
+	return map[string]string{lbRoundRobin: "round-robin", lbLeastRequest: "least-request"}[lb.algorithm]
+}
+
+// targetName names the target in diagnostics
+func (c *Converter) targetName() string {
+	if c.opts.Target == "" {
+		return "the implementation"
+	}
+	return c.opts.Target
+}
+
+// envoyLoadBalancer returns the loadBalancer section of an Envoy Gateway
+// BackendTrafficPolicy
+func envoyLoadBalancer(lb *loadBalancer) map[string]interface{} {
+	spec := map[string]interface{}{"type": lb.algorithm}
+	switch lb.hashType {
+	case hashSourceIP:
+		spec["consistentHash"] = map[string]interface{}{"type": hashSourceIP}
+	case hashHeader:
+		spec["consistentHash"] = map[string]interface{}{
+			"type":   hashHeader,
+			"header": map[string]interface{}{"name": lb.hashName},
+		}
+	case hashCookie:
+		spec["consistentHash"] = map[string]interface{}{
+			"type":   hashCookie,
+			"cookie": map[string]interface{}{"name": lb.hashName},
+		}
+	}
+	return spec
+}
+
+// istioDestinationRules converts the load balancing and the connect timeout
+// into a DestinationRule per backend Service of the Ingress
+func (c *Converter) istioDestinationRules(ing *networkingv1.Ingress, lb *loadBalancer, connectTimeout map[string]interface{}) []interface{} {
+	if c.opts.Target != TargetIstio || (lb == nil && connectTimeout == nil) {
+		return nil
+	}
+
+	// A Service has one effective DestinationRule, so the load balancer
+	// and the connection pool share it; it is named after its first use
+	trafficPolicy := map[string]interface{}{}
+	use := "loadbalancer"
+	if lb != nil {
+		trafficPolicy["loadBalancer"] = istioLoadBalancer(lb)
+	} else {
+		use = "timeout"
+	}
+	if connectTimeout != nil {
+		trafficPolicy["connectionPool"] = connectTimeout
+	}
+
+	var rules []interface{}
+	for _, svc := range backendServiceNames(ing) {
+		rules = append(rules, newPolicy("networking.istio.io/v1beta1", kindDestinationRule,
+			sanitizeName(svc+"-"+use), ing.Namespace,
+			map[string]interface{}{
+				"host":          svc,
+				"trafficPolicy": runtime.DeepCopyJSON(trafficPolicy),
+			}))
+	}
+	if len(rules) > 0 && lb != nil {
+		c.addDiagnostic(ing, lb.annotation, SeverityInfo,
+			"%s load balancing converted to Istio DestinationRules; merge them with existing DestinationRules of the Services", lb.describe())
+	}
+	return rules
+}
+
+// istioLoadBalancer converts the load balancing into the loadBalancer of an
+// Istio DestinationRule traffic policy
+func istioLoadBalancer(lb *loadBalancer) map[string]interface{} {
+	spec := map[string]interface{}{}
+	switch lb.hashType {
+	case hashSourceIP:
+		spec["consistentHash"] = map[string]interface{}{"useSourceIp": true}
+	case hashHeader:
+		spec["consistentHash"] = map[string]interface{}{"httpHeaderName": lb.hashName}
+	case hashCookie:
+		// Istio sets the cookie when the client has none
+		spec["consistentHash"] = map[string]interface{}{"httpCookie": map[string]interface{}{"name": lb.hashName, "ttl": "0s"}}
+	default:
+		spec["simple"] = map[string]string{lbRoundRobin: "ROUND_ROBIN", lbLeastRequest: "LEAST_REQUEST"}[lb.algorithm]
+	}
+	return spec
+}
+
+// setEnvoyLoadBalancer adds the load balancing to an Envoy Gateway
+// BackendTrafficPolicy
+func (c *Converter) setEnvoyLoadBalancer(ing *networkingv1.Ingress, lb *loadBalancer, policy *unstructured.Unstructured) {
+	if err := unstructured.SetNestedField(policy.Object, envoyLoadBalancer(lb), "spec", "loadBalancer"); err != nil {
+		c.addDiagnostic(ing, lb.annotation, SeverityError, "failed to set load balancer: %v", err)
+		return
+	}
+	c.addDiagnostic(ing, lb.annotation, SeverityInfo, "%s load balancing converted to an Envoy Gateway BackendTrafficPolicy", lb.describe())
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// AnnotationMapping maps an annotation to Gateway API constructs. Filter,
+// Timeouts and Policy are YAML templates of an HTTPRouteFilter, the
+// HTTPRouteTimeouts of the rule and a policy resource, executed with
+// MappingData for each rule of an Ingress carrying the annotation:
+//
+//	annotation: my.company/redirect-host
+//	filter: |
+//	  type: RequestRedirect
+//	  requestRedirect:
+//	    hostname: {{ .Value }}
+//	    statusCode: 301
+type AnnotationMapping struct {
+	Annotation string `json:"annotation"`
+	Filter     string `json:"filter,omitempty"`
+	Timeouts   string `json:"timeouts,omitempty"`
+	Policy     string `json:"policy,omitempty"`
+}
+
+// MappingData is what the templates of an AnnotationMapping are executed with
+type MappingData struct {
+	Value     string // value of the annotation
+	Ingress   string // name of the Ingress
+	Namespace string // namespace of the Ingress
+	Path      string // path match of the rule
+}
+
+// gatewayDurationRegex matches Gateway API durations such as 1h30m (GEP-2257)
+var gatewayDurationRegex = regexp.MustCompile(`^([0-9]{1,5}(h|m|s|ms)){1,4}$`)
+
+// mappingFile is the layout of an annotation mapping file
+type mappingFile struct {
+	Mappings []AnnotationMapping `json:"mappings"`
+}
+
+// LoadMappings reads the annotation mappings of a YAML file holding a
+// mappings list
+func LoadMappings(path string) ([]AnnotationMapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read annotation mappings: %w", err)
+	}
+	var file mappingFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse annotation mappings %s: %w", path, err)
+	}
+	return file.Mappings, nil
+}
+
+// RegisterMappings registers a translator for each mapping. Mapped
+// annotations must not have a translator yet.
+func (r *Registry) RegisterMappings(mappings []AnnotationMapping) error {
+	for i, m := range mappings {
+		t, err := newMappingTranslator(m)
+		if err != nil {
+			return fmt.Errorf("mapping %d: %w", i+1, err)
+		}
+		if err := r.Register(t); err != nil {
+			return fmt.Errorf("mapping %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// mappingTranslator is the translator of an AnnotationMapping
+type mappingTranslator struct {
+	annotation string
+	filter     *template.Template
+	timeouts   *template.Template
+	policy     *template.Template
+}
+
+// newMappingTranslator parses the templates of m
+func newMappingTranslator(m AnnotationMapping) (*mappingTranslator, error) {
+	if m.Annotation == "" {
+		return nil, fmt.Errorf("annotation is required")
+	}
+	if m.Filter == "" && m.Timeouts == "" && m.Policy == "" {
+		return nil, fmt.Errorf("annotation %s maps to nothing: set filter, timeouts or policy", m.Annotation)
+	}
+
+	t := &mappingTranslator{annotation: m.Annotation}
+	for _, field := range []struct {
+		name string
+		text string
+		tmpl **template.Template
+	}{
+		{"filter", m.Filter, &t.filter},
+		{"timeouts", m.Timeouts, &t.timeouts},
+		{"policy", m.Policy, &t.policy},
+	} {
+		if field.text == "" {
+			continue
+		}
+		tmpl, err := template.New(field.name).Parse(field.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s template of %s: %w", field.name, m.Annotation, err)
+		}
+		*field.tmpl = tmpl
+	}
+	return t, nil
+}
+
+func (m *mappingTranslator) Name() string          { return "mapping:" + m.annotation }
+func (m *mappingTranslator) Annotations() []string { return []string{m.annotation} }
+
+// Translate renders the templates of the mapping. Templates rendering
+// invalid constructs are reported and left out.
+func (m *mappingTranslator) Translate(t *Translation) error {
+	data := MappingData{
+		Value:     t.Ingress.Annotations[m.annotation],
+		Ingress:   t.Ingress.Name,
+		Namespace: t.Ingress.Namespace,
+	}
+	if t.Path.Value != nil {
+		data.Path = *t.Path.Value
+	}
+
+	if m.filter != nil {
+		var filter gatewayv1.HTTPRouteFilter
+		if m.render(t, m.filter, data, &filter) {
+			if filter.Type == "" {
+				t.Report(m.annotation, SeverityError, "mapped filter has no type, not converted")
+			} else {
+				t.Filters = mergeFilter(t, m.annotation, filter)
+			}
+		}
+	}
+	if m.timeouts != nil {
+		var timeouts gatewayv1.HTTPRouteTimeouts
+		switch {
+		case !m.render(t, m.timeouts, data, &timeouts):
+		case !validDurations(timeouts.Request, timeouts.BackendRequest):
+			t.Report(m.annotation, SeverityError, "mapped timeouts are not Gateway API durations such as 30s or 1m30s, not converted")
+		case !t.c.inChannel(FeatureHTTPRouteTimeouts):
+			t.c.unsupportedFeature(t.Ingress, m.annotation, FeatureHTTPRouteTimeouts, "mapped timeouts")
+		default:
+			t.Timeouts = &timeouts
+		}
+	}
+	if m.policy != nil {
+		policy := &unstructured.Unstructured{}
+		if m.render(t, m.policy, data, &policy.Object) {
+			if policy.GetNamespace() == "" {
+				policy.SetNamespace(t.Ingress.Namespace)
+			}
+			if policy.GetKind() == "" || policy.GetAPIVersion() == "" || policy.GetName() == "" {
+				t.Report(m.annotation, SeverityError, "mapped policy needs apiVersion, kind and metadata.name, not converted")
+			} else {
+				t.AddPolicy(policy)
+			}
+		}
+	}
+	return nil
+}
+
+// render executes tmpl and decodes the YAML it renders into out, reporting
+// failures against the annotation
+func (m *mappingTranslator) render(t *Translation, tmpl *template.Template, data MappingData, out interface{}) bool {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		t.Report(m.annotation, SeverityError, "mapped %s template failed: %v", tmpl.Name(), err)
+		return false
+	}
+	if err := yaml.UnmarshalStrict(buf.Bytes(), out); err != nil {
+		t.Report(m.annotation, SeverityError, "mapped %s is invalid, not converted: %v", tmpl.Name(), err)
+		return false
+	}
+	return true
+}
+
+// validDurations reports whether the set durations are Gateway API durations
+func validDurations(durations ...*gatewayv1.Duration) bool {
+	for _, d := range durations {
+		if d != nil && !gatewayDurationRegex.MatchString(string(*d)) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeFilter adds a mapped filter to the filters of a rule. Header
+// modifiers merge into those of the rule, since a rule may only carry one
+// of each; other filter types already on the rule are kept.
+func mergeFilter(t *Translation, annotation string, filter gatewayv1.HTTPRouteFilter) []gatewayv1.HTTPRouteFilter {
+	filters := t.Filters
+	var headers *gatewayv1.HTTPHeaderFilter
+	request := true
+	switch filter.Type {
+	case gatewayv1.HTTPRouteFilterRequestHeaderModifier:
+		headers = filter.RequestHeaderModifier
+	case gatewayv1.HTTPRouteFilterResponseHeaderModifier:
+		headers, request = filter.ResponseHeaderModifier, false
+	}
+	if headers != nil {
+		for _, h := range headers.Set {
+			filters = modifyHeader(filters, request, string(h.Name), h.Value)
+		}
+		for _, h := range headers.Add {
+			filters = addHeader(filters, request, string(h.Name), h.Value)
+		}
+		for _, name := range headers.Remove {
+			filters = modifyHeader(filters, request, name, "")
+		}
+		return filters
+	}
+
+	for _, f := range filters {
+		if f.Type == filter.Type {
+			t.Report(annotation, SeverityWarning, "rule already has a %s filter; mapped filter not added", filter.Type)
+			return filters
+		}
+	}
+	return append(filters, filter)
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// hostMerge collects the per-host routes of the Ingresses of a Convert call
+// so routes of one namespace, hostname and parentRef become one HTTPRoute
+type hostMerge struct {
+	groups map[string]*mergeGroup
+	keys   []string
+}
+
+// mergeGroup is the routes merged into one HTTPRoute
+type mergeGroup struct {
+	namespace string
+	host      string
+	members   []mergeMember
+}
+
+// mergeMember is a per-host route and the Ingress it was generated for
+type mergeMember struct {
+	ing   *networkingv1.Ingress
+	route *gatewayv1.HTTPRoute
+}
+
+// add takes the mergeable routes out of the resources generated for ing
+// and returns the rest. Routes targeted by a policy are kept apart, since
+// the policy would otherwise apply to the paths of every merged Ingress, and
+// so are routes owned by a progressive delivery controller.
+func (m *hostMerge) add(c *Converter, ing *networkingv1.Ingress, resources []interface{}) []interface{} {
+	targeted := policyTargets(resources)
+
+	var kept []interface{}
+	for _, res := range resources {
+		route, ok := res.(*gatewayv1.HTTPRoute)
+		if !ok || len(route.Spec.Hostnames) != 1 || route.Labels[managedByLabel] != "" {
+			kept = append(kept, res)
+			continue
+		}
+		host := string(route.Spec.Hostnames[0])
+		if targeted[route.Name] {
+			c.addDiagnostic(ing, "", SeverityInfo,
+				"HTTPRoute %s for host %s not merged with other Ingresses: its policies would apply to their paths too", route.Name, host)
+			kept = append(kept, res)
+			continue
+		}
+
+		parents, _ := json.Marshal(route.Spec.ParentRefs)
+		key := route.Namespace + "/" + host + "/" + string(parents)
+		if m.groups == nil {
+			m.groups = make(map[string]*mergeGroup)
+		}
+		g, exists := m.groups[key]
+		if !exists {
+			g = &mergeGroup{namespace: route.Namespace, host: host}
+			m.groups[key] = g
+			m.keys = append(m.keys, key)
+		}
+		g.members = append(g.members, mergeMember{ing: ing, route: route})
+	}
+	return kept
+}
+
+// routes returns an HTTPRoute per group, named after its hostname. Rules
+// keep the order of their Ingresses by age, oldest first, which is how
+// ingress-nginx picks between Ingresses defining the same path; a later
+// rule with the same matches could never match and is dropped.
+func (m *hostMerge) routes(c *Converter) []interface{} {
+	sort.Strings(m.keys)
+	names := make(map[string]int)
+
+	var routes []interface{}
+	for _, key := range m.keys {
+		g := m.groups[key]
+		sort.SliceStable(g.members, func(i, j int) bool {
+			a, b := g.members[i].ing, g.members[j].ing
+			if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+				return a.CreationTimestamp.Before(&b.CreationTimestamp)
+			}
+			return a.Name < b.Name
+		})
+
+		name := mergedRouteName(g.host)
+		if names[g.namespace+"/"+name]++; names[g.namespace+"/"+name] > 1 {
+			name = fmt.Sprintf("%s-%d", name, names[g.namespace+"/"+name])
+		}
+
+		route := g.members[0].route.DeepCopy()
+		route.Name = name
+		route.Spec.Rules = nil
+		var ingresses, ruleOwners []string
+		for _, member := range g.members {
+			ingresses = append(ingresses, member.ing.Name)
+			route.Labels = commonEntries(route.Labels, member.route.Labels)
+			route.Annotations = commonEntries(route.Annotations, member.route.Annotations)
+
+			for _, rule := range member.route.Spec.Rules {
+				if owner := matchedBy(route.Spec.Rules, ruleOwners, member.ing.Name, rule); owner != "" {
+					c.addDiagnostic(member.ing, "", SeverityWarning,
+						"path %s of host %s is also defined by ingress %s, which is older; dropped from HTTPRoute %s",
+						rulePath(rule), g.host, owner, name)
+					continue
+				}
+				route.Spec.Rules = append(route.Spec.Rules, rule)
+				ruleOwners = append(ruleOwners, member.ing.Name)
+			}
+		}
+
+		if len(g.members) > 1 {
+			for _, member := range g.members {
+				c.addDiagnostic(member.ing, "", SeverityInfo,
+					"paths of host %s merged into HTTPRoute %s with ingresses %s", g.host, name, strings.Join(ingresses, ", "))
+			}
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// matchedBy returns the Ingress of another Ingress's rule with the matches
+// of rule, "" if none. owners holds the Ingress of each of rules.
+func matchedBy(rules []gatewayv1.HTTPRouteRule, owners []string, ingress string, rule gatewayv1.HTTPRouteRule) string {
+	for i, other := range rules {
+		if owners[i] != ingress && reflect.DeepEqual(other.Matches, rule.Matches) {
+			return owners[i]
+		}
+	}
+	return ""
+}
+
+// mergedRouteName names the route merging the Ingresses of a hostname after
+// the hostname alone, since no single Ingress owns it
+func mergedRouteName(host string) string {
+	return strings.TrimPrefix(hostRouteName("", host), "-")
+}
+
+// rulePath describes the path a rule matches, e.g. "/api (PathPrefix)"
+func rulePath(rule gatewayv1.HTTPRouteRule) string {
+	for _, match := range rule.Matches {
+		if match.Path != nil && match.Path.Value != nil {
+			if match.Path.Type != nil {
+				return fmt.Sprintf("%s (%s)", *match.Path.Value, *match.Path.Type)
+			}
+			return *match.Path.Value
+		}
+	}
+	return "/"
+}
+
+// commonEntries returns the entries of a that b has too
+func commonEntries(a, b map[string]string) map[string]string {
+	var common map[string]string
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			continue
+		}
+		if common == nil {
+			common = make(map[string]string)
+		}
+		common[k] = v
+	}
+	return common
+}
+
+// policyTargets returns the names of the HTTPRoutes the policies among
+// resources attach to
+func policyTargets(resources []interface{}) map[string]bool {
+	targets := make(map[string]bool)
+	for _, res := range resources {
+		policy, ok := res.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		refs, _, _ := unstructured.NestedSlice(policy.Object, "spec", "targetRefs")
+		if ref, found, _ := unstructured.NestedMap(policy.Object, "spec", "targetRef"); found {
+			refs = append(refs, ref)
+		}
+		for _, r := range refs {
+			ref, ok := r.(map[string]interface{})
+			if ok && ref["kind"] == "HTTPRoute" {
+				if name, ok := ref["name"].(string); ok {
+					targets[name] = true
+				}
+			}
+		}
+	}
+	return targets
+}
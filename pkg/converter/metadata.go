@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// lastAppliedAnnotation describes the source Ingress and is never propagated
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// excludedKeys are never propagated: they configure or describe the source
+// Ingress and mean nothing on a route
+var excludedKeys = []string{lastAppliedAnnotation, nginxAnnotationPrefix + "*"}
+
+// RedactedValue replaces the value of redacted labels and annotations
+const RedactedValue = "REDACTED"
+
+// defaultDenyKeys are never propagated either, matched ignoring case: keys
+// that usually hold credentials, and state other tools track on the Ingress
+var defaultDenyKeys = []string{
+	"*password*", "*passwd*", "*secret*", "*token*", "*credential*",
+	"*api-key*", "*apikey*", "*private-key*",
+	"kubectl.kubernetes.io/*", "deployment.kubernetes.io/*", "field.cattle.io/*",
+}
+
+// DefaultDenyKeys returns the patterns of the labels and annotations that
+// are never propagated, whatever the options
+func DefaultDenyKeys() []string {
+	return append([]string(nil), defaultDenyKeys...)
+}
+
+// credentialValues match values that look like credentials whatever their key
+var credentialValues = []*regexp.Regexp{
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`),
+	regexp.MustCompile(`^eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*$`), // JWT
+	regexp.MustCompile(`(?i)^(bearer|basic) [A-Za-z0-9+/=._~-]{8,}$`),
+	regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`),             // AWS access key IDs
+	regexp.MustCompile(`[a-z][a-z0-9+.-]*://[^/\s:@]+:[^/\s@]+@`), // URL userinfo
+}
+
+// looksLikeCredential reports whether value matches a credential pattern
+func looksLikeCredential(value string) bool {
+	for _, re := range credentialValues {
+		if re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesKey reports whether key matches one of the patterns, globs where
+// "*" matches any run of characters, "/" included, and "?" any one
+// character. A pattern ending in "/" matches every key with that prefix.
+func matchesKey(key string, patterns []string) bool {
+	for _, p := range patterns {
+		if strings.HasSuffix(p, "/") {
+			p += "*"
+		}
+		if globMatch(p, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// globMatch reports whether s matches the glob pattern
+func globMatch(pattern, s string) bool {
+	// On a mismatch, retry from the last "*" consuming one more character
+	px, sx := 0, 0
+	starPx, starSx := -1, 0
+	for px < len(pattern) || sx < len(s) {
+		if px < len(pattern) {
+			switch c := pattern[px]; {
+			case c == '*':
+				starPx, starSx = px, sx
+				px++
+				continue
+			case sx < len(s) && (c == '?' || c == s[sx]):
+				px++
+				sx++
+				continue
+			}
+		}
+		if starPx < 0 || starSx >= len(s) {
+			return false
+		}
+		starSx++
+		px, sx = starPx+1, starSx
+	}
+	return true
+}
+
+// filterEntries returns the entries of m whose key matches keep, or every
+// key if keep is empty, and matches neither drop, excludedKeys nor
+// defaultDenyKeys. The keys left out only by defaultDenyKeys are returned
+// sorted as denied.
+func filterEntries(m map[string]string, keep, drop []string) (filtered map[string]string, denied []string) {
+	for k, v := range m {
+		if len(keep) > 0 && !matchesKey(k, keep) || matchesKey(k, drop) || matchesKey(k, excludedKeys) {
+			continue
+		}
+		if matchesKey(strings.ToLower(k), defaultDenyKeys) {
+			denied = append(denied, k)
+			continue
+		}
+		if filtered == nil {
+			filtered = make(map[string]string)
+		}
+		filtered[k] = v
+	}
+	sort.Strings(denied)
+	return filtered, denied
+}
+
+// routeLabels returns the Ingress labels to set on generated routes
+func (c *Converter) routeLabels(ing *networkingv1.Ingress) map[string]string {
+	return c.routeMetadata(ing, "label", ing.Labels, c.opts.CopyLabels, c.opts.StripLabels)
+}
+
+// routeAnnotations returns the Ingress annotations to set on generated
+// routes, none unless CopyAnnotations is set
+func (c *Converter) routeAnnotations(ing *networkingv1.Ingress) map[string]string {
+	if len(c.opts.CopyAnnotations) == 0 {
+		return nil
+	}
+	return c.routeMetadata(ing, "annotation", ing.Annotations, c.opts.CopyAnnotations, c.opts.DropAnnotations)
+}
+
+// routeMetadata filters labels or annotations for generated routes, leaving
+// out DenyKeys, reporting the keys left out by default, and redacting the values of RedactKeys and of values that
+// look like credentials
+func (c *Converter) routeMetadata(ing *networkingv1.Ingress, kind string, m map[string]string, keep, drop []string) map[string]string {
+	deny := make([]string, 0, len(drop)+len(c.opts.DenyKeys))
+	deny = append(append(deny, drop...), c.opts.DenyKeys...)
+
+	filtered, denied := filterEntries(m, keep, deny)
+	for _, k := range denied {
+		c.addDiagnostic(ing, "", SeverityInfo,
+			"%s %s matches a default deny pattern for credentials and tool state and is not propagated to generated resources", kind, k)
+	}
+	for k, v := range filtered {
+		switch {
+		case v == "":
+		case matchesKey(k, c.opts.RedactKeys):
+			filtered[k] = RedactedValue
+		case looksLikeCredential(v):
+			filtered[k] = RedactedValue
+			c.addDiagnostic(ing, "", SeverityWarning,
+				"%s %s looks like a credential and is redacted on generated resources; deny or redact it explicitly to silence this", kind, k)
+		}
+	}
+	return filtered
+}
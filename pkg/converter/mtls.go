@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	annotationAuthTLSSecret       = "nginx.ingress.kubernetes.io/auth-tls-secret"
+	annotationAuthTLSVerifyClient = "nginx.ingress.kubernetes.io/auth-tls-verify-client"
+	annotationAuthTLSVerifyDepth  = "nginx.ingress.kubernetes.io/auth-tls-verify-depth"
+	annotationAuthTLSErrorPage    = "nginx.ingress.kubernetes.io/auth-tls-error-page"
+	annotationAuthTLSPassCert     = "nginx.ingress.kubernetes.io/auth-tls-pass-certificate-to-upstream"
+	annotationAuthTLSMatchCN      = "nginx.ingress.kubernetes.io/auth-tls-match-cn"
+)
+
+// extractClientTLS converts auth-tls-* client certificate validation into
+// the target implementation's frontend TLS policy, or records manual-review
+// diagnostics. Client validation is configured per Gateway listener, so it
+// applies to every host served by that listener.
+func (c *Converter) extractClientTLS(ing *networkingv1.Ingress) *unstructured.Unstructured {
+	secret, exists := ing.Annotations[annotationAuthTLSSecret]
+	if !exists {
+		for _, annotation := range []string{annotationAuthTLSVerifyClient, annotationAuthTLSVerifyDepth,
+			annotationAuthTLSErrorPage, annotationAuthTLSPassCert, annotationAuthTLSMatchCN} {
+			if _, ok := ing.Annotations[annotation]; ok {
+				c.addDiagnostic(ing, annotation, SeverityWarning, "ignored without %s", annotationAuthTLSSecret)
+			}
+		}
+		return nil
+	}
+
+	caNamespace, caName := ing.Namespace, strings.TrimSpace(secret)
+	if ns, name, ok := strings.Cut(caName, "/"); ok {
+		caNamespace, caName = ns, name
+	}
+	if caName == "" || caNamespace == "" {
+		c.addDiagnostic(ing, annotationAuthTLSSecret, SeverityWarning, "invalid CA secret %q, client certificate validation not converted", secret)
+		return nil
+	}
+
+	verify := strings.TrimSpace(ing.Annotations[annotationAuthTLSVerifyClient])
+	switch verify {
+	case "", "on", "optional":
+	case "off":
+		c.addDiagnostic(ing, annotationAuthTLSVerifyClient, SeverityInfo, "client certificate verification is off, nothing to convert")
+		return nil
+	case "optional_no_ca":
+		c.addDiagnostic(ing, annotationAuthTLSVerifyClient, SeverityWarning,
+			"accepting client certificates without CA verification has no Gateway API or policy equivalent, needs manual review")
+		return nil
+	default:
+		c.addDiagnostic(ing, annotationAuthTLSVerifyClient, SeverityWarning, "invalid verify mode %q, client certificate validation not converted", verify)
+		return nil
+	}
+
+	if len(ing.Spec.TLS) == 0 {
+		c.addDiagnostic(ing, annotationAuthTLSSecret, SeverityWarning,
+			"client certificate validation requires an HTTPS listener but the Ingress has no TLS section")
+	}
+	if depth, ok := ing.Annotations[annotationAuthTLSVerifyDepth]; ok {
+		c.addDiagnostic(ing, annotationAuthTLSVerifyDepth, SeverityWarning,
+			"verification depth %q has no Gateway API or policy equivalent; the full chain up to the CA is verified", depth)
+	}
+	if page, ok := ing.Annotations[annotationAuthTLSErrorPage]; ok {
+		c.addDiagnostic(ing, annotationAuthTLSErrorPage, SeverityWarning,
+			"redirect to %s on failed client verification has no Gateway API equivalent; clients get a TLS handshake error instead", page)
+	}
+	if cn, ok := ing.Annotations[annotationAuthTLSMatchCN]; ok {
+		c.addDiagnostic(ing, annotationAuthTLSMatchCN, SeverityWarning,
+			"client certificate CN match %q needs manual review (restrict the issuing CA or add an authorization policy)", cn)
+	}
+
+	if c.opts.Target != TargetEnvoyGateway {
+		c.addDiagnostic(ing, annotationAuthTLSSecret, SeverityWarning,
+			"client certificate validation against CA secret %s/%s needs manual listener configuration (frontend TLS validation is not in Gateway API %s); select --target=envoy-gateway to generate a ClientTrafficPolicy",
+			caNamespace, caName, gatewayv1.GroupVersion.Version)
+		if _, ok := ing.Annotations[annotationAuthTLSPassCert]; ok {
+			c.addDiagnostic(ing, annotationAuthTLSPassCert, SeverityWarning, "passing the client certificate upstream needs manual configuration")
+		}
+		return nil
+	}
+
+	gateway := c.gatewayRef(ing)
+	targetRef := map[string]interface{}{
+		"group": gatewayv1.GroupName,
+		"kind":  "Gateway",
+		"name":  gateway.Name,
+	}
+	if gateway.SectionName != "" {
+		targetRef["sectionName"] = gateway.SectionName
+	}
+
+	caRef := map[string]interface{}{
+		"kind": "Secret",
+		"name": caName,
+	}
+	if caNamespace != gateway.Namespace {
+		caRef["namespace"] = caNamespace
+		c.addDiagnostic(ing, annotationAuthTLSSecret, SeverityInfo,
+			"CA secret %s/%s is in another namespace; a ReferenceGrant for the ClientTrafficPolicy is generated in %s", caNamespace, caName, caNamespace)
+	}
+	validation := map[string]interface{}{
+		"caCertificateRefs": []interface{}{caRef},
+	}
+	if verify == "optional" {
+		validation["optional"] = true
+	}
+
+	spec := map[string]interface{}{
+		"targetRefs": []interface{}{targetRef},
+		"tls": map[string]interface{}{
+			"clientValidation": validation,
+		},
+	}
+	if pass, ok := ing.Annotations[annotationAuthTLSPassCert]; ok && strings.TrimSpace(pass) == "true" {
+		spec["headers"] = map[string]interface{}{
+			"xForwardedClientCert": map[string]interface{}{
+				"mode":             "SanitizeSet",
+				"certDetailsToAdd": []interface{}{"Cert"},
+			},
+		}
+		c.addDiagnostic(ing, annotationAuthTLSPassCert, SeverityWarning,
+			"client certificate is forwarded in the X-Forwarded-Client-Cert header instead of ssl-client-cert; update upstreams reading it")
+	}
+
+	c.addDiagnostic(ing, annotationAuthTLSSecret, SeverityWarning,
+		"client certificate validation converted to an Envoy Gateway ClientTrafficPolicy on Gateway %s/%s; it applies to every host on the listener, and Ingresses sharing the Gateway need one merged policy",
+		gateway.Namespace, gateway.Name)
+
+	// The policy targets the Gateway, so it lives in the Gateway namespace
+	name := fmt.Sprintf("%s-client-tls", ing.Name)
+	if gateway.Namespace != ing.Namespace {
+		name = fmt.Sprintf("%s-%s-client-tls", ing.Namespace, ing.Name)
+	}
+	return newPolicy("gateway.envoyproxy.io/v1alpha1", "ClientTrafficPolicy",
+		sanitizeName(name), gateway.Namespace, spec)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// maxNameLength is the longest generated route or rule name, the DNS label
+// limit, which keeps names usable as label values too
+const maxNameLength = 63
+
+// DefaultNameTemplate names generated HTTPRoutes <ingress>-httproute
+const DefaultNameTemplate = "{{.Ingress}}-httproute"
+
+// RouteNameData is the data NameTemplate is executed with
+type RouteNameData struct {
+	Ingress   string // Ingress name
+	Namespace string // Ingress namespace
+	Class     string // ingress class, "" if none
+}
+
+// ParseNameTemplate parses a route name template
+func ParseNameTemplate(tmpl string) (*template.Template, error) {
+	t, err := template.New("name").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse name template: %w", err)
+	}
+	return t, nil
+}
+
+// routeName returns the base name of the HTTPRoutes generated for an
+// Ingress, to which split modes append their suffix
+func (c *Converter) routeName(ing *networkingv1.Ingress) (string, error) {
+	if c.opts.NameTemplate == "" {
+		return fmt.Sprintf("%s-httproute", ing.Name), nil
+	}
+
+	if c.nameTemplate == nil {
+		t, err := ParseNameTemplate(c.opts.NameTemplate)
+		if err != nil {
+			return "", err
+		}
+		c.nameTemplate = t
+	}
+
+	var b strings.Builder
+	data := RouteNameData{Ingress: ing.Name, Namespace: ing.Namespace, Class: ingressClass(ing)}
+	if err := c.nameTemplate.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to execute name template: %w", err)
+	}
+	name := sanitizeName(b.String())
+	if name == "" {
+		return "", fmt.Errorf("name template %q gives an empty name for ingress %s", c.opts.NameTemplate, ing.Name)
+	}
+	return name, nil
+}
+
+// hostRouteName names the per-host route of host after the sanitized
+// hostname, so names survive rule reordering. A hash of the exact hostname
+// keeps hosts that sanitize alike, such as *.example.com and
+// wildcard.example.com or a-b.com and a.b-com, apart.
+func hostRouteName(base, host string) string {
+	sum := sha256.Sum256([]byte(host))
+	suffix := "-" + hex.EncodeToString(sum[:])[:8]
+
+	name := base + "-" + sanitizeName(strings.ReplaceAll(host, "*", "wildcard"))
+	return strings.TrimRight(truncate(name, maxNameLength-len(suffix)), "-") + suffix
+}
+
+// hostSetKey identifies a set of hostnames regardless of their order
+func hostSetKey(hostnames []gatewayv1.Hostname) string {
+	hosts := make([]string, len(hostnames))
+	for i, h := range hostnames {
+		hosts[i] = string(h)
+	}
+	sort.Strings(hosts)
+	return strings.Join(hosts, ",")
+}
+
+// hostSetRouteName names a route of base serving hostnames after a hash of
+// the sorted host set, so names survive reordering of the Ingress rules
+func hostSetRouteName(base string, hostnames []gatewayv1.Hostname) string {
+	sum := sha256.Sum256([]byte(hostSetKey(hostnames)))
+	suffix := "-" + hex.EncodeToString(sum[:])[:8]
+	return strings.TrimRight(truncate(base, maxNameLength-len(suffix)), "-") + suffix
+}
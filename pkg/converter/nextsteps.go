@@ -0,0 +1,303 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// argoTrackingAnnotation is set by Argo CD on resources it tracks by
+// annotation, e.g. "app:networking.k8s.io/Ingress:default/web"
+const argoTrackingAnnotation = "argocd.argoproj.io/tracking-id"
+
+// RunOutput describes where a run wrote its resources
+type RunOutput struct {
+	Paths     []string // files or directories written, none for stdout
+	Recursive bool     // Paths are directories of manifests
+	Kustomize bool     // Paths is a directory with a kustomization.yaml
+	HelmChart bool     // Paths is a Helm chart directory
+}
+
+// commands returns the commands dry-running and applying the output; Helm
+// charts are installed into namespace
+func (o RunOutput) commands(namespace string) (dryRun, apply string) {
+	if o.HelmChart && len(o.Paths) > 0 {
+		chart := o.Paths[0]
+		release := sanitizeName(filepath.Base(filepath.Clean(chart)))
+		return fmt.Sprintf("helm template %s %s -n %s | kubectl apply --dry-run=server -f -", release, chart, namespace),
+			fmt.Sprintf("helm upgrade --install %s %s -n %s", release, chart, namespace)
+	}
+	args := o.applyArgs()
+	return "kubectl apply --dry-run=server " + args, "kubectl apply " + args
+}
+
+// applyArgs returns the kubectl apply arguments selecting the output
+func (o RunOutput) applyArgs() string {
+	switch {
+	case len(o.Paths) == 0:
+		return "-f <saved output>"
+	case o.Kustomize:
+		return "-k " + o.Paths[0]
+	case o.Recursive:
+		return "-R -f " + strings.Join(o.Paths, ",")
+	}
+	return "-f " + strings.Join(o.Paths, ",")
+}
+
+// NextSteps returns the checklist for taking the output of a run live: the
+// Gateways that must exist or were generated, the ReferenceGrants to apply
+// with the routes, the Ingresses whose warnings need manual follow-up, and
+// the commands to apply and verify the routes. ingresses are the converted
+// Ingresses, resources the generated resources and diags the diagnostics
+// of the run.
+func NextSteps(ingresses, resources []interface{}, diags []Diagnostic, out RunOutput) []string {
+	var steps []string
+
+	generated := make(map[string]bool)
+	var grants []string
+	for _, res := range resources {
+		switch r := res.(type) {
+		case *gatewayv1.Gateway:
+			generated[r.Namespace+"/"+r.Name] = true
+		case *gatewayv1beta1.ReferenceGrant:
+			grants = append(grants, r.Namespace+"/"+r.Name)
+		}
+	}
+
+	// Gateways the routes attach to, and the route namespaces of each
+	routeNamespaces := make(map[string]map[string]bool)
+	var gateways []string
+	for _, res := range resources {
+		namespace, refs := routeParentRefs(res)
+		for _, ref := range refs {
+			if ref.Kind != nil && *ref.Kind != "Gateway" {
+				continue
+			}
+			gwNamespace := namespace
+			if ref.Namespace != nil {
+				gwNamespace = string(*ref.Namespace)
+			}
+			key := gwNamespace + "/" + string(ref.Name)
+			if routeNamespaces[key] == nil {
+				routeNamespaces[key] = make(map[string]bool)
+				gateways = append(gateways, key)
+			}
+			routeNamespaces[key][namespace] = true
+		}
+	}
+	sort.Strings(gateways)
+
+	// Generated Gateways are applied with the routes, then waited for
+	var waits []string
+	for _, gw := range gateways {
+		gwNamespace, name, _ := strings.Cut(gw, "/")
+		if generated[gw] {
+			waits = append(waits, fmt.Sprintf("Wait until the generated Gateway %s is programmed: kubectl wait -n %s gateway/%s --for=condition=Programmed", gw, gwNamespace, name))
+			continue
+		}
+		step := fmt.Sprintf("Gateway %s must exist: kubectl get -n %s gateway/%s (create one with ingress-to-gateway bootstrap or --emit-gateway)", gw, gwNamespace, name)
+		var others []string
+		for ns := range routeNamespaces[gw] {
+			if ns != gwNamespace {
+				others = append(others, ns)
+			}
+		}
+		if len(others) > 0 {
+			sort.Strings(others)
+			step += fmt.Sprintf("; its listeners must allow routes from namespace(s) %s in allowedRoutes.namespaces", strings.Join(others, ", "))
+		}
+		steps = append(steps, step)
+	}
+
+	if len(grants) > 0 {
+		steps = append(steps, fmt.Sprintf("Apply ReferenceGrant(s) %s with the routes; cross-namespace references are refused without them", strings.Join(grants, ", ")))
+	}
+
+	// Ingresses needing manual follow-up, by severity
+	type followUp struct{ errors, warnings int }
+	followUps := make(map[string]*followUp)
+	var needing []string
+	for _, d := range diags {
+		if d.Severity != SeverityError && d.Severity != SeverityWarning {
+			continue
+		}
+		f, exists := followUps[d.Ingress]
+		if !exists {
+			f = &followUp{}
+			followUps[d.Ingress] = f
+			needing = append(needing, d.Ingress)
+		}
+		if d.Severity == SeverityError {
+			f.errors++
+		} else {
+			f.warnings++
+		}
+	}
+	sort.Strings(needing)
+	for _, ing := range needing {
+		f := followUps[ing]
+		var counts []string
+		if f.errors > 0 {
+			counts = append(counts, plural(f.errors, "error"))
+		}
+		if f.warnings > 0 {
+			counts = append(counts, plural(f.warnings, "warning"))
+		}
+		steps = append(steps, fmt.Sprintf("Review %s for %s; the routes do not yet behave like the Ingress", strings.Join(counts, " and "), ing))
+	}
+
+	var namespaces []string
+	seen := make(map[string]bool)
+	for _, res := range resources {
+		if namespace, refs := routeParentRefs(res); len(refs) > 0 && !seen[namespace] {
+			seen[namespace] = true
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces)
+
+	releaseNamespace := "default"
+	if len(namespaces) > 0 {
+		releaseNamespace = namespaces[0]
+	}
+	dryRun, apply := out.commands(releaseNamespace)
+	steps = append(steps, "Check the output against the cluster: "+dryRun)
+
+	apps := argoApplications(ingresses)
+	if len(apps) > 0 {
+		steps = append(steps, fmt.Sprintf("The Ingresses are managed by Argo CD: commit the output to the source of %s, then sync: %s",
+			strings.Join(apps, ", "), argoSyncCommands(apps)))
+	} else {
+		steps = append(steps, "Apply: "+apply)
+	}
+	steps = append(steps, waits...)
+
+	switch len(namespaces) {
+	case 0:
+	case 1:
+		steps = append(steps, fmt.Sprintf("Check the routes are Accepted with resolved references: kubectl describe -n %s httproutes", namespaces[0]))
+	default:
+		steps = append(steps, "Check the routes are Accepted with resolved references: kubectl describe -A httproutes")
+	}
+
+	if len(apps) > 0 {
+		steps = append(steps, "Once traffic is verified through the Gateway, remove the Ingresses from the Argo CD sources")
+	} else if commands := ingressDeleteCommands(ingresses); len(commands) > 0 {
+		steps = append(steps, "Once traffic is verified through the Gateway, remove the Ingresses: "+strings.Join(commands, " && "))
+	}
+	return steps
+}
+
+// routeParentRefs returns the namespace and parentRefs of a generated
+// route, none for other resources
+func routeParentRefs(res interface{}) (string, []gatewayv1.ParentReference) {
+	switch r := res.(type) {
+	case *gatewayv1.HTTPRoute:
+		return r.Namespace, r.Spec.ParentRefs
+	case *gatewayv1alpha2.GRPCRoute:
+		return r.Namespace, r.Spec.ParentRefs
+	case *gatewayv1alpha2.TLSRoute:
+		return r.Namespace, r.Spec.ParentRefs
+	case *gatewayv1alpha2.TCPRoute:
+		return r.Namespace, r.Spec.ParentRefs
+	case *gatewayv1alpha2.UDPRoute:
+		return r.Namespace, r.Spec.ParentRefs
+	case *unstructured.Unstructured:
+		if r.GetKind() != "HTTPRoute" {
+			break
+		}
+		var route gatewayv1.HTTPRoute
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(r.Object, &route); err == nil {
+			return route.Namespace, route.Spec.ParentRefs
+		}
+	}
+	return "", nil
+}
+
+// argoApplications returns the Argo CD applications tracking the Ingresses
+func argoApplications(ingresses []interface{}) []string {
+	seen := make(map[string]bool)
+	var apps []string
+	for _, obj := range ingresses {
+		ing, ok := obj.(*networkingv1.Ingress)
+		if !ok {
+			continue
+		}
+		app, _, found := strings.Cut(ing.Annotations[argoTrackingAnnotation], ":")
+		if !found || app == "" || seen[app] {
+			continue
+		}
+		seen[app] = true
+		apps = append(apps, app)
+	}
+	sort.Strings(apps)
+	return apps
+}
+
+// argoSyncCommands returns the argocd commands syncing apps. Applications
+// outside the Argo CD namespace are tracked as <namespace>_<name>.
+func argoSyncCommands(apps []string) string {
+	commands := make([]string, 0, len(apps))
+	for _, app := range apps {
+		commands = append(commands, "argocd app sync "+strings.Replace(app, "_", "/", 1))
+	}
+	return strings.Join(commands, " && ")
+}
+
+// ingressDeleteCommands returns the kubectl commands deleting the
+// Ingresses, one per namespace
+func ingressDeleteCommands(ingresses []interface{}) []string {
+	byNamespace := make(map[string][]string)
+	var namespaces []string
+	for _, obj := range ingresses {
+		ing, ok := obj.(*networkingv1.Ingress)
+		if !ok {
+			continue
+		}
+		if _, exists := byNamespace[ing.Namespace]; !exists {
+			namespaces = append(namespaces, ing.Namespace)
+		}
+		byNamespace[ing.Namespace] = append(byNamespace[ing.Namespace], ing.Name)
+	}
+	sort.Strings(namespaces)
+
+	commands := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		names := byNamespace[ns]
+		sort.Strings(names)
+		commands = append(commands, fmt.Sprintf("kubectl delete -n %s ingress %s", ns, strings.Join(names, " ")))
+	}
+	return commands
+}
+
+// plural formats a count of noun, e.g. "2 warnings"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
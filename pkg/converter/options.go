@@ -0,0 +1,296 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import "github.com/mayens/ingress-to-gateway/pkg/experimental"
+
+// Options contains converter configuration, grouped by feature. The groups
+// are embedded, so fields read as opts.SplitMode; the With functions set
+// them without spelling out the groups:
+//
+//	c := NewConverter(Options{}, WithSplitMode("per-host"), WithGatewayGeneration())
+type Options struct {
+	RoutingOptions
+	NamingOptions
+	GatewayOptions
+	MetadataOptions
+	OwnershipOptions
+	TargetOptions
+	ValidationOptions
+	OutputOptions
+}
+
+// RoutingOptions controls how Ingress rules become route rules
+type RoutingOptions struct {
+	SplitMode string // single, per-host, per-pattern
+
+	// DefaultBackendMode controls the default-backend annotation: catch-all (default), listener or none
+	DefaultBackendMode string
+	// SpecDefaultBackendMode controls spec.defaultBackend: rule (default), catch-all or none
+	SpecDefaultBackendMode string
+	// PrefixCompat adds a RegularExpression match to ImplementationSpecific paths so /foo keeps matching /foobar
+	PrefixCompat bool
+	// TimeoutPrecedence picks the route timeout when proxy-read and proxy-send timeouts differ: max (default) or min
+	TimeoutPrecedence string
+	// MergeHosts merges the paths of all Ingresses of a namespace for the same hostname into
+	// one HTTPRoute named after the hostname, in place of SplitMode
+	MergeHosts bool
+	// Translators converts annotations to rule filters, timeouts and policies;
+	// nil uses the built-in translators of DefaultRegistry
+	Translators *Registry
+}
+
+// NamingOptions controls the names of generated routes and rules
+type NamingOptions struct {
+	// NameTemplate is a text/template for the base name of generated HTTPRoutes,
+	// executed with RouteNameData; split modes append their suffix. Default: DefaultNameTemplate.
+	NameTemplate string
+	// RuleNames names each HTTPRoute rule after its path and backend. Rule names need
+	// apiVersion v1 and the experimental CRDs of Gateway API v1.2 or later.
+	RuleNames bool
+}
+
+// GatewayOptions selects the Gateway routes attach to and controls its generation
+type GatewayOptions struct {
+	GatewayName  string
+	GatewayClass string
+
+	// GatewayNamespace, SectionName and Port complete the parentRef of generated routes,
+	// e.g. to attach to one listener of a shared Gateway in a dedicated namespace
+	GatewayNamespace string
+	SectionName      string
+	Port             int32
+	// IngressClasses overrides the parentRef per ingress class
+	IngressClasses map[string]ParentRef
+
+	// EmitGateway also generates the referenced Gateways, with listeners derived from spec.tls
+	EmitGateway bool
+	// CertManager controls cert-manager.io annotations: gateway (default) carries them to
+	// generated Gateways, certificate generates a Certificate per spec.tls secret, none reports only
+	CertManager string
+}
+
+// MetadataOptions controls the labels and annotations carried over to routes.
+// Keys are matched against globs such as "argocd.argoproj.io/*" or
+// "team.example.com/*-owner"; a pattern ending in "/" matches the prefix.
+// nginx.ingress.kubernetes.io keys and DefaultDenyKeys() are never carried over.
+type MetadataOptions struct {
+	// CopyAnnotations lists the Ingress annotations propagated to generated routes;
+	// annotations are not copied by default
+	CopyAnnotations []string
+	// DropAnnotations lists annotations matching CopyAnnotations that are not propagated
+	DropAnnotations []string
+	// CopyLabels lists the Ingress labels propagated to generated routes; all by default
+	CopyLabels []string
+	// StripLabels lists Ingress labels not propagated to generated routes
+	StripLabels []string
+	// DenyKeys lists labels and annotations never propagated, on top of DefaultDenyKeys()
+	DenyKeys []string
+	// RedactKeys lists labels and annotations propagated with RedactedValue as value
+	RedactKeys []string
+}
+
+// OwnershipOptions controls Ingresses owned by other controllers
+type OwnershipOptions struct {
+	// ProgressiveDelivery controls Ingresses owned by Flagger or Argo Rollouts: skip (default) or generate
+	ProgressiveDelivery string
+	// IncludeManaged converts Ingresses owned by other controllers instead of skipping them
+	IncludeManaged bool
+}
+
+// TargetOptions describes the Gateway API implementation and CRDs the
+// output is generated for
+type TargetOptions struct {
+	Target string // gateway implementation for policy output, e.g. envoy-gateway
+
+	// APIVersion is the Gateway API version of generated Gateways and HTTPRoutes: v1 (default) or v1beta1
+	APIVersion string
+	// Channel is the release channel of the installed CRDs: experimental (default) or standard.
+	// The standard channel leaves out experimental resources and fields.
+	Channel string
+	// Experimental gates the experimental resources and fields the channel defines;
+	// nil emits all of them
+	Experimental *experimental.Gate
+}
+
+// ValidationOptions controls how Ingresses are checked before conversion
+type ValidationOptions struct {
+	// StrictAnnotations fails the conversion on a malformed annotation value
+	// instead of warning and leaving the annotation out
+	StrictAnnotations bool
+}
+
+// OutputOptions controls how resources are written
+type OutputOptions struct {
+	OutputFormat string // yaml, json, ndjson, helm
+}
+
+// Option sets converter options
+type Option func(*Options)
+
+// WithSplitMode sets the split mode: single, per-host or per-pattern
+func WithSplitMode(mode string) Option {
+	return func(o *Options) { o.SplitMode = mode }
+}
+
+// WithDefaultBackendModes sets how the default-backend annotation and
+// spec.defaultBackend are converted
+func WithDefaultBackendModes(annotation, spec string) Option {
+	return func(o *Options) {
+		o.DefaultBackendMode = annotation
+		o.SpecDefaultBackendMode = spec
+	}
+}
+
+// WithPrefixCompat keeps /foo matching /foobar for ImplementationSpecific paths
+func WithPrefixCompat() Option {
+	return func(o *Options) { o.PrefixCompat = true }
+}
+
+// WithTimeoutPrecedence sets the timeout picked when proxy-read and
+// proxy-send timeouts differ: max or min
+func WithTimeoutPrecedence(precedence string) Option {
+	return func(o *Options) { o.TimeoutPrecedence = precedence }
+}
+
+// WithMergeHosts merges the Ingresses of a namespace that share a hostname
+// into one HTTPRoute per hostname
+func WithMergeHosts() Option {
+	return func(o *Options) { o.MergeHosts = true }
+}
+
+// WithTranslators converts annotations with the translators of registry
+func WithTranslators(registry *Registry) Option {
+	return func(o *Options) { o.Translators = registry }
+}
+
+// WithNameTemplate sets the template of generated HTTPRoute names
+func WithNameTemplate(tmpl string) Option {
+	return func(o *Options) { o.NameTemplate = tmpl }
+}
+
+// WithRuleNames names generated rules after their path and backend
+func WithRuleNames() Option {
+	return func(o *Options) { o.RuleNames = true }
+}
+
+// WithGateway sets the Gateway, and optionally the listener, routes attach to
+func WithGateway(ref ParentRef) Option {
+	return func(o *Options) {
+		o.GatewayName = ref.Name
+		o.GatewayNamespace = ref.Namespace
+		o.SectionName = ref.SectionName
+		o.Port = ref.Port
+	}
+}
+
+// WithIngressClasses overrides the Gateway per ingress class
+func WithIngressClasses(classes map[string]ParentRef) Option {
+	return func(o *Options) { o.IngressClasses = classes }
+}
+
+// WithGatewayClass sets the GatewayClass of the Gateway
+func WithGatewayClass(class string) Option {
+	return func(o *Options) { o.GatewayClass = class }
+}
+
+// WithGatewayGeneration also generates the Gateways routes attach to
+func WithGatewayGeneration() Option {
+	return func(o *Options) { o.EmitGateway = true }
+}
+
+// WithCertManager sets how cert-manager.io annotations are converted:
+// gateway, certificate or none
+func WithCertManager(mode string) Option {
+	return func(o *Options) { o.CertManager = mode }
+}
+
+// WithMetadata sets the annotations copied to and the labels stripped from
+// generated routes
+func WithMetadata(copyAnnotations, stripLabels []string) Option {
+	return func(o *Options) {
+		o.CopyAnnotations = copyAnnotations
+		o.StripLabels = stripLabels
+	}
+}
+
+// WithAnnotationFilters sets the annotations copied to generated routes and
+// those left out of them
+func WithAnnotationFilters(copyAnnotations, dropAnnotations []string) Option {
+	return func(o *Options) {
+		o.CopyAnnotations = copyAnnotations
+		o.DropAnnotations = dropAnnotations
+	}
+}
+
+// WithLabelFilters sets the labels copied to generated routes, all if
+// empty, and those left out of them
+func WithLabelFilters(copyLabels, stripLabels []string) Option {
+	return func(o *Options) {
+		o.CopyLabels = copyLabels
+		o.StripLabels = stripLabels
+	}
+}
+
+// WithRedaction sets the labels and annotations left out of generated
+// routes whatever the copy filters, and those whose value is redacted
+func WithRedaction(denyKeys, redactKeys []string) Option {
+	return func(o *Options) {
+		o.DenyKeys = denyKeys
+		o.RedactKeys = redactKeys
+	}
+}
+
+// WithProgressiveDelivery sets how Ingresses owned by Flagger or Argo
+// Rollouts are handled: skip or generate
+func WithProgressiveDelivery(mode string) Option {
+	return func(o *Options) { o.ProgressiveDelivery = mode }
+}
+
+// WithIncludeManaged converts Ingresses owned by other controllers
+func WithIncludeManaged() Option {
+	return func(o *Options) { o.IncludeManaged = true }
+}
+
+// WithTarget sets the Gateway API implementation policies are generated for
+func WithTarget(target string) Option {
+	return func(o *Options) { o.Target = target }
+}
+
+// WithAPIVersion sets the Gateway API version and release channel of the
+// installed CRDs
+func WithAPIVersion(version, channel string) Option {
+	return func(o *Options) {
+		o.APIVersion = version
+		o.Channel = channel
+	}
+}
+
+// WithExperimental sets the gate of experimental resources and fields
+func WithExperimental(gate *experimental.Gate) Option {
+	return func(o *Options) { o.Experimental = gate }
+}
+
+// WithStrictAnnotations fails the conversion on malformed annotation values
+func WithStrictAnnotations() Option {
+	return func(o *Options) { o.StrictAnnotations = true }
+}
+
+// WithOutputFormat sets the output format: yaml, json, ndjson or helm
+func WithOutputFormat(format string) Option {
+	return func(o *Options) { o.OutputFormat = format }
+}
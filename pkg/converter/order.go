@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	yamlv2 "sigs.k8s.io/yaml/goyaml.v2"
+)
+
+// fieldOrder lists the fields that lead each object, by path from the
+// resource root with list indexes left out, in the order the Gateway API
+// documentation writes them. Other fields follow in struct order, or
+// alphabetically for unstructured resources.
+var fieldOrder = map[string][]string{
+	"":                       {"apiVersion", "kind", "metadata", "spec", "status"},
+	"metadata":               {"name", "namespace", "labels", "annotations"},
+	"spec":                   {"gatewayClassName", "targetRefs", "targetRef", "parentRefs", "hostnames", "listeners", "rules"},
+	"spec.parentRefs":        {"name", "namespace", "sectionName", "port"},
+	"spec.targetRefs":        {"group", "kind", "name", "sectionName"},
+	"spec.listeners":         {"name", "hostname", "port", "protocol", "tls", "allowedRoutes"},
+	"spec.rules":             {"name", "matches", "filters", "backendRefs", "timeouts"},
+	"spec.rules.matches":     {"path", "headers", "queryParams", "method"},
+	"spec.rules.backendRefs": {"name", "namespace", "port", "weight"},
+}
+
+// marshalYAML marshals a resource to YAML with its fields in the
+// conventional Gateway API order rather than alphabetically
+func marshalYAML(obj interface{}) ([]byte, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	value, err := decodeOrdered(dec, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %T: %w", obj, err)
+	}
+	return yamlv2.Marshal(value)
+}
+
+// decodeOrdered decodes the next JSON value, keeping object fields in
+// document order and moving the fields listed for path to the front
+func decodeOrdered(dec *json.Decoder, path string) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			var fields yamlv2.MapSlice
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, ok := keyTok.(string)
+				if !ok {
+					return nil, fmt.Errorf("unexpected object key %v", keyTok)
+				}
+				value, err := decodeOrdered(dec, joinPath(path, key))
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, yamlv2.MapItem{Key: key, Value: value})
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return orderFields(fields, fieldOrder[path]), nil
+		case '[':
+			items := []interface{}{}
+			for dec.More() {
+				item, err := decodeOrdered(dec, path)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return items, nil
+		}
+		return nil, fmt.Errorf("unexpected delimiter %v", t)
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i, nil
+		}
+		return t.Float64()
+	}
+	return tok, nil
+}
+
+// joinPath appends key to a field path
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// orderFields moves the fields named in order to the front, in that order
+func orderFields(fields yamlv2.MapSlice, order []string) yamlv2.MapSlice {
+	if len(order) == 0 {
+		return fields
+	}
+	ordered := make(yamlv2.MapSlice, 0, len(fields))
+	used := make(map[string]bool)
+	for _, name := range order {
+		for _, f := range fields {
+			if f.Key == name {
+				ordered = append(ordered, f)
+				used[name] = true
+			}
+		}
+	}
+	for _, f := range fields {
+		if !used[f.Key.(string)] {
+			ordered = append(ordered, f)
+		}
+	}
+	return ordered
+}
+
+// writeYAML writes resources as YAML documents separated by ---
+func writeYAML(resources []interface{}, w io.Writer) error {
+	for i, res := range resources {
+		if i > 0 {
+			fmt.Fprintln(w, "---")
+		}
+
+		data, err := marshalYAML(res)
+		if err != nil {
+			return fmt.Errorf("failed to marshal HTTPRoute: %w", err)
+		}
+
+		if _, err := w.Write(data); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+	return nil
+}
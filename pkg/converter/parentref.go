@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// ParentRef selects the Gateway, and optionally one of its listeners,
+// that generated routes attach to. Empty fields keep the defaults: the
+// Gateway derived from the ingress class, in the Ingress namespace.
+type ParentRef struct {
+	Name        string `json:"name,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	SectionName string `json:"sectionName,omitempty"`
+	Port        int32  `json:"port,omitempty"`
+}
+
+// ingressClass returns the class of an Ingress from spec.ingressClassName
+// or the legacy annotation, or "" when it has none
+func ingressClass(ing *networkingv1.Ingress) string {
+	if ing.Spec.IngressClassName != nil {
+		return *ing.Spec.IngressClassName
+	}
+	return ing.Annotations["kubernetes.io/ingress.class"]
+}
+
+// gatewayRef resolves the Gateway an Ingress's routes attach to. Fields set
+// for the ingress class in IngressClasses take precedence over the global
+// options, which take precedence over the defaults.
+func (c *Converter) gatewayRef(ing *networkingv1.Ingress) ParentRef {
+	ref := ParentRef{
+		Name:        c.opts.GatewayName,
+		Namespace:   c.opts.GatewayNamespace,
+		SectionName: c.opts.SectionName,
+		Port:        c.opts.Port,
+	}
+
+	if class, ok := c.opts.IngressClasses[ingressClass(ing)]; ok {
+		if class.Name != "" {
+			ref.Name = class.Name
+		}
+		if class.Namespace != "" {
+			ref.Namespace = class.Namespace
+		}
+		if class.SectionName != "" {
+			ref.SectionName = class.SectionName
+		}
+		if class.Port != 0 {
+			ref.Port = class.Port
+		}
+	}
+
+	if ref.Name == "" {
+		ref.Name = c.deriveGatewayName(ing)
+	}
+	if ref.Namespace == "" {
+		ref.Namespace = ing.Namespace
+	}
+	return ref
+}
+
+// parentRef builds the parentRef of the routes generated for an Ingress.
+// The namespace is only set when the Gateway lives in another namespace.
+func (c *Converter) parentRef(ing *networkingv1.Ingress) gatewayv1.ParentReference {
+	ref := c.gatewayRef(ing)
+
+	parentRef := gatewayv1.ParentReference{
+		Name: gatewayv1.ObjectName(ref.Name),
+	}
+	if ref.Namespace != ing.Namespace {
+		namespace := gatewayv1.Namespace(ref.Namespace)
+		parentRef.Namespace = &namespace
+	}
+	if ref.SectionName != "" {
+		sectionName := gatewayv1.SectionName(ref.SectionName)
+		parentRef.SectionName = &sectionName
+	}
+	if ref.Port != 0 && !c.inChannel(FeatureParentRefPort) {
+		c.unsupportedFeature(ing, "", FeatureParentRefPort, fmt.Sprintf("gateway port %d", ref.Port))
+	} else if ref.Port != 0 {
+		port := gatewayv1.PortNumber(ref.Port)
+		parentRef.Port = &port
+	}
+	return parentRef
+}
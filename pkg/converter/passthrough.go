@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+const annotationSSLPassthrough = "nginx.ingress.kubernetes.io/ssl-passthrough"
+
+// isPassthrough reports whether TLS for the Ingress is terminated by the backend
+func isPassthrough(ing *networkingv1.Ingress) bool {
+	return strings.EqualFold(ing.Annotations[annotationSSLPassthrough], "true")
+}
+
+// convertPassthrough creates TLSRoutes for an ssl-passthrough Ingress. The
+// proxy only sees the SNI, so hosts sharing a backend share a TLSRoute and
+// path rules are dropped.
+func (c *Converter) convertPassthrough(ing *networkingv1.Ingress) []interface{} {
+	parentRef := c.parentRef(ing)
+
+	type group struct {
+		backend   networkingv1.IngressServiceBackend
+		port      gatewayv1.PortNumber
+		hostnames []gatewayv1.Hostname
+	}
+	var groups []*group
+	byBackend := make(map[string]*group)
+
+	for _, rule := range c.ingressRules(ing) {
+		if rule.HTTP == nil || len(rule.HTTP.Paths) == 0 {
+			continue
+		}
+		if len(rule.HTTP.Paths) > 1 {
+			c.addDiagnostic(ing, annotationSSLPassthrough, SeverityWarning,
+				"host %q has %d paths; passthrough routes by SNI only, so all traffic goes to the first backend", rule.Host, len(rule.HTTP.Paths))
+		}
+		backend := rule.HTTP.Paths[0].Backend.Service
+		if backend == nil {
+			continue
+		}
+		port, ok := c.backendPort(ing, backend)
+		if !ok {
+			continue
+		}
+
+		key := fmt.Sprintf("%s:%d:%s", backend.Name, backend.Port.Number, backend.Port.Name)
+		g, exists := byBackend[key]
+		if !exists {
+			g = &group{backend: *backend, port: port}
+			byBackend[key] = g
+			groups = append(groups, g)
+		}
+		if rule.Host == "" {
+			c.addDiagnostic(ing, annotationSSLPassthrough, SeverityWarning,
+				"rule without host matches every SNI on the passthrough listener")
+			continue
+		}
+		g.hostnames = append(g.hostnames, c.hostname(ing, rule.Host))
+	}
+
+	if len(ing.Spec.TLS) > 0 {
+		c.addDiagnostic(ing, annotationSSLPassthrough, SeverityInfo,
+			"spec.tls is ignored with passthrough; the backend presents its own certificate")
+	}
+
+	var routes []interface{}
+	for i, g := range groups {
+		name := fmt.Sprintf("%s-passthrough", ing.Name)
+		if len(groups) > 1 {
+			name = fmt.Sprintf("%s-passthrough-%d", ing.Name, i+1)
+		}
+
+		port := g.port
+		routes = append(routes, &gatewayv1alpha2.TLSRoute{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: gatewayv1alpha2.GroupVersion.String(),
+				Kind:       "TLSRoute",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        sanitizeName(name),
+				Namespace:   ing.Namespace,
+				Labels:      c.routeLabels(ing),
+				Annotations: c.routeAnnotations(ing),
+			},
+			Spec: gatewayv1alpha2.TLSRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{parentRef},
+				},
+				Hostnames: g.hostnames,
+				Rules: []gatewayv1alpha2.TLSRouteRule{
+					{
+						BackendRefs: []gatewayv1.BackendRef{
+							{
+								BackendObjectReference: gatewayv1.BackendObjectReference{
+									Name: gatewayv1.ObjectName(g.backend.Name),
+									Port: &port,
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	if len(routes) > 0 {
+		c.addDiagnostic(ing, annotationSSLPassthrough, SeverityInfo,
+			"converted to TLSRoute (experimental channel); Gateway %s needs a listener with protocol TLS, port 443, tls.mode Passthrough and allowedRoutes kinds TLSRoute",
+			parentRef.Name)
+	}
+	return routes
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// Plan actions. A server-side apply updates a resource that exists with a
+// different spec; an apply that only creates resources skips it.
+const (
+	PlanCreate    = "create"
+	PlanUpdate    = "update"
+	PlanSkip      = "skip"
+	PlanUnchanged = "unchanged"
+)
+
+// ClusterScope is the namespace plans list cluster-scoped resources under
+const ClusterScope = "(cluster)"
+
+// PlanEntry is what applying a resource does to the cluster
+type PlanEntry struct {
+	Namespace string `json:"namespace"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Action    string `json:"action"`
+}
+
+// Plan lists what applying generated resources changes in the cluster
+type Plan struct {
+	Entries []PlanEntry `json:"entries"`
+}
+
+// NewPlan compares the resources to server-side apply with the existing
+// cluster resources of the same kind, namespace and name. A resource is
+// unchanged when every field it sets outside its metadata has the same value
+// in the cluster, so fields the API server defaults do not count as
+// changes; other existing resources are updated.
+func NewPlan(resources, existing []interface{}) (Plan, error) {
+	return newPlan(resources, existing, PlanUpdate)
+}
+
+// NewCreatePlan is NewPlan for an apply that only creates resources, which
+// skips existing resources with a different spec
+func NewCreatePlan(resources, existing []interface{}) (Plan, error) {
+	return newPlan(resources, existing, PlanSkip)
+}
+
+// newPlan plans resources against existing, with changed as the action of
+// existing resources that differ
+func newPlan(resources, existing []interface{}, changed string) (Plan, error) {
+	current := make(map[string]interface{}, len(existing))
+	for _, res := range existing {
+		kind, namespace, name := planIdentity(res)
+		if kind != "" {
+			current[kind+"/"+namespace+"/"+name] = res
+		}
+	}
+
+	var plan Plan
+	for _, res := range resources {
+		kind, namespace, name := planIdentity(res)
+		if kind == "" {
+			continue
+		}
+		entry := PlanEntry{Namespace: namespace, Kind: kind, Name: name, Action: PlanCreate}
+		if entry.Namespace == "" {
+			entry.Namespace = ClusterScope
+		}
+		if old, ok := current[kind+"/"+namespace+"/"+name]; ok {
+			same, err := sameSpec(old, res)
+			if err != nil {
+				return Plan{}, fmt.Errorf("failed to compare %s %s: %w", kind, name, err)
+			}
+			entry.Action = changed
+			if same {
+				entry.Action = PlanUnchanged
+			}
+		}
+		plan.Entries = append(plan.Entries, entry)
+	}
+	return plan, nil
+}
+
+// planIdentity returns the kind, namespace and name of a resource, "" for
+// types plans do not cover. Typed objects read from the cluster lack
+// TypeMeta, so their kind is taken from the Go type.
+func planIdentity(res interface{}) (kind, namespace, name string) {
+	switch r := res.(type) {
+	case *unstructured.Unstructured:
+		return r.GetKind(), r.GetNamespace(), r.GetName()
+	case *gatewayv1.HTTPRoute:
+		return "HTTPRoute", r.Namespace, r.Name
+	case *gatewayv1.Gateway:
+		return "Gateway", r.Namespace, r.Name
+	case *gatewayv1.GatewayClass:
+		return "GatewayClass", "", r.Name
+	case *gatewayv1beta1.ReferenceGrant:
+		return "ReferenceGrant", r.Namespace, r.Name
+	}
+	return "", "", ""
+}
+
+// sameSpec reports whether the cluster object old already has every field
+// of res outside its type, metadata and status, such as spec or the data
+// of a ConfigMap
+func sameSpec(old, res interface{}) (bool, error) {
+	oldContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(old)
+	if err != nil {
+		return false, err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(res)
+	if err != nil {
+		return false, err
+	}
+	for key, value := range content {
+		switch key {
+		case "apiVersion", "kind", "metadata", "status":
+			continue
+		}
+		if !containsFields(oldContent[key], value) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// containsFields reports whether have holds every field of want with the
+// same value
+func containsFields(have, want interface{}) bool {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		h, ok := have.(map[string]interface{})
+		if !ok {
+			return len(w) == 0 && have == nil
+		}
+		for key, value := range w {
+			if !containsFields(h[key], value) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		h, ok := have.([]interface{})
+		if !ok || len(h) != len(w) {
+			return len(w) == 0 && have == nil
+		}
+		for i := range w {
+			if !containsFields(h[i], w[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(have, want)
+}
+
+// Changes counts the resources applying the plan creates or updates
+func (p Plan) Changes() int {
+	return p.count(PlanCreate) + p.count(PlanUpdate)
+}
+
+// planKinds are the table columns, in order, and planHeaders their titles;
+// only the kinds in the plan are shown, and other kinds such as policies
+// are counted together in the last column
+var (
+	planKinds   = []string{"HTTPRoute", "GRPCRoute", "TLSRoute", "Gateway", "ReferenceGrant", "GatewayClass", planOther}
+	planHeaders = []string{"HTTPROUTES", "GRPCROUTES", "TLSROUTES", "GATEWAYS", "REFERENCEGRANTS", "GATEWAYCLASSES", "OTHER"}
+)
+
+// planOther is the column of kinds without their own
+const planOther = ""
+
+// WriteTable writes one line per namespace with the resources of each
+// kind applying the plan creates, updates, skips and leaves unchanged
+func (p Plan) WriteTable(w io.Writer) error {
+	columns := make(map[string]bool, len(planKinds))
+	for _, kind := range planKinds {
+		columns[kind] = true
+	}
+	counts := make(map[string]map[string]map[string]int)
+	planned := make(map[string]bool)
+	for _, e := range p.Entries {
+		kind := e.Kind
+		if !columns[kind] {
+			kind = planOther
+		}
+		planned[kind] = true
+		if counts[e.Namespace] == nil {
+			counts[e.Namespace] = make(map[string]map[string]int)
+		}
+		if counts[e.Namespace][kind] == nil {
+			counts[e.Namespace][kind] = make(map[string]int)
+		}
+		counts[e.Namespace][kind][e.Action]++
+	}
+	namespaces := make([]string, 0, len(counts))
+	for ns := range counts {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	if _, err := fmt.Fprintf(w, "%-20s", "NAMESPACE"); err != nil {
+		return err
+	}
+	var kinds []string
+	for i, kind := range planKinds {
+		if planned[kind] {
+			kinds = append(kinds, kind)
+			fmt.Fprintf(w, " %-16s", planHeaders[i])
+		}
+	}
+	fmt.Fprintln(w)
+	for _, ns := range namespaces {
+		fmt.Fprintf(w, "%-20s", ns)
+		for _, kind := range kinds {
+			c := counts[ns][kind]
+			fmt.Fprintf(w, " %-16s", fmt.Sprintf("+%d ~%d !%d =%d", c[PlanCreate], c[PlanUpdate], c[PlanSkip], c[PlanUnchanged]))
+		}
+		fmt.Fprintln(w)
+	}
+	_, err := fmt.Fprintf(w, "%d to create, %d to update, %d skipped, %d unchanged (+ create, ~ update, ! exists with a different spec, skipped, = unchanged)\n",
+		p.count(PlanCreate), p.count(PlanUpdate), p.count(PlanSkip), p.count(PlanUnchanged))
+	return err
+}
+
+// count counts the entries with action
+func (p Plan) count(action string) int {
+	n := 0
+	for _, e := range p.Entries {
+		if e.Action == action {
+			n++
+		}
+	}
+	return n
+}
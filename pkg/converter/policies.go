@@ -0,0 +1,461 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	annotationAffinity          = "nginx.ingress.kubernetes.io/affinity"
+	annotationSessionCookieName = "nginx.ingress.kubernetes.io/session-cookie-name"
+	annotationSessionCookieAge  = "nginx.ingress.kubernetes.io/session-cookie-max-age"
+	annotationUpstreamHashBy    = "nginx.ingress.kubernetes.io/upstream-hash-by"
+
+	annotationLimitRPS         = "nginx.ingress.kubernetes.io/limit-rps"
+	annotationLimitRPM         = "nginx.ingress.kubernetes.io/limit-rpm"
+	annotationLimitConnections = "nginx.ingress.kubernetes.io/limit-connections"
+	annotationProxyBodySize    = "nginx.ingress.kubernetes.io/proxy-body-size"
+	annotationConnectTimeout   = "nginx.ingress.kubernetes.io/proxy-connect-timeout"
+
+	// defaultSessionCookieName is the cookie name ingress-nginx uses when none is set
+	defaultSessionCookieName = "INGRESSCOOKIE"
+)
+
+// extractPolicies builds policy resources for Ingress features that cannot be
+// expressed on the HTTPRoutes generated for the Ingress
+func (c *Converter) extractPolicies(ing *networkingv1.Ingress, routes []interface{}) []interface{} {
+	var policies []interface{}
+
+	if policy := c.extractSessionPersistence(ing); policy != nil {
+		policies = append(policies, policy)
+	}
+
+	// Rate limits, connect timeouts, retries, load balancing and health
+	// checks share one BackendTrafficPolicy since Envoy Gateway applies only one policy
+	// per target
+	ranges := c.parseSourceRanges(ing)
+	var trafficPolicy *unstructured.Unstructured
+	if ranges.allExempt() {
+		c.addDiagnostic(ing, "", SeverityInfo, "every allowed source range is exempt from rate limiting, rate limit not converted")
+	} else {
+		trafficPolicy = c.extractRateLimit(ing, routes)
+		c.checkRateLimitExemption(ing, ranges, trafficPolicy != nil)
+	}
+	timeout := c.extractConnectTimeout(ing)
+	if timeout != nil && c.opts.Target == TargetEnvoyGateway {
+		trafficPolicy = backendTrafficPolicy(trafficPolicy, ing, routes, "timeout")
+		if err := unstructured.SetNestedField(trafficPolicy.Object, timeout, "spec", "timeout"); err != nil {
+			c.addDiagnostic(ing, annotationConnectTimeout, SeverityError, "failed to set connect timeout: %v", err)
+		}
+	}
+	if retry := c.extractRetry(ing); retry != nil {
+		trafficPolicy = backendTrafficPolicy(trafficPolicy, ing, routes, "retry")
+		if err := unstructured.SetNestedField(trafficPolicy.Object, retry, "spec", "retry"); err != nil {
+			c.addDiagnostic(ing, annotationProxyNextUpstream, SeverityError, "failed to set retry: %v", err)
+		}
+	}
+	lb := c.loadBalancer(ing)
+	if lb != nil && c.opts.Target == TargetEnvoyGateway {
+		trafficPolicy = backendTrafficPolicy(trafficPolicy, ing, routes, "loadbalancer")
+		c.setEnvoyLoadBalancer(ing, lb, trafficPolicy)
+	}
+	checks := c.healthChecks(ing)
+	if check := c.envoyHealthCheck(ing, checks); check != nil {
+		trafficPolicy = backendTrafficPolicy(trafficPolicy, ing, routes, "healthcheck")
+		c.setEnvoyHealthCheck(ing, check, trafficPolicy)
+	}
+	if trafficPolicy != nil {
+		policies = append(policies, trafficPolicy)
+	}
+	policies = append(policies, c.istioDestinationRules(ing, lb, timeout)...)
+	policies = append(policies, c.gkeHealthCheckPolicies(ing, checks)...)
+	policies = append(policies, c.extractBodySize(ing, routes)...)
+	if policy := c.extractTracing(ing, routes); policy != nil {
+		policies = append(policies, policy)
+	}
+	if policy := c.istioSourceRanges(ing, ranges); policy != nil {
+		policies = append(policies, policy)
+	}
+	cors := c.nginxCORS(ing)
+	if policy := c.extractSecurityPolicy(ing, routes, ranges, cors); policy != nil {
+		policies = append(policies, policy)
+	}
+	if cors != nil {
+		if plugin := c.kongCORSPlugin(ing, cors, routes); plugin != nil {
+			policies = append(policies, plugin)
+		}
+	}
+	if policy := c.extractClientTLS(ing); policy != nil {
+		policies = append(policies, policy)
+	}
+	policies = append(policies, c.extractGKEPolicies(ing)...)
+
+	return policies
+}
+
+// extractSecurityPolicy combines external auth, client source ranges and
+// CORS into one policy, since Envoy Gateway applies only one SecurityPolicy
+// per target
+func (c *Converter) extractSecurityPolicy(ing *networkingv1.Ingress, routes []interface{}, ranges sourceRanges, nginxCORS *corsConfig) *unstructured.Unstructured {
+	policy := c.extractExtAuth(ing, routes)
+	authorization := c.sourceRangeAuthorization(ing, ranges)
+	if authorization != nil {
+		policy = c.setSourceRangeAuthorization(ing, routes, policy, authorization)
+	}
+	cors, corsAnnotation := c.kongCORS(ing), annotationKongPlugins
+	if nginxCORS != nil {
+		cors, corsAnnotation = c.envoyCORS(ing, nginxCORS), annotationEnableCORS
+	}
+	if cors != nil {
+		if policy == nil {
+			policy = newPolicy("gateway.envoyproxy.io/v1alpha1", "SecurityPolicy",
+				sanitizeName(fmt.Sprintf("%s-cors", ing.Name)), ing.Namespace,
+				map[string]interface{}{"targetRefs": routeTargetRefs(routes)})
+		}
+		if err := unstructured.SetNestedField(policy.Object, cors, "spec", "cors"); err != nil {
+			c.addDiagnostic(ing, corsAnnotation, SeverityError, "failed to set CORS: %v", err)
+		}
+	}
+	return policy
+}
+
+// setSourceRangeAuthorization adds the source range authorization to the
+// external auth SecurityPolicy, or to a new one if nil
+func (c *Converter) setSourceRangeAuthorization(ing *networkingv1.Ingress, routes []interface{}, policy *unstructured.Unstructured, authorization map[string]interface{}) *unstructured.Unstructured {
+	if policy == nil {
+		c.addDiagnostic(ing, "", SeverityInfo,
+			"client source ranges converted to an Envoy Gateway SecurityPolicy; configure clientIPDetection when clients connect through a load balancer")
+		return newPolicy("gateway.envoyproxy.io/v1alpha1", "SecurityPolicy",
+			sanitizeName(fmt.Sprintf("%s-ip-access", ing.Name)), ing.Namespace,
+			map[string]interface{}{
+				"targetRefs":    routeTargetRefs(routes),
+				"authorization": authorization,
+			})
+	}
+	if err := unstructured.SetNestedField(policy.Object, authorization, "spec", "authorization"); err != nil {
+		c.addDiagnostic(ing, "", SeverityError, "failed to set source range authorization: %v", err)
+		return policy
+	}
+	c.addDiagnostic(ing, "", SeverityInfo,
+		"client source ranges merged into the external auth SecurityPolicy; configure clientIPDetection when clients connect through a load balancer")
+	return policy
+}
+
+// checkRateLimitExemption records that limit-whitelist ranges cannot be
+// exempted from a converted or manual rate limit
+func (c *Converter) checkRateLimitExemption(ing *networkingv1.Ingress, ranges sourceRanges, converted bool) {
+	if len(ranges.exempt) == 0 {
+		return
+	}
+	annotation := annotationLimitWhitelist
+	if _, ok := ing.Annotations[annotation]; !ok {
+		annotation = annotationLimitAllowlist
+	}
+	var cidrs []string
+	for _, cidr := range ranges.exempt {
+		cidrs = append(cidrs, cidr.String())
+	}
+	exempt := strings.Join(cidrs, ", ")
+	if converted {
+		c.addDiagnostic(ing, annotation, SeverityWarning,
+			"rate limit exemption for %s cannot be expressed in a BackendTrafficPolicy; exempt clients are rate limited too", exempt)
+		return
+	}
+	c.addDiagnostic(ing, annotation, SeverityWarning, "rate limit exemption for %s needs manual policy", exempt)
+}
+
+// extractRateLimit converts limit-rps/limit-rpm and Kong rate-limiting
+// plugins into the target
+// implementation's rate-limit policy, or records a manual-policy diagnostic
+func (c *Converter) extractRateLimit(ing *networkingv1.Ingress, routes []interface{}) *unstructured.Unstructured {
+	if limit, exists := ing.Annotations[annotationLimitConnections]; exists {
+		c.addDiagnostic(ing, annotationLimitConnections, SeverityWarning,
+			"connection limit %q has no Gateway API or policy equivalent, needs manual policy", limit)
+	}
+
+	limits := []struct {
+		annotation string
+		unit       string
+	}{
+		{annotationLimitRPS, "Second"},
+		{annotationLimitRPM, "Minute"},
+	}
+
+	var rules []interface{}
+	for _, l := range limits {
+		value, exists := ing.Annotations[l.annotation]
+		if !exists {
+			continue
+		}
+		requests, err := strconv.Atoi(value)
+		if err != nil || requests <= 0 {
+			c.addDiagnostic(ing, l.annotation, SeverityWarning, "invalid rate limit %q, not converted", value)
+			continue
+		}
+		if c.opts.Target != TargetEnvoyGateway {
+			c.addDiagnostic(ing, l.annotation, SeverityWarning,
+				"rate limit of %d requests per %s per client IP needs manual policy (no core Gateway API equivalent)", requests, strings.ToLower(l.unit))
+			continue
+		}
+
+		// ingress-nginx limits per client address, which maps to a distinct
+		// source CIDR selector in a global rate limit
+		rules = append(rules, map[string]interface{}{
+			"clientSelectors": []interface{}{
+				map[string]interface{}{
+					"sourceCIDR": map[string]interface{}{
+						"type":  "Distinct",
+						"value": "0.0.0.0/0",
+					},
+				},
+			},
+			"limit": map[string]interface{}{
+				"requests": int64(requests),
+				"unit":     l.unit,
+			},
+		})
+	}
+	rules = append(rules, c.kongRateLimitRules(ing)...)
+
+	if len(rules) == 0 {
+		return nil
+	}
+
+	c.addDiagnostic(ing, "", SeverityInfo,
+		"rate limits converted to an Envoy Gateway BackendTrafficPolicy; global rate limiting requires the Envoy rate limit service")
+
+	return newPolicy("gateway.envoyproxy.io/v1alpha1", "BackendTrafficPolicy",
+		sanitizeName(fmt.Sprintf("%s-ratelimit", ing.Name)), ing.Namespace,
+		map[string]interface{}{
+			"targetRefs": routeTargetRefs(routes),
+			"rateLimit": map[string]interface{}{
+				"type": "Global",
+				"global": map[string]interface{}{
+					"rules": rules,
+				},
+			},
+		})
+}
+
+// backendTrafficPolicy returns policy, or a new Envoy Gateway
+// BackendTrafficPolicy for the routes named after its first use if nil
+func backendTrafficPolicy(policy *unstructured.Unstructured, ing *networkingv1.Ingress, routes []interface{}, use string) *unstructured.Unstructured {
+	if policy != nil {
+		return policy
+	}
+	return newPolicy("gateway.envoyproxy.io/v1alpha1", "BackendTrafficPolicy",
+		sanitizeName(fmt.Sprintf("%s-%s", ing.Name, use)), ing.Namespace,
+		map[string]interface{}{
+			"targetRefs": routeTargetRefs(routes),
+		})
+}
+
+// extractConnectTimeout converts proxy-connect-timeout into the TCP timeout
+// of an Envoy Gateway BackendTrafficPolicy or the connection pool of Istio
+// DestinationRules, since HTTPRouteTimeouts has no connect timeout
+func (c *Converter) extractConnectTimeout(ing *networkingv1.Ingress) map[string]interface{} {
+	value, exists := ing.Annotations[annotationConnectTimeout]
+	if !exists {
+		return nil
+	}
+	seconds, err := strconv.Atoi(strings.TrimSuffix(value, "s"))
+	if err != nil || seconds <= 0 {
+		c.addDiagnostic(ing, annotationConnectTimeout, SeverityWarning, "invalid connect timeout %q, not converted", value)
+		return nil
+	}
+	switch c.opts.Target {
+	case TargetEnvoyGateway:
+		c.addDiagnostic(ing, annotationConnectTimeout, SeverityInfo,
+			"connect timeout converted to an Envoy Gateway BackendTrafficPolicy")
+	case TargetIstio:
+		c.addDiagnostic(ing, annotationConnectTimeout, SeverityInfo,
+			"connect timeout converted to Istio DestinationRules; merge them with existing DestinationRules of the Services")
+	default:
+		c.addDiagnostic(ing, annotationConnectTimeout, SeverityWarning,
+			"connect timeout of %ds cannot be expressed on HTTPRoute timeouts, needs manual policy", seconds)
+		return nil
+	}
+	return map[string]interface{}{
+		"tcp": map[string]interface{}{
+			"connectTimeout": fmt.Sprintf("%ds", seconds),
+		},
+	}
+}
+
+// extractSessionPersistence converts cookie affinity into an (experimental)
+// BackendLBPolicy targeting the Ingress backend Services
+func (c *Converter) extractSessionPersistence(ing *networkingv1.Ingress) *unstructured.Unstructured {
+	affinity, exists := ing.Annotations[annotationAffinity]
+	if !exists {
+		return nil
+	}
+	if affinity != "cookie" {
+		c.addDiagnostic(ing, annotationAffinity, SeverityWarning, "unsupported affinity type %q, session persistence not converted", affinity)
+		return nil
+	}
+
+	services := backendServiceNames(ing)
+	if len(services) == 0 {
+		return nil
+	}
+	if !c.supports(FeatureBackendLBPolicy) {
+		c.unsupportedFeature(ing, annotationAffinity, FeatureBackendLBPolicy, "cookie affinity")
+		return nil
+	}
+
+	cookieName := ing.Annotations[annotationSessionCookieName]
+	if cookieName == "" {
+		cookieName = defaultSessionCookieName
+	}
+
+	sessionPersistence := map[string]interface{}{
+		"type":        "Cookie",
+		"sessionName": cookieName,
+	}
+	if maxAge, exists := ing.Annotations[annotationSessionCookieAge]; exists {
+		if seconds, err := strconv.Atoi(maxAge); err == nil && seconds > 0 {
+			sessionPersistence["absoluteTimeout"] = fmt.Sprintf("%ds", seconds)
+			sessionPersistence["cookieConfig"] = map[string]interface{}{
+				"lifetimeType": "Permanent",
+			}
+		} else {
+			c.addDiagnostic(ing, annotationSessionCookieAge, SeverityWarning, "invalid max-age %q, cookie lifetime not converted", maxAge)
+		}
+	}
+
+	var targetRefs []interface{}
+	for _, svc := range services {
+		targetRefs = append(targetRefs, map[string]interface{}{
+			"group": "",
+			"kind":  "Service",
+			"name":  svc,
+		})
+	}
+
+	c.addDiagnostic(ing, annotationAffinity, SeverityInfo,
+		"cookie affinity converted to BackendLBPolicy (experimental channel); requires an implementation that supports session persistence")
+
+	return newPolicy("gateway.networking.k8s.io/v1alpha2", "BackendLBPolicy",
+		sanitizeName(fmt.Sprintf("%s-session", ing.Name)), ing.Namespace,
+		map[string]interface{}{
+			"targetRefs":         targetRefs,
+			"sessionPersistence": sessionPersistence,
+		})
+}
+
+// nginxSizeRegex matches nginx size values such as 8m, 512k or 0
+var nginxSizeRegex = regexp.MustCompile(`^[0-9]+[kKmMgG]?$`)
+
+// extractBodySize converts proxy-body-size into client settings policies for
+// targets that support request body limits
+func (c *Converter) extractBodySize(ing *networkingv1.Ingress, routes []interface{}) []interface{} {
+	size, exists := ing.Annotations[annotationProxyBodySize]
+	if !exists {
+		return nil
+	}
+	if !nginxSizeRegex.MatchString(size) {
+		c.addDiagnostic(ing, annotationProxyBodySize, SeverityWarning, "invalid body size %q, not converted", size)
+		return nil
+	}
+
+	switch c.opts.Target {
+	case TargetNginxGatewayFabric:
+		// ClientSettingsPolicy accepts a single targetRef, so emit one per route
+		var policies []interface{}
+		for _, ref := range routeTargetRefs(routes) {
+			name := ref.(map[string]interface{})["name"].(string)
+			policies = append(policies, newPolicy("gateway.nginx.org/v1alpha1", "ClientSettingsPolicy",
+				sanitizeName(fmt.Sprintf("%s-client-settings", name)), ing.Namespace,
+				map[string]interface{}{
+					"targetRef": ref,
+					"body": map[string]interface{}{
+						"maxSize": strings.ToLower(size),
+					},
+				}))
+		}
+		c.addDiagnostic(ing, annotationProxyBodySize, SeverityInfo, "body size limit %s converted to ClientSettingsPolicy", size)
+		return policies
+	default:
+		c.addDiagnostic(ing, annotationProxyBodySize, SeverityWarning,
+			"unsupported feature: request body size limit %s has no core Gateway API equivalent and no policy for target %q; configure it on the Gateway implementation", size, c.opts.Target)
+		return nil
+	}
+}
+
+// newPolicy creates an unstructured policy resource
+func newPolicy(apiVersion, kind, name, namespace string, spec map[string]interface{}) *unstructured.Unstructured {
+	policy := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": spec,
+		},
+	}
+	policy.SetAPIVersion(apiVersion)
+	policy.SetKind(kind)
+	policy.SetName(name)
+	policy.SetNamespace(namespace)
+	return policy
+}
+
+// routeTargetRefs builds policy targetRefs for the generated HTTPRoutes
+func routeTargetRefs(routes []interface{}) []interface{} {
+	var refs []interface{}
+	for _, r := range routes {
+		if hr, ok := r.(*gatewayv1.HTTPRoute); ok {
+			refs = append(refs, map[string]interface{}{
+				"group": "gateway.networking.k8s.io",
+				"kind":  "HTTPRoute",
+				"name":  hr.Name,
+			})
+		}
+	}
+	return refs
+}
+
+// backendServiceNames returns the sorted, unique Service names referenced by an Ingress
+func backendServiceNames(ing *networkingv1.Ingress) []string {
+	seen := make(map[string]bool)
+
+	if ing.Spec.DefaultBackend != nil && ing.Spec.DefaultBackend.Service != nil {
+		seen[ing.Spec.DefaultBackend.Service.Name] = true
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service != nil {
+				seen[path.Backend.Service.Name] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
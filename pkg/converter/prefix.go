@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// HasStringPrefixSemantics reports whether nginx matches path as a plain
+// string prefix, so /foo also matches /foobar. ingress-nginx only makes
+// pathType Prefix segment-aware; ImplementationSpecific paths become
+// ordinary nginx prefix locations.
+func HasStringPrefixSemantics(path networkingv1.HTTPIngressPath) bool {
+	if path.PathType != nil && *path.PathType != networkingv1.PathTypeImplementationSpecific {
+		return false
+	}
+	return path.Path != "" && !strings.HasSuffix(path.Path, "/")
+}
+
+// prefixCompatMatch returns the RegularExpression match reproducing nginx
+// string-prefix matching for a PathPrefix translation, or nil if the
+// translation already behaves like nginx. Without --prefix-compat the
+// behavior change is only reported.
+func (c *Converter) prefixCompatMatch(ing *networkingv1.Ingress, path networkingv1.HTTPIngressPath, t pathTranslation) *gatewayv1.HTTPRouteMatch {
+	if t.matchType != gatewayv1.PathMatchPathPrefix || t.value != path.Path || !HasStringPrefixSemantics(path) {
+		return nil
+	}
+
+	if !c.opts.PrefixCompat {
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"ImplementationSpecific path %q converted to segment-aware PathPrefix; nginx also matched paths like %sfoo, use --prefix-compat to keep that", path.Path, path.Path)
+		return nil
+	}
+	if !c.supports(FeatureRegexPath) {
+		c.unsupportedFeature(ing, "", FeatureRegexPath, fmt.Sprintf("nginx prefix semantics for path %q", path.Path))
+		return nil
+	}
+
+	matchType := gatewayv1.PathMatchRegularExpression
+	value := "^" + regexp.QuoteMeta(path.Path) + ".*"
+	c.addDiagnostic(ing, "", SeverityWarning,
+		"path %q also matched as RegularExpression %q to keep nginx prefix semantics; regex support is implementation-specific", path.Path, value)
+	return &gatewayv1.HTTPRouteMatch{
+		Path: &gatewayv1.HTTPPathMatch{
+			Type:  &matchType,
+			Value: &value,
+		},
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// Progressive delivery controllers that create and own canary Ingresses
+const (
+	ControllerFlagger      = "flagger"
+	ControllerArgoRollouts = "argo-rollouts"
+)
+
+// Progressive delivery modes
+const (
+	ProgressiveSkip     = "skip"     // leave managed Ingresses to their controller (default)
+	ProgressiveGenerate = "generate" // convert them with managed-by markers
+)
+
+// ProgressiveModes lists the accepted progressive delivery modes
+var ProgressiveModes = []string{ProgressiveSkip, ProgressiveGenerate}
+
+const (
+	managedByLabel      = "app.kubernetes.io/managed-by"
+	managedByAnnotation = "ingress-to-gateway.io/progressive-delivery"
+)
+
+// progressiveController returns the progressive delivery controller that
+// owns the Ingress, together with the owner's name
+func progressiveController(ing *networkingv1.Ingress) (string, string) {
+	for _, ref := range ing.OwnerReferences {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			continue
+		}
+		switch {
+		case gv.Group == "flagger.app" && ref.Kind == "Canary":
+			return ControllerFlagger, ref.Name
+		case gv.Group == "argoproj.io" && ref.Kind == "Rollout":
+			return ControllerArgoRollouts, ref.Name
+		}
+	}
+	return "", ""
+}
+
+// skipManaged reports whether the Ingress is owned by another controller
+// and should be left alone
+func (c *Converter) skipManaged(ing *networkingv1.Ingress) bool {
+	controller, owner := progressiveController(ing)
+	if controller != "" {
+		if c.opts.ProgressiveDelivery == ProgressiveGenerate {
+			return false
+		}
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"managed by %s %q; skipped so the migration does not conflict with the controller (use --progressive-delivery=generate to convert it)",
+			controller, owner)
+		return true
+	}
+
+	ref := ControllerOwner(ing)
+	if ref == nil || c.opts.IncludeManaged {
+		return false
+	}
+	c.addDiagnostic(ing, "", SeverityWarning,
+		"owned by %s %q (%s); skipped since its controller recreates it (use --include-managed to convert it)",
+		ref.Kind, ref.Name, ref.APIVersion)
+	return true
+}
+
+// ControllerOwner returns the owner reference of the controller managing
+// ing, nil when no owner reference is marked as its controller
+func ControllerOwner(ing *networkingv1.Ingress) *metav1.OwnerReference {
+	for i, ref := range ing.OwnerReferences {
+		if ref.Controller != nil && *ref.Controller {
+			return &ing.OwnerReferences[i]
+		}
+	}
+	return nil
+}
+
+// markManaged labels routes generated from a controller-owned Ingress and
+// records the controller settings needed to take them over
+func (c *Converter) markManaged(ing *networkingv1.Ingress, routes []interface{}) {
+	controller, owner := progressiveController(ing)
+	if controller == "" {
+		return
+	}
+
+	var names []string
+	for _, r := range routes {
+		route, ok := r.(*gatewayv1.HTTPRoute)
+		if !ok {
+			continue
+		}
+		// Copy before writing, route labels may share the Ingress map
+		route.Labels = withEntry(route.Labels, managedByLabel, controller)
+		route.Annotations = withEntry(route.Annotations, managedByAnnotation, owner)
+		names = append(names, route.Name)
+	}
+
+	switch controller {
+	case ControllerArgoRollouts:
+		c.addDiagnostic(ing, "", SeverityInfo,
+			"configure Rollout %q trafficRouting.plugins with the Gateway API plugin and httpRoute %s, then delete the canary Ingress",
+			owner, strings.Join(names, ", "))
+	case ControllerFlagger:
+		c.addDiagnostic(ing, "", SeverityInfo,
+			"switch Canary %q to the gatewayapi provider with spec.service.gatewayRefs; Flagger then owns routing and %s only documents the current setup",
+			owner, strings.Join(names, ", "))
+	}
+}
+
+// withEntry returns a copy of m with key set to value
+func withEntry(m map[string]string, key, value string) map[string]string {
+	result := make(map[string]string, len(m)+1)
+	for k, v := range m {
+		result[k] = v
+	}
+	result[key] = value
+	return result
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// AddReferenced indexes the resources among objs that converted resources
+// reference without being converted themselves, such as the Traefik
+// Middlewares of IngressRoutes and Ingress annotations, the Istio
+// DestinationRules of VirtualService destinations, the Kong plugins and
+// KongIngresses of Kong Ingresses, the VirtualServerRoutes of NGINX
+// VirtualServers, the GKE BackendConfigs and FrontendConfigs with the
+// Services selecting them, and the IngressClasses of generated Gateways. Convert indexes its own input; call
+// AddReferenced when the Middlewares are converted apart from the
+// resources using them.
+func (c *Converter) AddReferenced(objs []interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Runs in progress keep the index they started with
+	referenced := make(map[string]*unstructured.Unstructured, len(c.referenced))
+	for key, obj := range c.referenced {
+		referenced[key] = obj
+	}
+	for _, item := range objs {
+		obj, ok := item.(*unstructured.Unstructured)
+		if !ok || !isReferencedKind(obj) {
+			continue
+		}
+		// IngressClasses are cluster-scoped, whatever namespace the input sets
+		namespace := obj.GetNamespace()
+		if obj.GetKind() == kindIngressClass {
+			namespace = ""
+		}
+		referenced[referencedKey(obj.GetKind(), namespace, obj.GetName())] = obj
+	}
+	c.referenced = referenced
+}
+
+// IsReferenced reports whether obj is a resource AddReferenced indexes,
+// which Convert produces no routes for
+func IsReferenced(obj interface{}) bool {
+	u, ok := obj.(*unstructured.Unstructured)
+	return ok && isReferencedKind(u)
+}
+
+// isReferencedKind reports whether obj is only referenced by other
+// resources, producing no routes of its own
+func isReferencedKind(obj *unstructured.Unstructured) bool {
+	switch {
+	case obj.GetKind() == kindMiddleware:
+		return isTraefikKind(obj.GetAPIVersion(), obj.GetKind())
+	case obj.GetKind() == kindService:
+		return obj.GetAPIVersion() == "v1"
+	case obj.GetKind() == kindDestinationRule:
+		return isIstioKind(obj.GetAPIVersion(), obj.GetKind())
+	case isKongKind(obj.GetAPIVersion(), obj.GetKind()):
+		return true
+	case obj.GetKind() == kindVirtualServerRoute:
+		return isNginxIncKind(obj.GetAPIVersion(), obj.GetKind())
+	case obj.GetKind() == kindIngressClass:
+		return obj.GetAPIVersion() == networkingv1.SchemeGroupVersion.String()
+	}
+	return isGKEKind(obj.GetAPIVersion(), obj.GetKind())
+}
+
+// referencedKey identifies a referenced resource
+func referencedKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// grantKey identifies one ReferenceGrant: the namespace it is created in
+// and the resources it admits references from
+type grantKey struct {
+	namespace     string
+	fromGroup     string
+	fromKind      string
+	fromNamespace string
+}
+
+// crossNamespaceRef is a reference from a generated resource to an object
+// in another namespace
+type crossNamespaceRef struct {
+	grantKey
+	toGroup string
+	toKind  string
+	toName  string
+}
+
+// referenceGrants builds the ReferenceGrants that the cross-namespace
+// references of resources need to be accepted: one per target namespace and
+// referencing kind and namespace, listing every referenced object by name.
+func referenceGrants(resources []interface{}) []interface{} {
+	grants := make(map[grantKey]*gatewayv1beta1.ReferenceGrant)
+	seen := make(map[crossNamespaceRef]bool)
+	var keys []grantKey
+
+	for _, res := range resources {
+		for _, ref := range crossNamespaceRefs(res) {
+			if seen[ref] {
+				continue
+			}
+			seen[ref] = true
+
+			grant, exists := grants[ref.grantKey]
+			if !exists {
+				grant = newReferenceGrant(ref.grantKey)
+				grants[ref.grantKey] = grant
+				keys = append(keys, ref.grantKey)
+			}
+			name := gatewayv1beta1.ObjectName(ref.toName)
+			grant.Spec.To = append(grant.Spec.To, gatewayv1beta1.ReferenceGrantTo{
+				Group: gatewayv1beta1.Group(ref.toGroup),
+				Kind:  gatewayv1beta1.Kind(ref.toKind),
+				Name:  &name,
+			})
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return grants[keys[i]].Namespace+"/"+grants[keys[i]].Name < grants[keys[j]].Namespace+"/"+grants[keys[j]].Name
+	})
+	result := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, grants[key])
+	}
+	return result
+}
+
+// MergeReferenceGrants merges the ReferenceGrants among resources that
+// share a namespace and name, such as those of Ingresses converted one at
+// a time, joining their from and to entries. Other resources are kept in
+// order, followed by the merged grants sorted by namespace/name.
+func MergeReferenceGrants(resources []interface{}) []interface{} {
+	grants := make(map[string]*gatewayv1beta1.ReferenceGrant)
+	var keys []string
+	var result []interface{}
+
+	for _, res := range resources {
+		grant, ok := res.(*gatewayv1beta1.ReferenceGrant)
+		if !ok {
+			result = append(result, res)
+			continue
+		}
+		key := grant.Namespace + "/" + grant.Name
+		merged, exists := grants[key]
+		if !exists {
+			grants[key] = grant.DeepCopy()
+			keys = append(keys, key)
+			continue
+		}
+		for _, from := range grant.Spec.From {
+			if !containsGrantFrom(merged.Spec.From, from) {
+				merged.Spec.From = append(merged.Spec.From, from)
+			}
+		}
+		for _, to := range grant.Spec.To {
+			if !containsGrantTo(merged.Spec.To, to) {
+				merged.Spec.To = append(merged.Spec.To, *to.DeepCopy())
+			}
+		}
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		result = append(result, grants[key])
+	}
+	return result
+}
+
+// containsGrantFrom reports whether entries admits references from from
+func containsGrantFrom(entries []gatewayv1beta1.ReferenceGrantFrom, from gatewayv1beta1.ReferenceGrantFrom) bool {
+	for _, entry := range entries {
+		if entry == from {
+			return true
+		}
+	}
+	return false
+}
+
+// containsGrantTo reports whether entries already lists to
+func containsGrantTo(entries []gatewayv1beta1.ReferenceGrantTo, to gatewayv1beta1.ReferenceGrantTo) bool {
+	for _, entry := range entries {
+		if entry.Group == to.Group && entry.Kind == to.Kind && reflect.DeepEqual(entry.Name, to.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// newReferenceGrant starts an empty ReferenceGrant for key
+func newReferenceGrant(key grantKey) *gatewayv1beta1.ReferenceGrant {
+	return &gatewayv1beta1.ReferenceGrant{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "gateway.networking.k8s.io/v1beta1",
+			Kind:       "ReferenceGrant",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sanitizeName(fmt.Sprintf("from-%s-%s", key.fromNamespace, strings.ToLower(key.fromKind))),
+			Namespace: key.namespace,
+		},
+		Spec: gatewayv1beta1.ReferenceGrantSpec{
+			From: []gatewayv1beta1.ReferenceGrantFrom{
+				{
+					Group:     gatewayv1beta1.Group(key.fromGroup),
+					Kind:      gatewayv1beta1.Kind(key.fromKind),
+					Namespace: gatewayv1beta1.Namespace(key.fromNamespace),
+				},
+			},
+		},
+	}
+}
+
+// crossNamespaceRefs lists the references of a generated resource that
+// leave its namespace: Gateway certificates, route backends, the auth
+// service of an Envoy Gateway SecurityPolicy and the client CA of a
+// ClientTrafficPolicy
+func crossNamespaceRefs(res interface{}) []crossNamespaceRef {
+	var refs []crossNamespaceRef
+	add := func(from metav1.Object, fromGroup, fromKind, toGroup, toKind, toNamespace, toName string) {
+		if toNamespace == "" || toNamespace == from.GetNamespace() {
+			return
+		}
+		refs = append(refs, crossNamespaceRef{
+			grantKey: grantKey{
+				namespace:     toNamespace,
+				fromGroup:     fromGroup,
+				fromKind:      fromKind,
+				fromNamespace: from.GetNamespace(),
+			},
+			toGroup: toGroup,
+			toKind:  toKind,
+			toName:  toName,
+		})
+	}
+
+	switch r := res.(type) {
+	case *gatewayv1.Gateway:
+		for _, listener := range r.Spec.Listeners {
+			if listener.TLS == nil {
+				continue
+			}
+			for _, cert := range listener.TLS.CertificateRefs {
+				if cert.Namespace == nil {
+					continue
+				}
+				group, kind := objectGroupKind(cert.Group, cert.Kind, "Secret")
+				add(r, gatewayv1.GroupName, "Gateway", group, kind, string(*cert.Namespace), string(cert.Name))
+			}
+		}
+	case *gatewayv1.HTTPRoute:
+		for _, rule := range r.Spec.Rules {
+			for _, backend := range rule.BackendRefs {
+				if backend.Namespace == nil {
+					continue
+				}
+				group, kind := objectGroupKind(backend.Group, backend.Kind, "Service")
+				add(r, gatewayv1.GroupName, "HTTPRoute", group, kind, string(*backend.Namespace), string(backend.Name))
+			}
+		}
+	case *gatewayv1alpha2.TLSRoute:
+		for _, rule := range r.Spec.Rules {
+			for _, backend := range rule.BackendRefs {
+				if backend.Namespace == nil {
+					continue
+				}
+				group, kind := objectGroupKind(backend.Group, backend.Kind, "Service")
+				add(r, gatewayv1.GroupName, "TLSRoute", group, kind, string(*backend.Namespace), string(backend.Name))
+			}
+		}
+	case *unstructured.Unstructured:
+		if r.GetKind() == "ClientTrafficPolicy" {
+			caRefs, _, _ := unstructured.NestedSlice(r.Object, "spec", "tls", "clientValidation", "caCertificateRefs")
+			for _, field := range caRefs {
+				ref, ok := field.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				group, _ := ref["group"].(string)
+				kind, _ := ref["kind"].(string)
+				if kind == "" {
+					kind = "Secret"
+				}
+				namespace, _ := ref["namespace"].(string)
+				name, _ := ref["name"].(string)
+				add(r, r.GroupVersionKind().Group, "ClientTrafficPolicy", group, kind, namespace, name)
+			}
+			break
+		}
+		if r.GetKind() != "SecurityPolicy" {
+			break
+		}
+		for _, protocol := range []string{"http", "grpc"} {
+			field, _, _ := unstructured.NestedFieldNoCopy(r.Object, "spec", "extAuth", protocol, "backendRef")
+			ref, ok := field.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			group, _ := ref["group"].(string)
+			kind, _ := ref["kind"].(string)
+			if kind == "" {
+				kind = "Service"
+			}
+			namespace, _ := ref["namespace"].(string)
+			name, _ := ref["name"].(string)
+			add(r, r.GroupVersionKind().Group, "SecurityPolicy", group, kind, namespace, name)
+		}
+	}
+
+	return refs
+}
+
+// objectGroupKind defaults the optional group and kind of a reference
+func objectGroupKind(group *gatewayv1.Group, kind *gatewayv1.Kind, defaultKind string) (string, string) {
+	g, k := "", defaultKind
+	if group != nil {
+		g = string(*group)
+	}
+	if kind != nil {
+		k = string(*kind)
+	}
+	return g, k
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// ConversionResult is the typed result of ConvertIngresses. Each generated
+// resource appears in exactly one of the resource fields; Resources returns
+// them all in output order. HTTPRoutes with rule names are in HTTPRoutes
+// without the names, which only Resources keeps.
+type ConversionResult struct {
+	GatewayClasses     []*gatewayv1.GatewayClass
+	Gateways           []*gatewayv1.Gateway
+	HTTPRoutes         []*gatewayv1.HTTPRoute
+	GRPCRoutes         []*gatewayv1alpha2.GRPCRoute
+	TLSRoutes          []*gatewayv1alpha2.TLSRoute
+	TCPRoutes          []*gatewayv1alpha2.TCPRoute
+	UDPRoutes          []*gatewayv1alpha2.UDPRoute
+	ReferenceGrants    []*gatewayv1beta1.ReferenceGrant
+	BackendTLSPolicies []*gatewayv1alpha2.BackendTLSPolicy
+	Services           []*corev1.Service
+
+	// Policies holds implementation policies and other resources the
+	// vendored Gateway API types cannot represent
+	Policies []*unstructured.Unstructured
+
+	// Diagnostics, Fidelity and Annotations belong to this call, unlike
+	// Converter.Diagnostics which reports the call that finished last.
+	// Annotations lists every nginx annotation as converted, partial or
+	// skipped, for gating automation.
+	Diagnostics []Diagnostic
+	Fidelity    []Fidelity
+	Annotations []AnnotationResult
+
+	resources []interface{}
+}
+
+// Resources returns the generated resources in output order, as accepted by
+// WriteOutput, WriteChunks and ToUnstructured
+func (r *ConversionResult) Resources() []interface{} {
+	return r.resources
+}
+
+// ConvertIngresses converts Ingresses like Convert and returns the generated
+// resources by type, with the diagnostics and fidelity of this call
+func (c *Converter) ConvertIngresses(ctx context.Context, ingresses []*networkingv1.Ingress) (*ConversionResult, error) {
+	items := make([]interface{}, 0, len(ingresses))
+	for _, ing := range ingresses {
+		items = append(items, ing)
+	}
+
+	run := c.newRun()
+	resources, err := run.convert(ctx, items)
+	c.publish(run)
+	if err != nil {
+		return nil, err
+	}
+	return newConversionResult(resources, run.diagnostics, run.fidelity)
+}
+
+// newConversionResult sorts generated resources into a ConversionResult
+func newConversionResult(resources []interface{}, diags []Diagnostic, fidelity []Fidelity) (*ConversionResult, error) {
+	result := &ConversionResult{
+		Diagnostics: diags,
+		Fidelity:    fidelity,
+		Annotations: AnnotationResults(fidelity),
+		resources:   resources,
+	}
+	for _, res := range resources {
+		switch r := res.(type) {
+		case *gatewayv1.GatewayClass:
+			result.GatewayClasses = append(result.GatewayClasses, r)
+		case *gatewayv1.Gateway:
+			result.Gateways = append(result.Gateways, r)
+		case *gatewayv1.HTTPRoute:
+			result.HTTPRoutes = append(result.HTTPRoutes, r)
+		case *gatewayv1alpha2.GRPCRoute:
+			result.GRPCRoutes = append(result.GRPCRoutes, r)
+		case *gatewayv1alpha2.TLSRoute:
+			result.TLSRoutes = append(result.TLSRoutes, r)
+		case *gatewayv1alpha2.TCPRoute:
+			result.TCPRoutes = append(result.TCPRoutes, r)
+		case *gatewayv1alpha2.UDPRoute:
+			result.UDPRoutes = append(result.UDPRoutes, r)
+		case *gatewayv1beta1.ReferenceGrant:
+			result.ReferenceGrants = append(result.ReferenceGrants, r)
+		case *gatewayv1alpha2.BackendTLSPolicy:
+			result.BackendTLSPolicies = append(result.BackendTLSPolicies, r)
+		case *corev1.Service:
+			result.Services = append(result.Services, r)
+		case *unstructured.Unstructured:
+			if r.GroupVersionKind() != gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute") {
+				result.Policies = append(result.Policies, r)
+				continue
+			}
+			// Routes with rule names are unstructured since the vendored
+			// types lack the field; the typed view leaves the names out
+			route := &gatewayv1.HTTPRoute{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(r.Object, route); err != nil {
+				return nil, fmt.Errorf("failed to convert HTTPRoute %s: %w", r.GetName(), err)
+			}
+			result.HTTPRoutes = append(result.HTTPRoutes, route)
+		default:
+			return nil, fmt.Errorf("unexpected resource type %T", res)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+const (
+	annotationProxyNextUpstream      = "nginx.ingress.kubernetes.io/proxy-next-upstream"
+	annotationProxyNextUpstreamTries = "nginx.ingress.kubernetes.io/proxy-next-upstream-tries"
+
+	// ingress-nginx retries on connection errors and timeouts, three tries
+	// in all, unless configured otherwise
+	defaultProxyNextUpstream      = "error timeout"
+	defaultProxyNextUpstreamTries = 3
+)
+
+// extractRetry converts proxy-next-upstream and proxy-next-upstream-tries
+// into the retry section of an Envoy Gateway BackendTrafficPolicy
+func (c *Converter) extractRetry(ing *networkingv1.Ingress) map[string]interface{} {
+	conditions, hasConditions := ing.Annotations[annotationProxyNextUpstream]
+	triesValue, hasTries := ing.Annotations[annotationProxyNextUpstreamTries]
+	if !hasConditions && !hasTries {
+		return nil
+	}
+	annotation := annotationProxyNextUpstream
+	if !hasConditions {
+		annotation = annotationProxyNextUpstreamTries
+		conditions = defaultProxyNextUpstream
+	}
+
+	tries := defaultProxyNextUpstreamTries
+	if hasTries {
+		var err error
+		tries, err = strconv.Atoi(triesValue)
+		if err != nil || tries < 0 {
+			c.addDiagnostic(ing, annotationProxyNextUpstreamTries, SeverityWarning, "invalid number of tries %q, retries not converted", triesValue)
+			return nil
+		}
+		if tries == 0 {
+			c.addDiagnostic(ing, annotationProxyNextUpstreamTries, SeverityWarning,
+				"unlimited tries cannot be expressed; retries not converted")
+			return nil
+		}
+	}
+	if strings.TrimSpace(conditions) == "off" || tries == 1 {
+		// Retries are disabled, as they are by default on Gateway API implementations
+		return nil
+	}
+
+	if c.opts.Target != TargetEnvoyGateway {
+		c.addDiagnostic(ing, annotation, SeverityWarning,
+			"retries on %q (%d tries) need manual policy (no Gateway API v1.0 retry); select --target=envoy-gateway to generate one", conditions, tries)
+		return nil
+	}
+
+	var triggers []interface{}
+	var codes []interface{}
+	seen := make(map[string]bool)
+	trigger := func(t string) {
+		if !seen[t] {
+			seen[t] = true
+			triggers = append(triggers, t)
+		}
+	}
+	for _, condition := range strings.Fields(conditions) {
+		switch {
+		case condition == "error", condition == "timeout":
+			trigger("connect-failure")
+			trigger("reset")
+		case condition == "invalid_header":
+			trigger("reset")
+		case condition == "non_idempotent":
+			c.addDiagnostic(ing, annotationProxyNextUpstream, SeverityInfo,
+				"non_idempotent has no equivalent; Envoy Gateway retries requests of any method")
+		case strings.HasPrefix(condition, "http_"):
+			code, err := strconv.Atoi(strings.TrimPrefix(condition, "http_"))
+			if err != nil {
+				c.addDiagnostic(ing, annotationProxyNextUpstream, SeverityWarning, "unknown condition %q, not converted", condition)
+				continue
+			}
+			trigger("retriable-status-codes")
+			codes = append(codes, int64(code))
+		default:
+			c.addDiagnostic(ing, annotationProxyNextUpstream, SeverityWarning, "unknown condition %q, not converted", condition)
+		}
+	}
+	if len(triggers) == 0 {
+		return nil
+	}
+
+	retryOn := map[string]interface{}{"triggers": triggers}
+	if len(codes) > 0 {
+		retryOn["httpStatusCodes"] = codes
+	}
+	c.addDiagnostic(ing, annotation, SeverityInfo,
+		"retries converted to an Envoy Gateway BackendTrafficPolicy with %d retries", tries-1)
+	return map[string]interface{}{
+		// nginx counts the first try, Envoy only the retries
+		"numRetries": int64(tries - 1),
+		"retryOn":    retryOn,
+	}
+}
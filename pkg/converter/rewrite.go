@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	annotationRewriteTarget = "nginx.ingress.kubernetes.io/rewrite-target"
+	annotationUseRegex      = "nginx.ingress.kubernetes.io/use-regex"
+
+	annotationXForwardedPrefix = "nginx.ingress.kubernetes.io/x-forwarded-prefix"
+)
+
+var (
+	// prefixStripRegex matches paths such as /foo(/|$)(.*), /foo/(.*) and /foo(.*)
+	prefixStripRegex = regexp.MustCompile(`^(/[A-Za-z0-9._~/-]*?)/?(?:\(/\|\$\))?\(\.\*\)$`)
+
+	// captureTargetRegex matches rewrite targets such as /$2 or /api/$1
+	captureTargetRegex = regexp.MustCompile(`^((?:/[^$]*)?)/\$([0-9])$`)
+)
+
+// pathTranslation describes how a single Ingress path is matched and rewritten
+type pathTranslation struct {
+	matchType gatewayv1.PathMatchType
+	value     string
+	rewrite   *gatewayv1.HTTPURLRewriteFilter
+}
+
+// hasCaptureGroups reports whether a rewrite target references regex captures
+func hasCaptureGroups(target string) bool {
+	return strings.Contains(target, "$")
+}
+
+// isRegexPath reports whether a path contains regex metacharacters
+func isRegexPath(path string) bool {
+	return strings.ContainsAny(path, `()[]{}*+?|^$\`)
+}
+
+// rewriteFilter builds the URLRewrite filter for a rewrite target without path context
+func rewriteFilter(target string) *gatewayv1.HTTPURLRewriteFilter {
+	if !hasCaptureGroups(target) {
+		return &gatewayv1.HTTPURLRewriteFilter{
+			Path: &gatewayv1.HTTPPathModifier{
+				Type:            gatewayv1.FullPathHTTPPathModifier,
+				ReplaceFullPath: &target,
+			},
+		}
+	}
+
+	m := captureTargetRegex.FindStringSubmatch(target)
+	if m == nil {
+		return nil
+	}
+	prefix := m[1]
+	if prefix == "" {
+		prefix = "/"
+	}
+	return &gatewayv1.HTTPURLRewriteFilter{
+		Path: &gatewayv1.HTTPPathModifier{
+			Type:               gatewayv1.PrefixMatchHTTPPathModifier,
+			ReplacePrefixMatch: &prefix,
+		},
+	}
+}
+
+// translatePath resolves the path match and rewrite for an Ingress path,
+// honoring rewrite-target capture groups and use-regex
+func (c *Converter) translatePath(ing *networkingv1.Ingress, path networkingv1.HTTPIngressPath) pathTranslation {
+	t := pathTranslation{
+		matchType: gatewayv1.PathMatchPathPrefix,
+		value:     path.Path,
+	}
+	if path.PathType != nil && *path.PathType == networkingv1.PathTypeExact {
+		t.matchType = gatewayv1.PathMatchExact
+	}
+	if t.value == "" {
+		t.value = "/"
+	}
+	if regex, ok := kongRegexPath(path); ok {
+		t.matchType = gatewayv1.PathMatchRegularExpression
+		t.value = regex
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"Kong regex path %q converted to a RegularExpression match; regex support is implementation-specific", path.Path)
+		return t
+	}
+
+	target, hasRewrite := ing.Annotations[annotationRewriteTarget]
+
+	// ingress-nginx enables regex matching implicitly for capture-group rewrites
+	useRegex := ing.Annotations[annotationUseRegex] == "true" || (hasRewrite && hasCaptureGroups(target))
+	regexPath := useRegex && isRegexPath(t.value)
+
+	if !hasRewrite || !hasCaptureGroups(target) {
+		if hasRewrite {
+			t.rewrite = rewriteFilter(target)
+		}
+		if regexPath {
+			t.matchType = gatewayv1.PathMatchRegularExpression
+			c.addDiagnostic(ing, annotationUseRegex, SeverityWarning,
+				"path %q converted to a RegularExpression match; regex support is implementation-specific", t.value)
+		}
+		return t
+	}
+
+	// A rewrite like /$2 on /foo(/|$)(.*) only strips the prefix, which
+	// ReplacePrefixMatch expresses exactly
+	if prefix, ok := prefixStripMatch(t.value, target); ok {
+		t.matchType = gatewayv1.PathMatchPathPrefix
+		t.value = prefix
+		t.rewrite = rewriteFilter(target)
+		c.addDiagnostic(ing, annotationRewriteTarget, SeverityInfo,
+			"regex rewrite %q converted to ReplacePrefixMatch on prefix %q", target, prefix)
+		return t
+	}
+
+	if regexPath {
+		t.matchType = gatewayv1.PathMatchRegularExpression
+	}
+	c.addDiagnostic(ing, annotationRewriteTarget, SeverityError,
+		"regex rewrite %q on path %q cannot be expressed in Gateway API; rewrite dropped, manual review required", target, path.Path)
+	return t
+}
+
+// prefixStripMatch returns the literal prefix of path when target only
+// re-emits its trailing (.*) capture group
+func prefixStripMatch(path, target string) (string, bool) {
+	pm := prefixStripRegex.FindStringSubmatch(path)
+	tm := captureTargetRegex.FindStringSubmatch(target)
+	if pm == nil || tm == nil {
+		return "", false
+	}
+
+	group, err := strconv.Atoi(tm[2])
+	if err != nil || group != strings.Count(path, "(") {
+		return "", false
+	}
+
+	prefix := strings.TrimSuffix(pm[1], "/")
+	if prefix == "" {
+		prefix = "/"
+	}
+	return prefix, true
+}
+
+// applyRewrite replaces any URLRewrite filter with the path-specific rewrite
+func applyRewrite(filters []gatewayv1.HTTPRouteFilter, rewrite *gatewayv1.HTTPURLRewriteFilter) []gatewayv1.HTTPRouteFilter {
+	var result []gatewayv1.HTTPRouteFilter
+	for _, f := range filters {
+		if f.Type == gatewayv1.HTTPRouteFilterURLRewrite {
+			if rewrite == nil {
+				continue
+			}
+			f.URLRewrite = rewrite
+		}
+		result = append(result, f)
+	}
+	return result
+}
+
+// splitPrefixRewrite splits a rule replacing the prefix match into one rule
+// per match, since ReplacePrefixMatch requires exactly one PathPrefix match;
+// e.g. a Kong prefix path with several methods and strip-path
+func splitPrefixRewrite(rule gatewayv1.HTTPRouteRule) []gatewayv1.HTTPRouteRule {
+	if len(rule.Matches) < 2 {
+		return []gatewayv1.HTTPRouteRule{rule}
+	}
+	replacesPrefix := false
+	for _, f := range rule.Filters {
+		if f.Type == gatewayv1.HTTPRouteFilterURLRewrite && f.URLRewrite != nil && f.URLRewrite.Path != nil &&
+			f.URLRewrite.Path.Type == gatewayv1.PrefixMatchHTTPPathModifier {
+			replacesPrefix = true
+		}
+	}
+	if !replacesPrefix {
+		return []gatewayv1.HTTPRouteRule{rule}
+	}
+
+	rules := make([]gatewayv1.HTTPRouteRule, 0, len(rule.Matches))
+	for _, match := range rule.Matches {
+		split := *rule.DeepCopy()
+		split.Matches = []gatewayv1.HTTPRouteMatch{match}
+		rules = append(rules, split)
+	}
+	return rules
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// ruleNamesEnabled reports whether generated rules are named, telling the
+// user once per Ingress when RuleNames is set but the target CRDs lack them
+func (c *Converter) ruleNamesEnabled(ing *networkingv1.Ingress) bool {
+	if !c.opts.RuleNames {
+		return false
+	}
+	if !c.inChannel(FeatureHTTPRouteRuleNames) {
+		c.addDiagnostic(ing, "", SeverityInfo, "rule names left out: %s", c.channelGap(FeatureHTTPRouteRuleNames))
+		return false
+	}
+	if c.opts.APIVersion != "" && c.opts.APIVersion != APIVersionV1 {
+		c.addDiagnostic(ing, "", SeverityInfo,
+			"rule names left out: %s need apiVersion %s", FeatureHTTPRouteRuleNames, APIVersionV1)
+		return false
+	}
+	return true
+}
+
+// nameRules returns resources with every HTTPRoute replaced by an
+// unstructured copy whose rules carry a name. The vendored Gateway API v1.0
+// types have no rule name field, so the name is set on the unstructured form.
+func nameRules(resources []interface{}) ([]interface{}, error) {
+	named := make([]interface{}, 0, len(resources))
+	for _, res := range resources {
+		route, ok := res.(*gatewayv1.HTTPRoute)
+		if !ok {
+			named = append(named, res)
+			continue
+		}
+
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(route)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert HTTPRoute %s to unstructured: %w", route.Name, err)
+		}
+		u := &unstructured.Unstructured{Object: content}
+		unstructured.RemoveNestedField(u.Object, "metadata", "creationTimestamp")
+		unstructured.RemoveNestedField(u.Object, "status")
+
+		rules, _, err := unstructured.NestedSlice(u.Object, "spec", "rules")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rules of HTTPRoute %s: %w", route.Name, err)
+		}
+		for i, name := range ruleNames(route.Spec.Rules) {
+			rules[i].(map[string]interface{})["name"] = name
+		}
+		if err := unstructured.SetNestedSlice(u.Object, rules, "spec", "rules"); err != nil {
+			return nil, fmt.Errorf("failed to name rules of HTTPRoute %s: %w", route.Name, err)
+		}
+		named = append(named, u)
+	}
+	return named, nil
+}
+
+// ruleNames derives a deterministic name for each rule from its first path
+// match and backend, e.g. "api-api-svc" for /api to api-svc. Rules that
+// would share a name get a numeric suffix in order.
+func ruleNames(rules []gatewayv1.HTTPRouteRule) []string {
+	names := make([]string, len(rules))
+	seen := make(map[string]int)
+	for i, rule := range rules {
+		name := ruleName(rule)
+		seen[name]++
+		if n := seen[name]; n > 1 {
+			suffix := fmt.Sprintf("-%d", n)
+			name = strings.TrimRight(truncate(name, maxNameLength-len(suffix)), "-") + suffix
+		}
+		names[i] = name
+	}
+	return names
+}
+
+// ruleName derives the unsuffixed name of a rule
+func ruleName(rule gatewayv1.HTTPRouteRule) string {
+	path := "root"
+	for _, match := range rule.Matches {
+		if match.Path != nil && match.Path.Value != nil {
+			if p := sanitizeName(*match.Path.Value); p != "" {
+				path = p
+			}
+			break
+		}
+	}
+
+	backend := "no-backend"
+	if len(rule.BackendRefs) > 0 {
+		backend = sanitizeName(string(rule.BackendRefs[0].Name))
+	}
+
+	return strings.TrimRight(truncate(path+"-"+backend, maxNameLength), "-")
+}
+
+// truncate shortens s to at most n bytes
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
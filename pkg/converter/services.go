@@ -0,0 +1,326 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/yaml"
+)
+
+// tcp-services and udp-services ConfigMaps of a default ingress-nginx
+// installation
+const (
+	DefaultTCPServices = "ingress-nginx/tcp-services"
+	DefaultUDPServices = "ingress-nginx/udp-services"
+)
+
+// servicesProtocol describes the routes generated from a services ConfigMap
+type servicesProtocol struct {
+	name     string // listener and route name prefix
+	kind     string
+	feature  string
+	protocol gatewayv1.ProtocolType
+}
+
+var (
+	tcpServices = servicesProtocol{name: "tcp", kind: "TCPRoute", feature: FeatureTCPRoute, protocol: gatewayv1.TCPProtocolType}
+	udpServices = servicesProtocol{name: "udp", kind: "UDPRoute", feature: FeatureUDPRoute, protocol: gatewayv1.UDPProtocolType}
+)
+
+// servicesEntry is an entry of a tcp-services or udp-services ConfigMap:
+// "<port>": "<namespace>/<service>:<port>[:PROXY][:PROXY]"
+type servicesEntry struct {
+	port        gatewayv1.PortNumber // port exposed by the controller
+	namespace   string
+	service     string
+	servicePort networkingv1.ServiceBackendPort
+	proxyDecode bool // PROXY protocol expected from clients
+	proxyEncode bool // PROXY protocol sent to the backend
+}
+
+// LoadConfigMap reads a ConfigMap manifest, such as the tcp-services
+// ConfigMap of ingress-nginx
+func LoadConfigMap(r io.Reader) (*corev1.ConfigMap, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	var cm corev1.ConfigMap
+	if err := yaml.Unmarshal(data, &cm); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ConfigMap: %w", err)
+	}
+	if cm.Kind != "ConfigMap" {
+		return nil, fmt.Errorf("expected a ConfigMap, got kind %q", cm.Kind)
+	}
+	return &cm, nil
+}
+
+// ConvertTCPServices converts the tcp-services ConfigMap of ingress-nginx,
+// which exposes Services on raw TCP ports of the controller, to one
+// TCPRoute per port in the namespace of its Service. The routes attach to
+// a listener named tcp-<port> of the configured Gateway, by default
+// gateway-nginx in the ConfigMap namespace; with EmitGateway that Gateway is
+// generated with those listeners, otherwise the listeners to add are
+// reported. It resets Diagnostics like Convert does.
+func (c *Converter) ConvertTCPServices(ctx context.Context, cm *corev1.ConfigMap) ([]interface{}, error) {
+	run := c.newRun()
+	resources, err := run.convertServices(ctx, cm, tcpServices)
+	c.publish(run)
+	return resources, err
+}
+
+// ConvertUDPServices converts the udp-services ConfigMap of ingress-nginx
+// to UDPRoutes attached to udp-<port> listeners, like ConvertTCPServices
+func (c *Converter) ConvertUDPServices(ctx context.Context, cm *corev1.ConfigMap) ([]interface{}, error) {
+	run := c.newRun()
+	resources, err := run.convertServices(ctx, cm, udpServices)
+	c.publish(run)
+	return resources, err
+}
+
+// convertServices converts a services ConfigMap on a converter returned by
+// newRun
+func (c *Converter) convertServices(ctx context.Context, cm *corev1.ConfigMap, p servicesProtocol) ([]interface{}, error) {
+	source := configMapSource(cm)
+	if !c.supports(p.feature) {
+		c.unsupportedFeature(source, "", p.feature, p.name+"-services")
+		return nil, nil
+	}
+
+	entries, err := c.servicesEntries(ctx, source, cm)
+	if err != nil {
+		return nil, err
+	}
+	gateway := c.controllerGateway(cm)
+
+	var routes []interface{}
+	var listeners []gatewayv1.Listener
+	shared := false
+	for _, e := range entries {
+		if e.proxyDecode || e.proxyEncode {
+			c.addDiagnostic(source, "", SeverityWarning,
+				"port %d uses the PROXY protocol, which Gateway API does not configure; enable it in the implementation, e.g. an Envoy Gateway ClientTrafficPolicy or BackendTrafficPolicy", e.port)
+		}
+		if e.namespace != gateway.Namespace {
+			shared = true
+		}
+
+		listener := gatewayv1.SectionName(fmt.Sprintf("%s-%d", p.name, e.port))
+		listeners = append(listeners, gatewayv1.Listener{
+			Name:     listener,
+			Port:     e.port,
+			Protocol: p.protocol,
+			AllowedRoutes: &gatewayv1.AllowedRoutes{
+				Kinds: []gatewayv1.RouteGroupKind{{Kind: gatewayv1.Kind(p.kind)}},
+			},
+		})
+
+		parentRef := gatewayv1.ParentReference{
+			Name:        gatewayv1.ObjectName(gateway.Name),
+			SectionName: &listener,
+		}
+		if e.namespace != gateway.Namespace {
+			namespace := gatewayv1.Namespace(gateway.Namespace)
+			parentRef.Namespace = &namespace
+		}
+		port := gatewayv1.PortNumber(e.servicePort.Number)
+		meta := metav1.ObjectMeta{
+			Name:      sanitizeName(fmt.Sprintf("%s-%s-%d", e.service, p.name, e.port)),
+			Namespace: e.namespace,
+		}
+		common := gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{parentRef}}
+		backendRefs := []gatewayv1.BackendRef{
+			{
+				BackendObjectReference: gatewayv1.BackendObjectReference{
+					Name: gatewayv1.ObjectName(e.service),
+					Port: &port,
+				},
+			},
+		}
+		typeMeta := metav1.TypeMeta{APIVersion: gatewayv1alpha2.GroupVersion.String(), Kind: p.kind}
+
+		switch p.kind {
+		case "UDPRoute":
+			routes = append(routes, &gatewayv1alpha2.UDPRoute{
+				TypeMeta:   typeMeta,
+				ObjectMeta: meta,
+				Spec: gatewayv1alpha2.UDPRouteSpec{
+					CommonRouteSpec: common,
+					Rules:           []gatewayv1alpha2.UDPRouteRule{{BackendRefs: backendRefs}},
+				},
+			})
+		default:
+			routes = append(routes, &gatewayv1alpha2.TCPRoute{
+				TypeMeta:   typeMeta,
+				ObjectMeta: meta,
+				Spec: gatewayv1alpha2.TCPRouteSpec{
+					CommonRouteSpec: common,
+					Rules:           []gatewayv1alpha2.TCPRouteRule{{BackendRefs: backendRefs}},
+				},
+			})
+		}
+	}
+	if len(routes) == 0 {
+		return nil, nil
+	}
+
+	if !c.opts.EmitGateway {
+		for _, l := range listeners {
+			c.addDiagnostic(source, "", SeverityInfo,
+				"Gateway %s/%s needs listener %s: port %d, protocol %s, allowedRoutes kinds %s%s",
+				gateway.Namespace, gateway.Name, l.Name, l.Port, l.Protocol, p.kind, allNamespacesHint(shared))
+		}
+		return routes, nil
+	}
+
+	b := c.newGatewayBuilder(gateway.Namespace, gateway.Name)
+	b.gateway.Spec.Listeners = listeners
+	if shared {
+		b.allowAllNamespaces()
+	}
+	resources := append([]interface{}{b.gateway}, routes...)
+	c.setAPIVersion(resources)
+	return resources, nil
+}
+
+// servicesEntries parses the entries of a services ConfigMap, sorted by
+// port. Invalid entries are reported and skipped; named Service ports are
+// resolved through the Service lookup.
+func (c *Converter) servicesEntries(ctx context.Context, source *networkingv1.Ingress, cm *corev1.ConfigMap) ([]servicesEntry, error) {
+	var entries []servicesEntry
+	for key, value := range cm.Data {
+		port, err := strconv.Atoi(key)
+		if err != nil || port < 1 || port > 65535 {
+			c.addDiagnostic(source, "", SeverityWarning, "invalid port %q, entry skipped", key)
+			continue
+		}
+		e, ok := parseServicesEntry(value)
+		if !ok {
+			c.addDiagnostic(source, "", SeverityWarning,
+				"port %d: invalid value %q, want <namespace>/<service>:<port>[:PROXY][:PROXY]; entry skipped", port, value)
+			continue
+		}
+		e.port = gatewayv1.PortNumber(port)
+
+		if e.servicePort.Name != "" {
+			number, err := c.serviceEntryPort(ctx, source, e)
+			if err != nil {
+				return nil, err
+			}
+			if number == 0 {
+				continue
+			}
+			e.servicePort = networkingv1.ServiceBackendPort{Number: number}
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].port < entries[j].port })
+	return entries, nil
+}
+
+// parseServicesEntry parses <namespace>/<service>:<port>[:PROXY][:PROXY],
+// where the first PROXY decodes the PROXY protocol from clients and the
+// second sends it to the backend
+func parseServicesEntry(value string) (servicesEntry, bool) {
+	fields := strings.Split(strings.TrimSpace(value), ":")
+	if len(fields) < 2 || len(fields) > 4 {
+		return servicesEntry{}, false
+	}
+	namespace, service, ok := strings.Cut(fields[0], "/")
+	if !ok || namespace == "" || service == "" || fields[1] == "" {
+		return servicesEntry{}, false
+	}
+
+	e := servicesEntry{namespace: namespace, service: service}
+	if number, err := strconv.Atoi(fields[1]); err == nil {
+		e.servicePort.Number = int32(number)
+	} else {
+		e.servicePort.Name = fields[1]
+	}
+	if len(fields) > 2 {
+		e.proxyDecode = strings.EqualFold(fields[2], "PROXY")
+	}
+	if len(fields) > 3 {
+		e.proxyEncode = strings.EqualFold(fields[3], "PROXY")
+	}
+	return e, true
+}
+
+// serviceEntryPort resolves the named Service port of an entry, 0 when the
+// Service or port cannot be found
+func (c *Converter) serviceEntryPort(ctx context.Context, source *networkingv1.Ingress, e servicesEntry) (int32, error) {
+	if c.services == nil {
+		c.addDiagnostic(source, "", SeverityError,
+			"port %d: named port %q of Service %s/%s needs the Service to resolve; convert from the cluster or use a port number (entry skipped)",
+			e.port, e.servicePort.Name, e.namespace, e.service)
+		return 0, nil
+	}
+	svc, err := c.services.GetService(ctx, e.namespace, e.service)
+	switch {
+	case apierrors.IsNotFound(err), apierrors.IsForbidden(err):
+		svc = nil
+	case err != nil:
+		return 0, fmt.Errorf("failed to get service %s/%s: %w", e.namespace, e.service, err)
+	}
+	port := servicePort(svc, e.servicePort)
+	if port == nil {
+		c.addDiagnostic(source, "", SeverityError,
+			"port %d: named port %q not found on Service %s/%s (entry skipped)", e.port, e.servicePort.Name, e.namespace, e.service)
+		return 0, nil
+	}
+	return port.Port, nil
+}
+
+// controllerGateway returns the Gateway taking over from the ingress-nginx
+// controller configured by cm: the configured one, else gateway-nginx in
+// the ConfigMap namespace, next to the controller
+func (c *Converter) controllerGateway(cm *corev1.ConfigMap) ParentRef {
+	ref := ParentRef{Name: c.opts.GatewayName, Namespace: c.opts.GatewayNamespace}
+	if ref.Name == "" {
+		ref.Name = "gateway-nginx"
+	}
+	if ref.Namespace == "" {
+		ref.Namespace = cm.Namespace
+	}
+	return ref
+}
+
+// configMapSource stands in for an Ingress in the diagnostics of a ConfigMap
+func configMapSource(cm *corev1.ConfigMap) *networkingv1.Ingress {
+	return &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: cm.Namespace, Name: cm.Name}}
+}
+
+// allNamespacesHint completes a listener description when routes attach
+// from other namespaces
+func allNamespacesHint(shared bool) string {
+	if !shared {
+		return ""
+	}
+	return ", allowedRoutes namespaces from All"
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const annotationConfigurationSnippet = "nginx.ingress.kubernetes.io/configuration-snippet"
+
+// snippetResult holds what could be translated from an nginx snippet
+type snippetResult struct {
+	requestHeaders  *gatewayv1.HTTPHeaderFilter
+	responseHeaders *gatewayv1.HTTPHeaderFilter
+	redirect        *gatewayv1.HTTPRequestRedirectFilter
+	unrecognized    []string
+}
+
+// filters returns the Gateway API filters for the translated directives
+func (r *snippetResult) filters() []gatewayv1.HTTPRouteFilter {
+	var filters []gatewayv1.HTTPRouteFilter
+
+	if r.requestHeaders != nil {
+		filters = append(filters, gatewayv1.HTTPRouteFilter{
+			Type:                  gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+			RequestHeaderModifier: r.requestHeaders,
+		})
+	}
+	if r.responseHeaders != nil {
+		filters = append(filters, gatewayv1.HTTPRouteFilter{
+			Type:                   gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+			ResponseHeaderModifier: r.responseHeaders,
+		})
+	}
+	if r.redirect != nil {
+		filters = append(filters, gatewayv1.HTTPRouteFilter{
+			Type:            gatewayv1.HTTPRouteFilterRequestRedirect,
+			RequestRedirect: r.redirect,
+		})
+	}
+
+	return filters
+}
+
+// parseSnippet translates the common directives of a configuration-snippet:
+// proxy_set_header, more_set_input_headers, add_header, more_set_headers,
+// more_clear_headers and return 301/302. Everything else, including values
+// that use nginx variables, is reported as unrecognized.
+func parseSnippet(snippet string) *snippetResult {
+	result := &snippetResult{}
+
+	for _, stmt := range splitDirectives(snippet) {
+		fields := splitFields(stmt)
+		if len(fields) == 0 {
+			continue
+		}
+
+		var ok bool
+		switch fields[0] {
+		case "proxy_set_header":
+			ok = len(fields) == 3 && result.setHeader(&result.requestHeaders, fields[1], fields[2], false)
+		case "more_set_input_headers":
+			ok = len(fields) == 2 && result.setHeaderPair(&result.requestHeaders, fields[1])
+		case "add_header":
+			ok = (len(fields) == 3 || len(fields) == 4 && fields[3] == "always") &&
+				result.setHeader(&result.responseHeaders, fields[1], fields[2], true)
+		case "more_set_headers":
+			ok = len(fields) == 2 && result.setHeaderPair(&result.responseHeaders, fields[1])
+		case "more_clear_headers":
+			ok = len(fields) >= 2
+			for _, name := range fields[1:] {
+				if strings.ContainsAny(name, "*$") {
+					ok = false
+					break
+				}
+			}
+			if ok {
+				if result.responseHeaders == nil {
+					result.responseHeaders = &gatewayv1.HTTPHeaderFilter{}
+				}
+				result.responseHeaders.Remove = append(result.responseHeaders.Remove, fields[1:]...)
+			}
+		case "return":
+			ok = len(fields) == 3 && result.redirect == nil && result.setRedirect(fields[1], fields[2])
+		}
+
+		if !ok {
+			result.unrecognized = append(result.unrecognized, stmt)
+		}
+	}
+
+	return result
+}
+
+// setHeader records a header modification, refusing values with nginx variables
+func (r *snippetResult) setHeader(filter **gatewayv1.HTTPHeaderFilter, name, value string, add bool) bool {
+	if strings.Contains(name, "$") || strings.Contains(value, "$") {
+		return false
+	}
+	if *filter == nil {
+		*filter = &gatewayv1.HTTPHeaderFilter{}
+	}
+
+	header := gatewayv1.HTTPHeader{Name: gatewayv1.HTTPHeaderName(name), Value: value}
+	if add {
+		(*filter).Add = append((*filter).Add, header)
+	} else {
+		(*filter).Set = append((*filter).Set, header)
+	}
+	return true
+}
+
+// setHeaderPair records a "Name: value" header set from the headers-more module
+func (r *snippetResult) setHeaderPair(filter **gatewayv1.HTTPHeaderFilter, pair string) bool {
+	name, value, found := strings.Cut(pair, ":")
+	if !found {
+		return false
+	}
+	return r.setHeader(filter, strings.TrimSpace(name), strings.TrimSpace(value), false)
+}
+
+// setRedirect records a `return <code> <url>` redirect
+func (r *snippetResult) setRedirect(code, target string) bool {
+	statusCode, err := strconv.Atoi(code)
+	if err != nil || (statusCode != 301 && statusCode != 302) {
+		return false
+	}
+
+	redirect := redirectFilter(statusCode, target)
+	if redirect == nil {
+		return false
+	}
+	r.redirect = redirect
+	return true
+}
+
+// redirectFilter converts a redirect target URL to a RequestRedirect filter,
+// returning nil for targets the filter cannot express
+func redirectFilter(statusCode int, target string) *gatewayv1.HTTPRequestRedirectFilter {
+	// $request_uri keeps the original path, which is the redirect filter default
+	target = strings.TrimSuffix(target, "$request_uri")
+	if strings.Contains(target, "$") {
+		return nil
+	}
+
+	u, err := url.Parse(target)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+
+	redirect := &gatewayv1.HTTPRequestRedirectFilter{StatusCode: &statusCode}
+	if u.Scheme != "" {
+		scheme := u.Scheme
+		redirect.Scheme = &scheme
+	}
+	hostname := gatewayv1.PreciseHostname(u.Hostname())
+	redirect.Hostname = &hostname
+	if p := u.Port(); p != "" {
+		portNum, err := strconv.Atoi(p)
+		if err != nil {
+			return nil
+		}
+		port := gatewayv1.PortNumber(portNum)
+		redirect.Port = &port
+	}
+	if u.Path != "" && u.Path != "/" {
+		path := u.Path
+		redirect.Path = &gatewayv1.HTTPPathModifier{
+			Type:            gatewayv1.FullPathHTTPPathModifier,
+			ReplaceFullPath: &path,
+		}
+	}
+	return redirect
+}
+
+// splitDirectives splits a snippet into semicolon-terminated statements,
+// ignoring comments and semicolons inside quotes
+func splitDirectives(snippet string) []string {
+	var stmts []string
+	var current strings.Builder
+	var quote rune
+
+	for _, line := range strings.Split(snippet, "\n") {
+		if idx := strings.Index(line, "#"); idx >= 0 && !strings.ContainsAny(line[:idx], `"'`) {
+			line = line[:idx]
+		}
+		for _, ch := range line {
+			switch {
+			case quote != 0 && ch == quote:
+				quote = 0
+			case quote == 0 && (ch == '"' || ch == '\''):
+				quote = ch
+			case quote == 0 && ch == ';':
+				if stmt := strings.TrimSpace(current.String()); stmt != "" {
+					stmts = append(stmts, stmt)
+				}
+				current.Reset()
+				continue
+			}
+			current.WriteRune(ch)
+		}
+		current.WriteRune(' ')
+	}
+	if stmt := strings.TrimSpace(current.String()); stmt != "" {
+		stmts = append(stmts, stmt)
+	}
+
+	return stmts
+}
+
+// splitFields splits a directive into its arguments, unquoting quoted values
+func splitFields(stmt string) []string {
+	var fields []string
+	var current strings.Builder
+	var quote rune
+	inField := false
+
+	for _, ch := range stmt {
+		switch {
+		case quote != 0 && ch == quote:
+			quote = 0
+		case quote == 0 && (ch == '"' || ch == '\''):
+			quote = ch
+			inField = true
+		case quote == 0 && (ch == ' ' || ch == '\t'):
+			if inField {
+				fields = append(fields, current.String())
+				current.Reset()
+				inField = false
+			}
+		default:
+			current.WriteRune(ch)
+			inField = true
+		}
+	}
+	if inField {
+		fields = append(fields, current.String())
+	}
+
+	return fields
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"net"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	annotationWhitelistSourceRange = "nginx.ingress.kubernetes.io/whitelist-source-range"
+	annotationAllowlistSourceRange = "nginx.ingress.kubernetes.io/allowlist-source-range"
+	annotationDenylistSourceRange  = "nginx.ingress.kubernetes.io/denylist-source-range"
+	annotationLimitWhitelist       = "nginx.ingress.kubernetes.io/limit-whitelist"
+	annotationLimitAllowlist       = "nginx.ingress.kubernetes.io/limit-allowlist"
+)
+
+var (
+	// allowSourceRangeAnnotations list the clients allowed to connect
+	allowSourceRangeAnnotations = []string{
+		annotationWhitelistSourceRange, annotationAllowlistSourceRange,
+		annotationHAProxyAllowList, annotationHAProxyWhitelist,
+		annotationHAProxyIngressWhitelist, annotationHAProxyIngressAllowlist,
+	}
+
+	// denySourceRangeAnnotations list the clients refused
+	denySourceRangeAnnotations = []string{
+		annotationDenylistSourceRange,
+		annotationHAProxyDenyList, annotationHAProxyBlacklist,
+		annotationHAProxyIngressDenylist,
+	}
+)
+
+// sourceRangeAnnotations returns the annotations allowing or denying clients
+func sourceRangeAnnotations() []string {
+	return append(append([]string(nil), allowSourceRangeAnnotations...), denySourceRangeAnnotations...)
+}
+
+// sourceRanges are the client address ranges an Ingress allows, denies and
+// exempts from rate limiting
+type sourceRanges struct {
+	allow  []*net.IPNet
+	deny   []*net.IPNet
+	exempt []*net.IPNet
+}
+
+// parseSourceRanges reads the allow-list, deny-list and rate-limit exemption
+// annotations and records diagnostics for ranges that contradict each other
+func (c *Converter) parseSourceRanges(ing *networkingv1.Ingress) sourceRanges {
+	ranges := sourceRanges{
+		allow:  c.parseCIDRs(ing, allowSourceRangeAnnotations...),
+		deny:   c.parseCIDRs(ing, denySourceRangeAnnotations...),
+		exempt: c.parseCIDRs(ing, annotationLimitWhitelist, annotationLimitAllowlist),
+	}
+
+	denyAnnotation, _, _ := firstAnnotation(ing, denySourceRangeAnnotations)
+	for _, denied := range ranges.deny {
+		for _, allowed := range ranges.allow {
+			if cidrsOverlap(denied, allowed) {
+				c.addDiagnostic(ing, denyAnnotation, SeverityWarning,
+					"denied range %s overlaps allowed range %s; the deny rule takes precedence", denied, allowed)
+			}
+		}
+	}
+
+	if len(ranges.exempt) == 0 {
+		return ranges
+	}
+	exemptAnnotation := annotationLimitWhitelist
+	if _, ok := ing.Annotations[exemptAnnotation]; !ok {
+		exemptAnnotation = annotationLimitAllowlist
+	}
+	_, rps := ing.Annotations[annotationLimitRPS]
+	_, rpm := ing.Annotations[annotationLimitRPM]
+	if !rps && !rpm {
+		c.addDiagnostic(ing, exemptAnnotation, SeverityWarning, "rate limit exemption is ignored without limit-rps or limit-rpm")
+		ranges.exempt = nil
+		return ranges
+	}
+	for _, exempt := range ranges.exempt {
+		if len(ranges.allow) > 0 && !overlapsAny(exempt, ranges.allow) {
+			c.addDiagnostic(ing, exemptAnnotation, SeverityWarning,
+				"rate limit exemption for %s is outside the allowed source ranges and never applies", exempt)
+		}
+		for _, denied := range ranges.deny {
+			if cidrsOverlap(exempt, denied) {
+				c.addDiagnostic(ing, exemptAnnotation, SeverityWarning,
+					"rate limit exemption for %s overlaps denied range %s", exempt, denied)
+			}
+		}
+	}
+	return ranges
+}
+
+// parseCIDRs parses the comma-separated ranges of the given annotations.
+// Bare addresses are single-host ranges; invalid entries are dropped.
+func (c *Converter) parseCIDRs(ing *networkingv1.Ingress, annotations ...string) []*net.IPNet {
+	var cidrs []*net.IPNet
+	for _, annotation := range annotations {
+		value, exists := ing.Annotations[annotation]
+		if !exists {
+			continue
+		}
+		for _, entry := range strings.Split(value, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			cidrEntry := entry
+			if ip := net.ParseIP(entry); ip != nil {
+				if ip.To4() != nil {
+					cidrEntry += "/32"
+				} else {
+					cidrEntry += "/128"
+				}
+			}
+			_, cidr, err := net.ParseCIDR(cidrEntry)
+			if err != nil {
+				c.addDiagnostic(ing, annotation, SeverityWarning, "invalid source range %q, ignored", entry)
+				continue
+			}
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	return cidrs
+}
+
+// allExempt reports whether every allowed client is exempt from rate
+// limiting, which makes the rate limit a no-op
+func (r sourceRanges) allExempt() bool {
+	if len(r.allow) == 0 || len(r.exempt) == 0 {
+		return false
+	}
+	for _, allowed := range r.allow {
+		if !containedIn(allowed, r.exempt) {
+			return false
+		}
+	}
+	return true
+}
+
+// cidrsOverlap reports whether two ranges share any address
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// overlapsAny reports whether cidr shares an address with any of ranges
+func overlapsAny(cidr *net.IPNet, ranges []*net.IPNet) bool {
+	for _, r := range ranges {
+		if cidrsOverlap(cidr, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// containedIn reports whether inner lies entirely within one of outer
+func containedIn(inner *net.IPNet, outer []*net.IPNet) bool {
+	innerOnes, innerBits := inner.Mask.Size()
+	for _, o := range outer {
+		ones, bits := o.Mask.Size()
+		if bits == innerBits && ones <= innerOnes && o.Contains(inner.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceRangeAuthorization converts allow and deny lists into an Envoy
+// Gateway SecurityPolicy authorization section, or records a manual-policy
+// diagnostic for targets without a conversion
+func (c *Converter) sourceRangeAuthorization(ing *networkingv1.Ingress, ranges sourceRanges) map[string]interface{} {
+	if len(ranges.allow) == 0 && len(ranges.deny) == 0 {
+		return nil
+	}
+
+	switch c.opts.Target {
+	case TargetEnvoyGateway:
+	case TargetIstio:
+		// converted by istioSourceRanges
+		return nil
+	default:
+		c.manualSourceRanges(ing, "no core Gateway API equivalent")
+		return nil
+	}
+
+	// Envoy Gateway applies the first matching rule, so deny rules go first
+	var rules []interface{}
+	if len(ranges.deny) > 0 {
+		rules = append(rules, map[string]interface{}{
+			"action":    "Deny",
+			"principal": map[string]interface{}{"clientCIDRs": cidrStrings(ranges.deny)},
+		})
+	}
+	defaultAction := "Allow"
+	if len(ranges.allow) > 0 {
+		rules = append(rules, map[string]interface{}{
+			"action":    "Allow",
+			"principal": map[string]interface{}{"clientCIDRs": cidrStrings(ranges.allow)},
+		})
+		defaultAction = "Deny"
+	}
+
+	return map[string]interface{}{
+		"defaultAction": defaultAction,
+		"rules":         rules,
+	}
+}
+
+// istioSourceRanges converts allow and deny lists into a DENY
+// AuthorizationPolicy on the Gateway, limited to the Ingress hosts so the
+// other routes of the Gateway are not affected
+func (c *Converter) istioSourceRanges(ing *networkingv1.Ingress, ranges sourceRanges) *unstructured.Unstructured {
+	if c.opts.Target != TargetIstio || (len(ranges.allow) == 0 && len(ranges.deny) == 0) {
+		return nil
+	}
+	to := c.istioHostOperation(ing)
+	if to == nil {
+		c.manualSourceRanges(ing, "an AuthorizationPolicy on the Gateway cannot be limited to an Ingress without hosts")
+		return nil
+	}
+
+	var rules []interface{}
+	if len(ranges.deny) > 0 {
+		rules = append(rules, map[string]interface{}{
+			"from": []interface{}{
+				map[string]interface{}{"source": map[string]interface{}{"remoteIpBlocks": cidrStrings(ranges.deny)}},
+			},
+			"to": to,
+		})
+	}
+	if len(ranges.allow) > 0 {
+		rules = append(rules, map[string]interface{}{
+			"from": []interface{}{
+				map[string]interface{}{"source": map[string]interface{}{"notRemoteIpBlocks": cidrStrings(ranges.allow)}},
+			},
+			"to": to,
+		})
+	}
+
+	c.addDiagnostic(ing, "", SeverityInfo,
+		"client source ranges converted to an Istio AuthorizationPolicy; set numTrustedProxies when clients connect through a load balancer")
+	return c.istioAuthorizationPolicy(ing, "ip-access", map[string]interface{}{
+		"action": "DENY",
+		"rules":  rules,
+	})
+}
+
+// manualSourceRanges records that the source range annotations need a
+// manual authorization policy, and why
+func (c *Converter) manualSourceRanges(ing *networkingv1.Ingress, reason string) {
+	for _, annotation := range sourceRangeAnnotations() {
+		if value, ok := ing.Annotations[annotation]; ok {
+			c.addDiagnostic(ing, annotation, SeverityWarning,
+				"client source ranges %q need a manual authorization policy (%s); select --target=envoy-gateway or istio to generate one", value, reason)
+		}
+	}
+}
+
+// cidrStrings formats ranges for a policy
+func cidrStrings(cidrs []*net.IPNet) []interface{} {
+	out := make([]interface{}, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		out = append(out, cidr.String())
+	}
+	return out
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"github.com/mayens/ingress-to-gateway/pkg/experimental"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// Target implementations
+const (
+	TargetNginxGatewayFabric = "nginx-gateway-fabric"
+	TargetEnvoyGateway       = "envoy-gateway"
+	TargetIstio              = "istio"
+	TargetCilium             = "cilium"
+	TargetKong               = "kong"
+	TargetTraefik            = "traefik"
+	TargetGKE                = "gke"
+)
+
+// Targets lists the implementations the converter has a profile for
+var Targets = []string{TargetNginxGatewayFabric, TargetEnvoyGateway, TargetIstio, TargetCilium, TargetKong, TargetTraefik, TargetGKE}
+
+// Features beyond the HTTPRoute core that implementations differ on
+const (
+	FeatureGRPCRoute        = experimental.GRPCRoute
+	FeatureTLSRoute         = experimental.TLSRoute
+	FeatureTCPRoute         = experimental.TCPRoute
+	FeatureUDPRoute         = experimental.UDPRoute
+	FeatureBackendTLSPolicy = experimental.BackendTLSPolicy
+	FeatureBackendLBPolicy  = experimental.BackendLBPolicy
+	FeatureRegexPath        = "RegularExpression path matches"
+)
+
+// Profile describes how the converter targets a Gateway implementation
+type Profile struct {
+	// GatewayClass is the GatewayClass the implementation installs by default
+	GatewayClass string
+	// ControllerName is the controllerName of that GatewayClass, empty when
+	// the implementation cannot be bootstrapped
+	ControllerName string
+	// Unsupported lists the features the implementation does not implement
+	Unsupported []string
+	// Policies lists the implementation policies the converter generates
+	Policies []PolicyCapability
+	// ParametersGroup and ParametersKind name the resource the
+	// implementation's GatewayClasses take as parametersRef, if any
+	ParametersGroup string
+	ParametersKind  string
+}
+
+// PolicyCapability is an implementation policy kind and the nginx
+// annotations converted to it
+type PolicyCapability struct {
+	Kind        string   `json:"kind"`
+	CRD         string   `json:"crd"` // <plural>.<group>
+	Annotations []string `json:"annotations"`
+}
+
+// profiles holds the target profiles. Policy CRDs are selected by target
+// where each annotation is converted.
+var profiles = map[string]Profile{
+	TargetNginxGatewayFabric: {
+		GatewayClass:    "nginx",
+		ControllerName:  "gateway.nginx.org/nginx-gateway-controller",
+		ParametersGroup: "gateway.nginx.org",
+		ParametersKind:  "NginxProxy",
+		Unsupported:     []string{FeatureTLSRoute, FeatureTCPRoute, FeatureUDPRoute, FeatureBackendLBPolicy, FeatureRegexPath},
+		Policies: []PolicyCapability{
+			{Kind: "ClientSettingsPolicy", CRD: "clientsettingspolicies.gateway.nginx.org", Annotations: []string{annotationProxyBodySize}},
+			{Kind: "ObservabilityPolicy", CRD: "observabilitypolicies.gateway.nginx.org",
+				Annotations: []string{annotationEnableOpenTelemetry, annotationOpenTelemetryTrustSpan, annotationOpenTelemetryOperationName}},
+		},
+	},
+	TargetEnvoyGateway: {
+		GatewayClass:    "eg",
+		ControllerName:  "gateway.envoyproxy.io/gatewayclass-controller",
+		ParametersGroup: "gateway.envoyproxy.io",
+		ParametersKind:  "EnvoyProxy",
+		Unsupported:     []string{FeatureBackendLBPolicy},
+		Policies: []PolicyCapability{
+			{Kind: "BackendTrafficPolicy", CRD: "backendtrafficpolicies.gateway.envoyproxy.io",
+				Annotations: append([]string{annotationLimitRPS, annotationLimitRPM, annotationConnectTimeout, annotationProxyNextUpstream,
+					annotationProxyNextUpstreamTries, annotationLoadBalance, annotationUpstreamHashBy}, albHealthCheckAnnotations...)},
+			{Kind: "SecurityPolicy", CRD: "securitypolicies.gateway.envoyproxy.io",
+				Annotations: append([]string{annotationAuthURL, annotationEnableCORS}, sourceRangeAnnotations()...)},
+			{Kind: "ClientTrafficPolicy", CRD: "clienttrafficpolicies.gateway.envoyproxy.io",
+				Annotations: []string{annotationAuthTLSSecret, annotationAuthTLSVerifyClient, annotationAuthTLSPassCert}},
+		},
+	},
+	TargetIstio: {
+		GatewayClass:   "istio",
+		ControllerName: "istio.io/gateway-controller",
+		ParametersKind: "ConfigMap",
+		Unsupported:    []string{FeatureUDPRoute, FeatureBackendLBPolicy},
+		Policies: []PolicyCapability{
+			{Kind: "AuthorizationPolicy", CRD: "authorizationpolicies.security.istio.io",
+				Annotations: append([]string{annotationAuthURL}, sourceRangeAnnotations()...)},
+			{Kind: "DestinationRule", CRD: "destinationrules.networking.istio.io",
+				Annotations: []string{annotationLoadBalance, annotationUpstreamHashBy, annotationConnectTimeout}},
+		},
+	},
+	// Cilium has no policy CRDs for routes; CiliumGatewayClassConfig only
+	// configures the Service of the Gateway
+	TargetCilium: {
+		GatewayClass:    "cilium",
+		Unsupported:     []string{FeatureTCPRoute, FeatureUDPRoute, FeatureBackendTLSPolicy, FeatureBackendLBPolicy},
+		ParametersGroup: "cilium.io",
+		ParametersKind:  "CiliumGatewayClassConfig",
+	},
+	TargetKong: {
+		GatewayClass:    "kong",
+		Unsupported:     []string{FeatureBackendTLSPolicy, FeatureBackendLBPolicy},
+		ParametersGroup: "gateway-operator.konghq.com",
+		ParametersKind:  "GatewayConfiguration",
+		Policies: []PolicyCapability{
+			{Kind: "KongPlugin", CRD: "kongplugins.configuration.konghq.com", Annotations: []string{annotationKongPlugins, annotationEnableCORS}},
+		},
+	},
+	TargetTraefik: {
+		GatewayClass: "traefik",
+		Unsupported:  []string{FeatureUDPRoute, FeatureBackendLBPolicy, FeatureRegexPath},
+	},
+	TargetGKE: {
+		GatewayClass: "gke-l7-global-external-managed",
+		Unsupported:  []string{FeatureTLSRoute, FeatureTCPRoute, FeatureUDPRoute, FeatureBackendTLSPolicy, FeatureBackendLBPolicy, FeatureRegexPath},
+		Policies: []PolicyCapability{
+			{Kind: "GCPBackendPolicy", CRD: "gcpbackendpolicies.networking.gke.io"},
+			{Kind: "GCPGatewayPolicy", CRD: "gcpgatewaypolicies.networking.gke.io", Annotations: []string{annotationGKEFrontendConfig}},
+			{Kind: "HealthCheckPolicy", CRD: "healthcheckpolicies.networking.gke.io", Annotations: albHealthCheckAnnotations},
+		},
+	},
+}
+
+// TargetProfile returns the profile of a target implementation
+func TargetProfile(target string) (Profile, bool) {
+	p, ok := profiles[target]
+	return p, ok
+}
+
+// Supports reports whether the implementation implements feature
+func (p Profile) Supports(feature string) bool {
+	for _, f := range p.Unsupported {
+		if f == feature {
+			return false
+		}
+	}
+	return true
+}
+
+// supports reports whether the target implements feature and the CRDs of
+// the channel define it. Without a target every feature of the channel is
+// emitted.
+func (c *Converter) supports(feature string) bool {
+	if !c.inChannel(feature) {
+		return false
+	}
+	p, ok := TargetProfile(c.opts.Target)
+	return !ok || p.Supports(feature)
+}
+
+// gatewayClass returns the GatewayClass for generated Gateways: the
+// configured one, else the target's default, else nginx
+func (c *Converter) gatewayClass() string {
+	if c.opts.GatewayClass != "" {
+		return c.opts.GatewayClass
+	}
+	if p, ok := TargetProfile(c.opts.Target); ok {
+		return p.GatewayClass
+	}
+	return "nginx"
+}
+
+// checkTargetSupport warns about generated resources that use features the
+// target does not implement, so they are not applied unnoticed
+func (c *Converter) checkTargetSupport(ing *networkingv1.Ingress, resources []interface{}) {
+	if _, ok := TargetProfile(c.opts.Target); !ok {
+		return
+	}
+
+	for _, res := range resources {
+		var feature, name string
+		switch r := res.(type) {
+		case *gatewayv1alpha2.TLSRoute:
+			feature, name = FeatureTLSRoute, r.Name
+		case *gatewayv1alpha2.TCPRoute:
+			feature, name = FeatureTCPRoute, r.Name
+		case *gatewayv1alpha2.UDPRoute:
+			feature, name = FeatureUDPRoute, r.Name
+		case *gatewayv1alpha2.GRPCRoute:
+			feature, name = FeatureGRPCRoute, r.Name
+		case *gatewayv1alpha2.BackendTLSPolicy:
+			feature, name = FeatureBackendTLSPolicy, r.Name
+		case *unstructured.Unstructured:
+			if r.GetKind() == "BackendLBPolicy" {
+				feature, name = FeatureBackendLBPolicy, r.GetName()
+			}
+		case *gatewayv1.HTTPRoute:
+			if hasRegexPath(r) {
+				feature, name = FeatureRegexPath, r.Name
+			}
+		}
+		if feature != "" && !c.supports(feature) {
+			c.addDiagnostic(ing, "", SeverityWarning,
+				"%s uses %s, which %s does not support; it will not be accepted", name, feature, c.opts.Target)
+		}
+	}
+}
+
+// hasRegexPath reports whether a route matches any path by regex
+func hasRegexPath(hr *gatewayv1.HTTPRoute) bool {
+	for _, rule := range hr.Spec.Rules {
+		for _, match := range rule.Matches {
+			if match.Path != nil && match.Path.Type != nil && *match.Path.Type == gatewayv1.PathMatchRegularExpression {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// unsupportedFeature records that a translation was left out because the
+// channel or the target lacks feature, or the experimental gate holds it back
+func (c *Converter) unsupportedFeature(ing *networkingv1.Ingress, annotation, feature, what string) {
+	if !c.inChannel(feature) {
+		c.addDiagnostic(ing, annotation, SeverityWarning, "%s not converted: %s", what, c.channelGap(feature))
+		return
+	}
+	c.addDiagnostic(ing, annotation, SeverityWarning,
+		"%s not converted: %s does not support %s; configure it on the implementation directly", what, c.opts.Target, feature)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	annotationProxyReadTimeout = "nginx.ingress.kubernetes.io/proxy-read-timeout"
+	annotationProxySendTimeout = "nginx.ingress.kubernetes.io/proxy-send-timeout"
+)
+
+// Timeout precedences for Ingresses with both proxy-read and proxy-send timeouts
+const (
+	TimeoutPrecedenceMax = "max" // the longer timeout, so no request that completes today times out (default)
+	TimeoutPrecedenceMin = "min" // the shorter timeout, to fail slow backends as early as nginx would
+)
+
+// TimeoutPrecedences lists the accepted timeout precedences
+var TimeoutPrecedences = []string{TimeoutPrecedenceMax, TimeoutPrecedenceMin}
+
+// maxDurationValue is the largest number a Gateway API duration component holds
+const maxDurationValue = 99999
+
+// extractTimeouts maps proxy-read-timeout and proxy-send-timeout to the
+// request and backendRequest timeouts. HTTPRoute has a single backend
+// timeout, so when both annotations are set the longer or shorter one wins
+// according to TimeoutPrecedence.
+func (c *Converter) extractTimeouts(ing *networkingv1.Ingress) *gatewayv1.HTTPRouteTimeouts {
+	if !c.inChannel(FeatureHTTPRouteTimeouts) {
+		annotations := []string{annotationProxyReadTimeout, annotationProxySendTimeout}
+		annotations = append(annotations, haproxyBackendTimeouts...)
+		for _, annotation := range append(annotations, haproxyRequestTimeouts...) {
+			if _, exists := ing.Annotations[annotation]; exists {
+				c.unsupportedFeature(ing, annotation, FeatureHTTPRouteTimeouts, "timeout")
+			}
+		}
+		return nil
+	}
+
+	read, hasRead := c.parseTimeout(ing, annotationProxyReadTimeout)
+	send, hasSend := c.parseTimeout(ing, annotationProxySendTimeout)
+
+	var timeout time.Duration
+	switch {
+	case hasRead && hasSend:
+		precedence := c.opts.TimeoutPrecedence
+		if precedence == "" {
+			precedence = TimeoutPrecedenceMax
+		}
+		timeout = read
+		if (precedence == TimeoutPrecedenceMax && send > read) || (precedence == TimeoutPrecedenceMin && send < read) {
+			timeout = send
+		}
+		if read != send {
+			c.addDiagnostic(ing, annotationProxySendTimeout, SeverityInfo,
+				"proxy-read-timeout (%s) and proxy-send-timeout (%s) differ; using the %s, %s, for the request and backendRequest timeouts",
+				formatDuration(read), formatDuration(send), precedence, formatDuration(timeout))
+		}
+	case hasRead:
+		timeout = read
+	case hasSend:
+		timeout = send
+	default:
+		return c.haproxyTimeouts(ing)
+	}
+
+	// Equal timeouts keep the backendRequest <= request constraint
+	request := formatDuration(timeout)
+	backendRequest := request
+	return &gatewayv1.HTTPRouteTimeouts{
+		Request:        &request,
+		BackendRequest: &backendRequest,
+	}
+}
+
+// parseTimeout reads a timeout annotation: plain seconds as nginx expects,
+// or a duration with units such as 90s or 1m30s
+func (c *Converter) parseTimeout(ing *networkingv1.Ingress, annotation string) (time.Duration, bool) {
+	value, exists := ing.Annotations[annotation]
+	if !exists {
+		return 0, false
+	}
+
+	value = strings.TrimSpace(value)
+	var timeout time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		timeout = time.Duration(seconds) * time.Second
+	} else if d, err := time.ParseDuration(value); err == nil {
+		timeout = d
+	} else {
+		c.addDiagnostic(ing, annotation, SeverityWarning, "invalid timeout %q, not converted", value)
+		return 0, false
+	}
+
+	if timeout < time.Millisecond {
+		c.addDiagnostic(ing, annotation, SeverityWarning, "timeout %q is not positive, not converted", value)
+		return 0, false
+	}
+	return timeout, true
+}
+
+// formatDuration formats d as a Gateway API duration (GEP-2257). Whole
+// seconds stay in seconds as nginx writes them; other values become
+// composite durations such as 1h30m or 1s500ms.
+func formatDuration(d time.Duration) gatewayv1.Duration {
+	d = d.Truncate(time.Millisecond)
+	if d%time.Second == 0 && d/time.Second <= maxDurationValue {
+		return gatewayv1.Duration(fmt.Sprintf("%ds", d/time.Second))
+	}
+
+	var b strings.Builder
+	for _, unit := range []struct {
+		suffix string
+		size   time.Duration
+	}{
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+		{"ms", time.Millisecond},
+	} {
+		if n := d / unit.size; n > 0 {
+			fmt.Fprintf(&b, "%d%s", n, unit.suffix)
+			d -= n * unit.size
+		}
+	}
+	return gatewayv1.Duration(b.String())
+}
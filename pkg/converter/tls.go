@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// TLSHostMismatches compares the rule hosts of an Ingress that uses TLS with
+// its spec.tls hosts. httpOnly lists rule hosts no TLS block covers, which
+// are served in plaintext; unused lists TLS hosts no rule serves. An
+// Ingress without spec.tls is plaintext by intent and reports nothing.
+func TLSHostMismatches(ing *networkingv1.Ingress) (httpOnly, unused []string) {
+	if len(ing.Spec.TLS) == 0 {
+		return nil, nil
+	}
+
+	var tlsHosts []string
+	for _, tls := range ing.Spec.TLS {
+		// A TLS block without hosts serves its certificate for every rule
+		if len(tls.Hosts) == 0 {
+			return nil, nil
+		}
+		tlsHosts = append(tlsHosts, tls.Hosts...)
+	}
+
+	seen := make(map[string]bool)
+	var ruleHosts []string
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host == "" || seen[rule.Host] {
+			continue
+		}
+		seen[rule.Host] = true
+		ruleHosts = append(ruleHosts, rule.Host)
+		if !hostCovered(rule.Host, tlsHosts) {
+			httpOnly = append(httpOnly, rule.Host)
+		}
+	}
+
+	seen = make(map[string]bool)
+	for _, host := range tlsHosts {
+		if seen[host] {
+			continue
+		}
+		seen[host] = true
+		used := false
+		for _, ruleHost := range ruleHosts {
+			if hostCovered(ruleHost, []string{host}) {
+				used = true
+				break
+			}
+		}
+		if !used {
+			unused = append(unused, host)
+		}
+	}
+
+	return httpOnly, unused
+}
+
+// hostCovered reports whether host matches one of patterns, where a
+// wildcard pattern *.example.com matches exactly one extra label
+func hostCovered(host string, patterns []string) bool {
+	host = strings.ToLower(host)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if pattern == host {
+			return true
+		}
+		if suffix, ok := strings.CutPrefix(pattern, "*"); ok && strings.HasSuffix(host, suffix) {
+			if label := strings.TrimSuffix(host, suffix); label != "" && !strings.Contains(label, ".") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkTLSHosts reports the hosts that will be HTTP-only after migration
+func (c *Converter) checkTLSHosts(ing *networkingv1.Ingress) {
+	httpOnly, unused := TLSHostMismatches(ing)
+	for _, host := range httpOnly {
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"host %q is not in spec.tls and will be HTTP-only after migration; add it to a TLS block or to an HTTPS listener on the Gateway", host)
+	}
+	for _, host := range unused {
+		c.addDiagnostic(ing, "", SeverityInfo,
+			"spec.tls host %q is not served by any rule; its HTTPS listener is not needed", host)
+	}
+}
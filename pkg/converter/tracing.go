@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	annotationEnableOpenTelemetry        = "nginx.ingress.kubernetes.io/enable-opentelemetry"
+	annotationOpenTelemetryTrustSpan     = "nginx.ingress.kubernetes.io/opentelemetry-trust-incoming-span"
+	annotationOpenTelemetryOperationName = "nginx.ingress.kubernetes.io/opentelemetry-operation-name"
+)
+
+// extractTracing converts enable-opentelemetry into an NGINX Gateway Fabric
+// ObservabilityPolicy for the routes. Other targets configure tracing for
+// the whole proxy, so it needs manual configuration there.
+func (c *Converter) extractTracing(ing *networkingv1.Ingress, routes []interface{}) *unstructured.Unstructured {
+	value, exists := ing.Annotations[annotationEnableOpenTelemetry]
+	if !exists {
+		return nil
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		c.addDiagnostic(ing, annotationEnableOpenTelemetry, SeverityWarning, "invalid value %q, tracing not converted", value)
+		return nil
+	}
+	if !enabled {
+		return nil
+	}
+	if c.opts.Target != TargetNginxGatewayFabric {
+		c.addDiagnostic(ing, annotationEnableOpenTelemetry, SeverityWarning,
+			"OpenTelemetry tracing needs manual configuration of the Gateway implementation; select --target=nginx-gateway-fabric to generate an ObservabilityPolicy")
+		return nil
+	}
+
+	// ingress-nginx trusts incoming spans unless told otherwise
+	tracing := map[string]interface{}{
+		"strategy": "ratio",
+		"context":  "propagate",
+	}
+	if trust, ok := ing.Annotations[annotationOpenTelemetryTrustSpan]; ok {
+		trusted, err := strconv.ParseBool(trust)
+		switch {
+		case err != nil:
+			c.addDiagnostic(ing, annotationOpenTelemetryTrustSpan, SeverityWarning, "invalid value %q, incoming spans are trusted", trust)
+		case !trusted:
+			tracing["context"] = "inject"
+		}
+	}
+	if name, ok := ing.Annotations[annotationOpenTelemetryOperationName]; ok {
+		if strings.ContainsAny(name, `$"\`) {
+			c.addDiagnostic(ing, annotationOpenTelemetryOperationName, SeverityWarning,
+				"span name %q uses nginx variables, which an ObservabilityPolicy does not accept; the default span name is used", name)
+		} else {
+			tracing["spanName"] = name
+		}
+	}
+
+	c.addDiagnostic(ing, annotationEnableOpenTelemetry, SeverityInfo,
+		"tracing converted to an ObservabilityPolicy; configure the exporter in the NginxProxy resource of the GatewayClass")
+	return newPolicy("gateway.nginx.org/v1alpha1", "ObservabilityPolicy",
+		sanitizeName(fmt.Sprintf("%s-tracing", ing.Name)), ing.Namespace,
+		map[string]interface{}{
+			"targetRefs": routeTargetRefs(routes),
+			"tracing":    tracing,
+		})
+}
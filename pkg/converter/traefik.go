@@ -0,0 +1,430 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const traefikAnnotationPrefix = "traefik.ingress.kubernetes.io/"
+
+const (
+	annotationTraefikMiddlewares = traefikAnnotationPrefix + "router.middlewares"
+	annotationTraefikEntryPoints = traefikAnnotationPrefix + "router.entrypoints"
+	annotationTraefikTLS         = traefikAnnotationPrefix + "router.tls"
+	annotationTraefikPriority    = traefikAnnotationPrefix + "router.priority"
+)
+
+// Traefik custom resource kinds
+const (
+	kindIngressRoute = "IngressRoute"
+	kindMiddleware   = "Middleware"
+)
+
+// traefikGroups are the API groups of the Traefik CRDs, current and legacy
+var traefikGroups = map[string]bool{
+	"traefik.io":          true,
+	"traefik.containo.us": true,
+}
+
+// maxMiddlewareDepth bounds the expansion of nested middleware chains
+const maxMiddlewareDepth = 10
+
+// isTraefikKind reports whether apiVersion and kind name a Traefik CRD the
+// converter reads
+func isTraefikKind(apiVersion, kind string) bool {
+	group, _, _ := strings.Cut(apiVersion, "/")
+	return traefikGroups[group] && (kind == kindIngressRoute || kind == kindMiddleware)
+}
+
+// middlewareRef names a Traefik Middleware
+type middlewareRef struct {
+	namespace string
+	name      string
+}
+
+func (r middlewareRef) String() string {
+	return r.namespace + "/" + r.name
+}
+
+// middleware returns the Middleware ref names, if it was loaded
+func (c *Converter) middleware(ref middlewareRef) (*unstructured.Unstructured, bool) {
+	obj, ok := c.referenced[referencedKey(kindMiddleware, ref.namespace, ref.name)]
+	return obj, ok
+}
+
+// annotationMiddlewares resolves the router.middlewares annotation. Its
+// entries read <namespace>-<name>@kubernetescrd; since both parts may
+// contain dashes, they are matched against the loaded Middlewares.
+func (c *Converter) annotationMiddlewares(ing *networkingv1.Ingress) []middlewareRef {
+	value := ing.Annotations[annotationTraefikMiddlewares]
+
+	var refs []middlewareRef
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, provider, _ := strings.Cut(entry, "@")
+		if provider != "" && provider != "kubernetescrd" {
+			c.addDiagnostic(ing, annotationTraefikMiddlewares, SeverityWarning,
+				"middleware %s is defined by the %s provider, not as a Middleware resource; convert it manually", entry, provider)
+			continue
+		}
+
+		ref, found := c.findMiddleware(id)
+		if !found {
+			c.addDiagnostic(ing, annotationTraefikMiddlewares, SeverityWarning,
+				"middleware %s not found; add its Middleware manifest to the input to convert it", entry)
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// findMiddleware finds the loaded Middleware whose <namespace>-<name> is id
+func (c *Converter) findMiddleware(id string) (middlewareRef, bool) {
+	var keys []string
+	for key := range c.referenced {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		obj := c.referenced[key]
+		if obj.GetKind() != kindMiddleware {
+			continue
+		}
+		if obj.GetNamespace()+"-"+obj.GetName() == id {
+			return middlewareRef{namespace: obj.GetNamespace(), name: obj.GetName()}, true
+		}
+	}
+	return middlewareRef{}, false
+}
+
+// pathRewrite follows the path a request reaches the backend with through
+// stripPrefix, addPrefix and replacePath middlewares. value is what the
+// matched path or prefix becomes, or the whole new path when full is set.
+type pathRewrite struct {
+	matchType gatewayv1.PathMatchType
+	matched   string
+	value     string
+	full      bool
+}
+
+// filter returns the URLRewrite filter for the rewritten path, nil if the
+// path is unchanged
+func (r *pathRewrite) filter() *gatewayv1.HTTPURLRewriteFilter {
+	if !r.full && r.value == r.matched {
+		return nil
+	}
+	value := r.value
+	if r.full || r.matchType == gatewayv1.PathMatchExact {
+		return &gatewayv1.HTTPURLRewriteFilter{
+			Path: &gatewayv1.HTTPPathModifier{
+				Type:            gatewayv1.FullPathHTTPPathModifier,
+				ReplaceFullPath: &value,
+			},
+		}
+	}
+	return &gatewayv1.HTTPURLRewriteFilter{
+		Path: &gatewayv1.HTTPPathModifier{
+			Type:               gatewayv1.PrefixMatchHTTPPathModifier,
+			ReplacePrefixMatch: &value,
+		},
+	}
+}
+
+// middlewareFilters converts the middlewares applied to a rule matching
+// path to filters. Middlewares without a Gateway API equivalent are
+// reported against annotation, "" for IngressRoutes.
+func (c *Converter) middlewareFilters(ing *networkingv1.Ingress, annotation string, refs []middlewareRef, path gatewayv1.HTTPPathMatch) []gatewayv1.HTTPRouteFilter {
+	rewrite := &pathRewrite{matchType: *path.Type, matched: *path.Value, value: *path.Value}
+
+	var filters []gatewayv1.HTTPRouteFilter
+	for _, ref := range refs {
+		filters = c.applyMiddleware(ing, annotation, ref, rewrite, filters, 0)
+	}
+
+	if f := rewrite.filter(); f != nil {
+		if *path.Type == gatewayv1.PathMatchRegularExpression {
+			c.addDiagnostic(ing, annotation, SeverityWarning,
+				"path rewrite of regular expression path %q cannot be expressed in Gateway API, not converted", *path.Value)
+		} else {
+			filters = append(filters, gatewayv1.HTTPRouteFilter{Type: gatewayv1.HTTPRouteFilterURLRewrite, URLRewrite: f})
+		}
+	}
+	return filters
+}
+
+// applyMiddleware adds the effect of one Middleware to filters and rewrite,
+// expanding chains
+func (c *Converter) applyMiddleware(ing *networkingv1.Ingress, annotation string, ref middlewareRef, rewrite *pathRewrite, filters []gatewayv1.HTTPRouteFilter, depth int) []gatewayv1.HTTPRouteFilter {
+	obj, ok := c.middleware(ref)
+	if !ok {
+		c.addDiagnostic(ing, annotation, SeverityWarning,
+			"middleware %s not found; add its Middleware manifest to the input to convert it", ref)
+		return filters
+	}
+	spec, _, _ := unstructured.NestedMap(obj.Object, "spec")
+
+	var kinds []string
+	for kind := range spec {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	for _, kind := range kinds {
+		switch kind {
+		case "headers":
+			filters = c.headersMiddleware(ing, annotation, ref, spec, filters)
+
+		case "stripPrefix":
+			prefixes, _, _ := unstructured.NestedStringSlice(spec, "stripPrefix", "prefixes")
+			for _, prefix := range prefixes {
+				if rewrite.full {
+					break
+				}
+				if strings.HasPrefix(rewrite.value, prefix) {
+					rewrite.value = "/" + strings.TrimLeft(strings.TrimPrefix(rewrite.value, prefix), "/")
+					break
+				}
+				if strings.HasPrefix(prefix, rewrite.value) {
+					c.addDiagnostic(ing, annotation, SeverityWarning,
+						"middleware %s strips %s, longer than the matched path %s; add a rule matching %s to convert it", ref, prefix, rewrite.matched, prefix)
+				}
+			}
+
+		case "addPrefix":
+			prefix, _, _ := unstructured.NestedString(spec, "addPrefix", "prefix")
+			if rewrite.value == "/" && !rewrite.full {
+				rewrite.value = prefix
+			} else {
+				rewrite.value = strings.TrimRight(prefix, "/") + rewrite.value
+			}
+
+		case "replacePath":
+			rewrite.value, _, _ = unstructured.NestedString(spec, "replacePath", "path")
+			rewrite.full = true
+
+		case "chain":
+			if depth >= maxMiddlewareDepth {
+				c.addDiagnostic(ing, annotation, SeverityError, "middleware chain %s nests more than %d levels, not expanded", ref, maxMiddlewareDepth)
+				continue
+			}
+			chained, _, _ := unstructured.NestedSlice(spec, "chain", "middlewares")
+			for _, item := range chained {
+				m, _ := item.(map[string]interface{})
+				next := middlewareRef{namespace: ref.namespace}
+				next.name, _, _ = unstructured.NestedString(m, "name")
+				if ns, _, _ := unstructured.NestedString(m, "namespace"); ns != "" {
+					next.namespace = ns
+				}
+				filters = c.applyMiddleware(ing, annotation, next, rewrite, filters, depth+1)
+			}
+
+		case "redirectScheme":
+			c.addDiagnostic(ing, annotation, SeverityWarning,
+				"middleware %s redirectScheme not converted: the route serves every Gateway listener, so redirect from a separate HTTPRoute attached to the HTTP listener", ref)
+
+		default:
+			c.addDiagnostic(ing, annotation, SeverityWarning,
+				"middleware %s %s has no Gateway API filter equivalent, not converted", ref, kind)
+		}
+	}
+	return filters
+}
+
+// securityHeaders maps the boolean and string options of the Traefik
+// headers middleware to the response headers they set
+var securityHeaders = map[string]func(value interface{}) (string, string){
+	"frameDeny": func(v interface{}) (string, string) {
+		if v == true {
+			return "X-Frame-Options", "DENY"
+		}
+		return "", ""
+	},
+	"customFrameOptionsValue": func(v interface{}) (string, string) {
+		return "X-Frame-Options", headerValue(v)
+	},
+	"contentTypeNosniff": func(v interface{}) (string, string) {
+		if v == true {
+			return "X-Content-Type-Options", "nosniff"
+		}
+		return "", ""
+	},
+	"browserXssFilter": func(v interface{}) (string, string) {
+		if v == true {
+			return "X-XSS-Protection", "1; mode=block"
+		}
+		return "", ""
+	},
+	"referrerPolicy": func(v interface{}) (string, string) {
+		return "Referrer-Policy", headerValue(v)
+	},
+	"contentSecurityPolicy": func(v interface{}) (string, string) {
+		return "Content-Security-Policy", headerValue(v)
+	},
+	"permissionsPolicy": func(v interface{}) (string, string) {
+		return "Permissions-Policy", headerValue(v)
+	},
+}
+
+// headersMiddleware converts the headers middleware. Custom headers with an
+// empty value are removed, as Traefik does.
+func (c *Converter) headersMiddleware(ing *networkingv1.Ingress, annotation string, ref middlewareRef, spec map[string]interface{}, filters []gatewayv1.HTTPRouteFilter) []gatewayv1.HTTPRouteFilter {
+	headers, _, _ := unstructured.NestedMap(spec, "headers")
+
+	var options []string
+	for option := range headers {
+		options = append(options, option)
+	}
+	sort.Strings(options)
+
+	for _, option := range options {
+		value := headers[option]
+		switch option {
+		case "customRequestHeaders", "customResponseHeaders":
+			values, _, _ := unstructured.NestedStringMap(headers, option)
+			names := make([]string, 0, len(values))
+			for name := range values {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				filters = modifyHeader(filters, option == "customRequestHeaders", name, values[name])
+			}
+
+		case "stsSeconds":
+			sts := "max-age=" + headerValue(value)
+			if headers["stsIncludeSubdomains"] == true {
+				sts += "; includeSubDomains"
+			}
+			if headers["stsPreload"] == true {
+				sts += "; preload"
+			}
+			filters = setResponseHeader(filters, "Strict-Transport-Security", sts)
+
+		case "stsIncludeSubdomains", "stsPreload":
+			// Part of stsSeconds
+
+		default:
+			set, known := securityHeaders[option]
+			if !known {
+				c.addDiagnostic(ing, annotation, SeverityWarning,
+					"middleware %s headers option %s has no Gateway API filter equivalent, not converted", ref, option)
+				continue
+			}
+			if name, v := set(value); name != "" {
+				filters = setResponseHeader(filters, name, v)
+			}
+		}
+	}
+	return filters
+}
+
+// headerValue formats a middleware option as a header value. Numbers
+// decode as float64 and would otherwise print in exponent form.
+func headerValue(v interface{}) string {
+	if f, ok := v.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// modifyHeader sets a request or response header, or removes it when value
+// is empty
+func modifyHeader(filters []gatewayv1.HTTPRouteFilter, request bool, name, value string) []gatewayv1.HTTPRouteFilter {
+	if value != "" {
+		if request {
+			return setRequestHeader(filters, name, value)
+		}
+		return setResponseHeader(filters, name, value)
+	}
+
+	filterType := gatewayv1.HTTPRouteFilterResponseHeaderModifier
+	if request {
+		filterType = gatewayv1.HTTPRouteFilterRequestHeaderModifier
+	}
+	for i := range filters {
+		if filters[i].Type != filterType {
+			continue
+		}
+		modifier := filters[i].ResponseHeaderModifier
+		if request {
+			modifier = filters[i].RequestHeaderModifier
+		}
+		if modifier != nil {
+			modifier.Remove = append(modifier.Remove, name)
+			return filters
+		}
+	}
+
+	filter := gatewayv1.HTTPRouteFilter{Type: filterType}
+	modifier := &gatewayv1.HTTPHeaderFilter{Remove: []string{name}}
+	if request {
+		filter.RequestHeaderModifier = modifier
+	} else {
+		filter.ResponseHeaderModifier = modifier
+	}
+	return append(filters, filter)
+}
+
+// checkTraefikRouter reports the router annotations of a Traefik Ingress
+// that have no effect on the converted routes
+func (c *Converter) checkTraefikRouter(ing *networkingv1.Ingress) {
+	if value, ok := ing.Annotations[annotationTraefikEntryPoints]; ok {
+		c.checkEntryPoints(ing, annotationTraefikEntryPoints, strings.Split(value, ","))
+	}
+	if _, ok := ing.Annotations[annotationTraefikTLS]; ok && len(ing.Spec.TLS) == 0 {
+		c.addDiagnostic(ing, annotationTraefikTLS, SeverityWarning,
+			"router.tls without spec.tls uses Traefik's default certificate; add certificateRefs to the HTTPS listener manually")
+	}
+	if _, ok := ing.Annotations[annotationTraefikPriority]; ok {
+		c.addDiagnostic(ing, annotationTraefikPriority, SeverityWarning,
+			"router priority not converted: Gateway API orders matches by specificity, check overlapping routes")
+	}
+}
+
+// checkEntryPoints reports how the Traefik entrypoints of a router map to
+// Gateway listeners, which routes attach to all of unless a section is set
+func (c *Converter) checkEntryPoints(ing *networkingv1.Ingress, annotation string, entryPoints []string) {
+	if len(entryPoints) == 0 {
+		return
+	}
+	secureOnly := true
+	for _, ep := range entryPoints {
+		if strings.TrimSpace(ep) != "websecure" {
+			secureOnly = false
+		}
+	}
+	if secureOnly && c.gatewayRef(ing).SectionName == "" {
+		c.addDiagnostic(ing, annotation, SeverityWarning,
+			"router only serves the websecure entrypoint, but the routes attach to every Gateway listener; set --section-name to the HTTPS listener")
+		return
+	}
+	c.addDiagnostic(ing, annotation, SeverityInfo,
+		"entrypoints %s map to the listeners of the parent Gateway", strings.Join(entryPoints, ","))
+}
@@ -0,0 +1,304 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// Translator converts annotations to the filters and timeouts of route
+// rules, to policies and to diagnostics. Support for another controller's
+// annotations is added by registering translators for them.
+type Translator interface {
+	// Name identifies the translator in listings and errors
+	Name() string
+	// Annotations lists the annotation keys the translator converts
+	Annotations() []string
+	// Translate converts the annotations for one rule of an Ingress
+	Translate(t *Translation) error
+}
+
+// Translation is the rule a Translator converts annotations for. Translate
+// is called for each rule of an Ingress carrying one of its annotations,
+// with the filters and timeouts of the translators before it.
+type Translation struct {
+	// Ingress is the Ingress being converted; translators must not modify it
+	Ingress *networkingv1.Ingress
+	// Path is the path match of the rule
+	Path gatewayv1.HTTPPathMatch
+
+	// Filters and Timeouts are set on the rule after all translators ran
+	Filters  []gatewayv1.HTTPRouteFilter
+	Timeouts *gatewayv1.HTTPRouteTimeouts
+
+	ctx context.Context
+	c   *Converter
+}
+
+// Context returns the context of the conversion, canceled when the caller
+// of Convert gives up
+func (t *Translation) Context() context.Context {
+	return t.ctx
+}
+
+// Report records a diagnostic for an annotation of the Ingress; severity is
+// SeverityInfo, SeverityWarning or SeverityError
+func (t *Translation) Report(annotation, severity, format string, args ...interface{}) {
+	t.c.addDiagnostic(t.Ingress, annotation, severity, format, args...)
+}
+
+// AddPolicy adds a policy to the output of the Ingress. Policies of the
+// same kind, namespace and name added for several rules are kept once.
+func (t *Translation) AddPolicy(policy *unstructured.Unstructured) {
+	key := referencedKey(policy.GetKind(), policy.GetNamespace(), policy.GetName())
+	for _, p := range t.c.translatedPolicies {
+		if referencedKey(p.GetKind(), p.GetNamespace(), p.GetName()) == key {
+			return
+		}
+	}
+	t.c.translatedPolicies = append(t.c.translatedPolicies, policy)
+}
+
+// Unhandled returns the annotations of the Ingress that no registered
+// translator and no built-in conversion handles
+func (t *Translation) Unhandled() map[string]string {
+	unhandled := make(map[string]string)
+	for key, value := range t.Ingress.Annotations {
+		if key == lastAppliedAnnotation || isConvertedAnnotation(key) {
+			continue
+		}
+		if _, ok := t.c.translators().Lookup(key); !ok {
+			unhandled[key] = value
+		}
+	}
+	return unhandled
+}
+
+// Handle marks an unhandled annotation as converted in the annotation
+// results of the Ingress
+func (t *Translation) Handle(annotation string) {
+	if t.c.hooked == nil {
+		t.c.hooked = make(map[string]bool)
+	}
+	t.c.hooked[hookedKey(fmt.Sprintf("%s/%s", t.Ingress.Namespace, t.Ingress.Name), annotation)] = true
+}
+
+// hookedKey identifies an annotation of the Ingress namespace/name handled
+// by the hook
+func hookedKey(ingress, annotation string) string {
+	return ingress + " " + annotation
+}
+
+// funcTranslator is a Translator calling a function
+type funcTranslator struct {
+	name        string
+	annotations []string
+	translate   func(t *Translation) error
+}
+
+// NewTranslator returns a translator of annotations calling translate
+func NewTranslator(name string, annotations []string, translate func(t *Translation) error) Translator {
+	return &funcTranslator{name: name, annotations: annotations, translate: translate}
+}
+
+func (f *funcTranslator) Name() string                   { return f.name }
+func (f *funcTranslator) Annotations() []string          { return f.annotations }
+func (f *funcTranslator) Translate(t *Translation) error { return f.translate(t) }
+
+// Registry maps annotation keys to the translators converting them. Each
+// annotation has at most one translator; translators run in registration
+// order, followed by the hook. A Registry is safe for concurrent use.
+type Registry struct {
+	mu          sync.RWMutex
+	translators []Translator
+	owners      map[string]Translator
+	hook        Translator
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{owners: make(map[string]Translator)}
+}
+
+// DefaultRegistry returns a registry of the built-in translators, which
+// library users and the CLI extend with their own
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+	for _, t := range builtinTranslators() {
+		if err := r.Register(t); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// defaultRegistry serves converters without registry of their own
+var defaultRegistry = DefaultRegistry()
+
+// Register adds a translator. It fails when the name or one of the
+// annotations is taken; Remove the built-in translator to replace it.
+func (r *Registry) Register(t Translator) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.translators {
+		if existing.Name() == t.Name() {
+			return fmt.Errorf("translator %s is already registered", t.Name())
+		}
+	}
+	for _, annotation := range t.Annotations() {
+		if owner, ok := r.owners[annotation]; ok {
+			return fmt.Errorf("annotation %s is already translated by %s", annotation, owner.Name())
+		}
+	}
+	for _, annotation := range t.Annotations() {
+		r.owners[annotation] = t
+	}
+	r.translators = append(r.translators, t)
+	return nil
+}
+
+// Remove removes the translator named name, reporting whether it was registered
+func (r *Registry) Remove(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, t := range r.translators {
+		if t.Name() != name {
+			continue
+		}
+		for _, annotation := range t.Annotations() {
+			delete(r.owners, annotation)
+		}
+		r.translators = append(r.translators[:i:i], r.translators[i+1:]...)
+		return true
+	}
+	return false
+}
+
+// Lookup returns the translator of an annotation
+func (r *Registry) Lookup(annotation string) (Translator, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.owners[annotation]
+	return t, ok
+}
+
+// SetHook sets the translator run after the others for the annotations
+// none of them handles, such as an ExecHook; nil removes it
+func (r *Registry) SetHook(t Translator) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hook = t
+}
+
+// Hook returns the translator of unhandled annotations, or nil
+func (r *Registry) Hook() Translator {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.hook
+}
+
+// Translators returns the registered translators in registration order
+func (r *Registry) Translators() []Translator {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Translator(nil), r.translators...)
+}
+
+// translators returns the registry of the converter
+func (c *Converter) translators() *Registry {
+	if c.opts.Translators != nil {
+		return c.opts.Translators
+	}
+	return defaultRegistry
+}
+
+// translate runs the translators of the annotations of ing for a rule
+// matching path
+func (c *Converter) translate(ctx context.Context, ing *networkingv1.Ingress, path gatewayv1.HTTPPathMatch) (*Translation, error) {
+	t := &Translation{Ingress: ing, Path: path, ctx: ctx, c: c}
+	for _, translator := range c.translators().Translators() {
+		if !hasAnyAnnotation(ing, translator.Annotations()) {
+			continue
+		}
+		if err := translator.Translate(t); err != nil {
+			return nil, fmt.Errorf("failed to translate %s annotations: %w", translator.Name(), err)
+		}
+	}
+	if hook := c.translators().Hook(); hook != nil {
+		if err := hook.Translate(t); err != nil {
+			return nil, fmt.Errorf("failed to translate unhandled annotations: %w", err)
+		}
+	}
+	return t, nil
+}
+
+// hasAnyAnnotation reports whether ing has one of annotations
+func hasAnyAnnotation(ing *networkingv1.Ingress, annotations []string) bool {
+	for _, annotation := range annotations {
+		if _, ok := ing.Annotations[annotation]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// builtinTranslators returns the translators of the annotations converted
+// to rule filters and timeouts, in the order their filters are added
+func builtinTranslators() []Translator {
+	timeouts := []string{annotationProxyReadTimeout, annotationProxySendTimeout}
+	timeouts = append(timeouts, haproxyBackendTimeouts...)
+	timeouts = append(timeouts, haproxyRequestTimeouts...)
+
+	return []Translator{
+		NewTranslator("rewrite-target", []string{annotationRewriteTarget}, translateRewriteTarget),
+		NewTranslator("permanent-redirect", []string{annotationPermanentRedirect}, translatePermanentRedirect),
+		NewTranslator("haproxy-redirect", []string{
+			annotationHAProxySSLRedirect, annotationHAProxyIngressSSLRedirect, annotationHAProxyRequestRedirect,
+			annotationHAProxyRequestRedirectCode, annotationHAProxyIngressRedirectTo,
+		}, func(t *Translation) error {
+			t.Filters = append(t.Filters, t.c.haproxyRedirectFilters(t.Ingress)...)
+			return nil
+		}),
+		NewTranslator("configuration-snippet", []string{annotationConfigurationSnippet}, translateSnippet),
+		NewTranslator("x-forwarded-prefix", []string{annotationXForwardedPrefix}, func(t *Translation) error {
+			if prefix := t.Ingress.Annotations[annotationXForwardedPrefix]; prefix != "" {
+				t.Filters = setRequestHeader(t.Filters, "X-Forwarded-Prefix", prefix)
+			}
+			return nil
+		}),
+		NewTranslator("hsts", []string{
+			annotationHSTS, annotationHSTSMaxAge, annotationHSTSIncludeSubdomains, annotationHSTSPreload,
+		}, func(t *Translation) error {
+			if value, ok := t.c.hstsHeader(t.Ingress); ok {
+				t.Filters = setResponseHeader(t.Filters, "Strict-Transport-Security", value)
+			}
+			return nil
+		}),
+		NewTranslator("timeouts", timeouts, func(t *Translation) error {
+			t.Timeouts = t.c.extractTimeouts(t.Ingress)
+			return nil
+		}),
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ConvertToUnstructured converts Ingresses like Convert and returns the
+// generated resources as unstructured objects with their GVK set, ready for
+// dynamic clients and server-side apply
+func (c *Converter) ConvertToUnstructured(ctx context.Context, ingresses []interface{}) ([]*unstructured.Unstructured, error) {
+	resources, err := c.Convert(ctx, ingresses)
+	if err != nil {
+		return nil, err
+	}
+	return ToUnstructured(resources)
+}
+
+// ToUnstructured converts generated resources to unstructured objects
+func ToUnstructured(resources []interface{}) ([]*unstructured.Unstructured, error) {
+	objs := make([]*unstructured.Unstructured, 0, len(resources))
+	for _, res := range resources {
+		if u, ok := res.(*unstructured.Unstructured); ok {
+			objs = append(objs, u.DeepCopy())
+			continue
+		}
+
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(res)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %T to unstructured: %w", res, err)
+		}
+		u := &unstructured.Unstructured{Object: content}
+		if u.GetAPIVersion() == "" || u.GetKind() == "" {
+			return nil, fmt.Errorf("resource %s has no apiVersion or kind", u.GetName())
+		}
+
+		// Drop fields the API server fills in and apply would otherwise send as null
+		unstructured.RemoveNestedField(u.Object, "metadata", "creationTimestamp")
+		unstructured.RemoveNestedField(u.Object, "status")
+		objs = append(objs, u)
+	}
+	return objs, nil
+}
@@ -0,0 +1,606 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	kindVirtualServer      = "VirtualServer"
+	kindVirtualServerRoute = "VirtualServerRoute"
+
+	// nginxRequestURI is the NGINX variable of the original path and query
+	nginxRequestURI = "${request_uri}"
+)
+
+// isNginxIncKind reports whether apiVersion and kind name a VirtualServer
+// or VirtualServerRoute of the F5 NGINX Ingress Controller
+func isNginxIncKind(apiVersion, kind string) bool {
+	group, _, _ := strings.Cut(apiVersion, "/")
+	return group == "k8s.nginx.org" && (kind == kindVirtualServer || kind == kindVirtualServerRoute)
+}
+
+// nginxUpstream is a named Service port of a VirtualServer
+type nginxUpstream struct {
+	Name        string            `json:"name"`
+	Service     string            `json:"service"`
+	Port        int32             `json:"port"`
+	Subselector map[string]string `json:"subselector,omitempty"`
+}
+
+// nginxHeader is a header an action sets or adds
+type nginxHeader struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Always bool   `json:"always,omitempty"`
+}
+
+// nginxProxy is the proxy action: an upstream with request and response changes
+type nginxProxy struct {
+	Upstream       string `json:"upstream"`
+	RewritePath    string `json:"rewritePath,omitempty"`
+	RequestHeaders *struct {
+		Pass *bool         `json:"pass,omitempty"`
+		Set  []nginxHeader `json:"set,omitempty"`
+	} `json:"requestHeaders,omitempty"`
+	ResponseHeaders *struct {
+		Hide   []string      `json:"hide,omitempty"`
+		Pass   []string      `json:"pass,omitempty"`
+		Ignore []string      `json:"ignore,omitempty"`
+		Add    []nginxHeader `json:"add,omitempty"`
+	} `json:"responseHeaders,omitempty"`
+}
+
+// nginxAction is what a route does with the requests it matches
+type nginxAction struct {
+	Pass     string `json:"pass,omitempty"`
+	Redirect *struct {
+		URL  string `json:"url"`
+		Code int    `json:"code,omitempty"`
+	} `json:"redirect,omitempty"`
+	Return *struct {
+		Code int    `json:"code,omitempty"`
+		Body string `json:"body,omitempty"`
+	} `json:"return,omitempty"`
+	Proxy *nginxProxy `json:"proxy,omitempty"`
+}
+
+// nginxSplit is a weighted action of a traffic split
+type nginxSplit struct {
+	Weight int32        `json:"weight"`
+	Action *nginxAction `json:"action,omitempty"`
+}
+
+// nginxCondition is a condition of a match; all of them must hold
+type nginxCondition struct {
+	Header   string `json:"header,omitempty"`
+	Cookie   string `json:"cookie,omitempty"`
+	Argument string `json:"argument,omitempty"`
+	Variable string `json:"variable,omitempty"`
+	Value    string `json:"value"`
+}
+
+// nginxMatch is an advanced content-based routing rule of a route
+type nginxMatch struct {
+	Conditions []nginxCondition `json:"conditions"`
+	Action     *nginxAction     `json:"action,omitempty"`
+	Splits     []nginxSplit     `json:"splits,omitempty"`
+}
+
+// nginxRoute is a route of a VirtualServer or subroute of a VirtualServerRoute
+type nginxRoute struct {
+	Path             string        `json:"path"`
+	Route            string        `json:"route,omitempty"`
+	Action           *nginxAction  `json:"action,omitempty"`
+	Splits           []nginxSplit  `json:"splits,omitempty"`
+	Matches          []nginxMatch  `json:"matches,omitempty"`
+	ErrorPages       []interface{} `json:"errorPages,omitempty"`
+	Policies         []interface{} `json:"policies,omitempty"`
+	LocationSnippets string        `json:"location-snippets,omitempty"`
+}
+
+// virtualServerSpec is the part of the VirtualServer spec the converter reads
+type virtualServerSpec struct {
+	IngressClassName string `json:"ingressClassName,omitempty"`
+	Host             string `json:"host"`
+	TLS              *struct {
+		Secret   string `json:"secret,omitempty"`
+		Redirect *struct {
+			Enable bool `json:"enable"`
+			Code   int  `json:"code,omitempty"`
+		} `json:"redirect,omitempty"`
+	} `json:"tls,omitempty"`
+	Upstreams      []nginxUpstream `json:"upstreams,omitempty"`
+	Routes         []nginxRoute    `json:"routes,omitempty"`
+	Policies       []interface{}   `json:"policies,omitempty"`
+	ServerSnippets string          `json:"server-snippets,omitempty"`
+}
+
+// virtualServerRouteSpec is the part of the VirtualServerRoute spec the
+// converter reads
+type virtualServerRouteSpec struct {
+	Host      string          `json:"host"`
+	Upstreams []nginxUpstream `json:"upstreams,omitempty"`
+	Subroutes []nginxRoute    `json:"subroutes,omitempty"`
+}
+
+// nginxUpstreams are the upstreams of a VirtualServer or VirtualServerRoute,
+// whose Services live in its namespace
+type nginxUpstreams struct {
+	namespace string
+	upstreams []nginxUpstream
+}
+
+// lookup returns the upstream named name
+func (u nginxUpstreams) lookup(name string) (nginxUpstream, bool) {
+	for _, up := range u.upstreams {
+		if up.Name == name {
+			return up, true
+		}
+	}
+	return nginxUpstream{}, false
+}
+
+// nginxUpstreamFields are the upstream fields the converter converts
+var nginxUpstreamFields = map[string]bool{"name": true, "service": true, "port": true, "subselector": true}
+
+// virtualServerIngress returns the Ingress a VirtualServer stands in for
+// where the converter works on Ingresses, as virtualServiceIngress does for
+// VirtualServices. VirtualServers without ingress class belong to the
+// nginx class.
+func virtualServerIngress(obj *unstructured.Unstructured) *networkingv1.Ingress {
+	var spec virtualServerSpec
+	err := decodeSpec(obj, &spec)
+
+	className := spec.IngressClassName
+	if className == "" {
+		className = obj.GetAnnotations()["kubernetes.io/ingress.class"]
+	}
+	if className == "" {
+		className = "nginx"
+	}
+	ing := &networkingv1.Ingress{
+		TypeMeta: metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "Ingress"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        obj.GetName(),
+			Namespace:   obj.GetNamespace(),
+			Labels:      obj.GetLabels(),
+			Annotations: obj.GetAnnotations(),
+		},
+		Spec: networkingv1.IngressSpec{IngressClassName: &className},
+	}
+	if err != nil {
+		return ing
+	}
+
+	var paths []networkingv1.HTTPIngressPath
+	for _, up := range spec.Upstreams {
+		pathType := networkingv1.PathTypePrefix
+		paths = append(paths, networkingv1.HTTPIngressPath{
+			Path: "/", PathType: &pathType,
+			Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+				Name: up.Service, Port: networkingv1.ServiceBackendPort{Number: up.Port},
+			}},
+		})
+	}
+	ing.Spec.Rules = []networkingv1.IngressRule{{
+		Host:             spec.Host,
+		IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: paths}},
+	}}
+	if spec.TLS != nil && spec.TLS.Secret != "" {
+		ing.Spec.TLS = []networkingv1.IngressTLS{{Hosts: []string{spec.Host}, SecretName: spec.TLS.Secret}}
+	}
+	return ing
+}
+
+// convertVirtualServer converts the routes of a VirtualServer, and of the
+// VirtualServerRoutes it delegates to, to an HTTPRoute; ing is its stand-in
+// Ingress
+func (c *Converter) convertVirtualServer(obj *unstructured.Unstructured, ing *networkingv1.Ingress) ([]interface{}, error) {
+	var spec virtualServerSpec
+	if err := decodeSpec(obj, &spec); err != nil {
+		return nil, err
+	}
+	if spec.TLS != nil && spec.TLS.Redirect != nil && spec.TLS.Redirect.Enable {
+		c.addDiagnostic(ing, "", SeverityWarning, "tls.redirect not converted: add a redirect HTTPRoute on the HTTP listener")
+	}
+	if len(spec.Policies) > 0 || spec.ServerSnippets != "" {
+		c.addDiagnostic(ing, "", SeverityWarning, "VirtualServer policies and server-snippets not converted; configure them on the implementation")
+	}
+	c.checkNginxUpstreams(ing, obj)
+
+	var hostnames []gatewayv1.Hostname
+	if spec.Host != "" && c.validHostname(ing, spec.Host) {
+		hostnames = append(hostnames, c.hostname(ing, spec.Host))
+	}
+
+	upstreams := nginxUpstreams{namespace: ing.Namespace, upstreams: spec.Upstreams}
+	var rules []gatewayv1.HTTPRouteRule
+	for i, route := range spec.Routes {
+		label := fmt.Sprintf("route %d (%s)", i+1, route.Path)
+		if route.Route != "" {
+			rules = append(rules, c.virtualServerRouteRules(ing, spec.Host, route)...)
+			continue
+		}
+		rules = append(rules, c.nginxRouteRules(ing, label, route, upstreams)...)
+	}
+	if len(rules) == 0 {
+		c.addDiagnostic(ing, "", SeverityError, "VirtualServer has no route to convert")
+		return nil, nil
+	}
+
+	name, err := c.routeName(ing)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{&gatewayv1.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "gateway.networking.k8s.io/v1",
+			Kind:       "HTTPRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   ing.Namespace,
+			Labels:      c.routeLabels(ing),
+			Annotations: c.routeAnnotations(ing),
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{c.parentRef(ing)}},
+			Hostnames:       hostnames,
+			Rules:           rules,
+		},
+	}}, nil
+}
+
+// virtualServerRouteRules converts the subroutes of the VirtualServerRoute
+// a route delegates to, named namespace/name or name in the namespace of
+// the VirtualServer
+func (c *Converter) virtualServerRouteRules(ing *networkingv1.Ingress, host string, route nginxRoute) []gatewayv1.HTTPRouteRule {
+	ns, name, found := strings.Cut(route.Route, "/")
+	if !found {
+		ns, name = ing.Namespace, route.Route
+	}
+	obj, ok := c.referenced[referencedKey(kindVirtualServerRoute, ns, name)]
+	if !ok {
+		c.addDiagnostic(ing, "", SeverityError, "VirtualServerRoute %s/%s of route %s not found in the input; route dropped", ns, name, route.Path)
+		return nil
+	}
+	var spec virtualServerRouteSpec
+	if err := decodeSpec(obj, &spec); err != nil {
+		c.addDiagnostic(ing, "", SeverityError, "VirtualServerRoute %s/%s: %v; route dropped", ns, name, err)
+		return nil
+	}
+	if spec.Host != host {
+		c.addDiagnostic(ing, "", SeverityWarning, "VirtualServerRoute %s/%s host %s differs from the VirtualServer host %s, which NGINX rejects", ns, name, spec.Host, host)
+	}
+	c.checkNginxUpstreams(ing, obj)
+
+	upstreams := nginxUpstreams{namespace: ns, upstreams: spec.Upstreams}
+	var rules []gatewayv1.HTTPRouteRule
+	for i, sub := range spec.Subroutes {
+		label := fmt.Sprintf("VirtualServerRoute %s/%s subroute %d (%s)", ns, name, i+1, sub.Path)
+		rules = append(rules, c.nginxRouteRules(ing, label, sub, upstreams)...)
+	}
+	return rules
+}
+
+// checkNginxUpstreams reports the upstream settings of obj, such as load
+// balancing, timeouts and health checks, which HTTPRoute cannot express
+func (c *Converter) checkNginxUpstreams(ing *networkingv1.Ingress, obj *unstructured.Unstructured) {
+	upstreams, _, _ := unstructured.NestedSlice(obj.Object, "spec", "upstreams")
+	for _, item := range upstreams {
+		up, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, field := range sortedKeys(up) {
+			if !nginxUpstreamFields[field] {
+				c.addDiagnostic(ing, "", SeverityWarning,
+					"%s upstream %v %s not converted; configure it with a policy of the target implementation", obj.GetKind(), up["name"], field)
+			}
+		}
+	}
+}
+
+// nginxRouteRules converts a route to a rule per match and a rule for its
+// own action or splits
+func (c *Converter) nginxRouteRules(ing *networkingv1.Ingress, label string, route nginxRoute, upstreams nginxUpstreams) []gatewayv1.HTTPRouteRule {
+	if len(route.ErrorPages) > 0 || len(route.Policies) > 0 || route.LocationSnippets != "" {
+		c.addDiagnostic(ing, "", SeverityWarning, "%s errorPages, policies and location-snippets not converted", label)
+	}
+	path, ok := c.nginxPathMatch(ing, label, route.Path)
+	if !ok {
+		return nil
+	}
+
+	var rules []gatewayv1.HTTPRouteRule
+	for i, m := range route.Matches {
+		matchLabel := fmt.Sprintf("%s match %d", label, i+1)
+		match := c.nginxConditions(ing, matchLabel, m.Conditions)
+		match.Path = path
+		if rule, ok := c.nginxActionRule(ing, matchLabel, path, m.Action, m.Splits, upstreams); ok {
+			rule.Matches = []gatewayv1.HTTPRouteMatch{match}
+			rules = append(rules, rule)
+		}
+	}
+	// Routes with matches need no action of their own
+	if route.Action == nil && len(route.Splits) == 0 && len(route.Matches) > 0 {
+		return rules
+	}
+	if rule, ok := c.nginxActionRule(ing, label, path, route.Action, route.Splits, upstreams); ok {
+		rule.Matches = []gatewayv1.HTTPRouteMatch{{Path: path}}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// nginxPathMatch converts the path of a route: =/path is exact, ~ and ~*
+// are case sensitive and insensitive regular expressions, and others are
+// prefixes
+func (c *Converter) nginxPathMatch(ing *networkingv1.Ingress, label, path string) (*gatewayv1.HTTPPathMatch, bool) {
+	pathType := gatewayv1.PathMatchPathPrefix
+	switch {
+	case strings.HasPrefix(path, "="):
+		pathType, path = gatewayv1.PathMatchExact, strings.TrimSpace(path[1:])
+	case strings.HasPrefix(path, "~*"):
+		pathType, path = gatewayv1.PathMatchRegularExpression, "(?i)"+strings.TrimSpace(path[2:])
+	case strings.HasPrefix(path, "~"):
+		pathType, path = gatewayv1.PathMatchRegularExpression, strings.TrimSpace(path[1:])
+	case !strings.HasPrefix(path, "/"):
+		c.addDiagnostic(ing, "", SeverityError, "%s path %q is not a path; route dropped", label, path)
+		return nil, false
+	case path != "/" && !strings.HasSuffix(path, "/"):
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"%s prefix %s also matches paths such as %s-x in NGINX; PathPrefix only matches whole segments", label, path, path)
+	}
+	return &gatewayv1.HTTPPathMatch{Type: &pathType, Value: &path}, true
+}
+
+// nginxConditions converts the conditions of a match. Cookies become
+// regular expressions on the Cookie header; negated values and variables
+// other than $request_method cannot be expressed and are ignored.
+func (c *Converter) nginxConditions(ing *networkingv1.Ingress, label string, conditions []nginxCondition) gatewayv1.HTTPRouteMatch {
+	var match gatewayv1.HTTPRouteMatch
+	for _, cond := range conditions {
+		if strings.HasPrefix(cond.Value, "!") {
+			c.addDiagnostic(ing, "", SeverityWarning, "%s negated condition value %s has no HTTPRoute equivalent, ignored", label, cond.Value)
+			continue
+		}
+		switch {
+		case cond.Header != "":
+			headerType := gatewayv1.HeaderMatchExact
+			match.Headers = append(match.Headers, gatewayv1.HTTPHeaderMatch{Type: &headerType, Name: gatewayv1.HTTPHeaderName(cond.Header), Value: cond.Value})
+		case cond.Cookie != "":
+			headerType := gatewayv1.HeaderMatchRegularExpression
+			value := fmt.Sprintf("(^|;\\s*)%s=%s(;|$)", regexp.QuoteMeta(cond.Cookie), regexp.QuoteMeta(cond.Value))
+			match.Headers = append(match.Headers, gatewayv1.HTTPHeaderMatch{Type: &headerType, Name: "Cookie", Value: value})
+		case cond.Argument != "":
+			queryType := gatewayv1.QueryParamMatchExact
+			match.QueryParams = append(match.QueryParams, gatewayv1.HTTPQueryParamMatch{Type: &queryType, Name: gatewayv1.HTTPHeaderName(cond.Argument), Value: cond.Value})
+		case cond.Variable == "$request_method":
+			method := gatewayv1.HTTPMethod(strings.ToUpper(cond.Value))
+			match.Method = &method
+		default:
+			c.addDiagnostic(ing, "", SeverityWarning, "%s condition on variable %s has no HTTPRoute equivalent, ignored", label, cond.Variable)
+		}
+	}
+	return match
+}
+
+// nginxActionRule converts the action or splits of a route or match to a
+// rule without matches; ok is false when they cannot be converted
+func (c *Converter) nginxActionRule(ing *networkingv1.Ingress, label string, path *gatewayv1.HTTPPathMatch, action *nginxAction, splits []nginxSplit, upstreams nginxUpstreams) (gatewayv1.HTTPRouteRule, bool) {
+	var rule gatewayv1.HTTPRouteRule
+
+	if len(splits) > 0 {
+		for i, split := range splits {
+			splitLabel := fmt.Sprintf("%s split %d", label, i+1)
+			if split.Action == nil || (split.Action.Pass == "" && split.Action.Proxy == nil) {
+				c.addDiagnostic(ing, "", SeverityError, "%s does not pass or proxy to an upstream; route dropped", splitLabel)
+				return rule, false
+			}
+			upstream := split.Action.Pass
+			if split.Action.Proxy != nil {
+				upstream = split.Action.Proxy.Upstream
+			}
+			ref, ok := c.nginxBackendRef(ing, splitLabel, upstream, upstreams)
+			if !ok {
+				return rule, false
+			}
+			weight := split.Weight
+			ref.Weight = &weight
+			if proxy := split.Action.Proxy; proxy != nil {
+				if proxy.RewritePath != "" {
+					c.addDiagnostic(ing, "", SeverityWarning, "%s rewritePath not converted; rewrite the path of all splits alike", splitLabel)
+				}
+				ref.Filters = c.nginxHeaderFilters(ing, splitLabel, proxy)
+			}
+			rule.BackendRefs = append(rule.BackendRefs, ref)
+		}
+		return rule, true
+	}
+
+	switch {
+	case action == nil:
+	case action.Redirect != nil:
+		filter, ok := c.nginxRedirect(ing, label, action.Redirect.URL, action.Redirect.Code)
+		if !ok {
+			return rule, false
+		}
+		rule.Filters = append(rule.Filters, filter)
+		return rule, true
+	case action.Return != nil:
+		c.addDiagnostic(ing, "", SeverityError,
+			"%s return action (code %d) has no HTTPRoute equivalent; route dropped", label, action.Return.Code)
+		return rule, false
+	case action.Pass != "":
+		ref, ok := c.nginxBackendRef(ing, label, action.Pass, upstreams)
+		if !ok {
+			return rule, false
+		}
+		rule.BackendRefs = []gatewayv1.HTTPBackendRef{ref}
+		return rule, true
+	case action.Proxy != nil:
+		ref, ok := c.nginxBackendRef(ing, label, action.Proxy.Upstream, upstreams)
+		if !ok {
+			return rule, false
+		}
+		rule.BackendRefs = []gatewayv1.HTTPBackendRef{ref}
+		if filter, ok := c.nginxRewrite(ing, label, path, action.Proxy.RewritePath); ok {
+			rule.Filters = append(rule.Filters, filter)
+		}
+		rule.Filters = append(rule.Filters, c.nginxHeaderFilters(ing, label, action.Proxy)...)
+		return rule, true
+	}
+	c.addDiagnostic(ing, "", SeverityError, "%s has no action; route dropped", label)
+	return rule, false
+}
+
+// nginxBackendRef converts an upstream to a backendRef
+func (c *Converter) nginxBackendRef(ing *networkingv1.Ingress, label, name string, upstreams nginxUpstreams) (gatewayv1.HTTPBackendRef, bool) {
+	up, ok := upstreams.lookup(name)
+	if !ok {
+		c.addDiagnostic(ing, "", SeverityError, "%s upstream %s is not defined; route dropped", label, name)
+		return gatewayv1.HTTPBackendRef{}, false
+	}
+	if len(up.Subselector) > 0 {
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"%s upstream %s subselector not converted; create a Service selecting its pods and reference it instead", label, name)
+	}
+
+	port := gatewayv1.PortNumber(up.Port)
+	ref := gatewayv1.HTTPBackendRef{BackendRef: gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(up.Service), Port: &port},
+	}}
+	if upstreams.namespace != ing.Namespace {
+		namespace := gatewayv1.Namespace(upstreams.namespace)
+		ref.Namespace = &namespace
+	}
+	return ref, true
+}
+
+// nginxRewrite converts the rewritePath of a proxy action, which replaces
+// the matched prefix of prefix routes and the whole path of exact ones
+func (c *Converter) nginxRewrite(ing *networkingv1.Ingress, label string, path *gatewayv1.HTTPPathMatch, rewrite string) (gatewayv1.HTTPRouteFilter, bool) {
+	if rewrite == "" {
+		return gatewayv1.HTTPRouteFilter{}, false
+	}
+	var modifier gatewayv1.HTTPPathModifier
+	switch *path.Type {
+	case gatewayv1.PathMatchPathPrefix:
+		modifier = gatewayv1.HTTPPathModifier{Type: gatewayv1.PrefixMatchHTTPPathModifier, ReplacePrefixMatch: &rewrite}
+	case gatewayv1.PathMatchExact:
+		modifier = gatewayv1.HTTPPathModifier{Type: gatewayv1.FullPathHTTPPathModifier, ReplaceFullPath: &rewrite}
+	default:
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"%s rewritePath %s of a regular expression path not converted: HTTPRoute rewrites cannot use capture groups", label, rewrite)
+		return gatewayv1.HTTPRouteFilter{}, false
+	}
+	return gatewayv1.HTTPRouteFilter{
+		Type:       gatewayv1.HTTPRouteFilterURLRewrite,
+		URLRewrite: &gatewayv1.HTTPURLRewriteFilter{Path: &modifier},
+	}, true
+}
+
+// nginxHeaderFilters converts the request and response headers of a proxy
+// action to header modifier filters
+func (c *Converter) nginxHeaderFilters(ing *networkingv1.Ingress, label string, proxy *nginxProxy) []gatewayv1.HTTPRouteFilter {
+	var filters []gatewayv1.HTTPRouteFilter
+	if req := proxy.RequestHeaders; req != nil {
+		if req.Pass != nil && !*req.Pass {
+			c.addDiagnostic(ing, "", SeverityWarning, "%s requestHeaders.pass: false not converted; client headers are passed", label)
+		}
+		for _, h := range req.Set {
+			if strings.Contains(h.Value, "$") {
+				c.addDiagnostic(ing, "", SeverityWarning, "%s request header %s uses NGINX variables, which are set literally", label, h.Name)
+			}
+			filters = setRequestHeader(filters, h.Name, h.Value)
+		}
+	}
+	if resp := proxy.ResponseHeaders; resp != nil {
+		for _, h := range resp.Add {
+			if strings.Contains(h.Value, "$") {
+				c.addDiagnostic(ing, "", SeverityWarning, "%s response header %s uses NGINX variables, which are set literally", label, h.Name)
+			}
+			filters = addHeader(filters, false, h.Name, h.Value)
+		}
+		for _, name := range resp.Hide {
+			filters = modifyHeader(filters, false, name, "")
+		}
+		if len(resp.Pass)+len(resp.Ignore) > 0 {
+			c.addDiagnostic(ing, "", SeverityWarning, "%s responseHeaders.pass and ignore not converted", label)
+		}
+	}
+	return filters
+}
+
+// nginxRedirect converts a redirect action. The ${scheme} and ${host}
+// variables keep the scheme and host of the request, and a trailing
+// ${request_uri} its path; other variables cannot be expressed.
+func (c *Converter) nginxRedirect(ing *networkingv1.Ingress, label, target string, code int) (gatewayv1.HTTPRouteFilter, bool) {
+	switch code {
+	case 0:
+		code = 301
+	case 301, 302:
+	default:
+		c.addDiagnostic(ing, "", SeverityWarning, "%s redirect code %d is not supported by HTTPRoute, 301 used", label, code)
+		code = 301
+	}
+	redirect := &gatewayv1.HTTPRequestRedirectFilter{StatusCode: &code}
+
+	rest, keepPath := strings.CutSuffix(target, nginxRequestURI)
+	scheme, rest, found := strings.Cut(rest, "://")
+	if !found {
+		c.addDiagnostic(ing, "", SeverityError, "%s redirect url %s is not absolute; route dropped", label, target)
+		return gatewayv1.HTTPRouteFilter{}, false
+	}
+	if scheme != "${scheme}" {
+		redirect.Scheme = &scheme
+	}
+	authority, path, _ := strings.Cut(rest, "/")
+	if authority != "${host}" && authority != "" {
+		host := authority
+		if h, p, ok := strings.Cut(authority, ":"); ok {
+			n, err := strconv.Atoi(p)
+			if err != nil {
+				c.addDiagnostic(ing, "", SeverityError, "%s redirect url %s has an invalid port; route dropped", label, target)
+				return gatewayv1.HTTPRouteFilter{}, false
+			}
+			port := gatewayv1.PortNumber(n)
+			host, redirect.Port = h, &port
+		}
+		hostname := gatewayv1.PreciseHostname(host)
+		redirect.Hostname = &hostname
+	}
+	if !keepPath && (path != "" || strings.HasSuffix(rest, "/")) {
+		full := "/" + path
+		redirect.Path = &gatewayv1.HTTPPathModifier{Type: gatewayv1.FullPathHTTPPathModifier, ReplaceFullPath: &full}
+	}
+	if strings.Contains(strings.Replace(rest, "${host}", "", 1), "$") {
+		c.addDiagnostic(ing, "", SeverityWarning, "%s redirect url %s uses NGINX variables, which are kept literally", label, target)
+	}
+	return gatewayv1.HTTPRouteFilter{Type: gatewayv1.HTTPRouteFilterRequestRedirect, RequestRedirect: redirect}, true
+}
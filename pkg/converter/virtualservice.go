@@ -0,0 +1,667 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	kindVirtualService  = "VirtualService"
+	kindDestinationRule = "DestinationRule"
+
+	// istioMeshGateway is the reserved gateway name of sidecar routing
+	istioMeshGateway = "mesh"
+)
+
+// isIstioKind reports whether apiVersion and kind name an Istio
+// VirtualService or DestinationRule
+func isIstioKind(apiVersion, kind string) bool {
+	group, _, _ := strings.Cut(apiVersion, "/")
+	return group == "networking.istio.io" && (kind == kindVirtualService || kind == kindDestinationRule)
+}
+
+// istioStringMatch is an Istio exact, prefix or regex match
+type istioStringMatch struct {
+	Exact  string `json:"exact,omitempty"`
+	Prefix string `json:"prefix,omitempty"`
+	Regex  string `json:"regex,omitempty"`
+}
+
+// istioDestination is a Service of the mesh, optionally a subset of it
+type istioDestination struct {
+	Host   string `json:"host"`
+	Subset string `json:"subset,omitempty"`
+	Port   *struct {
+		Number int32 `json:"number"`
+	} `json:"port,omitempty"`
+}
+
+// istioHeaderOperations are the header changes of a route or destination
+type istioHeaderOperations struct {
+	Set    map[string]string `json:"set,omitempty"`
+	Add    map[string]string `json:"add,omitempty"`
+	Remove []string          `json:"remove,omitempty"`
+}
+
+type istioHeaders struct {
+	Request  *istioHeaderOperations `json:"request,omitempty"`
+	Response *istioHeaderOperations `json:"response,omitempty"`
+}
+
+// istioHTTPRoute is an entry of the http list of a VirtualService
+type istioHTTPRoute struct {
+	Name  string `json:"name,omitempty"`
+	Match []struct {
+		URI            *istioStringMatch           `json:"uri,omitempty"`
+		Method         *istioStringMatch           `json:"method,omitempty"`
+		Authority      *istioStringMatch           `json:"authority,omitempty"`
+		Headers        map[string]istioStringMatch `json:"headers,omitempty"`
+		QueryParams    map[string]istioStringMatch `json:"queryParams,omitempty"`
+		WithoutHeaders map[string]interface{}      `json:"withoutHeaders,omitempty"`
+		IgnoreURICase  bool                        `json:"ignoreUriCase,omitempty"`
+		Port           int32                       `json:"port,omitempty"`
+		SourceLabels   map[string]string           `json:"sourceLabels,omitempty"`
+		Gateways       []string                    `json:"gateways,omitempty"`
+	} `json:"match,omitempty"`
+	Route []struct {
+		Destination istioDestination `json:"destination"`
+		Weight      *int32           `json:"weight,omitempty"`
+		Headers     *istioHeaders    `json:"headers,omitempty"`
+	} `json:"route,omitempty"`
+	Redirect *struct {
+		URI          string `json:"uri,omitempty"`
+		Authority    string `json:"authority,omitempty"`
+		Port         int32  `json:"port,omitempty"`
+		Scheme       string `json:"scheme,omitempty"`
+		RedirectCode int    `json:"redirectCode,omitempty"`
+	} `json:"redirect,omitempty"`
+	Rewrite *struct {
+		URI       string `json:"uri,omitempty"`
+		Authority string `json:"authority,omitempty"`
+	} `json:"rewrite,omitempty"`
+	Timeout string `json:"timeout,omitempty"`
+	Retries *struct {
+		Attempts      int    `json:"attempts"`
+		PerTryTimeout string `json:"perTryTimeout,omitempty"`
+		RetryOn       string `json:"retryOn,omitempty"`
+	} `json:"retries,omitempty"`
+	Headers          *istioHeaders     `json:"headers,omitempty"`
+	Mirror           *istioDestination `json:"mirror,omitempty"`
+	MirrorPercentage interface{}       `json:"mirrorPercentage,omitempty"`
+	Fault            interface{}       `json:"fault,omitempty"`
+	CorsPolicy       interface{}       `json:"corsPolicy,omitempty"`
+	Delegate         interface{}       `json:"delegate,omitempty"`
+}
+
+// virtualServiceSpec is the part of the VirtualService spec the converter reads
+type virtualServiceSpec struct {
+	Hosts    []string         `json:"hosts,omitempty"`
+	Gateways []string         `json:"gateways,omitempty"`
+	HTTP     []istioHTTPRoute `json:"http,omitempty"`
+	TLS      []interface{}    `json:"tls,omitempty"`
+	TCP      []interface{}    `json:"tcp,omitempty"`
+}
+
+// destinationRuleSpec is the part of the DestinationRule spec the converter reads
+type destinationRuleSpec struct {
+	Host          string                 `json:"host"`
+	TrafficPolicy map[string]interface{} `json:"trafficPolicy,omitempty"`
+	Subsets       []struct {
+		Name          string                 `json:"name"`
+		Labels        map[string]string      `json:"labels,omitempty"`
+		TrafficPolicy map[string]interface{} `json:"trafficPolicy,omitempty"`
+	} `json:"subsets,omitempty"`
+}
+
+// istioService returns the Service name and namespace of a mesh host: a
+// short name, name.namespace or name.namespace.svc[.cluster.local]. Other
+// hosts are outside the cluster and ok is false.
+func istioService(host, namespace string) (name, ns string, ok bool) {
+	host = strings.TrimSuffix(strings.TrimSuffix(host, ".cluster.local"), ".svc")
+	parts := strings.Split(host, ".")
+	switch {
+	case host == "" || strings.Contains(host, "*"):
+		return "", "", false
+	case len(parts) == 1:
+		return parts[0], namespace, true
+	case len(parts) == 2:
+		return parts[0], parts[1], true
+	}
+	return "", "", false
+}
+
+// meshGatewayOnly reports whether a VirtualService only configures sidecar
+// routing: it lists no gateways, or only the mesh gateway
+func meshGatewayOnly(gateways []string) bool {
+	for _, gw := range gateways {
+		if gw != istioMeshGateway {
+			return false
+		}
+	}
+	return true
+}
+
+// virtualServiceIngress returns the Ingress a VirtualService stands in for
+// where the converter works on Ingresses, as ingressRouteIngress does for
+// IngressRoutes. VirtualServices without ingress class annotation belong
+// to the istio class.
+func virtualServiceIngress(obj *unstructured.Unstructured) *networkingv1.Ingress {
+	className := obj.GetAnnotations()["kubernetes.io/ingress.class"]
+	if className == "" {
+		className = "istio"
+	}
+	ing := &networkingv1.Ingress{
+		TypeMeta: metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "Ingress"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        obj.GetName(),
+			Namespace:   obj.GetNamespace(),
+			Labels:      obj.GetLabels(),
+			Annotations: obj.GetAnnotations(),
+		},
+		Spec: networkingv1.IngressSpec{IngressClassName: &className},
+	}
+
+	var spec virtualServiceSpec
+	if err := decodeSpec(obj, &spec); err != nil {
+		return ing
+	}
+	var paths []networkingv1.HTTPIngressPath
+	for _, route := range spec.HTTP {
+		for _, dest := range route.Route {
+			name, ns, ok := istioService(dest.Destination.Host, obj.GetNamespace())
+			if !ok || ns != obj.GetNamespace() {
+				continue
+			}
+			backend := &networkingv1.IngressServiceBackend{Name: name}
+			if dest.Destination.Port != nil {
+				backend.Port.Number = dest.Destination.Port.Number
+			}
+			pathType := networkingv1.PathTypePrefix
+			paths = append(paths, networkingv1.HTTPIngressPath{
+				Path: "/", PathType: &pathType,
+				Backend: networkingv1.IngressBackend{Service: backend},
+			})
+		}
+	}
+	// Mesh-only VirtualServices attach to Services, not to a Gateway listener
+	if meshGatewayOnly(spec.Gateways) {
+		if len(paths) > 0 {
+			ing.Spec.Rules = []networkingv1.IngressRule{{IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: paths}}}}
+		}
+		return ing
+	}
+	for _, host := range spec.Hosts {
+		if host == "*" {
+			host = ""
+		}
+		ing.Spec.Rules = append(ing.Spec.Rules, networkingv1.IngressRule{
+			Host:             host,
+			IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: paths}},
+		})
+	}
+	return ing
+}
+
+// convertVirtualService converts the http routes of an Istio
+// VirtualService to an HTTPRoute; ing is its stand-in Ingress. Routes bound
+// to Istio Gateways attach to the Gateway of the ingress class, sidecar
+// routes to the Services of the VirtualService hosts.
+func (c *Converter) convertVirtualService(obj *unstructured.Unstructured, ing *networkingv1.Ingress) ([]interface{}, error) {
+	var spec virtualServiceSpec
+	if err := decodeSpec(obj, &spec); err != nil {
+		return nil, err
+	}
+	if len(spec.TLS) > 0 || len(spec.TCP) > 0 {
+		c.addDiagnostic(ing, "", SeverityWarning, "tls and tcp routes not converted; only http routes are")
+	}
+
+	var parentRefs []gatewayv1.ParentReference
+	var hostnames []gatewayv1.Hostname
+	if meshGatewayOnly(spec.Gateways) {
+		for _, host := range spec.Hosts {
+			name, ns, ok := istioService(host, ing.Namespace)
+			if !ok {
+				c.addDiagnostic(ing, "", SeverityWarning, "mesh host %s is not a cluster Service; no parentRef generated for it", host)
+				continue
+			}
+			group := gatewayv1.Group("")
+			kind := gatewayv1.Kind(kindService)
+			ref := gatewayv1.ParentReference{Group: &group, Kind: &kind, Name: gatewayv1.ObjectName(name)}
+			if ns != ing.Namespace {
+				namespace := gatewayv1.Namespace(ns)
+				ref.Namespace = &namespace
+			}
+			parentRefs = append(parentRefs, ref)
+		}
+		if len(parentRefs) == 0 {
+			c.addDiagnostic(ing, "", SeverityError, "VirtualService has no in-cluster host to attach mesh routes to; not converted")
+			return nil, nil
+		}
+		c.addDiagnostic(ing, "", SeverityInfo,
+			"mesh routes attach to their Services (GAMMA); the mesh implementation must support Service parentRefs")
+	} else {
+		var gateways []string
+		for _, gw := range spec.Gateways {
+			if gw == istioMeshGateway {
+				c.addDiagnostic(ing, "", SeverityWarning,
+					"mesh routing not converted with the gateway routes; create an HTTPRoute with the Services as parentRefs for it")
+				continue
+			}
+			gateways = append(gateways, gw)
+		}
+		c.addDiagnostic(ing, "", SeverityInfo, "routes of Istio Gateway(s) %s attach to Gateway %s",
+			strings.Join(gateways, ", "), c.gatewayRef(ing).Name)
+		parentRefs = []gatewayv1.ParentReference{c.parentRef(ing)}
+		for _, host := range spec.Hosts {
+			if host == "*" {
+				continue
+			}
+			if c.validHostname(ing, host) {
+				hostnames = append(hostnames, c.hostname(ing, host))
+			}
+		}
+	}
+
+	var rules []gatewayv1.HTTPRouteRule
+	for i, route := range spec.HTTP {
+		if rule, ok := c.virtualServiceRule(ing, i, route); ok {
+			rules = append(rules, splitRewriteRule(rule)...)
+		}
+	}
+	if len(rules) == 0 {
+		c.addDiagnostic(ing, "", SeverityError, "VirtualService has no http route to convert")
+		return nil, nil
+	}
+
+	name, err := c.routeName(ing)
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{&gatewayv1.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "gateway.networking.k8s.io/v1",
+			Kind:       "HTTPRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   ing.Namespace,
+			Labels:      c.routeLabels(ing),
+			Annotations: c.routeAnnotations(ing),
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: parentRefs},
+			Hostnames:       hostnames,
+			Rules:           rules,
+		},
+	}}, nil
+}
+
+// virtualServiceRule converts http route i of a VirtualService; ok is false
+// when it cannot be converted
+func (c *Converter) virtualServiceRule(ing *networkingv1.Ingress, i int, route istioHTTPRoute) (gatewayv1.HTTPRouteRule, bool) {
+	label := fmt.Sprintf("http route %d", i+1)
+	if route.Name != "" {
+		label = fmt.Sprintf("http route %s", route.Name)
+	}
+	var rule gatewayv1.HTTPRouteRule
+
+	if route.Delegate != nil {
+		c.addDiagnostic(ing, "", SeverityError, "%s delegates to another VirtualService, which HTTPRoute cannot express; route dropped", label)
+		return rule, false
+	}
+
+	for _, m := range route.Match {
+		var match gatewayv1.HTTPRouteMatch
+		if m.URI != nil {
+			pathType, value := gatewayv1.PathMatchExact, m.URI.Exact
+			switch {
+			case m.URI.Prefix != "":
+				pathType, value = gatewayv1.PathMatchPathPrefix, m.URI.Prefix
+				if value != "/" && !strings.HasSuffix(value, "/") {
+					c.addDiagnostic(ing, "", SeverityWarning,
+						"%s prefix %s also matches paths such as %s-x in Istio; PathPrefix only matches whole segments", label, value, value)
+				}
+			case m.URI.Regex != "":
+				pathType, value = gatewayv1.PathMatchRegularExpression, m.URI.Regex
+			}
+			match.Path = &gatewayv1.HTTPPathMatch{Type: &pathType, Value: &value}
+		}
+		if m.Method != nil {
+			if m.Method.Exact == "" {
+				c.addDiagnostic(ing, "", SeverityWarning, "%s method match is not exact, not converted", label)
+			} else {
+				method := gatewayv1.HTTPMethod(strings.ToUpper(m.Method.Exact))
+				match.Method = &method
+			}
+		}
+		for _, name := range sortedKeys(m.Headers) {
+			matchType, value := istioValueMatch(m.Headers[name])
+			headerType := gatewayv1.HeaderMatchType(matchType)
+			match.Headers = append(match.Headers, gatewayv1.HTTPHeaderMatch{Type: &headerType, Name: gatewayv1.HTTPHeaderName(name), Value: value})
+		}
+		for _, name := range sortedKeys(m.QueryParams) {
+			matchType, value := istioValueMatch(m.QueryParams[name])
+			queryType := gatewayv1.QueryParamMatchType(matchType)
+			match.QueryParams = append(match.QueryParams, gatewayv1.HTTPQueryParamMatch{Type: &queryType, Name: gatewayv1.HTTPHeaderName(name), Value: value})
+		}
+		for what, set := range map[string]bool{
+			"authority": m.Authority != nil, "withoutHeaders": len(m.WithoutHeaders) > 0, "ignoreUriCase": m.IgnoreURICase,
+			"port": m.Port != 0, "sourceLabels": len(m.SourceLabels) > 0, "gateways": len(m.Gateways) > 0,
+		} {
+			if set {
+				c.addDiagnostic(ing, "", SeverityWarning, "%s match %s has no HTTPRoute equivalent, ignored", label, what)
+			}
+		}
+		if match.Path == nil {
+			pathType, value := gatewayv1.PathMatchPathPrefix, "/"
+			match.Path = &gatewayv1.HTTPPathMatch{Type: &pathType, Value: &value}
+		}
+		rule.Matches = append(rule.Matches, match)
+	}
+
+	if route.Redirect != nil {
+		rule.Filters = append(rule.Filters, c.istioRedirect(ing, label, route))
+		return rule, true
+	}
+	if len(route.Route) == 0 {
+		c.addDiagnostic(ing, "", SeverityError, "%s has no destination; route dropped", label)
+		return rule, false
+	}
+
+	for _, dest := range route.Route {
+		ref, ok := c.istioBackendRef(ing, label, dest.Destination)
+		if !ok {
+			continue
+		}
+		weight := int32(1)
+		if len(route.Route) > 1 || dest.Weight != nil {
+			weight = 0
+			if dest.Weight != nil {
+				weight = *dest.Weight
+			}
+		}
+		ref.Weight = &weight
+		if dest.Headers != nil {
+			ref.Filters = istioHeaderFilters(dest.Headers)
+		}
+		rule.BackendRefs = append(rule.BackendRefs, ref)
+	}
+	if len(rule.BackendRefs) == 0 {
+		c.addDiagnostic(ing, "", SeverityError, "%s has no convertible destination; route dropped", label)
+		return rule, false
+	}
+
+	if route.Rewrite != nil {
+		rewrite := &gatewayv1.HTTPURLRewriteFilter{}
+		if route.Rewrite.Authority != "" {
+			hostname := gatewayv1.PreciseHostname(route.Rewrite.Authority)
+			rewrite.Hostname = &hostname
+		}
+		if uri := route.Rewrite.URI; uri != "" {
+			// Istio replaces the matched prefix, or the whole path of other
+			// matches; splitRewriteRule picks the modifier per match
+			rewrite.Path = &gatewayv1.HTTPPathModifier{Type: gatewayv1.FullPathHTTPPathModifier, ReplaceFullPath: &uri}
+		}
+		rule.Filters = append(rule.Filters, gatewayv1.HTTPRouteFilter{Type: gatewayv1.HTTPRouteFilterURLRewrite, URLRewrite: rewrite})
+	}
+	if route.Headers != nil {
+		rule.Filters = append(rule.Filters, istioHeaderFilters(route.Headers)...)
+	}
+	if route.Mirror != nil {
+		if ref, ok := c.istioBackendRef(ing, label, *route.Mirror); ok {
+			rule.Filters = append(rule.Filters, gatewayv1.HTTPRouteFilter{
+				Type:          gatewayv1.HTTPRouteFilterRequestMirror,
+				RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{BackendRef: ref.BackendObjectReference},
+			})
+			if route.MirrorPercentage != nil {
+				c.addDiagnostic(ing, "", SeverityWarning, "%s mirrorPercentage not converted; all requests are mirrored", label)
+			}
+		}
+	}
+
+	if route.Timeout != "" {
+		if d, err := time.ParseDuration(route.Timeout); err != nil {
+			c.addDiagnostic(ing, "", SeverityWarning, "%s timeout %q is invalid, not converted", label, route.Timeout)
+		} else if !c.inChannel(FeatureHTTPRouteTimeouts) || !c.supports(FeatureHTTPRouteTimeouts) {
+			c.unsupportedFeature(ing, "", FeatureHTTPRouteTimeouts, label+" timeout")
+		} else {
+			timeout := formatDuration(d)
+			rule.Timeouts = &gatewayv1.HTTPRouteTimeouts{Request: &timeout}
+		}
+	}
+	if route.Retries != nil && route.Retries.Attempts > 0 {
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"%s retries (%d attempts) not converted: HTTPRoute retries need Gateway API v1.2; configure them on the implementation", label, route.Retries.Attempts)
+	}
+	for what, set := range map[string]bool{"fault": route.Fault != nil, "corsPolicy": route.CorsPolicy != nil} {
+		if set {
+			c.addDiagnostic(ing, "", SeverityWarning, "%s %s not converted; configure it on the implementation", label, what)
+		}
+	}
+	return rule, true
+}
+
+// istioValueMatch returns the Gateway API match type and value of an Istio
+// header or query parameter match. Prefixes become regular expressions.
+func istioValueMatch(m istioStringMatch) (string, string) {
+	switch {
+	case m.Prefix != "":
+		return "RegularExpression", "^" + regexp.QuoteMeta(m.Prefix) + ".*"
+	case m.Regex != "":
+		return "RegularExpression", m.Regex
+	}
+	return "Exact", m.Exact
+}
+
+// istioRedirect converts the redirect of an http route
+func (c *Converter) istioRedirect(ing *networkingv1.Ingress, label string, route istioHTTPRoute) gatewayv1.HTTPRouteFilter {
+	r := route.Redirect
+	code := r.RedirectCode
+	switch code {
+	case 0:
+		code = 301
+	case 301, 302:
+	default:
+		c.addDiagnostic(ing, "", SeverityWarning, "%s redirect code %d is not supported by HTTPRoute, 301 used", label, code)
+		code = 301
+	}
+	redirect := &gatewayv1.HTTPRequestRedirectFilter{StatusCode: &code}
+	if r.Scheme != "" {
+		scheme := r.Scheme
+		redirect.Scheme = &scheme
+	}
+	if r.Authority != "" {
+		hostname := gatewayv1.PreciseHostname(r.Authority)
+		redirect.Hostname = &hostname
+	}
+	if r.Port != 0 {
+		port := gatewayv1.PortNumber(r.Port)
+		redirect.Port = &port
+	}
+	if r.URI != "" {
+		uri := r.URI
+		redirect.Path = &gatewayv1.HTTPPathModifier{Type: gatewayv1.FullPathHTTPPathModifier, ReplaceFullPath: &uri}
+	}
+	return gatewayv1.HTTPRouteFilter{Type: gatewayv1.HTTPRouteFilterRequestRedirect, RequestRedirect: redirect}
+}
+
+// istioHeaderFilters converts header operations to header modifier filters
+func istioHeaderFilters(headers *istioHeaders) []gatewayv1.HTTPRouteFilter {
+	var filters []gatewayv1.HTTPRouteFilter
+	if f := istioHeaderFilter(headers.Request); f != nil {
+		filters = append(filters, gatewayv1.HTTPRouteFilter{Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier, RequestHeaderModifier: f})
+	}
+	if f := istioHeaderFilter(headers.Response); f != nil {
+		filters = append(filters, gatewayv1.HTTPRouteFilter{Type: gatewayv1.HTTPRouteFilterResponseHeaderModifier, ResponseHeaderModifier: f})
+	}
+	return filters
+}
+
+// istioHeaderFilter converts header operations, nil for none
+func istioHeaderFilter(ops *istioHeaderOperations) *gatewayv1.HTTPHeaderFilter {
+	if ops == nil || len(ops.Set)+len(ops.Add)+len(ops.Remove) == 0 {
+		return nil
+	}
+	filter := &gatewayv1.HTTPHeaderFilter{Remove: ops.Remove}
+	for _, name := range sortedKeys(ops.Set) {
+		filter.Set = append(filter.Set, gatewayv1.HTTPHeader{Name: gatewayv1.HTTPHeaderName(name), Value: ops.Set[name]})
+	}
+	for _, name := range sortedKeys(ops.Add) {
+		filter.Add = append(filter.Add, gatewayv1.HTTPHeader{Name: gatewayv1.HTTPHeaderName(name), Value: ops.Add[name]})
+	}
+	return filter
+}
+
+// istioBackendRef converts a destination to a backendRef. Subsets become
+// references to a Service per subset, which must be created.
+func (c *Converter) istioBackendRef(ing *networkingv1.Ingress, label string, dest istioDestination) (gatewayv1.HTTPBackendRef, bool) {
+	name, ns, ok := istioService(dest.Host, ing.Namespace)
+	if !ok {
+		c.addDiagnostic(ing, "", SeverityError,
+			"%s destination %s is not a cluster Service (ServiceEntry hosts cannot be referenced); destination dropped", label, dest.Host)
+		return gatewayv1.HTTPBackendRef{}, false
+	}
+
+	var port gatewayv1.PortNumber
+	if dest.Port != nil {
+		port = gatewayv1.PortNumber(dest.Port.Number)
+	} else if svc := c.backendService(ns, name); svc != nil && len(svc.Spec.Ports) == 1 {
+		port = gatewayv1.PortNumber(svc.Spec.Ports[0].Port)
+	} else {
+		c.addDiagnostic(ing, "", SeverityError,
+			"%s destination %s has no port and its Service does not have exactly one; set port.number (destination dropped)", label, dest.Host)
+		return gatewayv1.HTTPBackendRef{}, false
+	}
+
+	if rule, ok := c.destinationRule(ns, name); ok {
+		c.checkDestinationRule(ing, rule, dest.Subset)
+	}
+	if dest.Subset != "" {
+		subset := sanitizeName(name + "-" + dest.Subset)
+		c.addDiagnostic(ing, "", SeverityWarning,
+			"%s destination subset %s of %s is referenced as Service %s; create it selecting the subset's labels", label, dest.Subset, name, subset)
+		name = subset
+	}
+
+	ref := gatewayv1.HTTPBackendRef{BackendRef: gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(name), Port: &port},
+	}}
+	if ns != ing.Namespace {
+		namespace := gatewayv1.Namespace(ns)
+		ref.Namespace = &namespace
+	}
+	return ref, true
+}
+
+// destinationRule returns the DestinationRule in namespace for a Service
+func (c *Converter) destinationRule(namespace, service string) (*destinationRuleSpec, bool) {
+	var keys []string
+	for key := range c.referenced {
+		if strings.HasPrefix(key, referencedKey(kindDestinationRule, namespace, "")) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var spec destinationRuleSpec
+		if err := decodeSpec(c.referenced[key], &spec); err != nil {
+			continue
+		}
+		if name, ns, ok := istioService(spec.Host, namespace); ok && name == service && ns == namespace {
+			return &spec, true
+		}
+	}
+	return nil, false
+}
+
+// checkDestinationRule reports the traffic policies of a DestinationRule
+// and of subset, which HTTPRoute cannot express
+func (c *Converter) checkDestinationRule(ing *networkingv1.Ingress, rule *destinationRuleSpec, subset string) {
+	policies := []map[string]interface{}{rule.TrafficPolicy}
+	found := subset == ""
+	for _, s := range rule.Subsets {
+		if s.Name == subset {
+			found = true
+			policies = append(policies, s.TrafficPolicy)
+			if len(s.Labels) > 0 {
+				var labels []string
+				for _, key := range sortedKeys(s.Labels) {
+					labels = append(labels, key+"="+s.Labels[key])
+				}
+				c.addDiagnostic(ing, "", SeverityInfo, "subset %s of %s selects pods labelled %s", subset, rule.Host, strings.Join(labels, ","))
+			}
+		}
+	}
+	if !found {
+		c.addDiagnostic(ing, "", SeverityWarning, "subset %s not found in the DestinationRule of %s", subset, rule.Host)
+	}
+	for _, policy := range policies {
+		for _, field := range sortedKeys(policy) {
+			c.addDiagnostic(ing, "", SeverityWarning,
+				"DestinationRule %s trafficPolicy.%s not converted; configure it with a policy of the target implementation", rule.Host, field)
+		}
+	}
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// splitRewriteRule splits a rule rewriting the path into one rule per match,
+// since ReplacePrefixMatch requires a single PathPrefix match: PathPrefix
+// matches replace the matched prefix, other matches the full path
+func splitRewriteRule(rule gatewayv1.HTTPRouteRule) []gatewayv1.HTTPRouteRule {
+	index := -1
+	for i, f := range rule.Filters {
+		if f.Type == gatewayv1.HTTPRouteFilterURLRewrite && f.URLRewrite != nil && f.URLRewrite.Path != nil {
+			index = i
+		}
+	}
+	if index < 0 || len(rule.Matches) == 0 {
+		return []gatewayv1.HTTPRouteRule{rule}
+	}
+	value := rule.Filters[index].URLRewrite.Path.ReplaceFullPath
+
+	rules := make([]gatewayv1.HTTPRouteRule, 0, len(rule.Matches))
+	for _, match := range rule.Matches {
+		split := *rule.DeepCopy()
+		split.Matches = []gatewayv1.HTTPRouteMatch{match}
+		path := split.Filters[index].URLRewrite.Path
+		if match.Path != nil && match.Path.Type != nil && *match.Path.Type == gatewayv1.PathMatchPathPrefix {
+			prefix := *value
+			*path = gatewayv1.HTTPPathModifier{Type: gatewayv1.PrefixMatchHTTPPathModifier, ReplacePrefixMatch: &prefix}
+		}
+		rules = append(rules, split)
+	}
+	return rules
+}
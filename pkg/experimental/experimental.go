@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package experimental gates the alpha Gateway API resources and the
+// experimental-channel fields the tool emits and accepts. One switch
+// enables or disables them all; environment variables override it per
+// feature.
+package experimental
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix starts the environment variables that override the gate for
+// one feature, e.g. INGRESS_TO_GATEWAY_EXPERIMENTAL_GRPCROUTE=false
+const EnvPrefix = "INGRESS_TO_GATEWAY_EXPERIMENTAL_"
+
+// Maturity levels of experimental features
+const (
+	MaturityAlpha        = "alpha"        // resource served by an alpha API version
+	MaturityExperimental = "experimental" // experimental-channel field of a GA resource
+)
+
+// Experimental features
+const (
+	GRPCRoute         = "GRPCRoute"
+	TLSRoute          = "TLSRoute"
+	TCPRoute          = "TCPRoute"
+	UDPRoute          = "UDPRoute"
+	BackendTLSPolicy  = "BackendTLSPolicy"
+	BackendLBPolicy   = "BackendLBPolicy"
+	HTTPRouteTimeouts = "HTTPRoute timeouts"
+	ParentRefPort     = "parentRef port"
+	// HTTPRouteRuleNames was added in Gateway API v1.2
+	HTTPRouteRuleNames = "HTTPRoute rule names"
+)
+
+// Feature is an experimental resource or field
+type Feature struct {
+	Name     string
+	ID       string // suffix of its environment variable
+	Maturity string
+	Since    string // Gateway API release that introduced it
+}
+
+// EnvVar returns the environment variable that overrides the gate for the feature
+func (f Feature) EnvVar() string {
+	return EnvPrefix + f.ID
+}
+
+// Features lists the experimental features compiled in
+var Features = []Feature{
+	{Name: GRPCRoute, ID: "GRPCROUTE", Maturity: MaturityAlpha, Since: "v0.6.0"},
+	{Name: TLSRoute, ID: "TLSROUTE", Maturity: MaturityAlpha, Since: "v0.4.0"},
+	{Name: TCPRoute, ID: "TCPROUTE", Maturity: MaturityAlpha, Since: "v0.4.0"},
+	{Name: UDPRoute, ID: "UDPROUTE", Maturity: MaturityAlpha, Since: "v0.4.0"},
+	{Name: BackendTLSPolicy, ID: "BACKENDTLSPOLICY", Maturity: MaturityAlpha, Since: "v1.0.0"},
+	{Name: BackendLBPolicy, ID: "BACKENDLBPOLICY", Maturity: MaturityAlpha, Since: "v1.1.0"},
+	{Name: HTTPRouteTimeouts, ID: "HTTPROUTE_TIMEOUTS", Maturity: MaturityExperimental, Since: "v1.0.0"},
+	{Name: ParentRefPort, ID: "PARENTREF_PORT", Maturity: MaturityExperimental, Since: "v0.6.0"},
+	{Name: HTTPRouteRuleNames, ID: "HTTPROUTE_RULE_NAMES", Maturity: MaturityExperimental, Since: "v1.2.0"},
+}
+
+// Lookup returns the experimental feature named name
+func Lookup(name string) (Feature, bool) {
+	for _, f := range Features {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Feature{}, false
+}
+
+// IsExperimental reports whether name is an experimental feature
+func IsExperimental(name string) bool {
+	_, ok := Lookup(name)
+	return ok
+}
+
+// Gate decides which experimental features are emitted. A nil Gate
+// enables all of them.
+type Gate struct {
+	enabled   bool
+	overrides map[string]bool // by feature name
+}
+
+// NewGate returns a gate enabling every experimental feature or none,
+// overridden per feature by the EnvPrefix variables of environ, in
+// os.Environ format
+func NewGate(enabled bool, environ []string) (*Gate, error) {
+	g := &Gate{enabled: enabled, overrides: make(map[string]bool)}
+	for _, kv := range environ {
+		key, value, _ := strings.Cut(kv, "=")
+		id, ok := strings.CutPrefix(key, EnvPrefix)
+		if !ok {
+			continue
+		}
+		var feature *Feature
+		for i := range Features {
+			if Features[i].ID == id {
+				feature = &Features[i]
+			}
+		}
+		if feature == nil {
+			return nil, fmt.Errorf("unknown experimental feature in %s", key)
+		}
+		on, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q of %s: want true or false", value, key)
+		}
+		g.overrides[feature.Name] = on
+	}
+	return g, nil
+}
+
+// Enabled reports whether the gate lets feature through. Features that
+// are not experimental always pass.
+func (g *Gate) Enabled(feature string) bool {
+	if g == nil || !IsExperimental(feature) {
+		return true
+	}
+	if on, ok := g.overrides[feature]; ok {
+		return on
+	}
+	return g.enabled
+}
+
+// Override returns the environment override of feature, if any
+func (g *Gate) Override(feature string) (bool, bool) {
+	if g == nil {
+		return false, false
+	}
+	on, ok := g.overrides[feature]
+	return on, ok
+}
+
+// Disabled explains how to enable a feature the gate holds back
+func Disabled(feature string) string {
+	f, ok := Lookup(feature)
+	if !ok {
+		return feature + " is disabled"
+	}
+	return fmt.Sprintf("%s is %s and disabled; enable it with --experimental or %s=true", feature, f.Maturity, f.EnvVar())
+}
@@ -84,11 +84,12 @@ func (w *Wizard) Run(ctx context.Context) error {
 	}
 
 	// Step 6: Confirm and save
-	if err := w.confirmAndSave(ctx, ingress, opts); err != nil {
+	steps, err := w.confirmAndSave(ctx, ingress, opts)
+	if err != nil {
 		return err
 	}
 
-	w.printSuccess()
+	w.printSuccess(steps)
 	return nil
 }
 
@@ -266,7 +267,9 @@ func (w *Wizard) configureOptions(ingress *networkingv1.Ingress) (*converter.Opt
 	fmt.Println()
 
 	opts := &converter.Options{
-		OutputFormat: "yaml",
+		OutputOptions: converter.OutputOptions{
+			OutputFormat: "yaml",
+		},
 	}
 
 	// Split mode
@@ -354,7 +357,9 @@ func (w *Wizard) previewConversion(ctx context.Context, ingress *networkingv1.In
 	return nil
 }
 
-func (w *Wizard) confirmAndSave(ctx context.Context, ingress *networkingv1.Ingress, opts *converter.Options) error {
+// confirmAndSave converts, validates and saves the routes, returning the
+// next steps for the saved output
+func (w *Wizard) confirmAndSave(ctx context.Context, ingress *networkingv1.Ingress, opts *converter.Options) ([]string, error) {
 	fmt.Println()
 	fmt.Println("─────────────────────────────────────────────────────────────")
 	fmt.Println("Step 6: Confirm and Save")
@@ -365,7 +370,7 @@ func (w *Wizard) confirmAndSave(ctx context.Context, ingress *networkingv1.Ingre
 	c := converter.NewConverter(*opts)
 	routes, err := c.Convert(ctx, []interface{}{ingress})
 	if err != nil {
-		return fmt.Errorf("conversion failed: %w", err)
+		return nil, fmt.Errorf("conversion failed: %w", err)
 	}
 
 	// Validate
@@ -396,7 +401,8 @@ func (w *Wizard) confirmAndSave(ctx context.Context, ingress *networkingv1.Ingre
 		fmt.Println()
 		fmt.Println("⚠️  Validation found errors. Review and fix before applying.")
 	} else {
-		fmt.Println("✓ Validation passed\n")
+		fmt.Println("✓ Validation passed")
+		fmt.Println()
 	}
 
 	// Confirm
@@ -408,21 +414,30 @@ func (w *Wizard) confirmAndSave(ctx context.Context, ingress *networkingv1.Ingre
 
 	choice := w.prompt("Select option [1-3]")
 
+	var out converter.RunOutput
 	switch choice {
 	case "1":
-		return w.saveToFile(routes, ingress.Name)
+		filename, err := w.saveToFile(routes, ingress.Name)
+		if err != nil {
+			return nil, err
+		}
+		out.Paths = []string{filename}
 	case "2":
-		return w.printToStdout(routes)
+		err = w.printToStdout(routes)
 	case "3":
 		fmt.Println("Migration cancelled.")
-		return nil
+		return nil, nil
 	default:
 		fmt.Println("Invalid choice, printing to stdout...")
-		return w.printToStdout(routes)
+		err = w.printToStdout(routes)
+	}
+	if err != nil {
+		return nil, err
 	}
+	return converter.NextSteps([]interface{}{ingress}, routes, c.Diagnostics(), out), nil
 }
 
-func (w *Wizard) saveToFile(routes []interface{}, baseName string) error {
+func (w *Wizard) saveToFile(routes []interface{}, baseName string) (string, error) {
 	defaultFilename := fmt.Sprintf("%s-httproute.yaml", baseName)
 	fmt.Printf("Output filename (default: %s):\n", defaultFilename)
 	filename := w.prompt("Filename (Enter for default)")
@@ -432,7 +447,7 @@ func (w *Wizard) saveToFile(routes []interface{}, baseName string) error {
 
 	f, err := os.Create(filename)
 	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+		return "", fmt.Errorf("failed to create file: %w", err)
 	}
 	defer f.Close()
 
@@ -442,13 +457,13 @@ func (w *Wizard) saveToFile(routes []interface{}, baseName string) error {
 		}
 		routeYAML, err := yaml.Marshal(route)
 		if err != nil {
-			return fmt.Errorf("failed to marshal: %w", err)
+			return "", fmt.Errorf("failed to marshal: %w", err)
 		}
 		f.Write(routeYAML)
 	}
 
 	fmt.Printf("\n✓ Saved to: %s\n", filename)
-	return nil
+	return filename, nil
 }
 
 func (w *Wizard) printToStdout(routes []interface{}) error {
@@ -466,19 +481,19 @@ func (w *Wizard) printToStdout(routes []interface{}) error {
 	return nil
 }
 
-func (w *Wizard) printSuccess() {
+func (w *Wizard) printSuccess(steps []string) {
 	fmt.Println()
 	fmt.Println("╔════════════════════════════════════════════════════════════╗")
 	fmt.Println("║              Migration Completed Successfully!            ║")
 	fmt.Println("╚════════════════════════════════════════════════════════════╝")
 	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Println("  1. Review the generated HTTPRoute(s)")
-	fmt.Println("  2. Ensure Gateway resource exists in your cluster")
-	fmt.Println("  3. Apply: kubectl apply -f <httproute-file>")
-	fmt.Println("  4. Test traffic routing")
-	fmt.Println("  5. Monitor for any issues")
-	fmt.Println()
+	if len(steps) > 0 {
+		fmt.Println("Next steps:")
+		for i, step := range steps {
+			fmt.Printf("  %d. %s\n", i+1, step)
+		}
+		fmt.Println()
+	}
 	fmt.Println("Thank you for using ingress-to-gateway! 🚀")
 	fmt.Println()
 }
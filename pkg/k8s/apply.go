@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+)
+
+// LiveObjects returns the cluster copies of objs that exist. Objects that
+// are not found, or whose kind the cluster does not serve, are left out.
+func (c *Client) LiveObjects(ctx context.Context, objs []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	dyn, mapper, err := c.dynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	return liveObjects(ctx, dyn, mapper, objs)
+}
+
+// liveObjects reads objs through dyn, resolving their resources with mapper
+func liveObjects(ctx context.Context, dyn dynamic.Interface, mapper meta.RESTMapper, objs []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	var live []*unstructured.Unstructured
+	for _, obj := range objs {
+		resource, err := resourceFor(dyn, mapper, obj)
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to map %s: %w", obj.GroupVersionKind(), err)
+		}
+		current, err := resource.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s %s: %w", obj.GetKind(), objectName(obj), err)
+		}
+		live = append(live, current)
+	}
+	return live, nil
+}
+
+// Apply server-side applies objs, creating the missing ones and updating
+// the fields this tool manages on the others. It stops at the first object
+// the API server refuses.
+func (c *Client) Apply(ctx context.Context, objs []*unstructured.Unstructured) error {
+	dyn, mapper, err := c.dynamicClient()
+	if err != nil {
+		return err
+	}
+	return apply(ctx, dyn, mapper, objs)
+}
+
+// apply applies objs through dyn, resolving their resources with mapper
+func apply(ctx context.Context, dyn dynamic.Interface, mapper meta.RESTMapper, objs []*unstructured.Unstructured) error {
+	for _, obj := range objs {
+		resource, err := resourceFor(dyn, mapper, obj)
+		if meta.IsNoMatchError(err) {
+			return fmt.Errorf("%s %s is not served by the cluster; install its CRD", obj.GetAPIVersion(), obj.GetKind())
+		}
+		if err != nil {
+			return fmt.Errorf("failed to map %s: %w", obj.GroupVersionKind(), err)
+		}
+		_, err = resource.Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{
+			FieldManager: fieldManager,
+			Force:        true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply %s %s: %w", obj.GetKind(), objectName(obj), err)
+		}
+	}
+	return nil
+}
+
+// objectName formats the name of obj as namespace/name, or name when it is
+// cluster-scoped
+func objectName(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestLiveObjectsAndApply(t *testing.T) {
+	routeGVK := schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+	classGVK := schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "GatewayClass"}
+	policyGVK := schema.GroupVersionKind{Group: "gateway.envoyproxy.io", Version: "v1alpha1", Kind: "BackendTrafficPolicy"}
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(routeGVK, meta.RESTScopeNamespace)
+	mapper.Add(classGVK, meta.RESTScopeRoot)
+
+	object := func(gvk schema.GroupVersionKind, namespace, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		return obj
+	}
+
+	scheme := runtime.NewScheme()
+	listKinds := map[schema.GroupVersionResource]string{
+		{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"}:     "HTTPRouteList",
+		{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "gatewayclasses"}: "GatewayClassList",
+	}
+	existing := object(routeGVK, "default", "web")
+	existing.Object["spec"] = map[string]interface{}{"hostnames": []interface{}{"web.example.com"}}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, listKinds, existing)
+
+	objs := []*unstructured.Unstructured{
+		object(routeGVK, "default", "web"),
+		object(routeGVK, "default", "api"),
+		object(classGVK, "", "nginx"),
+		object(policyGVK, "default", "web"),
+	}
+	live, err := liveObjects(context.Background(), dyn, mapper, objs)
+	if err != nil {
+		t.Fatalf("liveObjects() error = %v", err)
+	}
+	if len(live) != 1 || live[0].GetName() != "web" || !reflect.DeepEqual(live[0].Object["spec"], existing.Object["spec"]) {
+		t.Errorf("liveObjects() = %+v, want the existing web route", live)
+	}
+
+	var applied []string
+	dyn.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch := action.(k8stesting.PatchAction)
+		if patch.GetPatchType() != "application/apply-patch+yaml" {
+			t.Errorf("patch type = %s, want a server-side apply", patch.GetPatchType())
+		}
+		applied = append(applied, action.GetResource().Resource+"/"+action.GetNamespace()+"/"+patch.GetName())
+		return true, &unstructured.Unstructured{}, nil
+	})
+	if err := apply(context.Background(), dyn, mapper, objs[:3]); err != nil {
+		t.Fatalf("apply() error = %v", err)
+	}
+	want := []string{"httproutes/default/web", "httproutes/default/api", "gatewayclasses//nginx"}
+	if !reflect.DeepEqual(applied, want) {
+		t.Errorf("apply() applied %v, want %v", applied, want)
+	}
+
+	// A kind the cluster does not serve stops the apply
+	err = apply(context.Background(), dyn, mapper, objs[3:])
+	if err == nil || !strings.Contains(err.Error(), "not served by the cluster") {
+		t.Errorf("apply() of an unserved kind error = %v, want it not served", err)
+	}
+
+	dyn.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	err = apply(context.Background(), dyn, mapper, objs[:1])
+	if err == nil || err.Error() != "failed to apply HTTPRoute default/web: connection refused" {
+		t.Errorf("apply() error = %v, want the failed route", err)
+	}
+
+	dyn.PrependReactor("get", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	if _, err := liveObjects(context.Background(), dyn, mapper, objs[:1]); err == nil {
+		t.Errorf("liveObjects() succeeded with an unreachable server")
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	// Register the legacy auth providers (oidc, and the gcp/azure stubs that
+	// point users at their exec plugin replacements)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+)
+
+// ConnectionInfo describes how the client reaches the API server
+type ConnectionInfo struct {
+	Server        string // API server URL
+	ServerVersion string // e.g. v1.28.4
+	Proxy         string // proxy URL, empty for a direct connection
+	AuthMethod    string // credential source, e.g. "exec plugin (gke-gcloud-auth-plugin)"
+	User          string // authenticated user, empty if the server cannot report it
+	Groups        []string
+}
+
+// AccessCheck is a permission a command needs
+type AccessCheck struct {
+	Command   string // command that needs the permission
+	Verb      string
+	Group     string
+	Resource  string
+	Namespace string // empty for all namespaces
+}
+
+// String formats the check as "verb group/resource"
+func (a AccessCheck) String() string {
+	resource := a.Resource
+	if a.Group != "" {
+		resource = a.Resource + "." + a.Group
+	}
+	return fmt.Sprintf("%s %s", a.Verb, resource)
+}
+
+// AccessResult is the outcome of an AccessCheck
+type AccessResult struct {
+	AccessCheck
+	Allowed bool
+	Reason  string
+}
+
+// ConnectionInfo contacts the API server and reports the connection details.
+// It fails if the server is unreachable or the credentials are rejected.
+func (c *Client) ConnectionInfo(ctx context.Context) (*ConnectionInfo, error) {
+	info := &ConnectionInfo{
+		Server:     c.config.Host,
+		Proxy:      c.proxyURL(),
+		AuthMethod: c.authMethod(),
+	}
+
+	version, err := c.clientset.Discovery().ServerVersion()
+	if err != nil {
+		return info, fmt.Errorf("failed to reach API server %s: %w", c.config.Host, err)
+	}
+	info.ServerVersion = version.GitVersion
+
+	// SelfSubjectReview is GA in Kubernetes 1.28; older servers leave User empty
+	review, err := c.clientset.AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err == nil {
+		info.User = review.Status.UserInfo.Username
+		info.Groups = review.Status.UserInfo.Groups
+	}
+
+	return info, nil
+}
+
+// CheckAccess runs a SelfSubjectAccessReview for each check
+func (c *Client) CheckAccess(ctx context.Context, checks []AccessCheck) ([]AccessResult, error) {
+	results := make([]AccessResult, 0, len(checks))
+	for _, check := range checks {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: check.Namespace,
+					Verb:      check.Verb,
+					Group:     check.Group,
+					Resource:  check.Resource,
+				},
+			},
+		}
+		resp, err := c.clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			return results, fmt.Errorf("failed to check %s: %w", check, err)
+		}
+		results = append(results, AccessResult{
+			AccessCheck: check,
+			Allowed:     resp.Status.Allowed,
+			Reason:      resp.Status.Reason,
+		})
+	}
+	return results, nil
+}
+
+// proxyURL returns the proxy used for the API server: the kubeconfig
+// proxy-url if set, otherwise HTTPS_PROXY/HTTP_PROXY/NO_PROXY from the
+// environment, which client-go honors by default
+func (c *Client) proxyURL() string {
+	proxy := c.config.Proxy
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+
+	host := c.config.Host
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	target, err := url.Parse(host)
+	if err != nil {
+		return ""
+	}
+	u, err := proxy(&http.Request{URL: target})
+	if err != nil || u == nil {
+		return ""
+	}
+	return u.Redacted()
+}
+
+// authMethod describes where the client credentials come from
+func (c *Client) authMethod() string {
+	var method string
+	switch {
+	case c.config.ExecProvider != nil:
+		method = fmt.Sprintf("exec plugin (%s)", c.config.ExecProvider.Command)
+	case c.config.AuthProvider != nil:
+		method = fmt.Sprintf("auth provider (%s)", c.config.AuthProvider.Name)
+	case c.config.BearerToken != "" || c.config.BearerTokenFile != "":
+		method = "bearer token"
+	case c.config.CertFile != "" || len(c.config.CertData) > 0:
+		method = "client certificate"
+	case c.config.Username != "":
+		method = "basic auth"
+	default:
+		method = "anonymous"
+	}
+
+	if c.config.Impersonate.UserName != "" {
+		method += fmt.Sprintf(", impersonating %s", c.config.Impersonate.UserName)
+	}
+	return method
+}
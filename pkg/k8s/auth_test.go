@@ -0,0 +1,227 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+// writeKubeconfig writes a kubeconfig with a single context named test,
+// adding cluster and user to their entries, and returns its path
+func writeKubeconfig(t *testing.T, server, cluster, user string) string {
+	t.Helper()
+	// Keep a Pod's service account from taking precedence
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("KUBECONFIG", "")
+
+	kubeconfig := `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: ` + server + `
+` + cluster + `contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+    namespace: team-a
+current-context: test
+users:
+- name: test
+  user:
+` + user
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := os.WriteFile(path, []byte(kubeconfig), 0600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+	return path
+}
+
+func TestKubeconfigConnection(t *testing.T) {
+	tests := []struct {
+		name       string
+		server     string
+		cluster    string
+		user       string
+		opts       ClientOptions
+		wantAuth   string
+		wantProxy  string
+		wantServer string
+	}{
+		{
+			name:       "exec plugin",
+			server:     "https://cluster.example.com",
+			user:       "    exec:\n      apiVersion: client.authentication.k8s.io/v1beta1\n      command: gke-gcloud-auth-plugin\n",
+			wantAuth:   "exec plugin (gke-gcloud-auth-plugin)",
+			wantServer: "https://cluster.example.com",
+		},
+		{
+			name:       "kubeconfig proxy-url",
+			server:     "https://cluster.example.com",
+			cluster:    "    proxy-url: http://proxy.example.com:3128\n",
+			user:       "    token: abc\n",
+			wantAuth:   "bearer token",
+			wantProxy:  "http://proxy.example.com:3128",
+			wantServer: "https://cluster.example.com",
+		},
+		{
+			name:       "impersonation",
+			server:     "https://cluster.example.com",
+			user:       "    token: abc\n",
+			opts:       ClientOptions{Impersonate: "system:serviceaccount:migration:auditor"},
+			wantAuth:   "bearer token, impersonating system:serviceaccount:migration:auditor",
+			wantServer: "https://cluster.example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeKubeconfig(t, tt.server, tt.cluster, tt.user)
+
+			client, err := NewClientWithOptions(path, tt.opts)
+			if err != nil {
+				t.Fatalf("NewClientWithOptions() error = %v", err)
+			}
+			if client.Context() != "test" {
+				t.Errorf("Context() = %q, want test", client.Context())
+			}
+			if client.Server() != tt.wantServer {
+				t.Errorf("Server() = %q, want %q", client.Server(), tt.wantServer)
+			}
+			if got := client.authMethod(); got != tt.wantAuth {
+				t.Errorf("authMethod() = %q, want %q", got, tt.wantAuth)
+			}
+			if got := client.proxyURL(); got != tt.wantProxy {
+				t.Errorf("proxyURL() = %q, want %q", got, tt.wantProxy)
+			}
+		})
+	}
+}
+
+func TestKubeconfigMissing(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	if _, err := NewClient(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("NewClient() of a missing kubeconfig succeeded")
+	}
+}
+
+func TestCheckAccess(t *testing.T) {
+	var authorization []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = append(authorization, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/version":
+			_, _ = w.Write([]byte(`{"major":"1","minor":"28","gitVersion":"v1.28.4"}`))
+		case "/apis/authentication.k8s.io/v1/selfsubjectreviews":
+			review := authenticationv1.SelfSubjectReview{}
+			review.Status.UserInfo.Username = "jane"
+			review.Status.UserInfo.Groups = []string{"developers"}
+			_ = json.NewEncoder(w).Encode(review)
+		case "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews":
+			var review authorizationv1.SelfSubjectAccessReview
+			if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			attrs := review.Spec.ResourceAttributes
+			// Cluster-wide access to Gateway API resources is denied
+			review.Status.Allowed = attrs.Namespace != "" || attrs.Group != "gateway.networking.k8s.io"
+			if !review.Status.Allowed {
+				review.Status.Reason = "no RBAC policy matched"
+			}
+			_ = json.NewEncoder(w).Encode(review)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	// Credentials are only sent over TLS
+	client, err := NewClient(writeKubeconfig(t, server.URL, "    insecure-skip-tls-verify: true\n", "    token: abc\n"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	info, err := client.ConnectionInfo(context.Background())
+	if err != nil {
+		t.Fatalf("ConnectionInfo() error = %v", err)
+	}
+	want := &ConnectionInfo{
+		Server:        server.URL,
+		ServerVersion: "v1.28.4",
+		AuthMethod:    "bearer token",
+		User:          "jane",
+		Groups:        []string{"developers"},
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("ConnectionInfo() = %+v, want %+v", info, want)
+	}
+
+	checks := []AccessCheck{
+		{Command: "audit", Verb: "list", Group: "networking.k8s.io", Resource: "ingresses", Namespace: "team-a"},
+		{Command: "audit", Verb: "list", Group: "gateway.networking.k8s.io", Resource: "httproutes"},
+		{Command: "bootstrap", Verb: "create", Group: "gateway.networking.k8s.io", Resource: "gateways", Namespace: "team-a"},
+	}
+	results, err := client.CheckAccess(context.Background(), checks)
+	if err != nil {
+		t.Fatalf("CheckAccess() error = %v", err)
+	}
+	wantResults := []AccessResult{
+		{AccessCheck: checks[0], Allowed: true},
+		{AccessCheck: checks[1], Reason: "no RBAC policy matched"},
+		{AccessCheck: checks[2], Allowed: true},
+	}
+	if !reflect.DeepEqual(results, wantResults) {
+		t.Errorf("CheckAccess() = %+v, want %+v", results, wantResults)
+	}
+
+	for _, header := range authorization {
+		if header != "Bearer abc" {
+			t.Errorf("request authorization = %q, want the kubeconfig token", header)
+		}
+	}
+	if stats := client.Stats(); stats.Requests != int64(len(authorization)) {
+		t.Errorf("Stats().Requests = %v, want %v", stats.Requests, len(authorization))
+	}
+}
+
+func TestAccessCheckString(t *testing.T) {
+	tests := []struct {
+		check AccessCheck
+		want  string
+	}{
+		{check: AccessCheck{Verb: "list", Resource: "namespaces"}, want: "list namespaces"},
+		{check: AccessCheck{Verb: "get", Group: "networking.k8s.io", Resource: "ingresses"}, want: "get ingresses.networking.k8s.io"},
+	}
+	for _, tt := range tests {
+		if got := tt.check.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
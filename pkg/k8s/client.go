@@ -19,60 +19,155 @@ package k8s
 import (
 	"context"
 	"fmt"
-	"os"
-	"path/filepath"
 
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayclient "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 )
 
 // Client wraps Kubernetes client functionality
 type Client struct {
 	clientset *kubernetes.Clientset
+	gateway   gatewayclient.Interface
 	config    *rest.Config
+	context   string
+	telemetry *apiTelemetry
+}
+
+// ClientOptions tunes how the client talks to the API server
+type ClientOptions struct {
+	MaxQPS float32 // client-side request rate limit, 0 keeps the client-go default
+
+	// Identity overrides, mirroring the kubectl flags of the same name
+	Impersonate       string   // --as
+	ImpersonateGroups []string // --as-group
+	Token             string   // --token
+	ClientCertificate string   // --client-certificate
+	ClientKey         string   // --client-key
+}
+
+// applyAuth overrides the kubeconfig identity with the options, if set
+func (o ClientOptions) applyAuth(config *rest.Config) error {
+	if (o.ClientCertificate == "") != (o.ClientKey == "") {
+		return fmt.Errorf("client certificate and key must be set together")
+	}
+	if len(o.ImpersonateGroups) > 0 && o.Impersonate == "" {
+		return fmt.Errorf("impersonating groups requires a user to impersonate")
+	}
+
+	if o.Token != "" || o.ClientCertificate != "" {
+		// Explicit credentials replace whatever the kubeconfig would use
+		config.ExecProvider = nil
+		config.AuthProvider = nil
+		config.BearerToken = ""
+		config.BearerTokenFile = ""
+		config.Username = ""
+		config.Password = ""
+		config.CertFile, config.CertData = "", nil
+		config.KeyFile, config.KeyData = "", nil
+	}
+	if o.Token != "" {
+		config.BearerToken = o.Token
+	}
+	if o.ClientCertificate != "" {
+		config.CertFile = o.ClientCertificate
+		config.KeyFile = o.ClientKey
+	}
+
+	if o.Impersonate != "" {
+		config.Impersonate = rest.ImpersonationConfig{
+			UserName: o.Impersonate,
+			Groups:   o.ImpersonateGroups,
+		}
+	}
+	return nil
 }
 
 // NewClient creates a new Kubernetes client
 func NewClient(kubeconfig string) (*Client, error) {
-	config, err := getKubeConfig(kubeconfig)
+	return NewClientWithOptions(kubeconfig, ClientOptions{})
+}
+
+// NewClientWithOptions creates a new Kubernetes client with custom options
+func NewClientWithOptions(kubeconfig string, opts ClientOptions) (*Client, error) {
+	config, context, err := getKubeConfig(kubeconfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get kubeconfig: %w", err)
 	}
 
+	if err := opts.applyAuth(config); err != nil {
+		return nil, fmt.Errorf("invalid client options: %w", err)
+	}
+
+	telemetry := &apiTelemetry{}
+	telemetry.instrument(config, opts.MaxQPS)
+
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create clientset: %w", err)
 	}
 
+	gateway, err := gatewayclient.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gateway clientset: %w", err)
+	}
+
 	return &Client{
 		clientset: clientset,
+		gateway:   gateway,
 		config:    config,
+		context:   context,
+		telemetry: telemetry,
 	}, nil
 }
 
-// getKubeConfig returns the Kubernetes config
-func getKubeConfig(kubeconfig string) (*rest.Config, error) {
+// Server returns the URL of the API server the client talks to
+func (c *Client) Server() string {
+	return c.config.Host
+}
+
+// Context returns the kubeconfig context in use, or "in-cluster" when
+// running in a Pod
+func (c *Client) Context() string {
+	return c.context
+}
+
+// Stats returns the API server load generated by this client so far
+func (c *Client) Stats() APIStats {
+	return c.telemetry.stats()
+}
+
+// getKubeConfig returns the Kubernetes config and the name of its context
+func getKubeConfig(kubeconfig string) (*rest.Config, string, error) {
 	// Try in-cluster config first
 	if config, err := rest.InClusterConfig(); err == nil {
-		return config, nil
+		return config, "in-cluster", nil
 	}
 
-	// Fall back to kubeconfig file
-	if kubeconfig == "" {
-		if home := os.Getenv("HOME"); home != "" {
-			kubeconfig = filepath.Join(home, ".kube", "config")
+	// Fall back to kubeconfig, honoring --kubeconfig, then $KUBECONFIG, then
+	// ~/.kube/config. Exec credential plugins and proxy-url are resolved here.
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{})
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		if clientcmd.IsEmptyConfig(err) {
+			return nil, "", fmt.Errorf("no kubeconfig found")
 		}
+		return nil, "", err
 	}
 
-	if kubeconfig == "" {
-		return nil, fmt.Errorf("no kubeconfig found")
+	var context string
+	if raw, err := clientConfig.RawConfig(); err == nil {
+		context = raw.CurrentContext
 	}
-
-	return clientcmd.BuildConfigFromFlags("", kubeconfig)
+	return config, context, nil
 }
 
 // CurrentNamespace returns the current namespace from kubeconfig
@@ -128,6 +223,21 @@ func (c *Client) ListIngresses(ctx context.Context, namespace string) ([]*networ
 	return ingresses, nil
 }
 
+// ListIngressClasses retrieves all IngressClass resources
+func (c *Client) ListIngressClasses(ctx context.Context) ([]*networkingv1.IngressClass, error) {
+	list, err := c.clientset.NetworkingV1().IngressClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	classes := make([]*networkingv1.IngressClass, 0, len(list.Items))
+	for i := range list.Items {
+		classes = append(classes, &list.Items[i])
+	}
+
+	return classes, nil
+}
+
 // GetService retrieves a Service resource
 func (c *Client) GetService(ctx context.Context, namespace, name string) (*corev1.Service, error) {
 	return c.clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
@@ -137,3 +247,77 @@ func (c *Client) GetService(ctx context.Context, namespace, name string) (*corev
 func (c *Client) GetSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
 	return c.clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
 }
+
+// GetConfigMap retrieves a ConfigMap resource
+func (c *Client) GetConfigMap(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	return c.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// GetGateway retrieves a Gateway resource
+func (c *Client) GetGateway(ctx context.Context, namespace, name string) (*gatewayv1.Gateway, error) {
+	return c.gateway.GatewayV1().Gateways(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// GetGatewayClass retrieves a GatewayClass resource
+func (c *Client) GetGatewayClass(ctx context.Context, name string) (*gatewayv1.GatewayClass, error) {
+	return c.gateway.GatewayV1().GatewayClasses().Get(ctx, name, metav1.GetOptions{})
+}
+
+// ListGateways retrieves all Gateway resources in a namespace (all namespaces
+// when empty). Clusters without the Gateway API CRDs return an empty list.
+func (c *Client) ListGateways(ctx context.Context, namespace string) ([]*gatewayv1.Gateway, error) {
+	list, err := c.gateway.GatewayV1().Gateways(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	gateways := make([]*gatewayv1.Gateway, 0, len(list.Items))
+	for i := range list.Items {
+		gateways = append(gateways, &list.Items[i])
+	}
+
+	return gateways, nil
+}
+
+// ListHTTPRoutes retrieves all HTTPRoute resources in a namespace (all
+// namespaces when empty). Clusters without the Gateway API CRDs return an
+// empty list.
+func (c *Client) ListHTTPRoutes(ctx context.Context, namespace string) ([]*gatewayv1.HTTPRoute, error) {
+	list, err := c.gateway.GatewayV1().HTTPRoutes(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	routes := make([]*gatewayv1.HTTPRoute, 0, len(list.Items))
+	for i := range list.Items {
+		routes = append(routes, &list.Items[i])
+	}
+
+	return routes, nil
+}
+
+// CreateGatewayClass creates a GatewayClass. It reports false, and no
+// error, when a GatewayClass of that name already exists.
+func (c *Client) CreateGatewayClass(ctx context.Context, class *gatewayv1.GatewayClass) (bool, error) {
+	_, err := c.gateway.GatewayV1().GatewayClasses().Create(ctx, class, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// CreateGateway creates a Gateway. It reports false, and no error, when a
+// Gateway of that name already exists in the namespace.
+func (c *Client) CreateGateway(ctx context.Context, gateway *gatewayv1.Gateway) (bool, error) {
+	_, err := c.gateway.GatewayV1().Gateways(gateway.Namespace).Create(ctx, gateway, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestApplyAuth(t *testing.T) {
+	// kubeconfig identity: an exec plugin, a token file and client cert data
+	kubeconfig := func() *rest.Config {
+		return &rest.Config{
+			Host:            "https://cluster.example.com",
+			ExecProvider:    &clientcmdapi.ExecConfig{Command: "aws", APIVersion: "client.authentication.k8s.io/v1"},
+			BearerTokenFile: "/var/run/token",
+			TLSClientConfig: rest.TLSClientConfig{CertData: []byte("cert"), KeyData: []byte("key")},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		opts    ClientOptions
+		want    func(*rest.Config)
+		wantErr string
+	}{
+		{
+			name: "no overrides",
+			want: func(*rest.Config) {},
+		},
+		{
+			name: "token",
+			opts: ClientOptions{Token: "secret"},
+			want: func(c *rest.Config) {
+				c.ExecProvider = nil
+				c.BearerTokenFile = ""
+				c.CertData, c.KeyData = nil, nil
+				c.BearerToken = "secret"
+			},
+		},
+		{
+			name: "client certificate",
+			opts: ClientOptions{ClientCertificate: "/certs/tls.crt", ClientKey: "/certs/tls.key"},
+			want: func(c *rest.Config) {
+				c.ExecProvider = nil
+				c.BearerTokenFile = ""
+				c.CertData, c.KeyData = nil, nil
+				c.CertFile, c.KeyFile = "/certs/tls.crt", "/certs/tls.key"
+			},
+		},
+		{
+			name: "impersonation keeps the kubeconfig credentials",
+			opts: ClientOptions{Impersonate: "jane", ImpersonateGroups: []string{"developers", "system:authenticated"}},
+			want: func(c *rest.Config) {
+				c.Impersonate = rest.ImpersonationConfig{UserName: "jane", Groups: []string{"developers", "system:authenticated"}}
+			},
+		},
+		{
+			name: "token and impersonation",
+			opts: ClientOptions{Token: "secret", Impersonate: "system:serviceaccount:ci:deployer"},
+			want: func(c *rest.Config) {
+				c.ExecProvider = nil
+				c.BearerTokenFile = ""
+				c.CertData, c.KeyData = nil, nil
+				c.BearerToken = "secret"
+				c.Impersonate = rest.ImpersonationConfig{UserName: "system:serviceaccount:ci:deployer"}
+			},
+		},
+		{
+			name:    "certificate without key",
+			opts:    ClientOptions{ClientCertificate: "/certs/tls.crt"},
+			wantErr: "client certificate and key must be set together",
+		},
+		{
+			name:    "key without certificate",
+			opts:    ClientOptions{ClientKey: "/certs/tls.key"},
+			wantErr: "client certificate and key must be set together",
+		},
+		{
+			name:    "groups without user",
+			opts:    ClientOptions{ImpersonateGroups: []string{"developers"}},
+			wantErr: "impersonating groups requires a user to impersonate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := kubeconfig()
+			err := tt.opts.applyAuth(config)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("applyAuth() error = %v, want %q", err, tt.wantErr)
+				}
+				if !reflect.DeepEqual(config, kubeconfig()) {
+					t.Errorf("applyAuth() changed the config on error: %+v", config)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyAuth() error = %v", err)
+			}
+
+			want := kubeconfig()
+			tt.want(want)
+			if !reflect.DeepEqual(config, want) {
+				t.Errorf("applyAuth() config = %+v, want %+v", config, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+// fieldManager is the field manager of applies and dry-run applies
+const fieldManager = "ingress-to-gateway"
+
+// DryRunRejection is a resource the API server refused in a server-side
+// dry run
+type DryRunRejection struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Reason    string
+}
+
+// String formats the rejection as Kind namespace/name: reason
+func (r DryRunRejection) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s %s: %s", r.Kind, r.Name, r.Reason)
+	}
+	return fmt.Sprintf("%s %s/%s: %s", r.Kind, r.Namespace, r.Name, r.Reason)
+}
+
+// ServerDryRun submits objs to the API server as server-side applies with
+// dry-run=All, so the CRD schemas and admission webhooks check them without
+// persisting anything. Objects the server refuses, or whose kind it does not
+// serve, are returned as rejections; failing to reach the server is an
+// error.
+func (c *Client) ServerDryRun(ctx context.Context, objs []*unstructured.Unstructured) ([]DryRunRejection, error) {
+	dyn, mapper, err := c.dynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	return serverDryRun(ctx, dyn, mapper, objs)
+}
+
+// dynamicClient returns a dynamic client for the cluster and a mapper
+// resolving kinds to its resources
+func (c *Client) dynamicClient() (dynamic.Interface, meta.RESTMapper, error) {
+	dyn, err := dynamic.NewForConfig(c.config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	return dyn, restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(c.clientset.Discovery())), nil
+}
+
+// resourceFor returns the resource of obj in dyn, nil with a NoMatch error
+// when the cluster does not serve its kind
+func resourceFor(dyn dynamic.Interface, mapper meta.RESTMapper, obj *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return dyn.Resource(mapping.Resource).Namespace(obj.GetNamespace()), nil
+	}
+	return dyn.Resource(mapping.Resource), nil
+}
+
+// serverDryRun dry-runs objs through dyn, resolving their resources with
+// mapper
+func serverDryRun(ctx context.Context, dyn dynamic.Interface, mapper meta.RESTMapper, objs []*unstructured.Unstructured) ([]DryRunRejection, error) {
+	var rejections []DryRunRejection
+	for _, obj := range objs {
+		gvk := obj.GroupVersionKind()
+		rejection := DryRunRejection{Kind: gvk.Kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+
+		resource, err := resourceFor(dyn, mapper, obj)
+		if meta.IsNoMatchError(err) {
+			rejection.Reason = fmt.Sprintf("%s %s is not served by the cluster; install its CRD", obj.GetAPIVersion(), gvk.Kind)
+			rejections = append(rejections, rejection)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to map %s: %w", gvk, err)
+		}
+
+		_, err = resource.Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{
+			DryRun:       []string{metav1.DryRunAll},
+			FieldManager: fieldManager,
+			Force:        true,
+		})
+		var status apierrors.APIStatus
+		if err != nil && errors.As(err, &status) {
+			rejection.Reason = status.Status().Message
+			rejections = append(rejections, rejection)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to dry-run %s: %w", rejection, err)
+		}
+	}
+	return rejections, nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestServerDryRun(t *testing.T) {
+	routeGVK := schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+	classGVK := schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "GatewayClass"}
+	policyGVK := schema.GroupVersionKind{Group: "gateway.envoyproxy.io", Version: "v1alpha1", Kind: "BackendTrafficPolicy"}
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(routeGVK, meta.RESTScopeNamespace)
+	mapper.Add(classGVK, meta.RESTScopeRoot)
+
+	object := func(gvk schema.GroupVersionKind, namespace, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		return obj
+	}
+
+	tests := []struct {
+		name           string
+		objs           []*unstructured.Unstructured
+		applyErr       error
+		wantApplied    []string // resource/namespace of each apply
+		wantRejections []string
+		wantErr        bool
+	}{
+		{
+			name:        "accepted",
+			objs:        []*unstructured.Unstructured{object(routeGVK, "default", "web"), object(classGVK, "", "nginx")},
+			wantApplied: []string{"httproutes/default", "gatewayclasses/"},
+		},
+		{
+			name:           "kind not served",
+			objs:           []*unstructured.Unstructured{object(policyGVK, "default", "web")},
+			wantRejections: []string{"BackendTrafficPolicy default/web: gateway.envoyproxy.io/v1alpha1 BackendTrafficPolicy is not served by the cluster; install its CRD"},
+		},
+		{
+			name: "refused by the server",
+			objs: []*unstructured.Unstructured{object(routeGVK, "default", "web")},
+			applyErr: apierrors.NewInvalid(routeGVK.GroupKind(), "web", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "hostnames").Index(0), "*", "must be a valid hostname"),
+			}),
+			wantApplied:    []string{"httproutes/default"},
+			wantRejections: []string{`HTTPRoute default/web: HTTPRoute.gateway.networking.k8s.io "web" is invalid: spec.hostnames[0]: Invalid value: "*": must be a valid hostname`},
+		},
+		{
+			name:     "server unreachable",
+			objs:     []*unstructured.Unstructured{object(routeGVK, "default", "web")},
+			applyErr: errors.New("dial tcp 10.0.0.1:443: connect: connection refused"),
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+			var applied []string
+			dyn.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				applied = append(applied, action.GetResource().Resource+"/"+action.GetNamespace())
+				if tt.applyErr != nil {
+					return true, nil, tt.applyErr
+				}
+				return true, &unstructured.Unstructured{}, nil
+			})
+
+			rejections, err := serverDryRun(context.Background(), dyn, mapper, tt.objs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("serverDryRun() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var got []string
+			for _, r := range rejections {
+				got = append(got, r.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.wantRejections, "\n") {
+				t.Errorf("serverDryRun() rejections = %q, want %q", got, tt.wantRejections)
+			}
+			if strings.Join(applied, ",") != strings.Join(tt.wantApplied, ",") {
+				t.Errorf("serverDryRun() applied %v, want %v", applied, tt.wantApplied)
+			}
+		})
+	}
+}
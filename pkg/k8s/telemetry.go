@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// throttleThreshold is the rate limiter wait counted as client-side throttling
+const throttleThreshold = 10 * time.Millisecond
+
+// APIStats summarizes the load a run put on the API server
+type APIStats struct {
+	Requests        int64         // requests sent to the API server
+	Throttled       int64         // requests delayed by the client-side rate limiter
+	ThrottledWait   time.Duration // total time spent waiting on the rate limiter
+	ServerThrottled int64         // requests rejected with 429 Too Many Requests
+}
+
+// String formats the stats for CLI output
+func (s APIStats) String() string {
+	return fmt.Sprintf("API requests: %d, client-side throttled: %d (waited %s), server throttled (429): %d",
+		s.Requests, s.Throttled, s.ThrottledWait.Round(time.Millisecond), s.ServerThrottled)
+}
+
+// apiTelemetry collects APIStats from the transport and rate limiter
+type apiTelemetry struct {
+	requests        atomic.Int64
+	throttled       atomic.Int64
+	throttledWait   atomic.Int64
+	serverThrottled atomic.Int64
+}
+
+// stats returns a snapshot of the collected counters
+func (t *apiTelemetry) stats() APIStats {
+	return APIStats{
+		Requests:        t.requests.Load(),
+		Throttled:       t.throttled.Load(),
+		ThrottledWait:   time.Duration(t.throttledWait.Load()),
+		ServerThrottled: t.serverThrottled.Load(),
+	}
+}
+
+// instrument installs the counting transport and rate limiter on config
+func (t *apiTelemetry) instrument(config *rest.Config, maxQPS float32) {
+	qps, burst := config.QPS, config.Burst
+	if maxQPS > 0 {
+		qps = maxQPS
+		burst = int(maxQPS * 2)
+		if burst < 1 {
+			burst = 1
+		}
+	}
+	if qps == 0 {
+		qps = rest.DefaultQPS
+	}
+	if burst == 0 {
+		burst = rest.DefaultBurst
+	}
+	config.QPS = qps
+	config.Burst = burst
+	config.RateLimiter = &timedRateLimiter{
+		RateLimiter: flowcontrol.NewTokenBucketRateLimiter(qps, burst),
+		telemetry:   t,
+	}
+
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &countingTransport{next: rt, telemetry: t}
+	})
+}
+
+// timedRateLimiter records how long requests wait for a token
+type timedRateLimiter struct {
+	flowcontrol.RateLimiter
+	telemetry *apiTelemetry
+}
+
+// Wait blocks until a token is available and records the delay
+func (l *timedRateLimiter) Wait(ctx context.Context) error {
+	start := time.Now()
+	err := l.RateLimiter.Wait(ctx)
+	l.record(time.Since(start))
+	return err
+}
+
+// Accept blocks until a token is available and records the delay
+func (l *timedRateLimiter) Accept() {
+	start := time.Now()
+	l.RateLimiter.Accept()
+	l.record(time.Since(start))
+}
+
+func (l *timedRateLimiter) record(wait time.Duration) {
+	if wait < throttleThreshold {
+		return
+	}
+	l.telemetry.throttled.Add(1)
+	l.telemetry.throttledWait.Add(int64(wait))
+}
+
+// countingTransport counts API requests and server-side throttling
+type countingTransport struct {
+	next      http.RoundTripper
+	telemetry *apiTelemetry
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.telemetry.requests.Add(1)
+	resp, err := t.next.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		t.telemetry.serverThrottled.Add(1)
+	}
+	return resp, err
+}
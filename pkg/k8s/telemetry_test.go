@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// delayedRateLimiter hands out tokens after a fixed delay
+type delayedRateLimiter struct {
+	flowcontrol.RateLimiter
+	delay time.Duration
+}
+
+func (l *delayedRateLimiter) Wait(ctx context.Context) error {
+	time.Sleep(l.delay)
+	return ctx.Err()
+}
+
+func (l *delayedRateLimiter) Accept() {
+	time.Sleep(l.delay)
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTimedRateLimiter(t *testing.T) {
+	tests := []struct {
+		name          string
+		delay         time.Duration
+		wantThrottled int64
+	}{
+		{name: "token available", delay: 0},
+		{name: "below threshold", delay: throttleThreshold / 5},
+		{name: "throttled", delay: 2 * throttleThreshold, wantThrottled: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			telemetry := &apiTelemetry{}
+			limiter := &timedRateLimiter{RateLimiter: &delayedRateLimiter{delay: tt.delay}, telemetry: telemetry}
+
+			if err := limiter.Wait(context.Background()); err != nil {
+				t.Fatalf("Wait() error = %v", err)
+			}
+			limiter.Accept()
+
+			stats := telemetry.stats()
+			if stats.Throttled != tt.wantThrottled {
+				t.Errorf("Throttled = %v, want %v", stats.Throttled, tt.wantThrottled)
+			}
+			if tt.wantThrottled == 0 && stats.ThrottledWait != 0 {
+				t.Errorf("ThrottledWait = %v, want 0 when not throttled", stats.ThrottledWait)
+			}
+			if tt.wantThrottled > 0 && stats.ThrottledWait < time.Duration(tt.wantThrottled)*tt.delay {
+				t.Errorf("ThrottledWait = %v, want at least %v", stats.ThrottledWait, time.Duration(tt.wantThrottled)*tt.delay)
+			}
+		})
+	}
+}
+
+func TestTimedRateLimiterRecordThreshold(t *testing.T) {
+	telemetry := &apiTelemetry{}
+	limiter := &timedRateLimiter{telemetry: telemetry}
+
+	limiter.record(throttleThreshold - time.Nanosecond)
+	limiter.record(throttleThreshold)
+	limiter.record(time.Second)
+
+	stats := telemetry.stats()
+	if stats.Throttled != 2 {
+		t.Errorf("Throttled = %v, want 2", stats.Throttled)
+	}
+	if want := throttleThreshold + time.Second; stats.ThrottledWait != want {
+		t.Errorf("ThrottledWait = %v, want %v", stats.ThrottledWait, want)
+	}
+}
+
+func TestCountingTransport(t *testing.T) {
+	statuses := []int{http.StatusOK, http.StatusTooManyRequests, http.StatusNotFound, http.StatusTooManyRequests}
+	var i int
+	telemetry := &apiTelemetry{}
+	transport := &countingTransport{
+		next: roundTripFunc(func(*http.Request) (*http.Response, error) {
+			if i == len(statuses) {
+				return nil, errors.New("connection refused")
+			}
+			status := statuses[i]
+			i++
+			return &http.Response{StatusCode: status}, nil
+		}),
+		telemetry: telemetry,
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "https://cluster.example.com/api", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	for range statuses {
+		if _, err := transport.RoundTrip(req); err != nil {
+			t.Fatalf("RoundTrip() error = %v", err)
+		}
+	}
+	if _, err := transport.RoundTrip(req); err == nil {
+		t.Errorf("RoundTrip() did not return the transport error")
+	}
+
+	stats := telemetry.stats()
+	if stats.Requests != 5 {
+		t.Errorf("Requests = %v, want 5", stats.Requests)
+	}
+	if stats.ServerThrottled != 2 {
+		t.Errorf("ServerThrottled = %v, want 2", stats.ServerThrottled)
+	}
+}
+
+func TestInstrument(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    rest.Config
+		maxQPS    float32
+		wantQPS   float32
+		wantBurst int
+	}{
+		{name: "client-go defaults", wantQPS: rest.DefaultQPS, wantBurst: rest.DefaultBurst},
+		{name: "kubeconfig limits", config: rest.Config{QPS: 20, Burst: 40}, wantQPS: 20, wantBurst: 40},
+		{name: "max qps", config: rest.Config{QPS: 20, Burst: 40}, maxQPS: 3, wantQPS: 3, wantBurst: 6},
+		{name: "max qps below one", maxQPS: 0.2, wantQPS: 0.2, wantBurst: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			(&apiTelemetry{}).instrument(&config, tt.maxQPS)
+
+			if config.QPS != tt.wantQPS || config.Burst != tt.wantBurst {
+				t.Errorf("QPS, Burst = %v, %v, want %v, %v", config.QPS, config.Burst, tt.wantQPS, tt.wantBurst)
+			}
+			if _, ok := config.RateLimiter.(*timedRateLimiter); !ok {
+				t.Errorf("RateLimiter = %T, want *timedRateLimiter", config.RateLimiter)
+			}
+			if config.WrapTransport == nil {
+				t.Fatalf("WrapTransport not set")
+			}
+			if _, ok := config.WrapTransport(http.DefaultTransport).(*countingTransport); !ok {
+				t.Errorf("wrapped transport is not a *countingTransport")
+			}
+		})
+	}
+}
+
+func TestAPIStatsString(t *testing.T) {
+	stats := APIStats{Requests: 42, Throttled: 3, ThrottledWait: 1234567 * time.Microsecond, ServerThrottled: 1}
+	want := "API requests: 42, client-side throttled: 3 (waited 1.235s), server throttled (429): 1"
+	if got := stats.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -20,18 +20,46 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/mayens/ingress-to-gateway/pkg/analyzer"
+	"github.com/mayens/ingress-to-gateway/pkg/converter"
 	"sigs.k8s.io/yaml"
 )
 
 // Reporter generates reports for analysis results
 type Reporter struct {
-	format   string // table, json, yaml
-	detailed bool
+	format     string // table, json, yaml
+	detailed   bool
+	metadata   *RunMetadata
+	controller *analyzer.ControllerConfig
 }
 
+// RunMetadata identifies the run that produced a report, so reports read
+// long after the fact can be traced back to the cluster and options used
+type RunMetadata struct {
+	Tool       string
+	Version    string
+	GitCommit  string
+	Timestamp  time.Time // UTC
+	Cluster    string    // API server URL
+	Context    string    // kubeconfig context, or "in-cluster"
+	Namespaces []string
+	Options    map[string]string // flags set explicitly on the command line
+}
+
+// auditReport is the structured form of an audit report
+type auditReport struct {
+	Metadata         *RunMetadata
+	ControllerConfig *analyzer.ControllerConfig `json:",omitempty"`
+	Ingresses        []*analyzer.AnalysisResult
+}
+
+// maxListedNamespaces is how many namespaces the table header lists by name
+const maxListedNamespaces = 10
+
 // NewReporter creates a new Reporter
 func NewReporter(format string, detailed bool) *Reporter {
 	return &Reporter{
@@ -40,6 +68,17 @@ func NewReporter(format string, detailed bool) *Reporter {
 	}
 }
 
+// SetMetadata sets the run metadata printed at the top of every report
+func (r *Reporter) SetMetadata(metadata *RunMetadata) {
+	r.metadata = metadata
+}
+
+// SetControllerConfig adds the analysis of the ingress-nginx controller
+// ConfigMap to audit reports
+func (r *Reporter) SetControllerConfig(cfg *analyzer.ControllerConfig) {
+	r.controller = cfg
+}
+
 // GenerateAuditReport generates an audit report
 func (r *Reporter) GenerateAuditReport(results []*analyzer.AnalysisResult, w io.Writer) error {
 	switch r.format {
@@ -59,8 +98,18 @@ func (r *Reporter) generateTableReport(results []*analyzer.AnalysisResult, w io.
 	fmt.Fprintln(w, "╚═══════════════════════════════════════════════════════════════════════════════╝")
 	fmt.Fprintln(w)
 
+	if r.metadata != nil {
+		r.printMetadata(w)
+		fmt.Fprintln(w)
+	}
+
 	// Summary
 	fmt.Fprintf(w, "Total Ingress Resources: %d\n", len(results))
+	fidelity := make([]converter.Fidelity, 0, len(results))
+	for _, result := range results {
+		fidelity = append(fidelity, result.Fidelity)
+	}
+	fmt.Fprintf(w, "Annotation Fidelity: %.1f%% (weighted share of nginx annotations converted)\n", converter.AggregateFidelity(fidelity))
 	fmt.Fprintln(w)
 
 	// Readiness summary
@@ -76,11 +125,18 @@ func (r *Reporter) generateTableReport(results []*analyzer.AnalysisResult, w io.
 			icon = "⚠️"
 		} else if readiness == "MANUAL_REVIEW_REQUIRED" {
 			icon = "❌"
+		} else if readiness == analyzer.ReadinessOperatorManaged {
+			icon = "⏭️"
 		}
 		fmt.Fprintf(w, "  %s %s: %d\n", icon, readiness, count)
 	}
 	fmt.Fprintln(w)
 
+	if r.controller != nil {
+		r.printControllerConfig(w)
+		fmt.Fprintln(w)
+	}
+
 	// Detailed results
 	fmt.Fprintln(w, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Fprintln(w, "INGRESS DETAILS")
@@ -95,6 +151,63 @@ func (r *Reporter) generateTableReport(results []*analyzer.AnalysisResult, w io.
 	return nil
 }
 
+// printMetadata prints the run metadata header, with the timestamp in both
+// local time and UTC so readers in other time zones can correlate it
+func (r *Reporter) printMetadata(w io.Writer) {
+	m := r.metadata
+	version := m.Version
+	if m.GitCommit != "" && m.GitCommit != "unknown" {
+		version += " (" + m.GitCommit + ")"
+	}
+	fmt.Fprintf(w, "Generated: %s (%s)\n", m.Timestamp.Local().Format("2006-01-02 15:04:05 MST"), m.Timestamp.UTC().Format(time.RFC3339))
+	fmt.Fprintf(w, "Tool: %s %s\n", m.Tool, version)
+	if m.Cluster != "" {
+		fmt.Fprintf(w, "Cluster: %s\n", m.Cluster)
+	}
+	if m.Context != "" {
+		fmt.Fprintf(w, "Context: %s\n", m.Context)
+	}
+	if len(m.Namespaces) <= maxListedNamespaces {
+		fmt.Fprintf(w, "Namespaces: %d (%s)\n", len(m.Namespaces), strings.Join(m.Namespaces, ", "))
+	} else {
+		fmt.Fprintf(w, "Namespaces: %d\n", len(m.Namespaces))
+	}
+	if len(m.Options) > 0 {
+		names := make([]string, 0, len(m.Options))
+		for name := range m.Options {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		options := make([]string, 0, len(names))
+		for _, name := range names {
+			options = append(options, fmt.Sprintf("--%s=%s", name, m.Options[name]))
+		}
+		fmt.Fprintf(w, "Options: %s\n", strings.Join(options, " "))
+	}
+}
+
+// printControllerConfig prints the controller ConfigMap settings that do
+// not fully carry over, all of them with --detailed
+func (r *Reporter) printControllerConfig(w io.Writer) {
+	cfg := r.controller
+	settings := cfg.Unconverted()
+	if r.detailed {
+		settings = cfg.Settings
+	}
+	fmt.Fprintf(w, "Controller ConfigMap: %s (%d settings, %d not fully converted)\n",
+		cfg.ConfigMap, len(cfg.Settings), len(cfg.Unconverted()))
+	for _, s := range settings {
+		line := fmt.Sprintf("  • %s=%s: %s", s.Key, s.Value, s.Status)
+		if s.ConvertedTo != "" {
+			line += " to " + s.ConvertedTo
+		}
+		if s.Reason != "" {
+			line += " (" + s.Reason + ")"
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
 // printIngressDetail prints detailed information for a single Ingress
 func (r *Reporter) printIngressDetail(result *analyzer.AnalysisResult, w io.Writer) {
 	// Header
@@ -115,14 +228,31 @@ func (r *Reporter) printIngressDetail(result *analyzer.AnalysisResult, w io.Writ
 		icon = "⚠️"
 	} else if result.MigrationReadiness == "MANUAL_REVIEW_REQUIRED" {
 		icon = "❌"
+	} else if result.MigrationReadiness == analyzer.ReadinessOperatorManaged {
+		icon = "⏭️"
 	}
 	fmt.Fprintf(w, "  Migration Readiness: %s %s (Complexity: %d)\n", icon, result.MigrationReadiness, result.ComplexityScore)
+	if result.ManagedBy != "" {
+		fmt.Fprintf(w, "  Managed By: %s (skipped by convert and batch unless --include-managed)\n", result.ManagedBy)
+	}
+	fmt.Fprintf(w, "  Annotation Fidelity: %s\n", result.Fidelity)
+	if len(result.Fidelity.Dropped) > 0 {
+		fmt.Fprintf(w, "  Not Converted: %s\n", strings.Join(result.Fidelity.Dropped, ", "))
+	}
 
 	// Features
 	if len(result.DetectedFeatures) > 0 {
 		fmt.Fprintf(w, "  Detected Features: %s\n", strings.Join(result.DetectedFeatures, ", "))
 	}
 
+	// Certificate renewal
+	if len(result.CertRotation) > 0 {
+		fmt.Fprintln(w, "  🔑 Certificate Rotation:")
+		for _, rotation := range result.CertRotation {
+			fmt.Fprintf(w, "    • %s (%s): %s\n", rotation.Secret, rotation.Source, rotation.Guidance)
+		}
+	}
+
 	// Issues
 	if len(result.Issues) > 0 {
 		fmt.Fprintln(w, "  ⚠️  Issues:")
@@ -144,15 +274,101 @@ func (r *Reporter) printIngressDetail(result *analyzer.AnalysisResult, w io.Writ
 func (r *Reporter) generateJSONReport(results []*analyzer.AnalysisResult, w io.Writer) error {
 	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
-	return encoder.Encode(results)
+	return encoder.Encode(auditReport{Metadata: r.metadata, ControllerConfig: r.controller, Ingresses: results})
 }
 
 // generateYAMLReport generates a YAML format report
 func (r *Reporter) generateYAMLReport(results []*analyzer.AnalysisResult, w io.Writer) error {
-	data, err := yaml.Marshal(results)
+	data, err := yaml.Marshal(auditReport{Metadata: r.metadata, ControllerConfig: r.controller, Ingresses: results})
 	if err != nil {
 		return err
 	}
 	_, err = w.Write(data)
 	return err
 }
+
+// GenerateLintReport generates an annotation lint report
+func (r *Reporter) GenerateLintReport(results []*analyzer.LintResult, w io.Writer) error {
+	switch r.format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	case "yaml":
+		data, err := yaml.Marshal(results)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	total := 0
+	for _, result := range results {
+		if len(result.Findings) == 0 {
+			if r.detailed {
+				fmt.Fprintf(w, "✅ %s/%s: no issues\n", result.Namespace, result.Name)
+			}
+			continue
+		}
+
+		fmt.Fprintf(w, "📋 Ingress: %s/%s\n", result.Namespace, result.Name)
+		for _, finding := range result.Findings {
+			icon := "⚠️ "
+			if finding.Severity == "error" {
+				icon = "❌"
+			}
+			fmt.Fprintf(w, "  %s %s: %s\n", icon, finding.Annotation, finding.Message)
+		}
+		fmt.Fprintln(w)
+		total += len(result.Findings)
+	}
+
+	fmt.Fprintf(w, "Linted %d Ingress resource(s), %d finding(s)\n", len(results), total)
+	return nil
+}
+
+// GenerateImplementationsReport generates a report of the target
+// implementations and what the converter generates for each
+func (r *Reporter) GenerateImplementationsReport(caps []converter.Capabilities, w io.Writer) error {
+	switch r.format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(caps)
+	case "yaml":
+		data, err := yaml.Marshal(caps)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	for _, c := range caps {
+		fmt.Fprintf(w, "🧩 %s\n", c.Name)
+		fmt.Fprintf(w, "  GatewayClass: %s\n", c.GatewayClass)
+		if c.ControllerName != "" {
+			fmt.Fprintf(w, "  Controller:   %s\n", c.ControllerName)
+		}
+		fmt.Fprintf(w, "  Features:     %s\n", strings.Join(c.Features, ", "))
+		if len(c.Unsupported) > 0 {
+			fmt.Fprintf(w, "  Unsupported:  %s\n", strings.Join(c.Unsupported, ", "))
+		}
+		for _, p := range c.Policies {
+			var annotations []string
+			for _, a := range p.Annotations {
+				annotations = append(annotations, strings.TrimPrefix(a, "nginx.ingress.kubernetes.io/"))
+			}
+			fmt.Fprintf(w, "  Policy:       %s (%s)\n", p.Kind, strings.Join(annotations, ", "))
+		}
+		if r.detailed {
+			fmt.Fprintln(w, "  CRDs:")
+			for _, crd := range c.CRDs {
+				fmt.Fprintf(w, "    - %s\n", crd)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// certExpiryWarning is how close to expiry a certificate is reported
+const certExpiryWarning = 14 * 24 * time.Hour
+
+// ClusterLookup resolves the cluster objects an HTTPRoute references
+type ClusterLookup interface {
+	GetGateway(ctx context.Context, namespace, name string) (*gatewayv1.Gateway, error)
+	GetSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error)
+}
+
+// CheckCertificate checks that the certificate in a TLS secret covers every
+// host (wildcards included) and is currently valid. Errors make the secret
+// unusable for the hosts; warnings flag certificates close to expiry.
+func CheckCertificate(secret *corev1.Secret, hosts []string, now time.Time) (errs, warnings []string) {
+	name := fmt.Sprintf("%s/%s", secret.Namespace, secret.Name)
+
+	if len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		return []string{fmt.Sprintf("TLS secret %s has no %s", name, corev1.TLSPrivateKeyKey)}, nil
+	}
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil || block.Type != "CERTIFICATE" {
+		return []string{fmt.Sprintf("TLS secret %s has no PEM certificate in %s", name, corev1.TLSCertKey)}, nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return []string{fmt.Sprintf("TLS secret %s: failed to parse certificate: %v", name, err)}, nil
+	}
+
+	switch {
+	case now.After(cert.NotAfter):
+		errs = append(errs, fmt.Sprintf("certificate in TLS secret %s expired on %s", name, cert.NotAfter.Format(time.RFC3339)))
+	case now.Before(cert.NotBefore):
+		errs = append(errs, fmt.Sprintf("certificate in TLS secret %s is not valid before %s", name, cert.NotBefore.Format(time.RFC3339)))
+	case cert.NotAfter.Sub(now) < certExpiryWarning:
+		warnings = append(warnings, fmt.Sprintf("certificate in TLS secret %s expires on %s", name, cert.NotAfter.Format(time.RFC3339)))
+	}
+
+	var uncovered []string
+	for _, host := range hosts {
+		if cert.VerifyHostname(host) != nil {
+			uncovered = append(uncovered, host)
+		}
+	}
+	if len(uncovered) > 0 {
+		errs = append(errs, fmt.Sprintf("certificate in TLS secret %s does not cover %s (SANs: %s)",
+			name, strings.Join(uncovered, ", "), strings.Join(certificateNames(cert), ", ")))
+	}
+
+	return errs, warnings
+}
+
+// certificateNames lists the DNS names a certificate is valid for
+func certificateNames(cert *x509.Certificate) []string {
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames
+	}
+	if cert.Subject.CommonName != "" {
+		return []string{cert.Subject.CommonName + " (CN only)"}
+	}
+	return []string{"none"}
+}
+
+// validateCertificates checks the certificates of the HTTPS listeners the
+// route attaches to against its hostnames, when a cluster lookup is set
+func (v *Validator) validateCertificates(ctx context.Context, hr *gatewayv1.HTTPRoute, result *ValidationResult) error {
+	lookup := v.clusterLookup()
+	if lookup == nil {
+		return nil
+	}
+
+	for _, ref := range hr.Spec.ParentRefs {
+		if ref.Kind != nil && *ref.Kind != "Gateway" {
+			continue
+		}
+		ns := hr.Namespace
+		if ref.Namespace != nil {
+			ns = string(*ref.Namespace)
+		}
+
+		gw, err := lookup.GetGateway(ctx, ns, string(ref.Name))
+		if apierrors.IsNotFound(err) {
+			// An error of validateParentGateways when it is enabled
+			if v.parentGatewayLookup() == nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("Gateway %s/%s not found; certificates not checked", ns, ref.Name))
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get gateway %s/%s: %w", ns, ref.Name, err)
+		}
+
+		for _, listener := range gw.Spec.Listeners {
+			if listener.Protocol != gatewayv1.HTTPSProtocolType || listener.TLS == nil {
+				continue
+			}
+			if ref.SectionName != nil && *ref.SectionName != listener.Name {
+				continue
+			}
+			hosts := listenerHosts(hr, listener)
+			if len(hosts) == 0 {
+				continue
+			}
+
+			for _, certRef := range listener.TLS.CertificateRefs {
+				secretNS := gw.Namespace
+				if certRef.Namespace != nil {
+					secretNS = string(*certRef.Namespace)
+				}
+				secret, err := lookup.GetSecret(ctx, secretNS, string(certRef.Name))
+				if apierrors.IsNotFound(err) {
+					result.Errors = append(result.Errors, fmt.Sprintf("listener %s: TLS secret %s/%s not found", listener.Name, secretNS, certRef.Name))
+					continue
+				}
+				if err != nil {
+					return fmt.Errorf("failed to get secret %s/%s: %w", secretNS, certRef.Name, err)
+				}
+				errs, warnings := CheckCertificate(secret, hosts, time.Now())
+				for _, e := range errs {
+					result.Errors = append(result.Errors, fmt.Sprintf("listener %s: %s", listener.Name, e))
+				}
+				for _, w := range warnings {
+					result.Warnings = append(result.Warnings, fmt.Sprintf("listener %s: %s", listener.Name, w))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// listenerHosts returns the route hostnames a listener serves
+func listenerHosts(hr *gatewayv1.HTTPRoute, listener gatewayv1.Listener) []string {
+	var hosts []string
+	for _, h := range hr.Spec.Hostnames {
+		host := string(h)
+		if listener.Hostname == nil || hostnameMatches(string(*listener.Hostname), host) {
+			hosts = append(hosts, host)
+		}
+	}
+	// A hostname-less route inherits the listener hostname
+	if len(hr.Spec.Hostnames) == 0 && listener.Hostname != nil {
+		hosts = append(hosts, string(*listener.Hostname))
+	}
+	return hosts
+}
+
+// hostnameMatches reports whether a listener hostname accepts a route
+// hostname; wildcards match one or more labels as in Gateway API
+func hostnameMatches(listener, host string) bool {
+	if listener == host {
+		return true
+	}
+	if suffix, ok := strings.CutPrefix(listener, "*"); ok {
+		return strings.HasSuffix(host, suffix) && len(host) > len(suffix)
+	}
+	if suffix, ok := strings.CutPrefix(host, "*"); ok {
+		return strings.HasSuffix(listener, suffix) && len(listener) > len(suffix)
+	}
+	return false
+}
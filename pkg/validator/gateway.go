@@ -0,0 +1,298 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// maxListeners is the most listeners a Gateway accepts
+const maxListeners = 64
+
+var (
+	// sectionNameRegex matches listener names
+	sectionNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+	// controllerNameRegex matches domain-prefixed GatewayClass controller
+	// names such as gateway.envoyproxy.io/gatewayclass-controller
+	controllerNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/[A-Za-z0-9/\-._~%!$&'()*+,;=:]+$`)
+)
+
+// listenerRouteKinds are the route kinds of the Gateway API group each core
+// listener protocol admits
+var listenerRouteKinds = map[gatewayv1.ProtocolType][]string{
+	gatewayv1.HTTPProtocolType:  {"HTTPRoute", "GRPCRoute"},
+	gatewayv1.HTTPSProtocolType: {"HTTPRoute", "GRPCRoute"},
+	gatewayv1.TLSProtocolType:   {"TLSRoute", "TCPRoute"},
+	gatewayv1.TCPProtocolType:   {"TCPRoute"},
+	gatewayv1.UDPProtocolType:   {"UDPRoute"},
+}
+
+// validateGateway validates a single Gateway
+func (v *Validator) validateGateway(gw *gatewayv1.Gateway) *ValidationResult {
+	result := &ValidationResult{
+		Kind:         "Gateway",
+		ResourceName: fmt.Sprintf("%s/%s", gw.Namespace, gw.Name),
+	}
+
+	v.validateMetadata(gw.Name, gw.Namespace, true, result)
+
+	if gw.Spec.GatewayClassName == "" {
+		result.Errors = append(result.Errors, "gatewayClassName is required")
+	}
+
+	if len(gw.Spec.Listeners) == 0 {
+		result.Errors = append(result.Errors, "at least one listener is required")
+		return result
+	}
+	if len(gw.Spec.Listeners) > maxListeners {
+		result.Errors = append(result.Errors, fmt.Sprintf("listeners: at most %d listeners are allowed, got %d", maxListeners, len(gw.Spec.Listeners)))
+	}
+
+	names := make(map[gatewayv1.SectionName]int)
+	for i, listener := range gw.Spec.Listeners {
+		if first, ok := names[listener.Name]; ok {
+			result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d].name %s is already used by listeners[%d]", i, listener.Name, first))
+		} else {
+			names[listener.Name] = i
+		}
+		v.validateListener(&listener, i, result)
+	}
+
+	v.checkListenerConflicts(gw, result)
+
+	return result
+}
+
+// validateListener validates a Gateway listener
+func (v *Validator) validateListener(l *gatewayv1.Listener, i int, result *ValidationResult) {
+	switch {
+	case l.Name == "":
+		result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d].name is required", i))
+	case !sectionNameRegex.MatchString(string(l.Name)) || len(l.Name) > 253:
+		result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d].name %s must consist of lower case alphanumeric characters, '-' or '.'", i, l.Name))
+	}
+
+	if l.Port < 1 || l.Port > 65535 {
+		result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d].port %d must be between 1 and 65535", i, l.Port))
+	}
+
+	_, core := listenerRouteKinds[l.Protocol]
+	switch {
+	case l.Protocol == "":
+		result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d].protocol is required", i))
+	case !core && !strings.Contains(string(l.Protocol), "/"):
+		result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d].protocol %s must be HTTP, HTTPS, TLS, TCP, UDP or domain-prefixed", i, l.Protocol))
+	case !core:
+		result.Warnings = append(result.Warnings, fmt.Sprintf("listeners[%d].protocol %s is implementation-specific", i, l.Protocol))
+	}
+
+	if l.Hostname != nil {
+		hostname := string(*l.Hostname)
+		switch {
+		case l.Protocol == gatewayv1.TCPProtocolType || l.Protocol == gatewayv1.UDPProtocolType:
+			result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d].hostname is not allowed for protocol %s", i, l.Protocol))
+		case !hostnameRegex.MatchString(hostname):
+			result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d].hostname: invalid hostname format: %s", i, hostname))
+		}
+	}
+
+	v.validateListenerTLS(l, i, result)
+	v.validateAllowedRoutes(l, i, result)
+}
+
+// validateListenerTLS checks the TLS configuration against the listener
+// protocol: HTTPS terminates TLS with certificates, TLS terminates or
+// passes it through, other protocols take none
+func (v *Validator) validateListenerTLS(l *gatewayv1.Listener, i int, result *ValidationResult) {
+	switch l.Protocol {
+	case gatewayv1.HTTPSProtocolType, gatewayv1.TLSProtocolType:
+	default:
+		if l.TLS != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d].tls is not allowed for protocol %s", i, l.Protocol))
+		}
+		return
+	}
+
+	if l.TLS == nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d].tls is required for protocol %s", i, l.Protocol))
+		return
+	}
+
+	mode := gatewayv1.TLSModeTerminate
+	if l.TLS.Mode != nil {
+		mode = *l.TLS.Mode
+	}
+	switch mode {
+	case gatewayv1.TLSModeTerminate:
+		if len(l.TLS.CertificateRefs) == 0 {
+			result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d].tls.certificateRefs: at least one certificate is required to terminate TLS", i))
+		}
+	case gatewayv1.TLSModePassthrough:
+		if l.Protocol == gatewayv1.HTTPSProtocolType {
+			result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d].tls.mode Passthrough is not allowed for protocol HTTPS, use protocol TLS", i))
+		}
+		if len(l.TLS.CertificateRefs) > 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("listeners[%d].tls.certificateRefs are ignored in Passthrough mode", i))
+		}
+	default:
+		result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d].tls.mode %s must be Terminate or Passthrough", i, mode))
+	}
+
+	for j, ref := range l.TLS.CertificateRefs {
+		if ref.Name == "" {
+			result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d].tls.certificateRefs[%d].name is required", i, j))
+		}
+		if (ref.Group != nil && *ref.Group != "") || (ref.Kind != nil && *ref.Kind != "Secret") {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("listeners[%d].tls.certificateRefs[%d] is not a Secret; support is implementation-specific", i, j))
+		}
+	}
+}
+
+// validateAllowedRoutes checks the namespaces and route kinds a listener
+// admits
+func (v *Validator) validateAllowedRoutes(l *gatewayv1.Listener, i int, result *ValidationResult) {
+	if l.AllowedRoutes == nil {
+		return
+	}
+
+	if ns := l.AllowedRoutes.Namespaces; ns != nil && ns.From != nil {
+		switch *ns.From {
+		case gatewayv1.NamespacesFromSelector:
+			if ns.Selector == nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d].allowedRoutes.namespaces.selector is required when from is Selector", i))
+			}
+		case gatewayv1.NamespacesFromAll, gatewayv1.NamespacesFromSame:
+			if ns.Selector != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("listeners[%d].allowedRoutes.namespaces.selector is ignored when from is %s", i, *ns.From))
+			}
+		default:
+			result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d].allowedRoutes.namespaces.from %s must be All, Same or Selector", i, *ns.From))
+		}
+	}
+
+	kinds, core := listenerRouteKinds[l.Protocol]
+	if !core {
+		return
+	}
+	for j, rgk := range l.AllowedRoutes.Kinds {
+		if rgk.Group != nil && *rgk.Group != gatewayv1.GroupName {
+			continue
+		}
+		if !containsString(kinds, string(rgk.Kind)) {
+			result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d].allowedRoutes.kinds[%d]: %s cannot attach to a %s listener (want %s)",
+				i, j, rgk.Kind, l.Protocol, strings.Join(kinds, " or ")))
+		}
+	}
+}
+
+// checkListenerConflicts reports listeners that cannot share a port: the
+// same protocol and hostname twice, HTTP with TLS-based protocols, or TCP
+// with anything but UDP
+func (v *Validator) checkListenerConflicts(gw *gatewayv1.Gateway, result *ValidationResult) {
+	listeners := gw.Spec.Listeners
+	for i := range listeners {
+		for j := i + 1; j < len(listeners); j++ {
+			a, b := listeners[i], listeners[j]
+			if a.Port != b.Port {
+				continue
+			}
+			if a.Protocol == b.Protocol {
+				if listenerHostname(a) == listenerHostname(b) {
+					result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d] and listeners[%d] conflict: both %s on port %d for hostname %s",
+						i, j, a.Protocol, a.Port, listenerHostname(a)))
+				}
+				continue
+			}
+			if !protocolsShareable(a.Protocol, b.Protocol) {
+				result.Errors = append(result.Errors, fmt.Sprintf("listeners[%d] and listeners[%d] conflict: protocols %s and %s cannot share port %d",
+					i, j, a.Protocol, b.Protocol, a.Port))
+			}
+		}
+	}
+}
+
+// protocolsShareable reports whether listeners of two different core
+// protocols can share a port
+func protocolsShareable(a, b gatewayv1.ProtocolType) bool {
+	if a == gatewayv1.UDPProtocolType || b == gatewayv1.UDPProtocolType {
+		return true
+	}
+	_, coreA := listenerRouteKinds[a]
+	_, coreB := listenerRouteKinds[b]
+	if !coreA || !coreB {
+		return true
+	}
+	// HTTPS and TLS listeners are both told apart by SNI
+	tlsBased := func(p gatewayv1.ProtocolType) bool {
+		return p == gatewayv1.HTTPSProtocolType || p == gatewayv1.TLSProtocolType
+	}
+	return tlsBased(a) && tlsBased(b)
+}
+
+// listenerHostname returns the listener hostname, * when it has none
+func listenerHostname(l gatewayv1.Listener) string {
+	if l.Hostname == nil {
+		return "*"
+	}
+	return string(*l.Hostname)
+}
+
+// validateGatewayClass validates a single GatewayClass
+func (v *Validator) validateGatewayClass(gc *gatewayv1.GatewayClass) *ValidationResult {
+	result := &ValidationResult{
+		Kind:         "GatewayClass",
+		ResourceName: gc.Name,
+	}
+
+	v.validateMetadata(gc.Name, gc.Namespace, false, result)
+
+	switch name := string(gc.Spec.ControllerName); {
+	case name == "":
+		result.Errors = append(result.Errors, "controllerName is required")
+	case !controllerNameRegex.MatchString(name):
+		result.Errors = append(result.Errors, fmt.Sprintf("controllerName %s must be a domain-prefixed path, e.g. example.com/gateway-controller", name))
+	}
+
+	if ref := gc.Spec.ParametersRef; ref != nil {
+		if ref.Kind == "" {
+			result.Errors = append(result.Errors, "parametersRef.kind is required")
+		}
+		if ref.Name == "" {
+			result.Errors = append(result.Errors, "parametersRef.name is required")
+		}
+		if ref.Group == "" && ref.Kind != "" && ref.Kind != "ConfigMap" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("parametersRef.group is empty, the core group has no %s kind", ref.Kind))
+		}
+	}
+
+	return result
+}
+
+// containsString reports whether s is in list
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
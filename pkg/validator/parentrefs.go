@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// validateParentGateways checks that the Gateways the route references
+// exist, are Accepted and have a listener for the route protocol and
+// hostnames, when enabled by SetParentGatewayChecks. Shape checks of the
+// references are done by validateParentRefs.
+func (v *Validator) validateParentGateways(ctx context.Context, hr *gatewayv1.HTTPRoute, result *ValidationResult) error {
+	lookup := v.parentGatewayLookup()
+	if lookup == nil {
+		return nil
+	}
+
+	for i, ref := range hr.Spec.ParentRefs {
+		if ref.Name == "" || !isGatewayRef(ref) {
+			continue
+		}
+		ns := hr.Namespace
+		if ref.Namespace != nil {
+			ns = string(*ref.Namespace)
+		}
+
+		gw, err := lookup.GetGateway(ctx, ns, string(ref.Name))
+		if apierrors.IsNotFound(err) {
+			result.Errors = append(result.Errors, fmt.Sprintf("parentRefs[%d]: Gateway %s/%s not found", i, ns, ref.Name))
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get gateway %s/%s: %w", ns, ref.Name, err)
+		}
+
+		accepted := meta.FindStatusCondition(gw.Status.Conditions, string(gatewayv1.GatewayConditionAccepted))
+		switch {
+		case accepted == nil:
+			result.Warnings = append(result.Warnings, fmt.Sprintf("parentRefs[%d]: Gateway %s/%s has not been accepted by a controller yet", i, ns, ref.Name))
+		case accepted.Status != metav1.ConditionTrue:
+			result.Errors = append(result.Errors, fmt.Sprintf("parentRefs[%d]: Gateway %s/%s is not Accepted: %s: %s", i, ns, ref.Name, accepted.Reason, accepted.Message))
+		}
+
+		v.validateListeners(hr, i, ref, gw, result)
+	}
+	return nil
+}
+
+// validateListeners checks that a listener of gw selected by parentRefs[i]
+// serves the route protocol and hostnames
+func (v *Validator) validateListeners(hr *gatewayv1.HTTPRoute, i int, ref gatewayv1.ParentReference, gw *gatewayv1.Gateway, result *ValidationResult) {
+	name := fmt.Sprintf("%s/%s", gw.Namespace, gw.Name)
+
+	var selected []gatewayv1.Listener
+	for _, listener := range gw.Spec.Listeners {
+		if ref.SectionName != nil && *ref.SectionName != listener.Name {
+			continue
+		}
+		if ref.Port != nil && *ref.Port != listener.Port {
+			continue
+		}
+		selected = append(selected, listener)
+	}
+	if len(selected) == 0 {
+		result.Errors = append(result.Errors, fmt.Sprintf("parentRefs[%d]: Gateway %s has no listener %s", i, name, describeSection(ref)))
+		return
+	}
+
+	var http []gatewayv1.Listener
+	for _, listener := range selected {
+		if listener.Protocol == gatewayv1.HTTPProtocolType || listener.Protocol == gatewayv1.HTTPSProtocolType {
+			http = append(http, listener)
+		}
+	}
+	if len(http) == 0 {
+		result.Errors = append(result.Errors, fmt.Sprintf("parentRefs[%d]: no HTTP or HTTPS listener of Gateway %s accepts HTTPRoutes (listeners: %s)", i, name, describeListeners(selected)))
+		return
+	}
+
+	// A hostname-less route attaches to every listener
+	if len(hr.Spec.Hostnames) == 0 {
+		return
+	}
+	var unserved []string
+	for _, h := range hr.Spec.Hostnames {
+		served := false
+		for _, listener := range http {
+			if listener.Hostname == nil || hostnameMatches(string(*listener.Hostname), string(h)) {
+				served = true
+				break
+			}
+		}
+		if !served {
+			unserved = append(unserved, string(h))
+		}
+	}
+	switch {
+	case len(unserved) == len(hr.Spec.Hostnames):
+		result.Errors = append(result.Errors, fmt.Sprintf("parentRefs[%d]: no listener hostname of Gateway %s matches the route hostnames (listeners: %s)", i, name, describeListeners(http)))
+	case len(unserved) > 0:
+		result.Warnings = append(result.Warnings, fmt.Sprintf("parentRefs[%d]: hostnames %s match no listener of Gateway %s", i, strings.Join(unserved, ", "), name))
+	}
+}
+
+// isGatewayRef reports whether a parentRef references a Gateway, the
+// default kind
+func isGatewayRef(ref gatewayv1.ParentReference) bool {
+	if ref.Group != nil && *ref.Group != gatewayv1.GroupName {
+		return false
+	}
+	return ref.Kind == nil || *ref.Kind == "Gateway"
+}
+
+// describeSection describes the listener a parentRef selects
+func describeSection(ref gatewayv1.ParentReference) string {
+	var parts []string
+	if ref.SectionName != nil {
+		parts = append(parts, fmt.Sprintf("named %s", *ref.SectionName))
+	}
+	if ref.Port != nil {
+		parts = append(parts, fmt.Sprintf("on port %d", *ref.Port))
+	}
+	return strings.Join(parts, " ")
+}
+
+// describeListeners lists listeners as name=protocol/hostname
+func describeListeners(listeners []gatewayv1.Listener) string {
+	var desc []string
+	for _, l := range listeners {
+		host := "*"
+		if l.Hostname != nil {
+			host = string(*l.Hostname)
+		}
+		desc = append(desc, fmt.Sprintf("%s=%s/%s", l.Name, l.Protocol, host))
+	}
+	return strings.Join(desc, ", ")
+}
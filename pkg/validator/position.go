@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The ingress-to-gateway Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/yaml"
+)
+
+// DocumentError reports a document of a file that could not be parsed, with
+// the position of the offending field when it is known
+type DocumentError struct {
+	Path     string // file the document was read from
+	Document int    // 1-based index of the document in the file
+	Line     int    // 1-based line in the file, 0 if unknown
+	Column   int    // 1-based column in the file, 0 if unknown
+	Err      error
+}
+
+func (e *DocumentError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d:%d: document %d: %v", e.Path, e.Line, e.Column, e.Document, e.Err)
+	}
+	return fmt.Sprintf("%s: document %d: %v", e.Path, e.Document, e.Err)
+}
+
+func (e *DocumentError) Unwrap() error {
+	return e.Err
+}
+
+// document is one YAML document of a file with its syntax tree, which
+// keeps the line and column of every field in the file
+type document struct {
+	index int
+	node  *yamlv3.Node
+}
+
+// splitDocuments parses data into its non-empty YAML documents
+func splitDocuments(path string, data []byte) ([]document, error) {
+	decoder := yamlv3.NewDecoder(strings.NewReader(string(data)))
+	var docs []document
+	for index := 1; ; index++ {
+		var node yamlv3.Node
+		err := decoder.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, &DocumentError{Path: path, Document: index, Err: err}
+		}
+		// Documents holding only comments or separators
+		if len(node.Content) == 0 || node.Content[0].Tag == "!!null" {
+			continue
+		}
+		docs = append(docs, document{index: index, node: &node})
+	}
+}
+
+// unmarshal decodes the document into obj as sigs.k8s.io/yaml would,
+// locating type errors in the file
+func (d document) unmarshal(path string, obj interface{}) error {
+	data, err := yamlv3.Marshal(d.node)
+	if err == nil {
+		data, err = yaml.YAMLToJSON(data)
+	}
+	if err != nil {
+		return &DocumentError{Path: path, Document: d.index, Line: d.root().Line, Column: d.root().Column, Err: err}
+	}
+
+	if err := json.Unmarshal(data, obj); err != nil {
+		docErr := &DocumentError{Path: path, Document: d.index, Line: d.root().Line, Column: d.root().Column, Err: err}
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			node := d.lookup(strings.Split(typeErr.Field, "."))
+			docErr.Line, docErr.Column = node.Line, node.Column
+			docErr.Err = fmt.Errorf("%s: cannot use %s as %s", typeErr.Field, typeErr.Value, typeErr.Type)
+		}
+		return docErr
+	}
+	return nil
+}
+
+// root returns the top-level node of the document
+func (d document) root() *yamlv3.Node {
+	return d.node.Content[0]
+}
+
+// value returns the scalar at a field path such as metadata.name, "" if
+// the field is absent
+func (d document) value(path ...string) string {
+	node := d.root()
+	for _, key := range path {
+		if node = childNode(node, key); node == nil {
+			return ""
+		}
+	}
+	if node.Kind != yamlv3.ScalarNode {
+		return ""
+	}
+	return node.Value
+}
+
+// lookup returns the node at a field path such as spec.rules.0.matches, or
+// the deepest node on the way when the field itself is absent
+func (d document) lookup(path []string) *yamlv3.Node {
+	node := d.root()
+	for _, key := range path {
+		next := childNode(node, key)
+		if next == nil {
+			return node
+		}
+		node = next
+	}
+	return node
+}
+
+// childNode returns the value of key in a mapping or the element at index
+// key in a sequence
+func childNode(node *yamlv3.Node, key string) *yamlv3.Node {
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i+1]
+			}
+		}
+	case yamlv3.SequenceNode:
+		if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < len(node.Content) {
+			return node.Content[i]
+		}
+	}
+	return nil
+}
+
+// fieldPathPattern matches the field path validation messages start with,
+// e.g. rules[0].matches[1].path.value, listeners[0].tls or metadata.name
+var fieldPathPattern = regexp.MustCompile(`^(metadata|parentRefs|rules|gatewayClassName|listeners|controllerName|parametersRef)(\[[0-9]+\]|\.[A-Za-z]+)*`)
+
+// locate appends the file position of the field a validation message is
+// about, e.g. "rules[0]: ... (line 12, column 7)"
+func (d document) locate(message string) string {
+	path := fieldPathPattern.FindString(message)
+	if path == "" {
+		return message
+	}
+	if !strings.HasPrefix(path, "metadata") {
+		path = "spec." + path
+	}
+	path = strings.NewReplacer("[", ".", "]", "").Replace(path)
+
+	node := d.lookup(strings.Split(path, "."))
+	return fmt.Sprintf("%s (line %d, column %d)", message, node.Line, node.Column)
+}
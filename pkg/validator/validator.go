@@ -23,19 +23,32 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 
+	"github.com/mayens/ingress-to-gateway/pkg/experimental"
+	"golang.org/x/net/idna"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
-	"sigs.k8s.io/yaml"
 )
 
-// Validator validates HTTPRoute resources
+// Validator validates Gateway API resources: HTTPRoutes, Gateways and
+// GatewayClasses. It is safe for concurrent use; validation keeps no state
+// between calls.
 type Validator struct {
 	strict bool
+
+	mu           sync.Mutex // guards lookup, checkParents and experimental
+	lookup       ClusterLookup
+	checkParents bool
+	experimental *experimental.Gate
 }
 
 // ValidationResult contains validation results for a resource
 type ValidationResult struct {
+	Kind         string
 	ResourceName string
+	Skipped      bool // the kind is not validated
+	Document     int  // 1-based index of the document in the file, 0 if not read from a file
+	Line         int  // line of the document in the file
 	Errors       []string
 	Warnings     []string
 }
@@ -47,29 +60,120 @@ func NewValidator(strict bool) *Validator {
 	}
 }
 
-// ValidateFile validates HTTPRoute resources in a file
+// SetClusterLookup enables checks against the cluster, such as whether the
+// certificates of the referenced Gateways cover the route hostnames
+func (v *Validator) SetClusterLookup(lookup ClusterLookup) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.lookup = lookup
+}
+
+// SetParentGatewayChecks also checks, through the cluster lookup, that the
+// referenced Gateways exist, are Accepted and have listeners for the route
+func (v *Validator) SetParentGatewayChecks(enabled bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.checkParents = enabled
+}
+
+// SetExperimental reports the experimental fields the gate holds back as
+// errors; by default every experimental field is accepted
+func (v *Validator) SetExperimental(gate *experimental.Gate) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.experimental = gate
+}
+
+// experimentalEnabled reports whether the gate set by SetExperimental lets
+// feature through
+func (v *Validator) experimentalEnabled(feature string) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.experimental.Enabled(feature)
+}
+
+// clusterLookup returns the lookup set by SetClusterLookup, nil if none
+func (v *Validator) clusterLookup() ClusterLookup {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.lookup
+}
+
+// parentGatewayLookup returns the cluster lookup when the parent Gateway
+// checks are enabled, nil otherwise
+func (v *Validator) parentGatewayLookup() ClusterLookup {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !v.checkParents {
+		return nil
+	}
+	return v.lookup
+}
+
+// ValidateFile validates the resources in a file by kind: HTTPRoutes,
+// Gateways and GatewayClasses, documents without a kind as HTTPRoutes.
+// Other kinds are returned as Skipped results. Documents that cannot be
+// parsed fail with a *DocumentError; validation messages about a field end
+// with its line and column in the file.
 func (v *Validator) ValidateFile(ctx context.Context, path string) ([]*ValidationResult, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
-	// Split by YAML document separator
-	docs := strings.Split(string(data), "---")
+	docs, err := splitDocuments(path, data)
+	if err != nil {
+		return nil, err
+	}
 	var results []*ValidationResult
 
-	for i, doc := range docs {
-		doc = strings.TrimSpace(doc)
-		if doc == "" {
-			continue
-		}
+	for _, doc := range docs {
+		var result *ValidationResult
+		kind, apiVersion := doc.value("kind"), doc.value("apiVersion")
+		// Kinds of other groups share names, such as the Istio Gateway
+		gatewayAPI := apiVersion == "" || strings.HasPrefix(apiVersion, gatewayv1.GroupName+"/")
+		switch {
+		case gatewayAPI && (kind == "HTTPRoute" || kind == ""):
+			var httpRoute gatewayv1.HTTPRoute
+			if err := doc.unmarshal(path, &httpRoute); err != nil {
+				return nil, err
+			}
 
-		var httpRoute gatewayv1.HTTPRoute
-		if err := yaml.Unmarshal([]byte(doc), &httpRoute); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal document %d: %w", i+1, err)
+			result = v.validateHTTPRoute(&httpRoute)
+			if err := v.validateParentGateways(ctx, &httpRoute, result); err != nil {
+				return nil, err
+			}
+			if err := v.validateCertificates(ctx, &httpRoute, result); err != nil {
+				return nil, err
+			}
+		case gatewayAPI && kind == "Gateway":
+			var gw gatewayv1.Gateway
+			if err := doc.unmarshal(path, &gw); err != nil {
+				return nil, err
+			}
+			result = v.validateGateway(&gw)
+		case gatewayAPI && kind == "GatewayClass":
+			var gc gatewayv1.GatewayClass
+			if err := doc.unmarshal(path, &gc); err != nil {
+				return nil, err
+			}
+			result = v.validateGatewayClass(&gc)
+		default:
+			name := doc.value("metadata", "name")
+			if ns := doc.value("metadata", "namespace"); ns != "" {
+				name = ns + "/" + name
+			}
+			result = &ValidationResult{Kind: kind, ResourceName: name, Skipped: true}
 		}
 
-		result := v.validateHTTPRoute(&httpRoute)
+		result.Document = doc.index
+		result.Line = doc.root().Line
+		for i := range result.Errors {
+			result.Errors[i] = doc.locate(result.Errors[i])
+		}
+		for i := range result.Warnings {
+			result.Warnings[i] = doc.locate(result.Warnings[i])
+		}
 		results = append(results, result)
 	}
 
@@ -79,11 +183,12 @@ func (v *Validator) ValidateFile(ctx context.Context, path string) ([]*Validatio
 // validateHTTPRoute validates a single HTTPRoute
 func (v *Validator) validateHTTPRoute(hr *gatewayv1.HTTPRoute) *ValidationResult {
 	result := &ValidationResult{
+		Kind:         "HTTPRoute",
 		ResourceName: fmt.Sprintf("%s/%s", hr.Namespace, hr.Name),
 	}
 
 	// Validate metadata
-	v.validateMetadata(hr, result)
+	v.validateMetadata(hr.Name, hr.Namespace, true, result)
 
 	// Validate hostnames
 	v.validateHostnames(hr, result)
@@ -97,26 +202,33 @@ func (v *Validator) validateHTTPRoute(hr *gatewayv1.HTTPRoute) *ValidationResult
 	return result
 }
 
-// validateMetadata validates HTTPRoute metadata
-func (v *Validator) validateMetadata(hr *gatewayv1.HTTPRoute, result *ValidationResult) {
-	if hr.Name == "" {
+// validateMetadata validates the name and namespace of a resource,
+// cluster-scoped unless namespaced
+func (v *Validator) validateMetadata(name, namespace string, namespaced bool, result *ValidationResult) {
+	if name == "" {
 		result.Errors = append(result.Errors, "metadata.name is required")
 	} else {
 		// Validate name format
 		nameRegex := regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
-		if !nameRegex.MatchString(hr.Name) {
+		if !nameRegex.MatchString(name) {
 			result.Errors = append(result.Errors, "metadata.name must consist of lower case alphanumeric characters or '-'")
 		}
-		if len(hr.Name) > 63 {
+		if len(name) > 63 {
 			result.Errors = append(result.Errors, "metadata.name must be no more than 63 characters")
 		}
 	}
 
-	if hr.Namespace == "" {
+	switch {
+	case namespaced && namespace == "":
 		result.Warnings = append(result.Warnings, "metadata.namespace not specified, will use default namespace")
+	case !namespaced && namespace != "":
+		result.Warnings = append(result.Warnings, "metadata.namespace is ignored, the resource is cluster-scoped")
 	}
 }
 
+// hostnameRegex matches hostnames, optionally with a leading wildcard label
+var hostnameRegex = regexp.MustCompile(`^(\*\.)?[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
 // validateHostnames validates HTTPRoute hostnames
 func (v *Validator) validateHostnames(hr *gatewayv1.HTTPRoute, result *ValidationResult) {
 	if len(hr.Spec.Hostnames) == 0 {
@@ -130,8 +242,21 @@ func (v *Validator) validateHostnames(hr *gatewayv1.HTTPRoute, result *Validatio
 			continue
 		}
 
+		// Gateway API only accepts ASCII hostnames
+		if !isASCII(string(hostname)) {
+			host := strings.TrimPrefix(string(hostname), "*.")
+			if ascii, err := idna.Lookup.ToASCII(host); err == nil {
+				if strings.HasPrefix(string(hostname), "*.") {
+					ascii = "*." + ascii
+				}
+				result.Errors = append(result.Errors, fmt.Sprintf("hostname %s must be ASCII, use punycode form %s", hostname, ascii))
+			} else {
+				result.Errors = append(result.Errors, fmt.Sprintf("hostname %s must be ASCII and cannot be converted to punycode: %v", hostname, err))
+			}
+			continue
+		}
+
 		// Check for valid hostname format
-		hostnameRegex := regexp.MustCompile(`^(\*\.)?[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
 		if !hostnameRegex.MatchString(string(hostname)) {
 			result.Errors = append(result.Errors, fmt.Sprintf("invalid hostname format: %s", hostname))
 		}
@@ -154,6 +279,10 @@ func (v *Validator) validateParentRefs(hr *gatewayv1.HTTPRoute, result *Validati
 		if ref.Kind != nil && *ref.Kind != "Gateway" {
 			result.Warnings = append(result.Warnings, fmt.Sprintf("parentRefs[%d].kind is %s, expected Gateway", i, *ref.Kind))
 		}
+
+		if ref.Port != nil && !v.experimentalEnabled(experimental.ParentRefPort) {
+			result.Errors = append(result.Errors, fmt.Sprintf("parentRefs[%d].port: %s", i, experimental.Disabled(experimental.ParentRefPort)))
+		}
 	}
 }
 
@@ -229,6 +358,10 @@ func (v *Validator) validateBackendRef(ref *gatewayv1.HTTPBackendRef, ruleIdx, r
 
 // validateTimeouts validates timeout configuration
 func (v *Validator) validateTimeouts(timeouts *gatewayv1.HTTPRouteTimeouts, ruleIdx int, result *ValidationResult) {
+	if !v.experimentalEnabled(experimental.HTTPRouteTimeouts) {
+		result.Errors = append(result.Errors, fmt.Sprintf("rules[%d].timeouts: %s", ruleIdx, experimental.Disabled(experimental.HTTPRouteTimeouts)))
+	}
+
 	// Validate duration format and constraint: backendRequest <= request
 	if timeouts.Request != nil && timeouts.BackendRequest != nil {
 		requestSec := parseDuration(string(*timeouts.Request))
@@ -282,33 +415,43 @@ func (v *Validator) checkPathConflicts(hr *gatewayv1.HTTPRoute, result *Validati
 	}
 }
 
-// isValidDuration checks if a duration string is valid
+// isASCII checks if a string only contains ASCII characters
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] > 127 {
+			return false
+		}
+	}
+	return true
+}
+
+// isValidDuration checks if a duration string is valid: up to four
+// components of at most five digits each, e.g. 1h30m (GEP-2257)
 func isValidDuration(duration string) bool {
-	durationRegex := regexp.MustCompile(`^[0-9]+(h|m|s|ms)$`)
+	durationRegex := regexp.MustCompile(`^([0-9]{1,5}(h|m|s|ms)){1,4}$`)
 	return durationRegex.MatchString(duration)
 }
 
-// parseDuration parses a duration string to seconds
+// parseDuration parses a duration string, possibly composite, to seconds
 func parseDuration(duration string) int {
-	durationRegex := regexp.MustCompile(`^([0-9]+)(h|m|s|ms)$`)
-	matches := durationRegex.FindStringSubmatch(duration)
-	if len(matches) != 3 {
+	if !isValidDuration(duration) {
 		return 0
 	}
 
-	value, _ := strconv.Atoi(matches[1])
-	unit := matches[2]
-
-	switch unit {
-	case "h":
-		return value * 3600
-	case "m":
-		return value * 60
-	case "s":
-		return value
-	case "ms":
-		return value / 1000
-	default:
-		return 0
+	componentRegex := regexp.MustCompile(`([0-9]+)(ms|h|m|s)`)
+	millis := 0
+	for _, matches := range componentRegex.FindAllStringSubmatch(duration, -1) {
+		value, _ := strconv.Atoi(matches[1])
+		switch matches[2] {
+		case "h":
+			millis += value * 3600 * 1000
+		case "m":
+			millis += value * 60 * 1000
+		case "s":
+			millis += value * 1000
+		case "ms":
+			millis += value
+		}
 	}
+	return millis / 1000
 }
@@ -17,8 +17,27 @@ limitations under the License.
 package validator
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/mayens/ingress-to-gateway/pkg/experimental"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
@@ -291,6 +310,10 @@ func TestIsValidDuration(t *testing.T) {
 		{"Valid minutes", "5m", true},
 		{"Valid hours", "2h", true},
 		{"Valid milliseconds", "100ms", true},
+		{"Valid composite", "1h30m", true},
+		{"Valid composite with milliseconds", "1s500ms", true},
+		{"Invalid - too many digits", "100000s", false},
+		{"Invalid - too many components", "1h1m1s1ms1s", false},
 		{"Invalid - no unit", "60", false},
 		{"Invalid - wrong unit", "60x", false},
 		{"Invalid - negative", "-60s", false},
@@ -317,6 +340,8 @@ func TestParseDuration(t *testing.T) {
 		{"Minutes", "5m", 300},
 		{"Hours", "2h", 7200},
 		{"Milliseconds", "1000ms", 1},
+		{"Composite", "1h30m", 5400},
+		{"Composite with milliseconds", "1m1500ms", 61},
 		{"Invalid", "invalid", 0},
 	}
 
@@ -332,9 +357,10 @@ func TestParseDuration(t *testing.T) {
 
 func TestValidateTimeouts(t *testing.T) {
 	tests := []struct {
-		name       string
-		timeouts   *gatewayv1.HTTPRouteTimeouts
-		wantErrors int
+		name                string
+		timeouts            *gatewayv1.HTTPRouteTimeouts
+		disableExperimental bool
+		wantErrors          int
 	}{
 		{
 			name: "Valid - backend <= request",
@@ -367,11 +393,26 @@ func TestValidateTimeouts(t *testing.T) {
 			},
 			wantErrors: 1,
 		},
+		{
+			name: "Experimental timeouts disabled",
+			timeouts: &gatewayv1.HTTPRouteTimeouts{
+				Request: durationPtr("600s"),
+			},
+			disableExperimental: true,
+			wantErrors:          1,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			v := NewValidator(false)
+			if tt.disableExperimental {
+				gate, err := experimental.NewGate(false, nil)
+				if err != nil {
+					t.Fatalf("NewGate() error = %v", err)
+				}
+				v.SetExperimental(gate)
+			}
 			result := &ValidationResult{ResourceName: "test"}
 			v.validateTimeouts(tt.timeouts, 0, result)
 
@@ -451,6 +492,56 @@ func TestValidateBackendRef(t *testing.T) {
 	}
 }
 
+func TestValidateHostnames(t *testing.T) {
+	tests := []struct {
+		name       string
+		hostname   gatewayv1.Hostname
+		wantErrors int
+		wantHint   string
+	}{
+		{
+			name:       "ASCII hostname",
+			hostname:   "app.example.com",
+			wantErrors: 0,
+		},
+		{
+			name:       "Punycode hostname",
+			hostname:   "xn--bcher-kva.example.com",
+			wantErrors: 0,
+		},
+		{
+			name:       "Unicode hostname",
+			hostname:   "bücher.example.com",
+			wantErrors: 1,
+			wantHint:   "xn--bcher-kva.example.com",
+		},
+		{
+			name:       "Unicode wildcard hostname",
+			hostname:   "*.bücher.example.com",
+			wantErrors: 1,
+			wantHint:   "*.xn--bcher-kva.example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValidator(false)
+			result := &ValidationResult{ResourceName: "test"}
+			hr := &gatewayv1.HTTPRoute{
+				Spec: gatewayv1.HTTPRouteSpec{Hostnames: []gatewayv1.Hostname{tt.hostname}},
+			}
+			v.validateHostnames(hr, result)
+
+			if len(result.Errors) != tt.wantErrors {
+				t.Fatalf("validateHostnames() errors = %v, want %v", result.Errors, tt.wantErrors)
+			}
+			if tt.wantHint != "" && !strings.Contains(result.Errors[0], tt.wantHint) {
+				t.Errorf("validateHostnames() error = %q, want hint %q", result.Errors[0], tt.wantHint)
+			}
+		})
+	}
+}
+
 // Helper functions
 func stringPtr(s string) *string {
 	return &s
@@ -473,3 +564,761 @@ func durationPtr(d string) *gatewayv1.Duration {
 	duration := gatewayv1.Duration(d)
 	return &duration
 }
+
+func TestCheckCertificate(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		dnsNames     []string
+		notAfter     time.Time
+		hosts        []string
+		noKey        bool
+		wantErrors   int
+		wantWarnings int
+	}{
+		{
+			name:     "covers hosts",
+			dnsNames: []string{"app.example.com", "api.example.com"},
+			notAfter: now.Add(90 * 24 * time.Hour),
+			hosts:    []string{"app.example.com", "api.example.com"},
+		},
+		{
+			name:     "wildcard SAN",
+			dnsNames: []string{"*.example.com"},
+			notAfter: now.Add(90 * 24 * time.Hour),
+			hosts:    []string{"app.example.com", "*.example.com"},
+		},
+		{
+			name:       "host not covered",
+			dnsNames:   []string{"app.example.com"},
+			notAfter:   now.Add(90 * 24 * time.Hour),
+			hosts:      []string{"app.example.com", "shop.example.com"},
+			wantErrors: 1,
+		},
+		{
+			name:       "expired",
+			dnsNames:   []string{"app.example.com"},
+			notAfter:   now.Add(-time.Hour),
+			hosts:      []string{"app.example.com"},
+			wantErrors: 1,
+		},
+		{
+			name:         "expires soon",
+			dnsNames:     []string{"app.example.com"},
+			notAfter:     now.Add(3 * 24 * time.Hour),
+			hosts:        []string{"app.example.com"},
+			wantWarnings: 1,
+		},
+		{
+			name:       "missing key",
+			dnsNames:   []string{"app.example.com"},
+			notAfter:   now.Add(90 * 24 * time.Hour),
+			hosts:      []string{"app.example.com"},
+			noKey:      true,
+			wantErrors: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := testTLSSecret(t, tt.dnsNames, now.Add(-24*time.Hour), tt.notAfter)
+			if tt.noKey {
+				delete(secret.Data, corev1.TLSPrivateKeyKey)
+			}
+
+			errs, warnings := CheckCertificate(secret, tt.hosts, now)
+			if len(errs) != tt.wantErrors || len(warnings) != tt.wantWarnings {
+				t.Errorf("CheckCertificate() = %v, %v, want %d errors and %d warnings", errs, warnings, tt.wantErrors, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+// fakeLookup serves Gateways and Secrets from memory
+type fakeLookup struct {
+	gateways map[string]*gatewayv1.Gateway
+	secrets  map[string]*corev1.Secret
+}
+
+func (f *fakeLookup) GetGateway(ctx context.Context, namespace, name string) (*gatewayv1.Gateway, error) {
+	if gw, ok := f.gateways[namespace+"/"+name]; ok {
+		return gw, nil
+	}
+	return nil, apierrors.NewNotFound(gatewayv1.Resource("gateways"), name)
+}
+
+func (f *fakeLookup) GetSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	if secret, ok := f.secrets[namespace+"/"+name]; ok {
+		return secret, nil
+	}
+	return nil, apierrors.NewNotFound(corev1.Resource("secrets"), name)
+}
+
+func TestValidateCertificates(t *testing.T) {
+	now := time.Now()
+	wildcard := gatewayv1.Hostname("*.example.com")
+	other := gatewayv1.Hostname("other.example.org")
+
+	lookup := &fakeLookup{
+		gateways: map[string]*gatewayv1.Gateway{
+			"default/gw": {
+				ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"},
+				Spec: gatewayv1.GatewaySpec{
+					Listeners: []gatewayv1.Listener{
+						{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType},
+						{
+							Name: "https", Port: 443, Protocol: gatewayv1.HTTPSProtocolType, Hostname: &wildcard,
+							TLS: &gatewayv1.GatewayTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "app-tls"}}},
+						},
+						{
+							Name: "https-other", Port: 443, Protocol: gatewayv1.HTTPSProtocolType, Hostname: &other,
+							TLS: &gatewayv1.GatewayTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "missing-tls"}}},
+						},
+					},
+				},
+			},
+		},
+		secrets: map[string]*corev1.Secret{
+			"default/app-tls": testTLSSecret(t, []string{"app.example.com"}, now.Add(-time.Hour), now.Add(90*24*time.Hour)),
+		},
+	}
+
+	tests := []struct {
+		name         string
+		gateway      string
+		hostnames    []gatewayv1.Hostname
+		parentChecks bool
+		wantErrors   int
+		wantWarnings int
+	}{
+		{
+			name:      "covered",
+			gateway:   "gw",
+			hostnames: []gatewayv1.Hostname{"app.example.com"},
+		},
+		{
+			name:       "not covered",
+			gateway:    "gw",
+			hostnames:  []gatewayv1.Hostname{"app.example.com", "shop.example.com"},
+			wantErrors: 1,
+		},
+		{
+			name:       "secret missing",
+			gateway:    "gw",
+			hostnames:  []gatewayv1.Hostname{"other.example.org"},
+			wantErrors: 1,
+		},
+		{
+			name:         "gateway missing",
+			gateway:      "missing",
+			hostnames:    []gatewayv1.Hostname{"app.example.com"},
+			wantWarnings: 1,
+		},
+		{
+			name:         "gateway missing reported by the parent checks",
+			gateway:      "missing",
+			hostnames:    []gatewayv1.Hostname{"app.example.com"},
+			parentChecks: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hr := &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default"},
+				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{
+						ParentRefs: []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(tt.gateway)}},
+					},
+					Hostnames: tt.hostnames,
+				},
+			}
+
+			v := NewValidator(false)
+			v.SetClusterLookup(lookup)
+			v.SetParentGatewayChecks(tt.parentChecks)
+			result := &ValidationResult{}
+			if err := v.validateCertificates(context.Background(), hr, result); err != nil {
+				t.Fatalf("validateCertificates() error = %v", err)
+			}
+			if len(result.Errors) != tt.wantErrors || len(result.Warnings) != tt.wantWarnings {
+				t.Errorf("validateCertificates() errors = %v, warnings = %v, want %d and %d", result.Errors, result.Warnings, tt.wantErrors, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestValidateParentGateways(t *testing.T) {
+	wildcard := gatewayv1.Hostname("*.example.com")
+	accepted := []metav1.Condition{{Type: string(gatewayv1.GatewayConditionAccepted), Status: metav1.ConditionTrue, Reason: "Accepted"}}
+	listeners := []gatewayv1.Listener{
+		{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType, Hostname: &wildcard},
+		{Name: "tcp", Port: 5432, Protocol: gatewayv1.TCPProtocolType},
+	}
+	lookup := &fakeLookup{
+		gateways: map[string]*gatewayv1.Gateway{
+			"default/gw": {
+				ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"},
+				Spec:       gatewayv1.GatewaySpec{Listeners: listeners},
+				Status:     gatewayv1.GatewayStatus{Conditions: accepted},
+			},
+			"default/pending": {
+				ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"},
+				Spec:       gatewayv1.GatewaySpec{Listeners: listeners},
+			},
+			"infra/rejected": {
+				ObjectMeta: metav1.ObjectMeta{Name: "rejected", Namespace: "infra"},
+				Spec:       gatewayv1.GatewaySpec{Listeners: listeners},
+				Status: gatewayv1.GatewayStatus{Conditions: []metav1.Condition{{
+					Type: string(gatewayv1.GatewayConditionAccepted), Status: metav1.ConditionFalse,
+					Reason: "InvalidParameters", Message: "parametersRef not found",
+				}}},
+			},
+		},
+	}
+	infra := gatewayv1.Namespace("infra")
+	tcp := gatewayv1.SectionName("tcp")
+	https := gatewayv1.SectionName("https")
+	service := gatewayv1.Kind("Service")
+
+	tests := []struct {
+		name       string
+		ref        gatewayv1.ParentReference
+		hostnames  []gatewayv1.Hostname
+		wantErrors []string
+		wantWarns  []string
+	}{
+		{
+			name:      "attached",
+			ref:       gatewayv1.ParentReference{Name: "gw"},
+			hostnames: []gatewayv1.Hostname{"app.example.com"},
+		},
+		{
+			name: "hostname-less route",
+			ref:  gatewayv1.ParentReference{Name: "gw"},
+		},
+		{
+			name:       "gateway missing",
+			ref:        gatewayv1.ParentReference{Name: "missing"},
+			hostnames:  []gatewayv1.Hostname{"app.example.com"},
+			wantErrors: []string{"Gateway default/missing not found"},
+		},
+		{
+			name:      "not accepted yet",
+			ref:       gatewayv1.ParentReference{Name: "pending"},
+			hostnames: []gatewayv1.Hostname{"app.example.com"},
+			wantWarns: []string{"has not been accepted"},
+		},
+		{
+			name:       "rejected",
+			ref:        gatewayv1.ParentReference{Name: "rejected", Namespace: &infra},
+			hostnames:  []gatewayv1.Hostname{"app.example.com"},
+			wantErrors: []string{"Gateway infra/rejected is not Accepted: InvalidParameters"},
+		},
+		{
+			name:       "no hostname intersects",
+			ref:        gatewayv1.ParentReference{Name: "gw"},
+			hostnames:  []gatewayv1.Hostname{"app.example.org"},
+			wantErrors: []string{"no listener hostname of Gateway default/gw matches"},
+		},
+		{
+			name:      "some hostnames not served",
+			ref:       gatewayv1.ParentReference{Name: "gw"},
+			hostnames: []gatewayv1.Hostname{"app.example.com", "app.example.org"},
+			wantWarns: []string{"hostnames app.example.org match no listener"},
+		},
+		{
+			name:       "section with another protocol",
+			ref:        gatewayv1.ParentReference{Name: "gw", SectionName: &tcp},
+			hostnames:  []gatewayv1.Hostname{"app.example.com"},
+			wantErrors: []string{"no HTTP or HTTPS listener of Gateway default/gw"},
+		},
+		{
+			name:       "section missing",
+			ref:        gatewayv1.ParentReference{Name: "gw", SectionName: &https},
+			hostnames:  []gatewayv1.Hostname{"app.example.com"},
+			wantErrors: []string{"Gateway default/gw has no listener named https"},
+		},
+		{
+			name:      "not a gateway",
+			ref:       gatewayv1.ParentReference{Name: "mesh", Kind: &service},
+			hostnames: []gatewayv1.Hostname{"app.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hr := &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default"},
+				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{tt.ref}},
+					Hostnames:       tt.hostnames,
+				},
+			}
+
+			v := NewValidator(false)
+			v.SetClusterLookup(lookup)
+			v.SetParentGatewayChecks(true)
+			result := &ValidationResult{}
+			if err := v.validateParentGateways(context.Background(), hr, result); err != nil {
+				t.Fatalf("validateParentGateways() error = %v", err)
+			}
+			if len(result.Errors) != len(tt.wantErrors) || len(result.Warnings) != len(tt.wantWarns) {
+				t.Fatalf("validateParentGateways() errors = %v, warnings = %v, want %v and %v", result.Errors, result.Warnings, tt.wantErrors, tt.wantWarns)
+			}
+			for i, want := range tt.wantErrors {
+				if !strings.Contains(result.Errors[i], want) {
+					t.Errorf("error %q does not contain %q", result.Errors[i], want)
+				}
+			}
+			for i, want := range tt.wantWarns {
+				if !strings.Contains(result.Warnings[i], want) {
+					t.Errorf("warning %q does not contain %q", result.Warnings[i], want)
+				}
+			}
+		})
+	}
+
+	// Without a cluster lookup only the shape of the references is checked,
+	// and a lookup alone only checks certificates
+	hr := &gatewayv1.HTTPRoute{Spec: gatewayv1.HTTPRouteSpec{CommonRouteSpec: gatewayv1.CommonRouteSpec{
+		ParentRefs: []gatewayv1.ParentReference{{Name: "missing"}},
+	}}}
+	v := NewValidator(false)
+	result := &ValidationResult{}
+	if err := v.validateParentGateways(context.Background(), hr, result); err != nil || len(result.Errors) != 0 {
+		t.Errorf("validateParentGateways() without lookup = %v, %v, want nothing", result.Errors, err)
+	}
+	v.SetClusterLookup(lookup)
+	if err := v.validateParentGateways(context.Background(), hr, result); err != nil || len(result.Errors) != 0 {
+		t.Errorf("validateParentGateways() without SetParentGatewayChecks = %v, %v, want nothing", result.Errors, err)
+	}
+}
+
+func TestValidateGateway(t *testing.T) {
+	hostname := gatewayv1.Hostname("app.example.com")
+	passthrough := gatewayv1.TLSModePassthrough
+	selector := gatewayv1.NamespacesFromSelector
+	certs := &gatewayv1.GatewayTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "app-tls"}}}
+	http := gatewayv1.Listener{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType}
+	https := gatewayv1.Listener{Name: "https", Port: 443, Protocol: gatewayv1.HTTPSProtocolType, Hostname: &hostname, TLS: certs}
+
+	tests := []struct {
+		name         string
+		listeners    []gatewayv1.Listener
+		wantErrors   []string
+		wantWarnings []string
+	}{
+		{
+			name:      "valid",
+			listeners: []gatewayv1.Listener{http, https},
+		},
+		{
+			name:       "no listeners",
+			wantErrors: []string{"at least one listener is required"},
+		},
+		{
+			name:       "duplicate name",
+			listeners:  []gatewayv1.Listener{http, {Name: "http", Port: 8080, Protocol: gatewayv1.HTTPProtocolType}},
+			wantErrors: []string{"listeners[1].name http is already used by listeners[0]"},
+		},
+		{
+			name:       "invalid port and protocol",
+			listeners:  []gatewayv1.Listener{{Name: "web", Protocol: "QUIC"}},
+			wantErrors: []string{"listeners[0].port 0 must be between 1 and 65535", "listeners[0].protocol QUIC must be"},
+		},
+		{
+			name:         "implementation-specific protocol",
+			listeners:    []gatewayv1.Listener{{Name: "quic", Port: 443, Protocol: "example.com/quic"}},
+			wantWarnings: []string{"listeners[0].protocol example.com/quic is implementation-specific"},
+		},
+		{
+			name:       "hostname on TCP",
+			listeners:  []gatewayv1.Listener{{Name: "db", Port: 5432, Protocol: gatewayv1.TCPProtocolType, Hostname: &hostname}},
+			wantErrors: []string{"listeners[0].hostname is not allowed for protocol TCP"},
+		},
+		{
+			name:       "HTTPS without certificates",
+			listeners:  []gatewayv1.Listener{{Name: "https", Port: 443, Protocol: gatewayv1.HTTPSProtocolType, TLS: &gatewayv1.GatewayTLSConfig{}}},
+			wantErrors: []string{"listeners[0].tls.certificateRefs: at least one certificate is required"},
+		},
+		{
+			name:       "HTTPS without tls",
+			listeners:  []gatewayv1.Listener{{Name: "https", Port: 443, Protocol: gatewayv1.HTTPSProtocolType}},
+			wantErrors: []string{"listeners[0].tls is required for protocol HTTPS"},
+		},
+		{
+			name: "HTTPS passthrough",
+			listeners: []gatewayv1.Listener{{Name: "https", Port: 443, Protocol: gatewayv1.HTTPSProtocolType,
+				TLS: &gatewayv1.GatewayTLSConfig{Mode: &passthrough}}},
+			wantErrors: []string{"listeners[0].tls.mode Passthrough is not allowed for protocol HTTPS"},
+		},
+		{
+			name: "TLS passthrough with certificates",
+			listeners: []gatewayv1.Listener{{Name: "tls", Port: 443, Protocol: gatewayv1.TLSProtocolType,
+				TLS: &gatewayv1.GatewayTLSConfig{Mode: &passthrough, CertificateRefs: certs.CertificateRefs}}},
+			wantWarnings: []string{"listeners[0].tls.certificateRefs are ignored in Passthrough mode"},
+		},
+		{
+			name:       "tls on HTTP",
+			listeners:  []gatewayv1.Listener{{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType, TLS: certs}},
+			wantErrors: []string{"listeners[0].tls is not allowed for protocol HTTP"},
+		},
+		{
+			name: "selector missing",
+			listeners: []gatewayv1.Listener{{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType,
+				AllowedRoutes: &gatewayv1.AllowedRoutes{Namespaces: &gatewayv1.RouteNamespaces{From: &selector}}}},
+			wantErrors: []string{"listeners[0].allowedRoutes.namespaces.selector is required"},
+		},
+		{
+			name: "route kind of another protocol",
+			listeners: []gatewayv1.Listener{{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType,
+				AllowedRoutes: &gatewayv1.AllowedRoutes{Kinds: []gatewayv1.RouteGroupKind{{Kind: "HTTPRoute"}, {Kind: "TCPRoute"}}}}},
+			wantErrors: []string{"listeners[0].allowedRoutes.kinds[1]: TCPRoute cannot attach to a HTTP listener"},
+		},
+		{
+			name:       "same port, protocol and hostname",
+			listeners:  []gatewayv1.Listener{http, {Name: "http-2", Port: 80, Protocol: gatewayv1.HTTPProtocolType}},
+			wantErrors: []string{"listeners[0] and listeners[1] conflict: both HTTP on port 80 for hostname *"},
+		},
+		{
+			name:       "HTTP and HTTPS on one port",
+			listeners:  []gatewayv1.Listener{https, {Name: "http", Port: 443, Protocol: gatewayv1.HTTPProtocolType}},
+			wantErrors: []string{"listeners[0] and listeners[1] conflict: protocols HTTPS and HTTP cannot share port 443"},
+		},
+		{
+			name: "TCP and UDP on one port",
+			listeners: []gatewayv1.Listener{
+				{Name: "dns-tcp", Port: 53, Protocol: gatewayv1.TCPProtocolType},
+				{Name: "dns-udp", Port: 53, Protocol: gatewayv1.UDPProtocolType},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gw := &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "edge", Namespace: "infra"},
+				Spec:       gatewayv1.GatewaySpec{GatewayClassName: "eg", Listeners: tt.listeners},
+			}
+
+			result := NewValidator(false).validateGateway(gw)
+			if len(result.Errors) != len(tt.wantErrors) || len(result.Warnings) != len(tt.wantWarnings) {
+				t.Fatalf("validateGateway() errors = %v, warnings = %v, want %v and %v", result.Errors, result.Warnings, tt.wantErrors, tt.wantWarnings)
+			}
+			for i, want := range tt.wantErrors {
+				if !strings.Contains(result.Errors[i], want) {
+					t.Errorf("error %q does not contain %q", result.Errors[i], want)
+				}
+			}
+			for i, want := range tt.wantWarnings {
+				if !strings.Contains(result.Warnings[i], want) {
+					t.Errorf("warning %q does not contain %q", result.Warnings[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateGatewayClass(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespace  string
+		spec       gatewayv1.GatewayClassSpec
+		wantErrors int
+		wantWarns  int
+	}{
+		{
+			name: "valid",
+			spec: gatewayv1.GatewayClassSpec{
+				ControllerName: "gateway.envoyproxy.io/gatewayclass-controller",
+				ParametersRef:  &gatewayv1.ParametersReference{Group: "gateway.envoyproxy.io", Kind: "EnvoyProxy", Name: "proxy"},
+			},
+		},
+		{
+			name:       "controllerName missing",
+			wantErrors: 1,
+		},
+		{
+			name:       "controllerName without domain",
+			spec:       gatewayv1.GatewayClassSpec{ControllerName: "envoy"},
+			wantErrors: 1,
+		},
+		{
+			name: "parametersRef incomplete",
+			spec: gatewayv1.GatewayClassSpec{
+				ControllerName: "example.com/controller",
+				ParametersRef:  &gatewayv1.ParametersReference{Kind: "EnvoyProxy"},
+			},
+			wantErrors: 1,
+			wantWarns:  1,
+		},
+		{
+			name:      "namespace set",
+			namespace: "default",
+			spec:      gatewayv1.GatewayClassSpec{ControllerName: "example.com/controller"},
+			wantWarns: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gc := &gatewayv1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "eg", Namespace: tt.namespace},
+				Spec:       tt.spec,
+			}
+			result := NewValidator(false).validateGatewayClass(gc)
+			if len(result.Errors) != tt.wantErrors || len(result.Warnings) != tt.wantWarns {
+				t.Errorf("validateGatewayClass() errors = %v, warnings = %v, want %d and %d", result.Errors, result.Warnings, tt.wantErrors, tt.wantWarns)
+			}
+		})
+	}
+}
+
+func TestValidateFileKinds(t *testing.T) {
+	bundle := `apiVersion: gateway.networking.k8s.io/v1
+kind: GatewayClass
+metadata:
+  name: eg
+spec:
+  controllerName: gateway.envoyproxy.io/gatewayclass-controller
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: edge
+  namespace: infra
+spec:
+  gatewayClassName: eg
+  listeners:
+  - name: https
+    port: 443
+    protocol: HTTPS
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: route
+  namespace: default
+spec:
+  parentRefs:
+  - name: edge
+    namespace: infra
+  hostnames:
+  - app.example.com
+  rules:
+  - backendRefs:
+    - name: svc
+      port: 80
+---
+apiVersion: networking.istio.io/v1
+kind: Gateway
+metadata:
+  name: istio
+  namespace: default
+---
+apiVersion: gateway.envoyproxy.io/v1alpha1
+kind: ClientTrafficPolicy
+metadata:
+  name: edge-client-traffic
+  namespace: infra
+`
+	path := filepath.Join(t.TempDir(), "bundle.yaml")
+	if err := os.WriteFile(path, []byte(bundle), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := NewValidator(false).ValidateFile(context.Background(), path)
+	if err != nil {
+		t.Fatalf("ValidateFile() error = %v", err)
+	}
+	var got []string
+	for _, r := range results {
+		got = append(got, fmt.Sprintf("%s %s skipped=%t errors=%d", r.Kind, r.ResourceName, r.Skipped, len(r.Errors)))
+	}
+	want := []string{
+		"GatewayClass eg skipped=false errors=0",
+		"Gateway infra/edge skipped=false errors=1",
+		"HTTPRoute default/route skipped=false errors=0",
+		"Gateway default/istio skipped=true errors=0",
+		"ClientTrafficPolicy infra/edge-client-traffic skipped=true errors=0",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ValidateFile() = %v, want %v", got, want)
+	}
+	if want := "(line 16, column 5)"; !strings.HasSuffix(results[1].Errors[0], want) {
+		t.Errorf("Gateway error %q not located at %s", results[1].Errors[0], want)
+	}
+}
+
+func TestValidateFilePositions(t *testing.T) {
+	route := `apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: route
+  namespace: default
+spec:
+  parentRefs:
+  - name: gw
+  hostnames:
+  - app.example.com
+  rules:
+  - matches:
+    - path:
+        type: PathPrefix
+        value: api
+    backendRefs:
+    - name: svc
+      port: %s
+`
+
+	tests := []struct {
+		name       string
+		content    string
+		wantErr    string
+		wantLine   int
+		wantColumn int
+		wantResult string
+	}{
+		{
+			name:       "validation message located",
+			content:    "# routes\n---\n" + fmt.Sprintf(route, "80"),
+			wantResult: "rules[0].matches[0].path.value must start with '/' (line 17, column 16)",
+		},
+		{
+			name:       "type error located",
+			content:    fmt.Sprintf(route, "80") + "---\n" + fmt.Sprintf(route, "http"),
+			wantErr:    "document 2",
+			wantLine:   37,
+			wantColumn: 13,
+		},
+		{
+			name:    "syntax error",
+			content: "metadata:\n  name: [route\n",
+			wantErr: "document 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "routes.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			results, err := NewValidator(false).ValidateFile(context.Background(), path)
+			if tt.wantErr != "" {
+				var docErr *DocumentError
+				if !errors.As(err, &docErr) {
+					t.Fatalf("ValidateFile() error = %v, want a DocumentError", err)
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ValidateFile() error = %v, want %q", err, tt.wantErr)
+				}
+				if tt.wantLine > 0 && (docErr.Line != tt.wantLine || docErr.Column != tt.wantColumn) {
+					t.Errorf("position = %d:%d, want %d:%d", docErr.Line, docErr.Column, tt.wantLine, tt.wantColumn)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateFile() error = %v", err)
+			}
+
+			// The comment before the first separator is not a document
+			if len(results) != 1 || results[0].Document != 1 || results[0].Line != 3 {
+				t.Fatalf("results = %+v, want one result for document 1 on line 3", results)
+			}
+			if !reflect.DeepEqual(results[0].Errors, []string{tt.wantResult}) {
+				t.Errorf("errors = %v, want %q", results[0].Errors, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestConcurrentValidateFile(t *testing.T) {
+	route := `apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: route
+  namespace: default
+spec:
+  parentRefs:
+  - name: gw
+  hostnames:
+  - app.example.com
+  rules:
+  - backendRefs:
+    - name: svc
+      port: 80
+`
+	path := filepath.Join(t.TempDir(), "routes.yaml")
+	if err := os.WriteFile(path, []byte(route), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	v := NewValidator(true)
+	v.SetClusterLookup(&fakeLookup{})
+	want, err := v.ValidateFile(context.Background(), path)
+	if err != nil {
+		t.Fatalf("ValidateFile() error = %v", err)
+	}
+
+	// Run with -race to catch state shared between calls
+	const workers = 8
+	got := make([][]*ValidationResult, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			got[i], errs[i] = v.ValidateFile(context.Background(), path)
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < workers; i++ {
+		if errs[i] != nil {
+			t.Fatalf("concurrent ValidateFile() error = %v", errs[i])
+		}
+		if !reflect.DeepEqual(got[i], want) {
+			t.Errorf("concurrent ValidateFile() = %+v, want %+v", got[i], want)
+		}
+	}
+}
+
+// testTLSSecret returns a kubernetes.io/tls secret with a self-signed
+// certificate for dnsNames
+func testTLSSecret(t *testing.T, dnsNames []string, notBefore, notAfter time.Time) *corev1.Secret {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-tls", Namespace: "default"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		},
+	}
+}
@@ -42,9 +42,15 @@ func TestE2E_SimpleIngressConversion(t *testing.T) {
 
 	// Convert
 	opts := converter.Options{
-		SplitMode:    "single",
-		GatewayClass: "nginx",
-		OutputFormat: "yaml",
+		RoutingOptions: converter.RoutingOptions{
+			SplitMode: "single",
+		},
+		GatewayOptions: converter.GatewayOptions{
+			GatewayClass: "nginx",
+		},
+		OutputOptions: converter.OutputOptions{
+			OutputFormat: "yaml",
+		},
 	}
 	c := converter.NewConverter(opts)
 
@@ -96,9 +102,15 @@ func TestE2E_ComplexIngressConversion(t *testing.T) {
 
 	// Convert
 	opts := converter.Options{
-		SplitMode:    "single",
-		GatewayClass: "nginx",
-		OutputFormat: "yaml",
+		RoutingOptions: converter.RoutingOptions{
+			SplitMode: "single",
+		},
+		GatewayOptions: converter.GatewayOptions{
+			GatewayClass: "nginx",
+		},
+		OutputOptions: converter.OutputOptions{
+			OutputFormat: "yaml",
+		},
 	}
 	c := converter.NewConverter(opts)
 
@@ -108,13 +120,14 @@ func TestE2E_ComplexIngressConversion(t *testing.T) {
 		t.Fatalf("Conversion failed: %v", err)
 	}
 
-	if len(routes) != 1 {
-		t.Errorf("Expected 1 HTTPRoute, got %d", len(routes))
+	// The hosts route different paths, so each gets its own HTTPRoute
+	if len(routes) != 2 {
+		t.Errorf("Expected 2 HTTPRoutes, got %d", len(routes))
 	}
 
-	routeYAML, err := yaml.Marshal(routes[0])
+	routeYAML, err := yaml.Marshal(routes)
 	if err != nil {
-		t.Fatalf("Failed to marshal HTTPRoute: %v", err)
+		t.Fatalf("Failed to marshal HTTPRoutes: %v", err)
 	}
 
 	routeStr := string(routeYAML)
@@ -153,9 +166,15 @@ func TestE2E_PerHostSplit(t *testing.T) {
 
 	// Convert with per-host split
 	opts := converter.Options{
-		SplitMode:    "per-host",
-		GatewayClass: "nginx",
-		OutputFormat: "yaml",
+		RoutingOptions: converter.RoutingOptions{
+			SplitMode: "per-host",
+		},
+		GatewayOptions: converter.GatewayOptions{
+			GatewayClass: "nginx",
+		},
+		OutputOptions: converter.OutputOptions{
+			OutputFormat: "yaml",
+		},
 	}
 	c := converter.NewConverter(opts)
 
@@ -171,6 +190,48 @@ func TestE2E_PerHostSplit(t *testing.T) {
 	}
 }
 
+func TestE2E_GeneratedFixtures(t *testing.T) {
+	ingresses := converter.GenerateFixtures(converter.FixtureOptions{
+		Count:                 200,
+		AnnotationsPerIngress: 5,
+		Namespaces:            4,
+		Seed:                  1,
+	})
+
+	// Generated manifests must load back as they are written
+	c := converter.NewConverter(converter.Options{}, converter.WithSplitMode("per-host"), converter.WithOutputFormat("yaml"))
+	var items []interface{}
+	for _, ing := range ingresses {
+		data, err := yaml.Marshal(ing)
+		if err != nil {
+			t.Fatalf("Failed to marshal ingress: %v", err)
+		}
+		var loaded networkingv1.Ingress
+		if err := yaml.Unmarshal(data, &loaded); err != nil {
+			t.Fatalf("Failed to unmarshal ingress: %v", err)
+		}
+		items = append(items, &loaded)
+	}
+
+	routes, err := c.Convert(context.Background(), items)
+	if err != nil {
+		t.Fatalf("Conversion failed: %v", err)
+	}
+
+	var hosts int
+	for _, ing := range ingresses {
+		hosts += len(ing.Spec.Rules)
+	}
+	if len(routes) < hosts {
+		t.Errorf("Expected at least %d HTTPRoutes, one per host, got %d", hosts, len(routes))
+	}
+	for _, d := range c.Diagnostics() {
+		if d.Severity == converter.SeverityError {
+			t.Errorf("Unexpected error diagnostic for %s: %s", d.Ingress, d.Message)
+		}
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) > 0 && len(substr) > 0 && (s == substr || len(s) >= len(substr) && containsSubstr(s, substr))
 }